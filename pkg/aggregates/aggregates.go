@@ -0,0 +1,124 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package aggregates builds aggregation queries for the Falcon API and renders
+// their results.
+package aggregates
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Terms returns a terms aggregation counting the distinct values of field.
+// The aggregation is named after the field.
+func Terms(field, filter string, size int32) *models.MsaAggregateQueryRequest {
+	aggType := "terms"
+	name := field
+
+	return &models.MsaAggregateQueryRequest{
+		Type:   &aggType,
+		Name:   &name,
+		Field:  &field,
+		Filter: &filter,
+		Size:   &size,
+	}
+}
+
+// Count counts the distinct values of a field computed from entities
+// rather than by the API. Buckets are ordered by decreasing count, then by
+// value, and values past the first size are summed as other documents.
+// A size of 0 keeps every value.
+func Count(name string, values []string, size int) *models.MsaAggregationResult {
+	counts := map[string]int64{}
+	for _, v := range values {
+		counts[v]++
+	}
+
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	result := &models.MsaAggregationResult{Name: &name, Buckets: []*models.MsaAggregationResultItem{}}
+	for i, k := range keys {
+		count := counts[k]
+		if size > 0 && i >= size {
+			result.SumOtherDocCount += count
+			continue
+		}
+		result.Buckets = append(result.Buckets, &models.MsaAggregationResultItem{KeyAsString: k, Count: &count})
+	}
+	return result
+}
+
+// Print writes aggregation results to w. The table format lists one row per
+// bucket, while JSON and YAML output the raw results.
+func Print(w io.Writer, format string, results []*models.MsaAggregationResult) error {
+	return output.Print(w, format, results, func(t *output.Table) {
+		t.SetHeaders("FIELD", "VALUE", "COUNT")
+		for _, r := range results {
+			name := ""
+			if r.Name != nil {
+				name = *r.Name
+			}
+
+			for _, b := range r.Buckets {
+				t.AddRow(name, BucketLabel(b), strconv.FormatInt(BucketCount(b), 10))
+			}
+
+			if r.SumOtherDocCount > 0 {
+				t.AddRow(name, "(other)", strconv.FormatInt(r.SumOtherDocCount, 10))
+			}
+		}
+	})
+}
+
+// BucketLabel returns the display value of an aggregation bucket
+func BucketLabel(b *models.MsaAggregationResultItem) string {
+	if b.KeyAsString != "" {
+		return b.KeyAsString
+	}
+
+	if b.Label != nil {
+		return fmt.Sprint(b.Label)
+	}
+
+	return b.ValueAsString
+}
+
+// BucketCount returns the document count of an aggregation bucket
+func BucketCount(b *models.MsaAggregationResultItem) int64 {
+	if b.Count == nil {
+		return 0
+	}
+	return *b.Count
+}
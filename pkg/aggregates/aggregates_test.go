@@ -0,0 +1,62 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package aggregates
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+func TestCount(t *testing.T) {
+	values := []string{"119.0", "118.0", "119.0", "117.0", "118.0", "119.0", "116.0"}
+
+	tests := []struct {
+		name string
+		size int
+		want string
+	}{
+		{
+			name: "all values",
+			size: 0,
+			want: "FIELD     VALUE   COUNT\nversion   119.0   3\nversion   118.0   2\nversion   116.0   1\nversion   117.0   1\n",
+		},
+		{
+			name: "top values",
+			size: 2,
+			want: "FIELD     VALUE     COUNT\nversion   119.0     3\nversion   118.0     2\nversion   (other)   2\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Print(&buf, output.FormatTable, []*models.MsaAggregationResult{Count("version", values, tt.size)}); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
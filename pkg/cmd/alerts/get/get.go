@@ -0,0 +1,115 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package get
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/alerts/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Show alerts by composite ID`
+	longDesc  = templates.LongDesc(`
+		Show the details of one or more alerts.
+
+		Alerts are identified by their composite ID. Pass "-" to read composite
+		IDs from standard input, one per line.`)
+	examples = templates.Examples(`
+		# Show an alert as YAML
+		falcon alerts get <composite_id> -o yaml
+
+		# Show the details of every new alert
+		falcon alerts list --status new -o json | jq -r '.[].composite_id' | falcon alerts get -
+	`)
+)
+
+type GetOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	IDs    []string
+	Format string
+}
+
+// NewCmdGet represents the alerts get command
+func NewCmdGet(f *factory.Factory) *cobra.Command {
+	opts := &GetOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "get <composite_id>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"show"},
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			ids, err := utils.ReadIDs(args, opts.IO.In)
+			if err != nil {
+				return err
+			}
+
+			if err = shared.ValidateCompositeIDs(ids); err != nil {
+				return err
+			}
+			opts.IDs = ids
+
+			return getRun(cmd.Context(), opts)
+		},
+	}
+
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func getRun(ctx context.Context, opts *GetOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := shared.GetAlerts(ctx, c, opts.IDs)
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 0 {
+		return fmt.Errorf("no alerts found")
+	}
+
+	return shared.PrintAlerts(opts.IO.Out, opts.Format, list)
+}
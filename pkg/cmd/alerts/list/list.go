@@ -0,0 +1,191 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/alerts/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/alerts"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List alerts`
+	longDesc  = templates.LongDesc(`
+		List alerts matching the given criteria.
+
+		The --product, --status and --severity flags are combined with any FQL
+		expression given with --filter.`)
+	examples = templates.Examples(`
+		# List the 100 most recent alerts
+		falcon alerts list
+
+		# List new identity and endpoint alerts with severity of at least 70
+		falcon alerts list --product idp,epp --status new --severity 70
+
+		# List every alert for a host as JSON
+		falcon alerts list --filter "agent_id:'<aid>'" --all -o json
+	`)
+)
+
+// maxQueryLimit is the maximum number of alert IDs returned per query
+const maxQueryLimit = 10000
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Filter   string
+	Query    string
+	Sort     string
+	Products []string
+	Statuses []string
+	Severity int
+	Limit    int
+	All      bool
+	Format   string
+}
+
+// NewCmdList represents the alerts list command
+func NewCmdList(f *factory.Factory) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if err := shared.ValidateProducts(opts.Products); err != nil {
+				return err
+			}
+
+			for _, s := range opts.Statuses {
+				if err := shared.ValidateStatus(s); err != nil {
+					return err
+				}
+			}
+
+			if opts.Limit < 1 {
+				return fmt.Errorf("--limit must be greater than 0")
+			}
+
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter alerts using a Falcon Query Language (FQL) expression")
+	cmd.Flags().StringVarP(&opts.Query, "query", "q", "", "Search all alert metadata for the provided string")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "created_timestamp|desc", "Sort alerts by a field, e.g. severity|desc")
+	cmd.Flags().StringSliceVar(&opts.Products, "product", nil, "Only include alerts from these product types")
+	cmd.Flags().StringSliceVar(&opts.Statuses, "status", nil, "Only include alerts with these statuses")
+	cmd.Flags().IntVar(&opts.Severity, "severity", 0, "Only include alerts with at least this severity (0-100)")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 100, "Maximum number of alerts to return")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Return all matching alerts, ignoring --limit")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	filter := fql.New().
+		Raw(opts.Filter).
+		In("product", opts.Products...).
+		In("status", opts.Statuses...)
+	if opts.Severity > 0 {
+		filter.Raw(fmt.Sprintf("severity:>=%d", opts.Severity))
+	}
+
+	var query *string
+	if opts.Query != "" {
+		query = &opts.Query
+	}
+
+	ids := []string{}
+	for {
+		limit := int64(maxQueryLimit)
+		if !opts.All && opts.Limit-len(ids) < maxQueryLimit {
+			limit = int64(opts.Limit - len(ids))
+		}
+		offset := int64(len(ids))
+
+		res, err := c.Alerts.GetQueriesAlertsV1(&alerts.GetQueriesAlertsV1Params{
+			Context: ctx,
+			Filter:  filter.Ptr(),
+			Q:       query,
+			Sort:    &opts.Sort,
+			Limit:   &limit,
+			Offset:  &offset,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to query alerts: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+
+		ids = append(ids, res.Payload.Resources...)
+
+		if len(res.Payload.Resources) == 0 || (!opts.All && len(ids) >= opts.Limit) {
+			break
+		}
+
+		total := int64(0)
+		if res.Payload.Meta != nil && res.Payload.Meta.Pagination != nil {
+			total = utils.Deref(res.Payload.Meta.Pagination.Total)
+		}
+		if int64(len(ids)) >= total {
+			break
+		}
+	}
+
+	list, err := shared.GetAlerts(ctx, c, ids)
+	if err != nil {
+		return err
+	}
+
+	return shared.PrintAlerts(opts.IO.Out, opts.Format, list)
+}
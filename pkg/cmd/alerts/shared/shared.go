@@ -0,0 +1,122 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/alerts"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Products are the product types that raise alerts
+var Products = []string{"epp", "idp", "mobile", "xdr", "overwatch", "cwpp", "ngsiem", "thirdparty"}
+
+// Statuses are the valid alert statuses
+var Statuses = []string{"new", "in_progress", "closed", "reopened"}
+
+// maxEntities is the maximum number of alerts that can be fetched per request
+const maxEntities = 1000
+
+// ValidateProducts checks that every product is a known alert product type
+func ValidateProducts(products []string) error {
+	return utils.ValidateOneOf("product", Products, products...)
+}
+
+// ValidateStatus checks that status is a valid alert status
+func ValidateStatus(status string) error {
+	return utils.ValidateOneOf("status", Statuses, status)
+}
+
+// ValidateCompositeIDs checks that every ID is an alert composite ID. Alerts
+// are addressed by composite IDs of the form <cid>:<type>:<identifiers...>,
+// which differ from the legacy detection IDs.
+func ValidateCompositeIDs(ids []string) error {
+	for _, id := range ids {
+		if strings.HasPrefix(id, "ldt:") {
+			return fmt.Errorf("%q is a legacy detection ID, alerts are addressed by composite IDs of the form <cid>:<type>:<id>", id)
+		}
+
+		parts := strings.Split(id, ":")
+		if len(parts) < 3 {
+			return fmt.Errorf("invalid alert composite ID %q, expected <cid>:<type>:<id>", id)
+		}
+
+		for _, p := range parts {
+			if p == "" {
+				return fmt.Errorf("invalid alert composite ID %q, expected <cid>:<type>:<id>", id)
+			}
+		}
+	}
+	return nil
+}
+
+// GetAlerts fetches the alerts with the given composite IDs
+func GetAlerts(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) ([]*models.DetectsInvestigatable, error) {
+	result := []*models.DetectsInvestigatable{}
+
+	for _, chunk := range utils.Chunk(ids, maxEntities) {
+		res, err := c.Alerts.PostEntitiesAlertsV1(&alerts.PostEntitiesAlertsV1Params{
+			Context: ctx,
+			Body: &models.DetectsapiPostEntitiesInvestigatablesV1Request{
+				Ids: chunk,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alerts: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		result = append(result, res.Payload.Resources...)
+	}
+
+	return result, nil
+}
+
+// PrintAlerts writes alerts to w in the given output format
+func PrintAlerts(w io.Writer, format string, list []*models.DetectsInvestigatable) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("COMPOSITE ID", "PRODUCT", "SEVERITY", "STATUS", "TACTIC", "TECHNIQUE", "ASSIGNED", "CREATED")
+		for _, a := range list {
+			t.AddRow(
+				a.CompositeID,
+				a.Product,
+				strconv.FormatInt(a.Severity, 10),
+				a.Status,
+				a.Tactic,
+				a.Technique,
+				a.AssignedToName,
+				output.Time(a.CreatedTimestamp),
+			)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import "testing"
+
+func TestValidateCompositeIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     []string
+		wantErr bool
+	}{
+		{name: "composite IDs", ids: []string{"cid1:ind:aid1:12345-67890", "cid1:ldt:aid2:98765"}},
+		{name: "minimal", ids: []string{"cid:type:id"}},
+		{name: "none", ids: nil},
+		{name: "legacy detection ID", ids: []string{"ldt:aid1:12345"}, wantErr: true},
+		{name: "empty part", ids: []string{"cid1::aid1:12345"}, wantErr: true},
+		{name: "trailing separator", ids: []string{"cid1:ind:"}, wantErr: true},
+		{name: "one invalid among valid", ids: []string{"cid1:ind:aid1:1", "abc"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCompositeIDs(tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCompositeIDs(%q) error = %v, wantErr %v", tt.ids, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stats
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/aggregates"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/alerts/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/alerts"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Show alert counts grouped by field`
+	longDesc  = templates.LongDesc(`
+		Show the number of alerts grouped by one or more fields.
+
+		Each field given with --by is aggregated separately and listed with
+		the count of alerts for each of its values.`)
+	examples = templates.Examples(`
+		# Count alerts by severity and tactic
+		falcon alerts stats --by severity,tactic
+
+		# Count new cloud alerts by status over the last week
+		falcon alerts stats --by status --product cwpp --filter "created_timestamp:>'now-7d'"
+	`)
+)
+
+type StatsOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	By       []string
+	Filter   string
+	Products []string
+	Size     int32
+	Format   string
+}
+
+// NewCmdStats represents the alerts stats command
+func NewCmdStats(f *factory.Factory) *cobra.Command {
+	opts := &StatsOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "stats",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if err := shared.ValidateProducts(opts.Products); err != nil {
+				return err
+			}
+
+			if len(opts.By) == 0 {
+				return fmt.Errorf("at least one field is required with --by")
+			}
+
+			return statsRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.By, "by", []string{"severity"}, "Fields to group alerts by")
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter alerts using a Falcon Query Language (FQL) expression")
+	cmd.Flags().StringSliceVar(&opts.Products, "product", nil, "Only include alerts from these product types")
+	cmd.Flags().Int32Var(&opts.Size, "size", 20, "Maximum number of values to show per field")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func statsRun(ctx context.Context, opts *StatsOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	filter := fql.New().Raw(opts.Filter).In("product", opts.Products...).String()
+
+	body := []*models.MsaAggregateQueryRequest{}
+	for _, field := range opts.By {
+		body = append(body, aggregates.Terms(field, filter, opts.Size))
+	}
+
+	res, err := c.Alerts.PostAggregatesAlertsV1(&alerts.PostAggregatesAlertsV1Params{
+		Context: ctx,
+		Body:    body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to aggregate alerts: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return err
+	}
+
+	return aggregates.Print(opts.IO.Out, opts.Format, res.Payload.Resources)
+}
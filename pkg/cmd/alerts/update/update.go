@@ -0,0 +1,194 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package update
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/alerts/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/alerts"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Update alerts`
+	longDesc  = templates.LongDesc(`
+		Update the status, assignee, tags or comments of one or more alerts.
+
+		Alerts are identified by their composite ID. Pass "-" to read composite
+		IDs from standard input, one per line.`)
+	examples = templates.Examples(`
+		# Close an alert with a comment
+		falcon alerts update <composite_id> --status closed --comment "False positive"
+
+		# Assign alerts to a user and tag them
+		falcon alerts update <composite_id> <composite_id> --assign-uuid <user_uuid> --add-tag triage
+	`)
+)
+
+// maxUpdateIDs is the maximum number of alerts that can be updated per request
+const maxUpdateIDs = 1000
+
+type UpdateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	IDs        []string
+	Status     string
+	AssignUUID string
+	AssignName string
+	Unassign   bool
+	AddTags    []string
+	RemoveTags []string
+	Comment    string
+	ShowInUI   bool
+
+	showInUIChanged bool
+}
+
+// NewCmdUpdate represents the alerts update command
+func NewCmdUpdate(f *factory.Factory) *cobra.Command {
+	opts := &UpdateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "update <composite_id>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := utils.ReadIDs(args, opts.IO.In)
+			if err != nil {
+				return err
+			}
+
+			if err = shared.ValidateCompositeIDs(ids); err != nil {
+				return err
+			}
+			opts.IDs = ids
+
+			if opts.Status != "" {
+				if err = shared.ValidateStatus(opts.Status); err != nil {
+					return err
+				}
+			}
+
+			if opts.Unassign && (opts.AssignUUID != "" || opts.AssignName != "") {
+				return fmt.Errorf("--unassign cannot be combined with --assign-uuid or --assign-name")
+			}
+			opts.showInUIChanged = cmd.Flags().Changed("show-in-ui")
+
+			return updateRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Status, "status", "", "Set the alert status: new, in_progress, closed or reopened")
+	cmd.Flags().StringVar(&opts.AssignUUID, "assign-uuid", "", "Assign the alerts to the user with this UUID")
+	cmd.Flags().StringVar(&opts.AssignName, "assign-name", "", "Assign the alerts to the user with this name")
+	cmd.Flags().BoolVar(&opts.Unassign, "unassign", false, "Remove the current assignee")
+	cmd.Flags().StringSliceVar(&opts.AddTags, "add-tag", nil, "Add tags to the alerts")
+	cmd.Flags().StringSliceVar(&opts.RemoveTags, "remove-tag", nil, "Remove tags from the alerts")
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Append a comment to the alerts")
+	cmd.Flags().BoolVar(&opts.ShowInUI, "show-in-ui", true, "Show or hide the alerts in the Falcon console")
+
+	return cmd
+}
+
+func (opts *UpdateOptions) actionParameters() []*models.MsaspecActionParameter {
+	params := []*models.MsaspecActionParameter{}
+	add := func(name, value string) {
+		params = append(params, &models.MsaspecActionParameter{
+			Name:  &name,
+			Value: &value,
+		})
+	}
+
+	if opts.Status != "" {
+		add("update_status", opts.Status)
+	}
+	if opts.AssignUUID != "" {
+		add("assign_to_uuid", opts.AssignUUID)
+	}
+	if opts.AssignName != "" {
+		add("assign_to_name", opts.AssignName)
+	}
+	if opts.Unassign {
+		add("unassign", "")
+	}
+	for _, tag := range opts.AddTags {
+		add("add_tag", tag)
+	}
+	for _, tag := range opts.RemoveTags {
+		add("remove_tag", tag)
+	}
+	if opts.Comment != "" {
+		add("append_comment", opts.Comment)
+	}
+	if opts.showInUIChanged {
+		add("show_in_ui", strconv.FormatBool(opts.ShowInUI))
+	}
+
+	return params
+}
+
+func updateRun(ctx context.Context, opts *UpdateOptions) error {
+	params := opts.actionParameters()
+	if len(params) == 0 {
+		return fmt.Errorf("nothing to update, specify at least one change")
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range utils.Chunk(opts.IDs, maxUpdateIDs) {
+		res, err := c.Alerts.PatchEntitiesAlertsV2(&alerts.PatchEntitiesAlertsV2Params{
+			Context: ctx,
+			Body: &models.DetectsapiPatchEntitiesInvestigatablesV2Request{
+				ActionParameters: params,
+				Ids:              chunk,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update alerts: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Updated %d alert(s)\n", len(opts.IDs))
+	return nil
+}
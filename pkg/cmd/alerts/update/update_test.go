@@ -0,0 +1,79 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package update
+
+import (
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestActionParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *UpdateOptions
+		want [][2]string
+	}{
+		{
+			name: "nothing",
+			opts: &UpdateOptions{ShowInUI: true},
+			want: [][2]string{},
+		},
+		{
+			name: "status and comment",
+			opts: &UpdateOptions{Status: "closed", Comment: "False positive"},
+			want: [][2]string{{"update_status", "closed"}, {"append_comment", "False positive"}},
+		},
+		{
+			name: "assignee and tags",
+			opts: &UpdateOptions{AssignUUID: "uuid-1", AssignName: "jdoe", AddTags: []string{"triage", "vip"}, RemoveTags: []string{"new"}},
+			want: [][2]string{{"assign_to_uuid", "uuid-1"}, {"assign_to_name", "jdoe"}, {"add_tag", "triage"}, {"add_tag", "vip"}, {"remove_tag", "new"}},
+		},
+		{
+			name: "unassign",
+			opts: &UpdateOptions{Unassign: true},
+			want: [][2]string{{"unassign", ""}},
+		},
+		{
+			name: "show in ui left unchanged",
+			opts: &UpdateOptions{ShowInUI: false},
+			want: [][2]string{},
+		},
+		{
+			name: "hide in ui",
+			opts: &UpdateOptions{ShowInUI: false, showInUIChanged: true},
+			want: [][2]string{{"show_in_ui", "false"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := [][2]string{}
+			for _, p := range tt.opts.actionParameters() {
+				got = append(got, [2]string{utils.Deref(p.Name), utils.Deref(p.Value)})
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("actionParameters() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
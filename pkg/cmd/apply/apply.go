@@ -0,0 +1,151 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/apply/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Apply manifests to the Falcon tenant`
+	longDesc  = templates.LongDesc(`
+		Bring the Falcon tenant in line with YAML manifests.
+
+		Each manifest describes a resource and names its kind with the kind
+		field. Supported kinds are HostGroup, FirewallRuleGroup,
+		PreventionPolicy, SensorUpdatePolicy, FirewallPolicy,
+		DeviceControlPolicy, ResponsePolicy, MLExclusion, IOAExclusion,
+		SVExclusion and IOC. Policy, exclusion and rule group
+		manifests are the specifications written by "falcon policy <type>
+		get -o yaml", "falcon exclusions <type> export" and "falcon firewall
+		group export". A file may hold several manifests separated by "---",
+		and directories are read recursively.
+
+		The manifests are compared with the tenant and the plan is printed
+		before it is applied. Resources are created and updated with their
+		dependencies first, so a policy can be assigned to a host group
+		created by the same run. Fields left out of a manifest are left
+		unchanged.
+
+		With --prune, the resources of the kinds present in the manifests
+		that no manifest describes are deleted. The default policies are
+		never deleted.
+
+		Note that -f is the shorthand of the global --cid flag: pass the
+		manifests as arguments or with --filename.`)
+	examples = templates.Examples(`
+		# Show what applying a directory of manifests would change
+		falcon apply ./falcon --dry-run
+
+		# Apply the manifests and delete the host groups they do not describe
+		falcon apply ./falcon/host-groups --prune
+
+		# Apply manifests read from stdin
+		cat policies.yaml | falcon apply --filename -
+	`)
+)
+
+type ApplyOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	shared.Options
+	Paths  []string
+	DryRun bool
+}
+
+// NewCmdApply represents the apply command
+func NewCmdApply(f *factory.Factory) *cobra.Command {
+	opts := &ApplyOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "apply [<file|dir>...]",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			paths, err := opts.Options.Paths(args)
+			if err != nil {
+				return err
+			}
+
+			opts.Paths = paths
+			return applyRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddFlags(cmd, &opts.Options)
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Print the plan without applying it")
+
+	return cmd
+}
+
+func applyRun(ctx context.Context, opts *ApplyOptions) error {
+	docs, err := manifest.ReadPaths(opts.Paths, opts.IO.In)
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	plan, err := shared.Plan(ctx, c, docs, opts.Prune)
+	if err != nil {
+		return err
+	}
+
+	if len(plan.Pending()) == 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "No changes, %d resources are up to date\n", len(plan))
+		return nil
+	}
+
+	if err := plan.Print(opts.IO.Out); err != nil {
+		return err
+	}
+	if opts.DryRun {
+		return nil
+	}
+
+	fmt.Fprintln(opts.IO.Out)
+	err = plan.Apply(ctx, func(s *manifest.Step) {
+		fmt.Fprintf(opts.IO.Out, "%s %sd\n", s, s.Action)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Applied: %s\n", plan.Pending().Summary())
+	return nil
+}
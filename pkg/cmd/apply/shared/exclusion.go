@@ -0,0 +1,142 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/exclusion"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+)
+
+// exclusionKind plans the manifests of an exclusion type, which are the
+// specifications read by "falcon exclusions <type> import"
+func exclusionKind(t *exclusion.Type, dir string) *Kind {
+	return &Kind{
+		Name:    t.Kind,
+		Dir:     dir,
+		Aliases: []string{"exclusions", t.Name + "-exclusions"},
+		Plan: func(ctx context.Context, c *client.CrowdStrikeAPISpecification, docs []*manifest.Document, prune bool) (manifest.Plan, error) {
+			return planExclusions(ctx, c, t, docs, prune)
+		},
+		Export: func(ctx context.Context, c *client.CrowdStrikeAPISpecification) ([]*manifest.Object, error) {
+			list, err := t.New(c).List(ctx, "")
+			if err != nil {
+				return nil, err
+			}
+
+			objects := []*manifest.Object{}
+			for _, e := range list {
+				spec := e.Spec(t)
+				objects = append(objects, &manifest.Object{Name: t.Key(spec), Spec: spec})
+			}
+			return objects, nil
+		},
+	}
+}
+
+func planExclusions(ctx context.Context, c *client.CrowdStrikeAPISpecification, t *exclusion.Type, docs []*manifest.Document, prune bool) (manifest.Plan, error) {
+	specs := []*exclusion.Spec{}
+	seen := map[string]bool{}
+
+	for _, d := range docs {
+		spec := &exclusion.Spec{}
+		if err := d.Decode(spec); err != nil {
+			return nil, err
+		}
+		if err := t.Validate(spec); err != nil {
+			return nil, d.Errorf("%v", err)
+		}
+
+		key := t.Key(spec)
+		if seen[key] {
+			return nil, d.Errorf("%s %q is described more than once", t.Title, key)
+		}
+		seen[key] = true
+
+		specs = append(specs, spec)
+	}
+
+	svc := t.New(c)
+	list, err := svc.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]*exclusion.Exclusion{}
+	for _, e := range list {
+		existing[t.Key(e.Spec(t))] = e
+	}
+
+	plan := manifest.Plan{}
+	for _, spec := range specs {
+		spec := spec
+		apply := func(ctx context.Context) error {
+			_, _, err := exclusion.Apply(ctx, c, t, spec, "")
+			return err
+		}
+
+		e, ok := existing[t.Key(spec)]
+		if !ok {
+			fields, err := changes(nil, spec)
+			if err != nil {
+				return nil, err
+			}
+
+			plan = append(plan, &manifest.Step{
+				Kind:    t.Kind,
+				Name:    t.Key(spec),
+				Action:  manifest.ActionCreate,
+				Changes: fields,
+				Run:     apply,
+			})
+			continue
+		}
+
+		fields, err := exclusion.Changes(t, e, spec)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, update(t.Kind, t.Key(spec), fields, apply))
+	}
+
+	if prune {
+		for _, e := range list {
+			key := t.Key(e.Spec(t))
+			if seen[key] {
+				continue
+			}
+
+			id := e.ID
+			plan = append(plan, &manifest.Step{
+				Kind:   t.Kind,
+				Name:   key,
+				Action: manifest.ActionDelete,
+				Run: func(ctx context.Context) error {
+					return svc.Delete(ctx, []string{id}, "")
+				},
+			})
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+)
+
+// FirewallRuleGroup plans firewall rule group manifests, which are the
+// specifications read by "falcon firewall group import"
+var FirewallRuleGroup = &Kind{
+	Name:    firewall.Kind,
+	Dir:     "firewall-rule-groups",
+	Aliases: []string{"firewall", "firewall-rule-groups"},
+	Plan:    planFirewallRuleGroups,
+	Export:  exportFirewallRuleGroups,
+}
+
+func exportFirewallRuleGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification) ([]*manifest.Object, error) {
+	groups, err := firewall.ListGroups(ctx, c, "")
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []*manifest.Object{}
+	for _, g := range groups {
+		objects = append(objects, &manifest.Object{Name: g.Name, Spec: g.Spec()})
+	}
+	return objects, nil
+}
+
+func planFirewallRuleGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification, docs []*manifest.Document, prune bool) (manifest.Plan, error) {
+	specs := []*firewall.GroupSpec{}
+	seen := map[string]bool{}
+
+	for _, d := range docs {
+		spec := &firewall.GroupSpec{}
+		if err := d.Decode(spec); err != nil {
+			return nil, err
+		}
+		if err := spec.Validate(); err != nil {
+			return nil, d.Errorf("%v", err)
+		}
+
+		key := strings.ToLower(spec.Name)
+		if seen[key] {
+			return nil, d.Errorf("firewall rule group %q is described more than once", spec.Name)
+		}
+		seen[key] = true
+
+		specs = append(specs, spec)
+	}
+
+	groups, err := firewall.ListGroups(ctx, c, "")
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*firewall.Group{}
+	for _, g := range groups {
+		byName[strings.ToLower(g.Name)] = g
+	}
+
+	plan := manifest.Plan{}
+	for _, spec := range specs {
+		spec := spec
+		existing, ok := byName[strings.ToLower(spec.Name)]
+
+		if !ok {
+			fields, err := changes(nil, spec)
+			if err != nil {
+				return nil, err
+			}
+
+			plan = append(plan, &manifest.Step{
+				Kind:    firewall.Kind,
+				Name:    spec.Name,
+				Action:  manifest.ActionCreate,
+				Changes: fields,
+				Run: func(ctx context.Context) error {
+					_, err := firewall.CreateGroup(ctx, c, spec, "")
+					return err
+				},
+			})
+			continue
+		}
+
+		if existing.Platform != spec.Platform {
+			return nil, fmt.Errorf("firewall rule group %q is for %s and cannot be moved to %s, delete it first", spec.Name, existing.Platform, spec.Platform)
+		}
+
+		fields, err := firewall.Changes(existing, spec)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, update(firewall.Kind, spec.Name, fields, func(ctx context.Context) error {
+			return firewall.UpdateGroup(ctx, c, existing, spec, "")
+		}))
+	}
+
+	if prune {
+		for _, g := range groups {
+			if seen[strings.ToLower(g.Name)] {
+				continue
+			}
+
+			id := g.ID
+			plan = append(plan, &manifest.Step{
+				Kind:   firewall.Kind,
+				Name:   g.Name,
+				Action: manifest.ActionDelete,
+				Run: func(ctx context.Context) error {
+					return firewall.DeleteGroups(ctx, c, []string{id}, "")
+				},
+			})
+		}
+	}
+
+	return plan, nil
+}
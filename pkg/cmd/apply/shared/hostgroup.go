@@ -0,0 +1,194 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// KindHostGroup is the kind of host group manifests
+const KindHostGroup = "HostGroup"
+
+// HostGroupSpec is the manifest of a host group. The assignment rule is the
+// FQL filter selecting the hosts of a dynamic group.
+type HostGroupSpec struct {
+	Kind           string `json:"kind" yaml:"kind"`
+	Name           string `json:"name" yaml:"name"`
+	Description    string `json:"description,omitempty" yaml:"description,omitempty"`
+	GroupType      string `json:"group_type" yaml:"group_type"`
+	AssignmentRule string `json:"assignment_rule,omitempty" yaml:"assignment_rule,omitempty"`
+}
+
+// HostGroupSpecFromAPI returns the manifest of a host group
+func HostGroupSpecFromAPI(g *models.ResponsesHostGroupV1) *HostGroupSpec {
+	return &HostGroupSpec{
+		Kind:           KindHostGroup,
+		Name:           utils.Deref(g.Name),
+		Description:    utils.Deref(g.Description),
+		GroupType:      g.GroupType,
+		AssignmentRule: g.AssignmentRule,
+	}
+}
+
+// HostGroup plans host group manifests
+var HostGroup = &Kind{
+	Name:    KindHostGroup,
+	Dir:     "host-groups",
+	Aliases: []string{"groups", "host-groups"},
+	Plan:    planHostGroups,
+	Export:  exportHostGroups,
+}
+
+func exportHostGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification) ([]*manifest.Object, error) {
+	groups, err := hosts.QueryGroups(ctx, c, "")
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []*manifest.Object{}
+	for _, g := range groups {
+		spec := HostGroupSpecFromAPI(g)
+		objects = append(objects, &manifest.Object{Name: spec.Name, Spec: spec})
+	}
+	return objects, nil
+}
+
+func planHostGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification, docs []*manifest.Document, prune bool) (manifest.Plan, error) {
+	specs := []*HostGroupSpec{}
+	seen := map[string]bool{}
+
+	for _, d := range docs {
+		spec := &HostGroupSpec{}
+		if err := d.Decode(spec); err != nil {
+			return nil, err
+		}
+
+		if strings.TrimSpace(spec.Name) == "" {
+			return nil, d.Errorf("name is required")
+		}
+		if spec.GroupType == "" {
+			spec.GroupType = hosts.GroupTypeStatic
+		}
+		if err := utils.ValidateOneOf("group_type", hosts.GroupTypes, spec.GroupType); err != nil {
+			return nil, d.Errorf("%v", err)
+		}
+		if spec.AssignmentRule != "" && spec.GroupType != hosts.GroupTypeDynamic {
+			return nil, d.Errorf("assignment_rule is only valid for dynamic host groups")
+		}
+
+		key := strings.ToLower(spec.Name)
+		if seen[key] {
+			return nil, d.Errorf("host group %q is described more than once", spec.Name)
+		}
+		seen[key] = true
+
+		specs = append(specs, spec)
+	}
+
+	groups, err := hosts.QueryGroups(ctx, c, "")
+	if err != nil {
+		return nil, err
+	}
+
+	byName := map[string]*models.ResponsesHostGroupV1{}
+	for _, g := range groups {
+		byName[strings.ToLower(utils.Deref(g.Name))] = g
+	}
+
+	plan := manifest.Plan{}
+	for _, spec := range specs {
+		spec := spec
+		existing, ok := byName[strings.ToLower(spec.Name)]
+
+		if !ok {
+			fields, err := changes(nil, spec)
+			if err != nil {
+				return nil, err
+			}
+
+			plan = append(plan, &manifest.Step{
+				Kind:    KindHostGroup,
+				Name:    spec.Name,
+				Action:  manifest.ActionCreate,
+				Changes: fields,
+				Run: func(ctx context.Context) error {
+					_, err := hosts.CreateGroup(ctx, c, spec.Name, spec.GroupType, spec.Description, spec.AssignmentRule)
+					return err
+				},
+			})
+			continue
+		}
+
+		// the update API ignores empty values, so fields left out of the
+		// manifest are left unchanged
+		have := HostGroupSpecFromAPI(existing)
+		if spec.Description == "" {
+			have.Description = ""
+		}
+		if spec.AssignmentRule == "" {
+			have.AssignmentRule = ""
+		}
+
+		fields, err := changes(have, spec)
+		if err != nil {
+			return nil, err
+		}
+
+		id := utils.Deref(existing.ID)
+		for _, f := range fields {
+			if f.Path == "group_type" {
+				return nil, fmt.Errorf("host group %q is %s and cannot be changed to %s, delete it first", spec.Name, have.GroupType, spec.GroupType)
+			}
+		}
+
+		plan = append(plan, update(KindHostGroup, spec.Name, fields, func(ctx context.Context) error {
+			return hosts.UpdateGroup(ctx, c, id, spec.Name, spec.Description, spec.AssignmentRule)
+		}))
+	}
+
+	if prune {
+		for _, g := range groups {
+			if seen[strings.ToLower(utils.Deref(g.Name))] {
+				continue
+			}
+
+			id := utils.Deref(g.ID)
+			plan = append(plan, &manifest.Step{
+				Kind:   KindHostGroup,
+				Name:   utils.Deref(g.Name),
+				Action: manifest.ActionDelete,
+				Run: func(ctx context.Context) error {
+					return hosts.DeleteGroups(ctx, c, []string{id})
+				},
+			})
+		}
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,382 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	iocShared "github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/ioc"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/strfmt"
+)
+
+// KindIOC is the kind of custom indicator manifests
+const KindIOC = "IOC"
+
+// maxValuesPerQuery bounds the size of the FQL filter used to look up the
+// indicators of the manifests
+const maxValuesPerQuery = 100
+
+// IOCSpec is the manifest of a custom indicator, identified by its type and
+// value. Host groups are referenced by name.
+type IOCSpec struct {
+	Kind            string   `json:"kind" yaml:"kind"`
+	Type            string   `json:"type" yaml:"type"`
+	Value           string   `json:"value" yaml:"value"`
+	Action          string   `json:"action" yaml:"action"`
+	Severity        string   `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Platforms       []string `json:"platforms" yaml:"platforms"`
+	AppliedGlobally bool     `json:"applied_globally,omitempty" yaml:"applied_globally,omitempty"`
+	HostGroups      []string `json:"host_groups,omitempty" yaml:"host_groups,omitempty"`
+	Expiration      string   `json:"expiration,omitempty" yaml:"expiration,omitempty"`
+	Description     string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags            []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Source          string   `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+// IOCSpecFromAPI returns the manifest of an indicator, naming its host
+// groups with groupNames
+func IOCSpecFromAPI(in *models.APIIndicatorV1, groupNames map[string]string) *IOCSpec {
+	spec := &IOCSpec{
+		Kind:            KindIOC,
+		Type:            in.Type,
+		Value:           in.Value,
+		Action:          in.Action,
+		Severity:        in.Severity,
+		Platforms:       sorted(in.Platforms),
+		AppliedGlobally: in.AppliedGlobally,
+		Description:     in.Description,
+		Tags:            sorted(in.Tags),
+		Source:          in.Source,
+	}
+
+	for _, id := range in.HostGroups {
+		if name, ok := groupNames[id]; ok {
+			id = name
+		}
+		spec.HostGroups = append(spec.HostGroups, id)
+	}
+	sort.Strings(spec.HostGroups)
+
+	if t := time.Time(in.Expiration); !t.IsZero() && t.Unix() != 0 {
+		spec.Expiration = t.UTC().Format(time.RFC3339)
+	}
+
+	return spec
+}
+
+// key identifies the indicator of the manifest
+func (s *IOCSpec) key() string {
+	return s.Type + ":" + strings.ToLower(s.Value)
+}
+
+// indicator validates the manifest and returns it as an indicator. The
+// manifest is normalized so that it compares equal to the API values.
+func (s *IOCSpec) indicator() (*ioc.Indicator, error) {
+	expiration, err := ioc.ParseExpiration(s.Expiration)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &ioc.Indicator{
+		Type:            s.Type,
+		Value:           s.Value,
+		Action:          s.Action,
+		Severity:        s.Severity,
+		Platforms:       sorted(s.Platforms),
+		Expiration:      expiration,
+		HostGroups:      sorted(s.HostGroups),
+		AppliedGlobally: s.AppliedGlobally,
+		Description:     s.Description,
+		Tags:            sorted(s.Tags),
+		Source:          s.Source,
+	}
+	i.Normalize()
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.Type, s.Value, s.Action, s.Severity = i.Type, i.Value, i.Action, i.Severity
+	s.Platforms, s.HostGroups, s.Tags = i.Platforms, i.HostGroups, i.Tags
+	if !expiration.IsZero() {
+		s.Expiration = expiration.UTC().Format(time.RFC3339)
+	}
+
+	return i, nil
+}
+
+// IOC plans custom indicator manifests
+var IOC = &Kind{
+	Name:    KindIOC,
+	Dir:     "iocs",
+	Aliases: []string{"ioc", "iocs", "indicators"},
+	Plan:    planIndicators,
+	Export:  exportIndicators,
+}
+
+func exportIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification) ([]*manifest.Object, error) {
+	live, err := iocShared.QueryIndicators(ctx, c, "", "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	groupNames, err := hostGroupNames(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []*manifest.Object{}
+	for _, in := range live {
+		spec := IOCSpecFromAPI(in, groupNames)
+		objects = append(objects, &manifest.Object{Name: spec.key(), Spec: spec})
+	}
+	return objects, nil
+}
+
+// hostGroupNames maps the IDs of the host groups to their names
+func hostGroupNames(ctx context.Context, c *client.CrowdStrikeAPISpecification) (map[string]string, error) {
+	groups, err := hosts.QueryGroups(ctx, c, "")
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+	for _, g := range groups {
+		names[utils.Deref(g.ID)] = utils.Deref(g.Name)
+	}
+	return names, nil
+}
+
+func planIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification, docs []*manifest.Document, prune bool) (manifest.Plan, error) {
+	specs := []*IOCSpec{}
+	indicators := map[string]*ioc.Indicator{}
+
+	for _, d := range docs {
+		spec := &IOCSpec{}
+		if err := d.Decode(spec); err != nil {
+			return nil, err
+		}
+
+		i, err := spec.indicator()
+		if err != nil {
+			return nil, d.Errorf("%v", err)
+		}
+
+		if _, ok := indicators[spec.key()]; ok {
+			return nil, d.Errorf("indicator %s is described more than once", spec.key())
+		}
+		indicators[spec.key()] = i
+
+		specs = append(specs, spec)
+	}
+
+	live, err := queryIndicators(ctx, c, specs, prune)
+	if err != nil {
+		return nil, err
+	}
+
+	groupNames, err := hostGroupNames(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]*models.APIIndicatorV1{}
+	for _, in := range live {
+		existing[in.Type+":"+strings.ToLower(in.Value)] = in
+	}
+
+	plan := manifest.Plan{}
+	for _, spec := range specs {
+		spec, i := spec, indicators[spec.key()]
+		in, ok := existing[spec.key()]
+
+		if !ok {
+			fields, err := changes(nil, spec)
+			if err != nil {
+				return nil, err
+			}
+
+			plan = append(plan, &manifest.Step{
+				Kind:    KindIOC,
+				Name:    spec.key(),
+				Action:  manifest.ActionCreate,
+				Changes: fields,
+				Run: func(ctx context.Context) error {
+					return createIndicator(ctx, c, i)
+				},
+			})
+			continue
+		}
+
+		// optional fields left out of the manifest are left unchanged
+		have := IOCSpecFromAPI(in, groupNames)
+		if spec.Severity == "" {
+			have.Severity = ""
+		}
+		if spec.Expiration == "" {
+			have.Expiration = ""
+		}
+		if spec.Description == "" {
+			have.Description = ""
+		}
+		if spec.Tags == nil {
+			have.Tags = nil
+		}
+		if spec.Source == "" {
+			have.Source = ""
+		}
+
+		fields, err := changes(have, spec)
+		if err != nil {
+			return nil, err
+		}
+
+		plan = append(plan, update(KindIOC, spec.key(), fields, func(ctx context.Context) error {
+			return updateIndicator(ctx, c, in, i)
+		}))
+	}
+
+	if prune {
+		for _, in := range live {
+			key := in.Type + ":" + strings.ToLower(in.Value)
+			if _, ok := indicators[key]; ok {
+				continue
+			}
+
+			id := in.ID
+			plan = append(plan, &manifest.Step{
+				Kind:   KindIOC,
+				Name:   key,
+				Action: manifest.ActionDelete,
+				Run: func(ctx context.Context) error {
+					_, err := iocShared.DeleteIndicators(ctx, c, []string{id}, "", "")
+					return err
+				},
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+// queryIndicators returns the live indicators with the values of the
+// manifests, or every indicator when pruning
+func queryIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification, specs []*IOCSpec, all bool) ([]*models.APIIndicatorV1, error) {
+	if all {
+		return iocShared.QueryIndicators(ctx, c, "", "", 0)
+	}
+
+	values := []string{}
+	for _, s := range specs {
+		values = append(values, s.Value)
+	}
+
+	live := []*models.APIIndicatorV1{}
+	for _, chunk := range utils.Chunk(values, maxValuesPerQuery) {
+		list, err := iocShared.QueryIndicators(ctx, c, fql.New().In("value", chunk...).String(), "", 0)
+		if err != nil {
+			return nil, err
+		}
+		live = append(live, list...)
+	}
+
+	return live, nil
+}
+
+// resolveGroups replaces the host group names of an indicator with their IDs
+func resolveGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification, names []string) ([]string, error) {
+	resolved, err := hosts.ResolveGroupIDs(ctx, c, names)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, name := range names {
+		ids = append(ids, resolved[name])
+	}
+	return ids, nil
+}
+
+func createIndicator(ctx context.Context, c *client.CrowdStrikeAPISpecification, i *ioc.Indicator) error {
+	created := *i
+	ids, err := resolveGroups(ctx, c, i.HostGroups)
+	if err != nil {
+		return err
+	}
+	created.HostGroups = ids
+
+	results := iocShared.CreateIndicators(ctx, c, []*ioc.Row{{Indicator: &created}}, "", false)
+	if len(results) == 1 && results[0].Status != iocShared.StatusCreated {
+		return fmt.Errorf("%s", results[0].Error)
+	}
+	return nil
+}
+
+func updateIndicator(ctx context.Context, c *client.CrowdStrikeAPISpecification, in *models.APIIndicatorV1, i *ioc.Indicator) error {
+	ids, err := resolveGroups(ctx, c, i.HostGroups)
+	if err != nil {
+		return err
+	}
+
+	req := iocShared.UpdateRequest(in)
+	req.Action = i.Action
+	req.Platforms = i.Platforms
+	req.AppliedGlobally = i.AppliedGlobally
+	req.HostGroups = ids
+	if i.Severity != "" {
+		req.Severity = i.Severity
+	}
+	if !i.Expiration.IsZero() {
+		req.Expiration = strfmt.DateTime(i.Expiration)
+	}
+	if i.Description != "" {
+		req.Description = i.Description
+	}
+	if i.Tags != nil {
+		req.Tags = i.Tags
+	}
+	if i.Source != "" {
+		req.Source = i.Source
+	}
+
+	_, err = iocShared.UpdateIndicators(ctx, c, []*models.APIIndicatorUpdateReqV1{req}, "", false)
+	return err
+}
+
+// sorted returns a sorted copy of list, or nil for an empty list
+func sorted(list []string) []string {
+	if len(list) == 0 {
+		return nil
+	}
+	s := append([]string{}, list...)
+	sort.Strings(s)
+	return s
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+)
+
+// policyKind plans the manifests of a policy type, which are the
+// specifications read by "falcon policy <type> apply"
+func policyKind(t *policy.Type) *Kind {
+	return &Kind{
+		Name:    t.Kind,
+		Dir:     strings.ReplaceAll(t.Plural, " ", "-"),
+		Aliases: []string{"policies", t.Name},
+		Plan: func(ctx context.Context, c *client.CrowdStrikeAPISpecification, docs []*manifest.Document, prune bool) (manifest.Plan, error) {
+			return planPolicies(ctx, c, t, docs, prune)
+		},
+		Export: func(ctx context.Context, c *client.CrowdStrikeAPISpecification) ([]*manifest.Object, error) {
+			list, err := t.New(c).List(ctx, "")
+			if err != nil {
+				return nil, err
+			}
+
+			objects := []*manifest.Object{}
+			for _, p := range list {
+				objects = append(objects, &manifest.Object{Name: policyName(p.Platform, p.Name), Spec: p.Spec(t)})
+			}
+			return objects, nil
+		},
+	}
+}
+
+func planPolicies(ctx context.Context, c *client.CrowdStrikeAPISpecification, t *policy.Type, docs []*manifest.Document, prune bool) (manifest.Plan, error) {
+	specs := []*policy.Spec{}
+	seen := map[string]bool{}
+
+	for _, d := range docs {
+		spec := &policy.Spec{}
+		if err := d.Decode(spec); err != nil {
+			return nil, err
+		}
+		if err := spec.Validate(t); err != nil {
+			return nil, d.Errorf("%v", err)
+		}
+
+		key := policyKey(spec.Platform, spec.Name)
+		if seen[key] {
+			return nil, d.Errorf("%s %q is described more than once for %s", t.Title, spec.Name, spec.Platform)
+		}
+		seen[key] = true
+
+		specs = append(specs, spec)
+	}
+
+	svc := t.New(c)
+	list, err := svc.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]*policy.Policy{}
+	for _, p := range list {
+		existing[policyKey(p.Platform, p.Name)] = p
+	}
+
+	plan := manifest.Plan{}
+	for _, spec := range specs {
+		spec := spec
+		apply := func(ctx context.Context) error {
+			_, _, err := policy.Apply(ctx, c, t, spec)
+			return err
+		}
+
+		p, ok := existing[policyKey(spec.Platform, spec.Name)]
+		if !ok {
+			fields, err := changes(nil, spec)
+			if err != nil {
+				return nil, err
+			}
+
+			plan = append(plan, &manifest.Step{
+				Kind:    t.Kind,
+				Name:    policyName(spec.Platform, spec.Name),
+				Action:  manifest.ActionCreate,
+				Changes: fields,
+				Run:     apply,
+			})
+			continue
+		}
+
+		fields, err := policy.Changes(t, p, spec)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, update(t.Kind, policyName(spec.Platform, spec.Name), fields, apply))
+	}
+
+	if prune {
+		for _, p := range list {
+			if p.Name == policy.DefaultPolicyName || seen[policyKey(p.Platform, p.Name)] {
+				continue
+			}
+
+			p := p
+			plan = append(plan, &manifest.Step{
+				Kind:   t.Kind,
+				Name:   policyName(p.Platform, p.Name),
+				Action: manifest.ActionDelete,
+				Run: func(ctx context.Context) error {
+					// enabled policies cannot be deleted
+					if p.Enabled {
+						if err := svc.Action(ctx, policy.ActionDisable, []string{p.ID}, ""); err != nil {
+							return err
+						}
+					}
+					return svc.Delete(ctx, []string{p.ID})
+				},
+			})
+		}
+	}
+
+	return plan, nil
+}
+
+// policyKey identifies a policy by platform and name, as names are only
+// unique within a platform
+func policyKey(platform, name string) string {
+	return strings.ToLower(platform + "/" + name)
+}
+
+// policyName names a policy in plans
+func policyName(platform, name string) string {
+	return platform + "/" + name
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/exclusion"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+)
+
+// Kind plans the changes for the manifests of one kind of resource
+type Kind struct {
+	// Name is the value of the kind field of the manifests
+	Name string
+	// Dir is the directory manifests of the kind are exported to
+	Dir string
+	// Aliases are the other names of the kind accepted by --kinds. Several
+	// kinds can share an alias, e.g. policies.
+	Aliases []string
+	// Plan returns a step for each manifest and, when prune is set, a
+	// delete step for each resource of the tenant without a manifest
+	Plan func(ctx context.Context, c *client.CrowdStrikeAPISpecification, docs []*manifest.Document, prune bool) (manifest.Plan, error)
+	// Export returns the manifest of every resource of the kind
+	Export func(ctx context.Context, c *client.CrowdStrikeAPISpecification) ([]*manifest.Object, error)
+}
+
+// Kinds lists the supported kinds of manifests with their dependencies
+// first: policies, exclusions and indicators are assigned to host groups,
+// and firewall policies reference firewall rule groups.
+var Kinds = []*Kind{
+	HostGroup,
+	FirewallRuleGroup,
+	policyKind(policy.Prevention),
+	policyKind(policy.SensorUpdate),
+	policyKind(policy.Firewall),
+	policyKind(policy.DeviceControl),
+	policyKind(policy.Response),
+	exclusionKind(exclusion.ML, "ml-exclusions"),
+	exclusionKind(exclusion.IOA, "ioa-exclusions"),
+	exclusionKind(exclusion.SV, "sv-exclusions"),
+	IOC,
+}
+
+// KindNames returns the names of the supported kinds in dependency order
+func KindNames() []string {
+	names := []string{}
+	for _, k := range Kinds {
+		names = append(names, k.Name)
+	}
+	return names
+}
+
+// SelectKinds returns the kinds matching the given names or aliases, case
+// insensitively, in dependency order. No names selects every kind.
+func SelectKinds(names []string) ([]*Kind, error) {
+	if len(names) == 0 {
+		return Kinds, nil
+	}
+
+	selected := map[*Kind]bool{}
+	for _, name := range names {
+		found := false
+		for _, k := range Kinds {
+			for _, alias := range append([]string{k.Name}, k.Aliases...) {
+				if strings.EqualFold(alias, strings.TrimSpace(name)) {
+					selected[k] = true
+					found = true
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown kind %q, must be one of: %s", name, strings.Join(kindAliases(), ", "))
+		}
+	}
+
+	kinds := []*Kind{}
+	for _, k := range Kinds {
+		if selected[k] {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds, nil
+}
+
+// kindAliases lists the names and aliases accepted by SelectKinds
+func kindAliases() []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, k := range Kinds {
+		for _, alias := range append([]string{k.Name}, k.Aliases...) {
+			if !seen[alias] {
+				names = append(names, alias)
+				seen[alias] = true
+			}
+		}
+	}
+	return names
+}
+
+// Options are the flags shared by the apply and diff commands
+type Options struct {
+	Filenames []string
+	Prune     bool
+}
+
+// AddFlags registers the flags selecting the manifests
+func AddFlags(cmd *cobra.Command, opts *Options) {
+	cmd.Flags().StringSliceVar(&opts.Filenames, "filename", nil, "Manifest file or directory, or - to read from stdin (repeatable)")
+	cmd.Flags().BoolVar(&opts.Prune, "prune", false, "Delete the resources of the kinds present in the manifests that no manifest describes")
+}
+
+// Paths returns the manifest paths given as arguments and with --filename
+func (o *Options) Paths(args []string) ([]string, error) {
+	paths := append(append([]string{}, args...), o.Filenames...)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no manifests given, pass files or directories as arguments or with --filename")
+	}
+	return paths, nil
+}
+
+// Plan computes the changes that bring the tenant in line with the
+// manifests. Only the kinds present in the manifests are compared, so
+// pruning never touches other kinds of resources.
+func Plan(ctx context.Context, c *client.CrowdStrikeAPISpecification, docs []*manifest.Document, prune bool) (manifest.Plan, error) {
+	grouped, err := manifest.ByKind(docs, KindNames())
+	if err != nil {
+		return nil, err
+	}
+
+	plan := manifest.Plan{}
+	for _, k := range Kinds {
+		if len(grouped[k.Name]) == 0 {
+			continue
+		}
+
+		steps, err := k.Plan(ctx, c, grouped[k.Name], prune)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, steps...)
+	}
+
+	plan.Order(KindNames())
+	return plan, nil
+}
+
+// changes compares two values through their JSON representation. have is
+// nil for resources that do not exist yet.
+func changes(have, want interface{}) ([]diff.Change, error) {
+	a := interface{}(map[string]interface{}{})
+	if have != nil {
+		var err error
+		if a, err = output.ToGeneric(have); err != nil {
+			return nil, err
+		}
+	}
+
+	b, err := output.ToGeneric(want)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Compare(a, b), nil
+}
+
+// update returns an update step, or an unchanged step when there are no
+// changes
+func update(kind, name string, changes []diff.Change, run func(ctx context.Context) error) *manifest.Step {
+	action := manifest.ActionUpdate
+	if len(changes) == 0 {
+		action = manifest.ActionUnchanged
+	}
+	return &manifest.Step{Kind: kind, Name: name, Action: action, Changes: changes, Run: run}
+}
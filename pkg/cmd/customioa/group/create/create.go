@@ -0,0 +1,135 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package create
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/customioa"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type CreateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Name        string
+	Platform    string
+	Description string
+	Enable      bool
+	Comment     string
+}
+
+var (
+	shortDesc = `Create a custom IOA rule group`
+	longDesc  = templates.LongDesc(`
+		Create a custom IOA rule group for a platform, as listed by
+		"falcon custom-ioa platforms".
+
+		Rule groups are created disabled unless --enable is given. A rule
+		group takes effect once it is also assigned to a prevention policy.`)
+	examples = templates.Examples(`
+		# Create a rule group for Windows
+		falcon custom-ioa group create LOLBins --platform windows --description "Living off the land binaries"
+	`)
+)
+
+// NewCmdCreate represents the custom-ioa group create command
+func NewCmdCreate(f *factory.Factory) *cobra.Command {
+	opts := &CreateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "create <name>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+			return createRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Platform, "platform", "", "Platform of the rule group")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of the rule group")
+	cmd.Flags().BoolVar(&opts.Enable, "enable", false, "Enable the rule group once created")
+	utils.AddCommentFlag(cmd, &opts.Comment)
+	_ = cmd.MarkFlagRequired("platform")
+
+	return cmd
+}
+
+func createRun(ctx context.Context, opts *CreateOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	platforms, err := customioa.Platforms(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	platform := ""
+	ids := []string{}
+	for _, p := range platforms {
+		if strings.EqualFold(p.ID, opts.Platform) || strings.EqualFold(p.Label, opts.Platform) {
+			platform = p.ID
+		}
+		ids = append(ids, p.ID)
+	}
+	if platform == "" {
+		return fmt.Errorf("invalid platform %q, must be one of: %s", opts.Platform, strings.Join(ids, ", "))
+	}
+
+	_, err = customioa.FindGroup(ctx, c, opts.Name)
+	if err == nil {
+		return fmt.Errorf("rule group %q already exists", opts.Name)
+	}
+	if !errors.Is(err, customioa.ErrNotFound) {
+		return err
+	}
+
+	g, err := customioa.CreateGroup(ctx, c, opts.Name, platform, opts.Description, opts.Comment)
+	if err != nil {
+		return err
+	}
+
+	if opts.Enable {
+		if g, err = customioa.SetGroupEnabled(ctx, c, g, true, opts.Comment); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Created rule group %q (%s)\n", g.Name, g.ID)
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package enable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/customioa"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type EnableOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Enable  bool
+	Groups  []string
+	Comment string
+}
+
+// NewCmdEnable represents the custom-ioa group enable command
+func NewCmdEnable(f *factory.Factory) *cobra.Command {
+	return newCmd(f, true)
+}
+
+// NewCmdDisable represents the custom-ioa group disable command
+func NewCmdDisable(f *factory.Factory) *cobra.Command {
+	return newCmd(f, false)
+}
+
+func newCmd(f *factory.Factory, enable bool) *cobra.Command {
+	opts := &EnableOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Enable:       enable,
+	}
+
+	action, title := "disable", "Disable"
+	if enable {
+		action, title = "enable", "Enable"
+	}
+
+	cmd := &cobra.Command{
+		Use:   action + " <group>...",
+		Short: title + " custom IOA rule groups",
+		Long: templates.LongDesc(fmt.Sprintf(`
+			%s one or more custom IOA rule groups, given by name or ID.`, title)),
+		Example: templates.Examples(fmt.Sprintf(`
+			# %[2]s a rule group
+			falcon custom-ioa group %[1]s LOLBins --comment CHG-1234
+		`, action, title)),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Groups = args
+			return enableRun(cmd.Context(), opts)
+		},
+	}
+
+	utils.AddCommentFlag(cmd, &opts.Comment)
+
+	return cmd
+}
+
+func enableRun(ctx context.Context, opts *EnableOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	groups := []*customioa.Group{}
+	for _, name := range opts.Groups {
+		g, err := customioa.FindGroup(ctx, c, name)
+		if err != nil {
+			return err
+		}
+		groups = append(groups, g)
+	}
+
+	for _, g := range groups {
+		if g.Enabled == opts.Enable {
+			continue
+		}
+		if _, err := customioa.SetGroupEnabled(ctx, c, g, opts.Enable, opts.Comment); err != nil {
+			return err
+		}
+	}
+
+	state := "Disabled"
+	if opts.Enable {
+		state = "Enabled"
+	}
+	fmt.Fprintf(opts.IO.ErrOut, "%s %d rule groups\n", state, len(groups))
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package group
+
+import (
+	createCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/customioa/group/create"
+	deleteCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/customioa/group/delete"
+	enableCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/customioa/group/enable"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/customioa/group/list"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdGroup represents the custom-ioa group command
+func NewCmdGroup(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "group <command>",
+		Short:   "Manage custom IOA rule groups",
+		Aliases: []string{"groups"},
+	}
+
+	cmd.AddCommand(
+		listCmd.NewCmdList(f),
+		createCmd.NewCmdCreate(f),
+		enableCmd.NewCmdEnable(f),
+		enableCmd.NewCmdDisable(f),
+		deleteCmd.NewCmdDelete(f),
+	)
+	return cmd
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package list
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/customioa/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/customioa"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Filter string
+	Format string
+}
+
+var (
+	shortDesc = `List custom IOA rule groups`
+	longDesc  = templates.LongDesc(`
+		List custom IOA rule groups with the number of rules they hold.`)
+	examples = templates.Examples(`
+		# List the rule groups
+		falcon custom-ioa group list
+
+		# List the enabled Windows rule groups
+		falcon custom-ioa group list --filter "platform:'windows'+enabled:true"
+	`)
+)
+
+// NewCmdList represents the custom-ioa group list command
+func NewCmdList(f *factory.Factory) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter rule groups using a Falcon Query Language (FQL) expression")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := customioa.ListGroups(ctx, c, opts.Filter)
+	if err != nil {
+		return err
+	}
+
+	return shared.PrintGroups(opts.IO.Out, opts.Format, list)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package platforms
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/customioa"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type PlatformsOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Format string
+}
+
+var (
+	shortDesc = `List the platforms of custom IOA rule groups`
+	longDesc  = templates.LongDesc(`
+		List the platforms custom IOA rule groups can be created for.`)
+	examples = templates.Examples(`
+		falcon custom-ioa platforms
+	`)
+)
+
+// NewCmdPlatforms represents the custom-ioa platforms command
+func NewCmdPlatforms(f *factory.Factory) *cobra.Command {
+	opts := &PlatformsOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "platforms",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			return platformsRun(cmd.Context(), opts)
+		},
+	}
+
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func platformsRun(ctx context.Context, opts *PlatformsOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := customioa.Platforms(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	return output.Print(opts.IO.Out, opts.Format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "LABEL")
+		for _, p := range list {
+			t.AddRow(p.ID, p.Label)
+		}
+	})
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package add
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/customioa"
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type AddOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Files   []string
+	Group   string
+	Comment string
+	DryRun  bool
+}
+
+var (
+	shortDesc = `Add custom IOA rules from YAML specifications`
+	longDesc  = templates.LongDesc(`
+		Add custom IOA rules to rule groups from YAML specifications such
+		as:
+
+		    kind: CustomIOARule
+		    name: certutil download
+		    rule_group: LOLBins
+		    rule_type: Process Creation
+		    severity: high
+		    action: Detect
+		    enabled: true
+		    fields:
+		      - name: ImageFilename
+		        include: '.*\\certutil\.exe'
+		      - name: CommandLine
+		        include: '.*-urlcache.*'
+		        exclude: '.*http://localhost/.*'
+
+		The rule group, rule type and action are given by name. Use
+		"falcon custom-ioa rule-types" to list the rule types with their
+		fields and actions. Fields take include and exclude regular
+		expressions, or values for fields matching a set of values. Fields
+		that are left out match anything.
+
+		A rule of the same name in the group is updated instead. All rules
+		are checked before any is added. Use --dry-run to only show the
+		changes, and "falcon custom-ioa rule test" to try the regular
+		expressions against samples first.`)
+	examples = templates.Examples(`
+		# Show what adding the rules of a directory would change
+		falcon custom-ioa rule add rules/*.yaml --dry-run
+
+		# Add a rule to a group not named in the file
+		falcon custom-ioa rule add certutil.yaml --group "LOLBins (staging)" --comment CHG-1234
+	`)
+)
+
+// NewCmdAdd represents the custom-ioa rule add command
+func NewCmdAdd(f *factory.Factory) *cobra.Command {
+	opts := &AddOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "add <file>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Files = args
+			return addRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Group, "group", "", "Rule group to add the rules to, overriding rule_group")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the changes without making them")
+	utils.AddCommentFlag(cmd, &opts.Comment)
+
+	return cmd
+}
+
+func addRun(ctx context.Context, opts *AddOptions) error {
+	specs, err := manifest.ReadFiles(opts.Files, opts.IO.In, customioa.ReadSpecs)
+	if err != nil {
+		return err
+	}
+
+	for _, spec := range specs {
+		if opts.Group != "" {
+			spec.RuleGroup = opts.Group
+		}
+		if spec.RuleGroup == "" {
+			return fmt.Errorf("rule %q has no rule_group, use --group", spec.Name)
+		}
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	types, err := customioa.RuleTypes(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	// check every rule before changing any
+	ruleTypes := map[*customioa.RuleSpec]*customioa.RuleType{}
+	for _, spec := range specs {
+		g, err := customioa.FindGroup(ctx, c, spec.RuleGroup)
+		if err != nil {
+			return err
+		}
+
+		t, err := spec.Resolve(types, g.Platform)
+		if err != nil {
+			return fmt.Errorf("rule %q: %v", spec.Name, err)
+		}
+		ruleTypes[spec] = t
+	}
+
+	for _, spec := range specs {
+		if err := add(ctx, c, opts, spec, ruleTypes[spec]); err != nil {
+			return fmt.Errorf("rule %q: %v", spec.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// add creates or updates the rule of a specification. The group is looked
+// up again for each rule as every change bumps its version.
+func add(ctx context.Context, c *client.CrowdStrikeAPISpecification, opts *AddOptions, spec *customioa.RuleSpec, t *customioa.RuleType) error {
+	g, err := customioa.FindGroup(ctx, c, spec.RuleGroup)
+	if err != nil {
+		return err
+	}
+
+	current, err := g.FindRule(spec.Name)
+	if errors.Is(err, customioa.ErrNotFound) {
+		if opts.DryRun {
+			fmt.Fprintf(opts.IO.Out, "Rule %q would be added to rule group %q\n", spec.Name, g.Name)
+			return nil
+		}
+		return create(ctx, c, opts, g, spec, t)
+	}
+	if err != nil {
+		return err
+	}
+
+	changes, err := customioa.Changes(current, spec)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(opts.IO.Out, "Rule %q of rule group %q is up to date\n", spec.Name, g.Name)
+		return nil
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(opts.IO.Out, "Rule %q of rule group %q would be updated:\n", spec.Name, g.Name)
+		return diff.Print(opts.IO.Out, changes)
+	}
+
+	r, err := customioa.UpdateRule(ctx, c, g, current, t, spec, opts.Comment)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Updated rule %q of rule group %q (%s)\n", r.Name, g.Name, r.ID)
+	return nil
+}
+
+// create adds a rule to a group. Rules are created disabled, so a rule to
+// be enabled is enabled once created.
+func create(ctx context.Context, c *client.CrowdStrikeAPISpecification, opts *AddOptions, g *customioa.Group, spec *customioa.RuleSpec, t *customioa.RuleType) error {
+	r, err := customioa.CreateRule(ctx, c, g, t, spec, opts.Comment)
+	if err != nil {
+		return err
+	}
+
+	if spec.Enabled != nil && *spec.Enabled && !r.Enabled {
+		if g, err = customioa.FindGroup(ctx, c, g.ID); err != nil {
+			return err
+		}
+		if err := customioa.SetRulesEnabled(ctx, c, g, []*customioa.Rule{r}, true, opts.Comment); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Added rule %q to rule group %q (%s)\n", r.Name, g.Name, r.ID)
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/crowdstrike/falcon-cli/pkg/customioa"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ExportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Groups []string
+	File   string
+}
+
+var (
+	shortDesc = `Export custom IOA rules as YAML specifications`
+	longDesc  = templates.LongDesc(`
+		Export the rules of custom IOA rule groups, given by name or ID, as
+		a stream of YAML specifications that can be added again with
+		"falcon custom-ioa rule add".
+
+		Fields that match anything are left out.`)
+	examples = templates.Examples(`
+		# Keep the rules of a group under version control
+		falcon custom-ioa rule export LOLBins --file rules/lolbins.yaml
+	`)
+)
+
+// NewCmdExport represents the custom-ioa rule export command
+func NewCmdExport(f *factory.Factory) *cobra.Command {
+	opts := &ExportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "export <group>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Groups = args
+			return exportRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.File, "file", "", "Write the specifications to a file instead of standard output")
+
+	return cmd
+}
+
+func exportRun(ctx context.Context, opts *ExportOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	specs := []*customioa.RuleSpec{}
+	for _, name := range opts.Groups {
+		g, err := customioa.FindGroup(ctx, c, name)
+		if err != nil {
+			return err
+		}
+		rules := []*customioa.RuleSpec{}
+		for _, r := range g.Rules {
+			rules = append(rules, r.Spec(g.Name))
+		}
+		sort.SliceStable(rules, func(i, j int) bool {
+			return rules[i].Name < rules[j].Name
+		})
+		specs = append(specs, rules...)
+	}
+
+	var w io.Writer = opts.IO.Out
+	if opts.File != "" {
+		f, err := os.Create(filepath.Clean(opts.File))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := customioa.WriteSpecs(w, specs); err != nil {
+		return err
+	}
+
+	if opts.File != "" {
+		fmt.Fprintf(opts.IO.ErrOut, "Exported %d rules to %s\n", len(specs), opts.File)
+	}
+	return nil
+}
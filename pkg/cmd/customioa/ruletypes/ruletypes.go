@@ -0,0 +1,124 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ruletypes
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/customioa"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type RuleTypesOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Platform string
+	Format   string
+}
+
+var (
+	shortDesc = `List the rule types of custom IOA rules`
+	longDesc  = templates.LongDesc(`
+		List the types of custom IOA rules with the fields their rules match
+		on and the actions they can take.
+
+		The rule_type, fields and action of a rule specification use the
+		names listed here.`)
+	examples = templates.Examples(`
+		# List the rule types of Linux
+		falcon custom-ioa rule-types --platform linux
+
+		# Show every detail of the rule types as JSON
+		falcon custom-ioa rule-types -o json
+	`)
+)
+
+// NewCmdRuleTypes represents the custom-ioa rule-types command
+func NewCmdRuleTypes(f *factory.Factory) *cobra.Command {
+	opts := &RuleTypesOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "rule-types",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			return ruleTypesRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Platform, "platform", "", "Only list the rule types of a platform")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func ruleTypesRun(ctx context.Context, opts *RuleTypesOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	types, err := customioa.RuleTypes(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	list := []*customioa.RuleType{}
+	for _, t := range types {
+		if opts.Platform == "" || strings.EqualFold(t.Platform, opts.Platform) {
+			list = append(list, t)
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Platform != list[j].Platform {
+			return list[i].Platform < list[j].Platform
+		}
+		return list[i].Name < list[j].Name
+	})
+
+	return output.Print(opts.IO.Out, opts.Format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "NAME", "PLATFORM", "FIELDS", "ACTIONS")
+		for _, rt := range list {
+			actions := []string{}
+			for _, d := range rt.Dispositions {
+				actions = append(actions, d.Label)
+			}
+			t.AddRow(rt.ID, rt.Name, rt.Platform, strings.Join(rt.Fields, ","), strings.Join(actions, ","))
+		}
+	})
+}
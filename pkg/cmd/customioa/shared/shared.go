@@ -0,0 +1,71 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/customioa"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+)
+
+// PrintGroups writes a list of rule groups in the requested format
+func PrintGroups(w io.Writer, format string, list []*customioa.Group) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "NAME", "PLATFORM", "ENABLED", "RULES", "VERSION", "MODIFIED")
+		for _, g := range list {
+			t.AddRow(
+				g.ID,
+				g.Name,
+				g.Platform,
+				strconv.FormatBool(g.Enabled),
+				strconv.Itoa(len(g.Rules)),
+				strconv.FormatInt(g.Version, 10),
+				output.Time(g.ModifiedOn),
+			)
+		}
+	})
+}
+
+// PrintRules writes a list of rules in the requested format
+func PrintRules(w io.Writer, format string, list []*customioa.Rule) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "NAME", "RULE TYPE", "SEVERITY", "ACTION", "ENABLED", "FIELDS", "MODIFIED")
+		for _, r := range list {
+			fields := []string{}
+			for _, f := range r.Spec("").Fields {
+				fields = append(fields, f.Name)
+			}
+			t.AddRow(
+				r.ID,
+				r.Name,
+				r.RuleTypeName,
+				r.Severity,
+				r.Action,
+				strconv.FormatBool(r.Enabled),
+				strings.Join(fields, ","),
+				output.Time(r.ModifiedOn),
+			)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package diff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/apply/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Compare manifests with the Falcon tenant`
+	longDesc  = templates.LongDesc(`
+		Print the changes "falcon apply" would make to bring the Falcon
+		tenant in line with YAML manifests.
+
+		The command exits with an error when the tenant differs from the
+		manifests, so it can be used to check for drift in CI pipelines.
+
+		Note that -f is the shorthand of the global --cid flag: pass the
+		manifests as arguments or with --filename.`)
+	examples = templates.Examples(`
+		# Check a directory of manifests for drift
+		falcon diff ./falcon
+
+		# List the changes as JSON, including resources to delete
+		falcon diff ./falcon --prune -o json
+	`)
+)
+
+type DiffOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	shared.Options
+	Paths  []string
+	Format string
+}
+
+// NewCmdDiff represents the diff command
+func NewCmdDiff(f *factory.Factory) *cobra.Command {
+	opts := &DiffOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "diff [<file|dir>...]",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Format != "text" && opts.Format != output.FormatJSON {
+				return fmt.Errorf("unsupported output format %q, must be one of: text|json", opts.Format)
+			}
+
+			paths, err := opts.Options.Paths(args)
+			if err != nil {
+				return err
+			}
+
+			opts.Paths = paths
+			return diffRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddFlags(cmd, &opts.Options)
+	cmd.Flags().StringVarP(&opts.Format, "output", "o", "text", "Output format. One of: text|json")
+
+	return cmd
+}
+
+func diffRun(ctx context.Context, opts *DiffOptions) error {
+	docs, err := manifest.ReadPaths(opts.Paths, opts.IO.In)
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	plan, err := shared.Plan(ctx, c, docs, opts.Prune)
+	if err != nil {
+		return err
+	}
+
+	pending := plan.Pending()
+	if opts.Format == output.FormatJSON {
+		if err := output.PrintJSON(opts.IO.Out, pending); err != nil {
+			return err
+		}
+	} else if len(pending) > 0 {
+		if err := plan.Print(opts.IO.Out); err != nil {
+			return err
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("the tenant differs from the manifests: %s", pending.Summary())
+	}
+
+	fmt.Fprintln(opts.IO.ErrOut, "No differences")
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package accounts
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/discover/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/discover"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List the accounts seen on hosts`
+	longDesc  = templates.LongDesc(`
+		List the user accounts seen by Falcon Discover on managed hosts,
+		with their last successful and failed logins.
+
+		Conditions given with flags are combined with any FQL expression
+		given with --filter, on fields such as username, account_name,
+		account_type, login_domain, admin_privileges or
+		last_successful_login_timestamp.
+
+		Use --count-by to count the matching accounts by the values of
+		fields instead of listing them.`)
+	examples = templates.Examples(`
+		# List the local accounts with administrator privileges
+		falcon discover accounts --admin --type Local --all
+
+		# Count the accounts by domain
+		falcon discover accounts --count-by login_domain
+	`)
+)
+
+type AccountsOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Usernames []string
+	Types     []string
+	Admin     bool
+	List      shared.ListFlags
+}
+
+// NewCmdAccounts represents the discover accounts command
+func NewCmdAccounts(f *factory.Factory) *cobra.Command {
+	opts := &AccountsOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "accounts",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.List.Validate(); err != nil {
+				return err
+			}
+			return accountsRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Usernames, "username", nil, "Only list accounts of these user names")
+	cmd.Flags().StringSliceVar(&opts.Types, "type", nil, "Only list accounts of these types, e.g. Local or Domain")
+	cmd.Flags().BoolVar(&opts.Admin, "admin", false, "Only list accounts with administrator privileges")
+	shared.AddListFlags(cmd, &opts.List, "username|asc")
+
+	return cmd
+}
+
+func accountsRun(ctx context.Context, opts *AccountsOptions) error {
+	filter := fql.New().
+		In("username", opts.Usernames...).
+		In("account_type", opts.Types...)
+	if opts.Admin {
+		filter.Equal("admin_privileges", "Yes")
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := discover.Accounts(ctx, c, opts.List.Query(filter))
+	if err != nil {
+		return err
+	}
+
+	return opts.List.Print(opts.IO.Out, list, func(t *output.Table) {
+		t.SetHeaders("ID", "USERNAME", "DOMAIN", "TYPE", "ADMIN", "LAST LOGIN", "LAST LOGIN HOST")
+		for _, a := range list {
+			t.AddRow(
+				utils.Deref(a.ID),
+				a.Username,
+				a.LoginDomain,
+				a.AccountType,
+				a.AdminPrivileges,
+				a.LastSuccessfulLoginTimestamp,
+				a.LastSuccessfulLoginHostname,
+			)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package apps
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/discover/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/discover"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List the applications installed on hosts`
+	longDesc  = templates.LongDesc(`
+		List the applications seen by Falcon Discover, one entry per
+		application installed on a host.
+
+		Conditions given with flags are combined with any FQL expression
+		given with --filter, on fields such as name, vendor, version,
+		software_type, category, installation_timestamp,
+		last_used_timestamp, host.hostname or host.platform_name.
+
+		Use --count-by to count the matching applications by the values of
+		fields instead of listing them, such as the installed versions of
+		an application.`)
+	examples = templates.Examples(`
+		# Count the installed versions of an application
+		falcon discover apps --name "Google Chrome" --count-by version
+
+		# Find every host running a given application version
+		falcon discover apps --name "Google Chrome" --version 118.0.5993.70 --all
+
+		# Count the applications of a vendor by name and version
+		falcon discover apps --vendor Oracle --count-by name_vendor_version --size 0
+	`)
+)
+
+type AppsOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Names     []string
+	Vendors   []string
+	Versions  []string
+	Hostnames []string
+	List      shared.ListFlags
+}
+
+// NewCmdApps represents the discover apps command
+func NewCmdApps(f *factory.Factory) *cobra.Command {
+	opts := &AppsOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "apps",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"applications"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.List.Validate(); err != nil {
+				return err
+			}
+			return appsRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Names, "name", nil, "Only list applications of these names")
+	cmd.Flags().StringSliceVar(&opts.Vendors, "vendor", nil, "Only list applications of these vendors")
+	cmd.Flags().StringSliceVar(&opts.Versions, "version", nil, "Only list applications of these versions")
+	cmd.Flags().StringSliceVar(&opts.Hostnames, "hostname", nil, "Only list applications installed on these hosts")
+	shared.AddListFlags(cmd, &opts.List, "name|asc")
+
+	return cmd
+}
+
+func appsRun(ctx context.Context, opts *AppsOptions) error {
+	filter := fql.New().
+		In("name", opts.Names...).
+		In("vendor", opts.Vendors...).
+		In("version", opts.Versions...).
+		In("host.hostname", opts.Hostnames...)
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := discover.Applications(ctx, c, opts.List.Query(filter))
+	if err != nil {
+		return err
+	}
+
+	return opts.List.Print(opts.IO.Out, list, func(t *output.Table) {
+		t.SetHeaders("HOSTNAME", "PLATFORM", "NAME", "VENDOR", "VERSION", "LAST USED")
+		for _, a := range list {
+			hostname, platform := "", ""
+			if a.Host != nil {
+				hostname, platform = a.Host.Hostname, a.Host.PlatformName
+			}
+			t.AddRow(hostname, platform, a.Name, a.Vendor, a.Version, a.LastUsedTimestamp)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package discover
+
+import (
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/discover/accounts"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/discover/apps"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/discover/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/discover/logins"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Query the assets, applications and accounts seen by Falcon Discover`
+	longDesc  = templates.LongDesc(`
+		Query the hosts, applications, accounts and logins seen by Falcon
+		Discover, including unmanaged assets seen on the network by managed
+		hosts.
+
+		Every command takes an FQL filter and can count the matches by the
+		values of fields, such as the versions of an application, for quick
+		inventory answers.`)
+	examples = templates.Examples(`
+		# List the unmanaged assets seen in the last week
+		falcon discover hosts --unmanaged --since 7d
+
+		# Count the installed versions of an application
+		falcon discover apps --name "Google Chrome" --count-by version
+
+		# Find every host running a given application version
+		falcon discover apps --name "Google Chrome" --version 118.0.5993.70 --all
+	`)
+)
+
+// NewDiscoverCmd represents the discover command
+func NewDiscoverCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "discover <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		hosts.NewCmdHosts(f),
+		apps.NewCmdApps(f),
+		accounts.NewCmdAccounts(f),
+		logins.NewCmdLogins(f),
+	)
+	return cmd
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package hosts
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/discover/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/discover"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List the hosts seen by Falcon Discover`
+	longDesc  = templates.LongDesc(`
+		List the hosts seen by Falcon Discover: managed hosts running the
+		Falcon sensor, unmanaged hosts seen on the network by managed hosts
+		and unsupported hosts such as network devices.
+
+		Conditions given with flags are combined with any FQL expression
+		given with --filter, on fields such as hostname, entity_type,
+		platform_name, os_version, current_local_ip, external_ip, site_name
+		or last_seen_timestamp.
+
+		Use --count-by to count the matching hosts by the values of fields
+		instead of listing them.`)
+	examples = templates.Examples(`
+		# List the unmanaged assets seen in the last day
+		falcon discover hosts --unmanaged --since 24h --all
+
+		# Count the hosts by type and platform
+		falcon discover hosts --count-by entity_type,platform_name
+	`)
+)
+
+var entityTypes = []string{"managed", "unmanaged", "unsupported"}
+
+type HostsOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	EntityTypes []string
+	Unmanaged   bool
+	Platforms   []string
+	Since       string
+	List        shared.ListFlags
+}
+
+// NewCmdHosts represents the discover hosts command
+func NewCmdHosts(f *factory.Factory) *cobra.Command {
+	opts := &HostsOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "hosts",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.List.Validate(); err != nil {
+				return err
+			}
+			if err := utils.ValidateOneOf("--entity-type", entityTypes, opts.EntityTypes...); err != nil {
+				return err
+			}
+			return hostsRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.EntityTypes, "entity-type", nil, "Only list hosts of these types: managed, unmanaged or unsupported")
+	cmd.Flags().BoolVar(&opts.Unmanaged, "unmanaged", false, "Only list unmanaged hosts, same as --entity-type unmanaged")
+	cmd.Flags().StringSliceVar(&opts.Platforms, "platform", nil, "Only list hosts of these platforms, e.g. Windows, Mac or Linux")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only list hosts seen since a time, e.g. 24h, 7d or 2023-10-01")
+	shared.AddListFlags(cmd, &opts.List, "hostname|asc")
+
+	return cmd
+}
+
+func hostsRun(ctx context.Context, opts *HostsOptions) error {
+	since, err := shared.Since(opts.Since)
+	if err != nil {
+		return err
+	}
+
+	types := opts.EntityTypes
+	if opts.Unmanaged {
+		types = append(types, "unmanaged")
+	}
+
+	filter := fql.New().
+		In("entity_type", types...).
+		In("platform_name", opts.Platforms...).
+		Compare("last_seen_timestamp", ">=", since)
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := discover.Hosts(ctx, c, opts.List.Query(filter))
+	if err != nil {
+		return err
+	}
+
+	return opts.List.Print(opts.IO.Out, list, func(t *output.Table) {
+		t.SetHeaders("ID", "HOSTNAME", "TYPE", "PLATFORM", "OS VERSION", "LOCAL IP", "EXTERNAL IP", "LAST SEEN")
+		for _, h := range list {
+			t.AddRow(
+				utils.Deref(h.ID),
+				h.Hostname,
+				h.EntityType,
+				h.PlatformName,
+				h.OsVersion,
+				h.CurrentLocalIP,
+				h.ExternalIP,
+				h.LastSeenTimestamp,
+			)
+		}
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logins
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/discover/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/discover"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List the logins of accounts on hosts`
+	longDesc  = templates.LongDesc(`
+		List the logins of accounts on managed hosts seen by Falcon
+		Discover, most recent first.
+
+		Conditions given with flags are combined with any FQL expression
+		given with --filter, on fields such as username, hostname,
+		login_type, login_status, remote_ip, is_suspicious or
+		login_timestamp.
+
+		Use --count-by to count the matching logins by the values of fields
+		instead of listing them.`)
+	examples = templates.Examples(`
+		# List the failed logins of an account in the last day
+		falcon discover logins --username jdoe --failed --since 24h
+
+		# Count the logins on a host by user name and login type
+		falcon discover logins --hostname web-01 --since 7d --count-by username,login_type
+	`)
+)
+
+type LoginsOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Usernames []string
+	Hostnames []string
+	Failed    bool
+	Since     string
+	List      shared.ListFlags
+}
+
+// NewCmdLogins represents the discover logins command
+func NewCmdLogins(f *factory.Factory) *cobra.Command {
+	opts := &LoginsOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "logins",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.List.Validate(); err != nil {
+				return err
+			}
+			return loginsRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Usernames, "username", nil, "Only list logins of these user names")
+	cmd.Flags().StringSliceVar(&opts.Hostnames, "hostname", nil, "Only list logins on these hosts")
+	cmd.Flags().BoolVar(&opts.Failed, "failed", false, "Only list failed logins")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only list logins since a time, e.g. 24h, 7d or 2023-10-01")
+	shared.AddListFlags(cmd, &opts.List, "login_timestamp|desc")
+
+	return cmd
+}
+
+func loginsRun(ctx context.Context, opts *LoginsOptions) error {
+	since, err := shared.Since(opts.Since)
+	if err != nil {
+		return err
+	}
+
+	filter := fql.New().
+		In("username", opts.Usernames...).
+		In("hostname", opts.Hostnames...).
+		Compare("login_timestamp", ">=", since)
+	if opts.Failed {
+		filter.Equal("login_status", "Failed")
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := discover.Logins(ctx, c, opts.List.Query(filter))
+	if err != nil {
+		return err
+	}
+
+	return opts.List.Print(opts.IO.Out, list, func(t *output.Table) {
+		t.SetHeaders("TIME", "USERNAME", "DOMAIN", "HOSTNAME", "TYPE", "STATUS", "REMOTE IP")
+		for _, l := range list {
+			t.AddRow(l.LoginTimestamp, l.Username, l.LoginDomain, l.Hostname, l.LoginType, l.LoginStatus, l.RemoteIP)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/aggregates"
+	"github.com/crowdstrike/falcon-cli/pkg/discover"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/spf13/cobra"
+)
+
+// ListFlags holds the flags of the discover commands
+type ListFlags struct {
+	Filter  string
+	Sort    string
+	Limit   int
+	All     bool
+	CountBy []string
+	Size    int
+	Format  string
+}
+
+// AddListFlags adds the flags of the discover commands to cmd, sorting by
+// defaultSort unless told otherwise
+func AddListFlags(cmd *cobra.Command, flags *ListFlags, defaultSort string) {
+	cmd.Flags().StringVar(&flags.Filter, "filter", "", "FQL filter expression")
+	cmd.Flags().StringVar(&flags.Sort, "sort", defaultSort, "Sort results by a field")
+	cmd.Flags().IntVarP(&flags.Limit, "limit", "l", 50, "Maximum number of results to return")
+	cmd.Flags().BoolVar(&flags.All, "all", false, "Return all matching results, ignoring --limit")
+	cmd.Flags().StringSliceVar(&flags.CountBy, "count-by", nil, "Count the matching results by the values of fields instead of listing them")
+	cmd.Flags().IntVar(&flags.Size, "size", 20, "Maximum number of values to count by field, 0 for all")
+	output.AddFormatFlag(cmd, &flags.Format)
+}
+
+// Validate checks the flags
+func (f *ListFlags) Validate() error {
+	if err := output.ValidateFormat(f.Format); err != nil {
+		return err
+	}
+	if f.Limit < 1 {
+		return fmt.Errorf("--limit must be greater than 0")
+	}
+	if f.Size < 0 {
+		return fmt.Errorf("--size must not be negative")
+	}
+	return nil
+}
+
+// Query returns the query of the flags, adding the conditions of filter to
+// --filter. Counts are made over every match.
+func (f *ListFlags) Query(filter *fql.Filter) discover.Query {
+	q := discover.Query{
+		Filter: fql.New().Raw(f.Filter).Raw(filter.String()).String(),
+		Sort:   f.Sort,
+		Limit:  f.Limit,
+	}
+	if f.All || len(f.CountBy) > 0 {
+		q.Limit = 0
+	}
+	return q
+}
+
+// Since returns the RFC 3339 time of a --since flag, or an empty string
+// when not given
+func Since(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+
+	t, err := utils.ParseTime(s, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("invalid --since: %v", err)
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// Print writes list, a slice of entities, to w. With --count-by, the counts
+// of the values of each field are written instead.
+func (f *ListFlags) Print(w io.Writer, list interface{}, table func(t *output.Table)) error {
+	if len(f.CountBy) == 0 {
+		return output.Print(w, f.Format, list, table)
+	}
+
+	results := []*models.MsaAggregationResult{}
+	for _, field := range f.CountBy {
+		values, err := discover.FieldValues(list, field)
+		if err != nil {
+			return err
+		}
+		results = append(results, aggregates.Count(field, values, f.Size))
+	}
+	return aggregates.Print(w, f.Format, results)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package create
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/exclusion"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type CreateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *exclusion.Type
+
+	Key     string
+	Flags   shared.SpecFlags
+	Comment string
+	DryRun  bool
+}
+
+// NewCmdCreate represents the exclusions create command of an exclusion type
+func NewCmdCreate(f *factory.Factory, t *exclusion.Type) *cobra.Command {
+	opts := &CreateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("create <%s>", shared.KeyName(t)),
+		Short: fmt.Sprintf("Create %s %s", shared.Article(t), t.Title),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Create %[1]s %[2]s applied to host groups, given by name, or to
+			every host with --global.
+
+			%[3]s Use --dry-run to only check the exclusion.`, shared.Article(t), t.Title, patternHelp(t))),
+		Example: templates.Examples(createExamples(t)),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Flags.Validate(cmd.Flags().Changed); err != nil {
+				return err
+			}
+
+			opts.Key = args[0]
+			spec := &exclusion.Spec{Kind: t.Kind}
+			if t == exclusion.IOA {
+				spec.Name = opts.Key
+			} else {
+				spec.Value = opts.Key
+			}
+			opts.Flags.Apply(cmd.Flags().Changed, spec)
+
+			if err := t.Validate(spec); err != nil {
+				return err
+			}
+
+			if opts.DryRun {
+				fmt.Fprintf(opts.IO.Out, "%s %q is valid\n", t.Title, opts.Key)
+				return nil
+			}
+
+			return createRun(cmd.Context(), opts, spec)
+		},
+	}
+
+	shared.AddSpecFlags(cmd, t, &opts.Flags)
+	utils.AddCommentFlag(cmd, &opts.Comment)
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Validate the exclusion without creating it")
+
+	return cmd
+}
+
+// patternHelp describes how the patterns of an exclusion type are checked
+// before it is submitted
+func patternHelp(t *exclusion.Type) string {
+	if t == exclusion.IOA {
+		return `The regular expressions are checked before the exclusion is
+			submitted. An omitted expression matches anything, but at least
+			one must be given.`
+	}
+	return `The glob pattern is checked before the exclusion is submitted.
+			It must not start with a drive letter, a double asterisk must be
+			a whole path segment, and it must not match every file.`
+}
+
+func createExamples(t *exclusion.Type) string {
+	switch t {
+	case exclusion.IOA:
+		return fmt.Sprintf(`
+			# Stop a detection pattern from triggering for msbuild on the build servers
+			%s create "msbuild spawning cmd" --pattern-id 10197 --ifn-regex '.*\\msbuild\.exe' --cl-regex '.*/t:Build.*' --host-group "Build Servers"
+		`, shared.Command(t))
+	case exclusion.ML:
+		return fmt.Sprintf(`
+			# Exclude build outputs from detections and uploads on the build servers
+			%s create '/home/build/**/out/**' --excluded-from blocking,extraction --host-group "Build Servers" --comment CHG-1234
+		`, shared.Command(t))
+	}
+	return fmt.Sprintf(`
+		# Stop monitoring the processes of a backup agent on every host
+		%s create '/opt/backup/bin/**' --global --comment CHG-1234
+	`, shared.Command(t))
+}
+
+func createRun(ctx context.Context, opts *CreateOptions, spec *exclusion.Spec) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	svc := opts.Type.New(c)
+	_, err = exclusion.Find(ctx, svc, opts.Type, opts.Key)
+	if err == nil {
+		return fmt.Errorf("%s %q already exists", opts.Type.Title, opts.Key)
+	}
+	if !errors.Is(err, exclusion.ErrNotFound) {
+		return err
+	}
+
+	groups, err := exclusion.GroupIDs(ctx, c, spec)
+	if err != nil {
+		return err
+	}
+
+	e, err := svc.Create(ctx, spec, groups, opts.Comment)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Created %s %q (%s)\n", opts.Type.Title, opts.Key, e.ID)
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package delete
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/exclusion"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type DeleteOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *exclusion.Type
+
+	Exclusions []string
+	Comment    string
+}
+
+// NewCmdDelete represents the exclusions delete command of an exclusion type
+func NewCmdDelete(f *factory.Factory, t *exclusion.Type) *cobra.Command {
+	opts := &DeleteOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("delete <id|%s>...", shared.KeyName(t)),
+		Short: fmt.Sprintf("Delete %s", t.Plural),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Delete %s given by ID or %s. Pass "-" to read them from
+			standard input, one per line.`, t.Plural, shared.KeyName(t))),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Delete an exclusion, recording the change ticket
+			%[1]s delete <id> --comment CHG-1234
+		`, shared.Command(t))),
+		Aliases: []string{"rm"},
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			exclusions, err := utils.ReadIDs(args, opts.IO.In)
+			if err != nil {
+				return err
+			}
+
+			opts.Exclusions = exclusions
+			return deleteRun(cmd.Context(), opts)
+		},
+	}
+
+	utils.AddCommentFlag(cmd, &opts.Comment)
+
+	return cmd
+}
+
+func deleteRun(ctx context.Context, opts *DeleteOptions) error {
+	t := opts.Type
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	svc := t.New(c)
+	list, err := svc.List(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	missing := []string{}
+	for _, want := range opts.Exclusions {
+		found := false
+		for _, e := range list {
+			if e.ID == want || t.Key(e.Spec(t)) == want {
+				ids = append(ids, e.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("unknown %s: %s", t.Plural, strings.Join(missing, ", "))
+	}
+
+	if err := svc.Delete(ctx, ids, opts.Comment); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		fmt.Fprintf(opts.IO.Out, "Deleted %s %s\n", t.Title, id)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package exclusions
+
+import (
+	createCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/create"
+	deleteCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/delete"
+	exportCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/export"
+	importCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/import"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/list"
+	updateCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/update"
+	"github.com/crowdstrike/falcon-cli/pkg/exclusion"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage exclusions`
+	longDesc  = templates.LongDesc(`
+		Manage the exclusions that stop Falcon from detecting, blocking or
+		monitoring known good activity.
+
+		Machine learning (ml) exclusions stop files matching a glob pattern
+		from being detected or uploaded. Sensor visibility (sv) exclusions
+		stop the sensor from monitoring the processes started from them. IOA
+		exclusions stop a behavioral detection pattern from triggering for
+		processes matching a command line and image file name.`)
+	examples = templates.Examples(`
+		# List the machine learning exclusions
+		falcon exclusions ml list
+
+		# Keep the IOA exclusions in a file and apply changes from it
+		falcon exclusions ioa export --file ioa-exclusions.yaml
+		falcon exclusions ioa import ioa-exclusions.yaml --dry-run
+	`)
+)
+
+// NewExclusionsCmd represents the exclusions command
+func NewExclusionsCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "exclusions <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"exclusion"},
+	}
+
+	cmd.AddCommand(
+		newTypeCmd(f, exclusion.ML, "Manage machine learning exclusions"),
+		newTypeCmd(f, exclusion.IOA, "Manage IOA exclusions"),
+		newTypeCmd(f, exclusion.SV, "Manage sensor visibility exclusions"),
+	)
+	return cmd
+}
+
+// newTypeCmd returns the command managing one type of exclusion
+func newTypeCmd(f *factory.Factory, t *exclusion.Type, short string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   t.Name + " <command>",
+		Short: short,
+	}
+
+	cmd.AddCommand(
+		listCmd.NewCmdList(f, t),
+		createCmd.NewCmdCreate(f, t),
+		updateCmd.NewCmdUpdate(f, t),
+		deleteCmd.NewCmdDelete(f, t),
+		importCmd.NewCmdImport(f, t),
+		exportCmd.NewCmdExport(f, t),
+	)
+	return cmd
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/exclusion"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ExportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *exclusion.Type
+
+	Filter string
+	File   string
+}
+
+// NewCmdExport represents the exclusions export command of an exclusion type
+func NewCmdExport(f *factory.Factory, t *exclusion.Type) *cobra.Command {
+	opts := &ExportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: fmt.Sprintf("Export %s to a YAML file", t.Plural),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Write %[1]s as YAML specifications, sorted by %[2]s, that can be
+			edited and applied back with "%[3]s import".`, t.Plural, shared.KeyName(t), shared.Command(t))),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Export the exclusions to a file kept under version control
+			%[1]s export --file exclusions.yaml
+		`, shared.Command(t))),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter exclusions using a Falcon Query Language (FQL) expression")
+	cmd.Flags().StringVar(&opts.File, "file", "", "File to write to instead of standard output")
+
+	return cmd
+}
+
+func exportRun(ctx context.Context, opts *ExportOptions) error {
+	t := opts.Type
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := t.New(c).List(ctx, opts.Filter)
+	if err != nil {
+		return err
+	}
+
+	specs := []*exclusion.Spec{}
+	for _, e := range list {
+		specs = append(specs, e.Spec(t))
+	}
+	sort.SliceStable(specs, func(i, j int) bool {
+		return t.Key(specs[i]) < t.Key(specs[j])
+	})
+
+	var w io.Writer = opts.IO.Out
+	if opts.File != "" {
+		f, err := os.Create(filepath.Clean(opts.File))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := exclusion.WriteSpecs(w, specs); err != nil {
+		return err
+	}
+
+	if opts.File != "" {
+		fmt.Fprintf(opts.IO.ErrOut, "Exported %d %s to %s\n", len(specs), t.Plural, opts.File)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package importcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/exclusion"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ImportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *exclusion.Type
+
+	File    string
+	Comment string
+	DryRun  bool
+}
+
+// NewCmdImport represents the exclusions import command of an exclusion type
+func NewCmdImport(f *factory.Factory, t *exclusion.Type) *cobra.Command {
+	opts := &ImportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: fmt.Sprintf("Create or update %s from a YAML file", t.Plural),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Create or update %[1]s from YAML specifications, as written by
+			"%[2]s export". Documents are separated by "---".
+
+			An exclusion is updated when one with the same %[3]s exists and
+			created otherwise. Every specification is validated before any
+			change is made. Use --dry-run to show the changes without making
+			them, and pass "-" to read from standard input.`, t.Plural, shared.Command(t), shared.KeyName(t))),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Show the changes a file would make
+			%[1]s import exclusions.yaml --dry-run
+
+			# Apply the file, recording the change ticket
+			%[1]s import exclusions.yaml --comment CHG-1234
+		`, shared.Command(t))),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.File = args[0]
+			return importRun(cmd.Context(), opts)
+		},
+	}
+
+	utils.AddCommentFlag(cmd, &opts.Comment)
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the changes without making them")
+
+	return cmd
+}
+
+func importRun(ctx context.Context, opts *ImportOptions) error {
+	t := opts.Type
+
+	specs, err := readSpecs(opts)
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	svc := t.New(c)
+	created, updated, unchanged := 0, 0, 0
+	for _, spec := range specs {
+		key := t.Key(spec)
+
+		current, err := exclusion.Find(ctx, svc, t, key)
+		if err != nil && !errors.Is(err, exclusion.ErrNotFound) {
+			return err
+		}
+
+		if current == nil {
+			created++
+			if opts.DryRun {
+				fmt.Fprintf(opts.IO.Out, "%s %q would be created\n", t.Title, key)
+				continue
+			}
+		} else {
+			changes, err := exclusion.Changes(t, current, spec)
+			if err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				unchanged++
+				continue
+			}
+
+			updated++
+			if opts.DryRun {
+				fmt.Fprintf(opts.IO.Out, "%s %q would be updated:\n", t.Title, key)
+				if err := diff.Print(opts.IO.Out, changes); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		e, isNew, err := exclusion.Apply(ctx, c, t, spec, opts.Comment)
+		if err != nil {
+			return fmt.Errorf("%s %q: %v", t.Title, key, err)
+		}
+
+		action := "Updated"
+		if isNew {
+			action = "Created"
+		}
+		fmt.Fprintf(opts.IO.Out, "%s %s %q (%s)\n", action, t.Title, key, e.ID)
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "%d created, %d updated, %d unchanged\n", created, updated, unchanged)
+	return nil
+}
+
+func readSpecs(opts *ImportOptions) ([]*exclusion.Spec, error) {
+	return manifest.ReadFiles([]string{opts.File}, opts.IO.In, func(r io.Reader) ([]*exclusion.Spec, error) {
+		return exclusion.ReadSpecs(opts.Type, r)
+	})
+}
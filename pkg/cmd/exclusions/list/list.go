@@ -0,0 +1,95 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/exclusion"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *exclusion.Type
+
+	Filter string
+	Format string
+}
+
+// NewCmdList represents the exclusions list command of an exclusion type
+func NewCmdList(f *factory.Factory, t *exclusion.Type) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: fmt.Sprintf("List %s", t.Plural),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			List %s with the host groups they apply to.`, t.Plural)),
+		Example: templates.Examples(fmt.Sprintf(`
+			# List the %[2]s
+			%[1]s list
+
+			# List the exclusions applied to every host as JSON
+			%[1]s list --filter "applied_globally:true" -o json
+		`, shared.Command(t), t.Plural)),
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter exclusions using a Falcon Query Language (FQL) expression")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := opts.Type.New(c).List(ctx, opts.Filter)
+	if err != nil {
+		return err
+	}
+
+	return shared.PrintExclusions(opts.IO.Out, opts.Format, opts.Type, list)
+}
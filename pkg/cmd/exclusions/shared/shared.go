@@ -0,0 +1,147 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/exclusion"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+// Command returns the command line of an exclusion type for use in help
+// texts
+func Command(t *exclusion.Type) string {
+	return "falcon exclusions " + t.Name
+}
+
+// Article returns the indefinite article of an exclusion type's title
+func Article(t *exclusion.Type) string {
+	if strings.ContainsRune("aeiouAEIOU", rune(t.Title[0])) {
+		return "an"
+	}
+	return "a"
+}
+
+// KeyName names the field identifying the exclusions of a type
+func KeyName(t *exclusion.Type) string {
+	if t == exclusion.IOA {
+		return "name"
+	}
+	return "value"
+}
+
+// SpecFlags are the flags setting the fields of an exclusion
+type SpecFlags struct {
+	ExcludedFrom  []string
+	Description   string
+	PatternID     string
+	PatternName   string
+	CommandLine   string
+	ImageFilename string
+	HostGroups    []string
+	Global        bool
+}
+
+// AddSpecFlags registers the flags of the fields of an exclusion type
+func AddSpecFlags(cmd *cobra.Command, t *exclusion.Type, f *SpecFlags) {
+	switch t {
+	case exclusion.ML:
+		cmd.Flags().StringSliceVar(&f.ExcludedFrom, "excluded-from", nil, fmt.Sprintf("What to exclude the files from: %s (default blocking)", strings.Join(exclusion.ExcludedFrom, ", ")))
+	case exclusion.IOA:
+		cmd.Flags().StringVar(&f.Description, "description", "", "Description of the exclusion")
+		cmd.Flags().StringVar(&f.PatternID, "pattern-id", "", "ID of the detection pattern to exclude, as shown in the detection")
+		cmd.Flags().StringVar(&f.PatternName, "pattern-name", "", "Name of the detection pattern")
+		cmd.Flags().StringVar(&f.CommandLine, "cl-regex", "", "Regular expression matching the command line of the process")
+		cmd.Flags().StringVar(&f.ImageFilename, "ifn-regex", "", "Regular expression matching the image file name of the process")
+	}
+
+	cmd.Flags().StringSliceVar(&f.HostGroups, "host-group", nil, "Name or ID of a host group the exclusion applies to (repeatable)")
+	cmd.Flags().BoolVar(&f.Global, "global", false, "Apply the exclusion to every host")
+}
+
+// Validate checks that the scope flags are not combined
+func (f *SpecFlags) Validate(changed func(string) bool) error {
+	if changed("global") && changed("host-group") {
+		return fmt.Errorf("--global and --host-group cannot be used together")
+	}
+	return nil
+}
+
+// Apply sets the fields of spec given on the command line
+func (f *SpecFlags) Apply(changed func(string) bool, spec *exclusion.Spec) {
+	if changed("excluded-from") {
+		spec.ExcludedFrom = f.ExcludedFrom
+	}
+	if changed("description") {
+		spec.Description = f.Description
+	}
+	if changed("pattern-id") {
+		spec.PatternID = f.PatternID
+	}
+	if changed("pattern-name") {
+		spec.PatternName = f.PatternName
+	}
+	if changed("cl-regex") {
+		spec.CommandLine = f.CommandLine
+	}
+	if changed("ifn-regex") {
+		spec.ImageFilename = f.ImageFilename
+	}
+	if changed("global") {
+		spec.AppliedGlobally = f.Global
+		spec.HostGroups = nil
+	}
+	if changed("host-group") {
+		spec.AppliedGlobally = false
+		spec.HostGroups = f.HostGroups
+	}
+}
+
+// PrintExclusions writes a list of exclusions in the requested format
+func PrintExclusions(w io.Writer, format string, t *exclusion.Type, list []*exclusion.Exclusion) error {
+	return output.Print(w, format, list, func(table *output.Table) {
+		switch t {
+		case exclusion.IOA:
+			table.SetHeaders("ID", "NAME", "PATTERN", "IFN REGEX", "CL REGEX", "SCOPE", "MODIFIED")
+			for _, e := range list {
+				pattern := e.PatternID
+				if e.PatternName != "" {
+					pattern = fmt.Sprintf("%s (%s)", e.PatternName, e.PatternID)
+				}
+				table.AddRow(e.ID, e.Name, pattern, e.ImageFilename, e.CommandLine, e.Scope(), output.Time(e.LastModified))
+			}
+		case exclusion.ML:
+			table.SetHeaders("ID", "VALUE", "EXCLUDED FROM", "SCOPE", "MODIFIED")
+			for _, e := range list {
+				table.AddRow(e.ID, e.Value, strings.Join(e.ExcludedFrom, ","), e.Scope(), output.Time(e.LastModified))
+			}
+		default:
+			table.SetHeaders("ID", "VALUE", "SCOPE", "MODIFIED")
+			for _, e := range list {
+				table.AddRow(e.ID, e.Value, e.Scope(), output.Time(e.LastModified))
+			}
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/exclusion"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type UpdateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *exclusion.Type
+
+	Exclusion string
+	NewKey    string
+	Flags     shared.SpecFlags
+	Comment   string
+	DryRun    bool
+
+	changed func(string) bool
+}
+
+// NewCmdUpdate represents the exclusions update command of an exclusion type
+func NewCmdUpdate(f *factory.Factory, t *exclusion.Type) *cobra.Command {
+	opts := &UpdateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	key := shared.KeyName(t)
+	cmd := &cobra.Command{
+		Use:   fmt.Sprintf("update <id|%s>", key),
+		Short: fmt.Sprintf("Update %s %s", shared.Article(t), t.Title),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Change the fields of %[1]s %[2]s given on the command line. Other
+			fields are kept. Host groups given with --host-group replace
+			the groups the exclusion applies to.`, shared.Article(t), t.Title)),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Apply an exclusion to a second host group
+			%[1]s update <id> --host-group "Build Servers" --host-group "Build Agents"
+
+			# Show the changes without making them
+			%[1]s update <id> --global --dry-run
+		`, shared.Command(t))),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.changed = cmd.Flags().Changed
+			if err := opts.Flags.Validate(opts.changed); err != nil {
+				return err
+			}
+
+			opts.Exclusion = args[0]
+			return updateRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.NewKey, key, "", fmt.Sprintf("New %s of the exclusion", key))
+	shared.AddSpecFlags(cmd, t, &opts.Flags)
+	utils.AddCommentFlag(cmd, &opts.Comment)
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the changes without making them")
+
+	return cmd
+}
+
+func updateRun(ctx context.Context, opts *UpdateOptions) error {
+	t := opts.Type
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	svc := t.New(c)
+	current, err := exclusion.Find(ctx, svc, t, opts.Exclusion)
+	if err != nil {
+		return err
+	}
+
+	spec := current.Spec(t)
+	if opts.NewKey != "" {
+		if t == exclusion.IOA {
+			spec.Name = opts.NewKey
+		} else {
+			spec.Value = opts.NewKey
+		}
+	}
+	opts.Flags.Apply(opts.changed, spec)
+
+	if err := t.Validate(spec); err != nil {
+		return err
+	}
+
+	changes, err := exclusion.Changes(t, current, spec)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "%s %s is up to date\n", t.Title, current.ID)
+		return nil
+	}
+	if opts.DryRun {
+		return diff.Print(opts.IO.Out, changes)
+	}
+
+	groups, err := exclusion.GroupIDs(ctx, c, spec)
+	if err != nil {
+		return err
+	}
+
+	if _, err := svc.Update(ctx, current.ID, spec, groups, opts.Comment); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Updated %s %s\n", t.Title, current.ID)
+	return diff.Print(opts.IO.Out, changes)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/apply/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Export the tenant configuration as manifests`
+	longDesc  = templates.LongDesc(`
+		Write every host group, policy, exclusion and custom indicator of
+		the Falcon tenant to a directory as YAML manifests, one file per
+		resource in a directory per kind.
+
+		The manifests only hold the configuration: IDs, timestamps and other
+		fields set by the server are left out, and keys and lists are
+		sorted, so exporting an unchanged tenant produces the same files.
+		Files of resources that no longer exist are removed. Committing the
+		directory to git shows the changes made in the console, and the
+		manifests can be applied with "falcon apply".
+
+		Select what to export with --kinds, using the kinds of the manifests
+		or the aliases groups, policies, exclusions and ioc.`)
+	examples = templates.Examples(`
+		# Snapshot the whole tenant configuration
+		falcon export -d ./snapshot
+
+		# Snapshot the policies and host groups only
+		falcon export --kinds policies,groups -d ./snapshot
+	`)
+)
+
+type ExportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Kinds []string
+	Dir   string
+}
+
+// NewCmdExport represents the export command
+func NewCmdExport(f *factory.Factory) *cobra.Command {
+	opts := &ExportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Kinds, "kinds", nil, "Kinds of resources to export (default all)")
+	cmd.Flags().StringVarP(&opts.Dir, "dir", "d", "", "Directory to write the manifests to")
+	_ = cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
+
+func exportRun(ctx context.Context, opts *ExportOptions) error {
+	kinds, err := shared.SelectKinds(opts.Kinds)
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	for _, k := range kinds {
+		objects, err := k.Export(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		dir := filepath.Join(opts.Dir, k.Dir)
+		removed, err := manifest.WriteDir(dir, objects)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(opts.IO.ErrOut, "Exported %d %s manifests to %s", len(objects), k.Name, dir)
+		if removed > 0 {
+			fmt.Fprintf(opts.IO.ErrOut, ", removed %d", removed)
+		}
+		fmt.Fprintln(opts.IO.ErrOut)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package firewall
+
+import (
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/firewall/group"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/firewall/rule"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/firewall/validate"
+	policyCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage firewall rule groups, rules and policies`
+	longDesc  = templates.LongDesc(`
+		Manage Falcon Firewall Management: rule groups holding ordered
+		allow and deny rules, and the firewall policies enforcing rule
+		groups on the hosts they are assigned to.
+
+		Rule groups are kept as YAML specifications so that network teams
+		can review firewall changes as pull requests: export them, validate
+		the edited files locally and import them once approved. Firewall
+		policies are also available as "falcon policy firewall".`)
+	examples = templates.Examples(`
+		# Export the rule groups, then check and import the edited file
+		falcon firewall group export --file groups.yaml
+		falcon firewall validate groups.yaml
+		falcon firewall group import groups.yaml --dry-run
+
+		# Add a rule to a group
+		falcon firewall rule create "Web servers" "allow https" --action allow --direction in --protocol tcp --local-port 443
+	`)
+)
+
+// NewFirewallCmd represents the firewall command
+func NewFirewallCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "firewall <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"fw"},
+	}
+
+	policy := policyCmd.NewCmdFirewall(f)
+	policy.Use = "policy <command>"
+	policy.Aliases = []string{"policies"}
+
+	cmd.AddCommand(
+		group.NewCmdGroup(f),
+		rule.NewCmdRule(f),
+		validate.NewCmdValidate(f),
+		policy,
+	)
+	return cmd
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package create
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type CreateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Name        string
+	Platform    string
+	Description string
+	Enable      bool
+	Comment     string
+}
+
+var (
+	shortDesc = `Create an empty firewall rule group`
+	longDesc  = templates.LongDesc(`
+		Create a firewall rule group without rules. Add rules with
+		"falcon firewall rule create", or create the group with its rules
+		from a YAML specification with "falcon firewall group import".
+
+		Rule groups are created disabled unless --enable is given. A rule
+		group takes effect once it is also part of a firewall policy.`)
+	examples = templates.Examples(`
+		falcon firewall group create "Web servers" --platform windows --description "Inbound web traffic"
+	`)
+)
+
+// NewCmdCreate represents the firewall group create command
+func NewCmdCreate(f *factory.Factory) *cobra.Command {
+	opts := &CreateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "create <name>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+			return createRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Platform, "platform", "", "Platform of the rule group")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of the rule group")
+	cmd.Flags().BoolVar(&opts.Enable, "enable", false, "Enable the rule group once created")
+	utils.AddCommentFlag(cmd, &opts.Comment)
+	_ = cmd.MarkFlagRequired("platform")
+
+	return cmd
+}
+
+func createRun(ctx context.Context, opts *CreateOptions) error {
+	spec := &firewall.GroupSpec{
+		Kind:        firewall.Kind,
+		Name:        opts.Name,
+		Description: opts.Description,
+		Platform:    opts.Platform,
+		Enabled:     &opts.Enable,
+		Rules:       []firewall.RuleSpec{},
+	}
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = firewall.FindGroup(ctx, c, opts.Name)
+	if err == nil {
+		return fmt.Errorf("firewall rule group %q already exists", opts.Name)
+	}
+	if !errors.Is(err, firewall.ErrNotFound) {
+		return err
+	}
+
+	id, err := firewall.CreateGroup(ctx, c, spec, opts.Comment)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Created firewall rule group %q (%s)\n", spec.Name, id)
+	return nil
+}
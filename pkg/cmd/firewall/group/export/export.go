@@ -0,0 +1,131 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ExportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Groups []string
+	File   string
+}
+
+var (
+	shortDesc = `Export firewall rule groups as YAML specifications`
+	longDesc  = templates.LongDesc(`
+		Export firewall rule groups, given by name or ID, with their rules
+		as a stream of YAML specifications. Every rule group is exported
+		when none is given.
+
+		The specifications can be reviewed as a pull request, checked with
+		"falcon firewall validate" and imported again with
+		"falcon firewall group import".`)
+	examples = templates.Examples(`
+		# Keep every rule group under version control
+		falcon firewall group export --file firewall/groups.yaml
+
+		# Export one rule group
+		falcon firewall group export "Web servers"
+	`)
+)
+
+// NewCmdExport represents the firewall group export command
+func NewCmdExport(f *factory.Factory) *cobra.Command {
+	opts := &ExportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "export [<group>...]",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Groups = args
+			return exportRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.File, "file", "", "Write the specifications to a file instead of standard output")
+
+	return cmd
+}
+
+func exportRun(ctx context.Context, opts *ExportOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	groups := []*firewall.Group{}
+	if len(opts.Groups) == 0 {
+		if groups, err = firewall.ListGroups(ctx, c, ""); err != nil {
+			return err
+		}
+	}
+	for _, name := range opts.Groups {
+		g, err := firewall.FindGroup(ctx, c, name)
+		if err != nil {
+			return err
+		}
+		groups = append(groups, g)
+	}
+
+	specs := []*firewall.GroupSpec{}
+	for _, g := range groups {
+		specs = append(specs, g.Spec())
+	}
+
+	var w io.Writer = opts.IO.Out
+	if opts.File != "" {
+		f, err := os.Create(filepath.Clean(opts.File))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := firewall.WriteSpecs(w, specs); err != nil {
+		return err
+	}
+
+	if opts.File != "" {
+		fmt.Fprintf(opts.IO.ErrOut, "Exported %d rule groups to %s\n", len(specs), opts.File)
+	}
+	return nil
+}
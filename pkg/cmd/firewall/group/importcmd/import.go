@@ -0,0 +1,173 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package importcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ImportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Files   []string
+	Comment string
+	DryRun  bool
+}
+
+var (
+	shortDesc = `Create or update firewall rule groups from YAML specifications`
+	longDesc  = templates.LongDesc(`
+		Create or update firewall rule groups from YAML specifications such
+		as:
+
+		    kind: FirewallRuleGroup
+		    name: Web servers
+		    platform: windows
+		    enabled: true
+		    rules:
+		      - name: allow https
+		        action: ALLOW
+		        direction: IN
+		        protocol: tcp
+		        local_ports: ["443"]
+		        remote_addresses: [10.0.0.0/8]
+		      - name: block telnet
+		        action: DENY
+		        direction: BOTH
+		        protocol: tcp
+		        remote_ports: ["23"]
+
+		Rules are listed in precedence order. Addresses and ports that are
+		left out match any, and the address family is inferred from the
+		addresses. A rule group of the same name is updated so that its rules
+		match the specification exactly.
+
+		Every specification is validated before any change is made. Use
+		--dry-run to only show the changes.`)
+	examples = templates.Examples(`
+		# Review the changes of a pull request, then import them
+		falcon firewall group import firewall/groups.yaml --dry-run
+		falcon firewall group import firewall/groups.yaml --comment CHG-1234
+	`)
+)
+
+// NewCmdImport represents the firewall group import command
+func NewCmdImport(f *factory.Factory) *cobra.Command {
+	opts := &ImportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "import <file>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Files = args
+			return importRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the changes without making them")
+	utils.AddCommentFlag(cmd, &opts.Comment)
+
+	return cmd
+}
+
+func importRun(ctx context.Context, opts *ImportOptions) error {
+	specs, err := manifest.ReadFiles(opts.Files, opts.IO.In, firewall.ReadSpecs)
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	for _, spec := range specs {
+		if err := importGroup(ctx, c, opts, spec); err != nil {
+			return fmt.Errorf("rule group %q: %v", spec.Name, err)
+		}
+	}
+	return nil
+}
+
+func importGroup(ctx context.Context, c *client.CrowdStrikeAPISpecification, opts *ImportOptions, spec *firewall.GroupSpec) error {
+	g, err := firewall.FindGroup(ctx, c, spec.Name)
+	if errors.Is(err, firewall.ErrNotFound) {
+		if opts.DryRun {
+			fmt.Fprintf(opts.IO.Out, "Rule group %q would be created with %d rules\n", spec.Name, len(spec.Rules))
+			return nil
+		}
+
+		id, err := firewall.CreateGroup(ctx, c, spec, opts.Comment)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(opts.IO.Out, "Created rule group %q (%s)\n", spec.Name, id)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if g.Platform != spec.Platform {
+		return fmt.Errorf("the rule group is for %s and cannot be moved to %s, delete it first", g.Platform, spec.Platform)
+	}
+
+	changes, err := firewall.Changes(g, spec)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(opts.IO.Out, "Rule group %q is up to date\n", spec.Name)
+		return nil
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(opts.IO.Out, "Rule group %q would be updated:\n", spec.Name)
+		return diff.Print(opts.IO.Out, changes)
+	}
+
+	if err := firewall.UpdateGroup(ctx, c, g, spec, opts.Comment); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Updated rule group %q (%s)\n", spec.Name, g.ID)
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type UpdateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Group       string
+	Name        string
+	Description string
+	Enabled     bool
+	Comment     string
+
+	setName        bool
+	setDescription bool
+	setEnabled     bool
+}
+
+var (
+	shortDesc = `Rename, describe, enable or disable a firewall rule group`
+	longDesc  = templates.LongDesc(`
+		Update the name, description or enabled state of a firewall rule
+		group, given by name or ID. Only the fields given on the command
+		line are changed.`)
+	examples = templates.Examples(`
+		# Enable a rule group
+		falcon firewall group update "Web servers" --enabled
+
+		# Rename a rule group
+		falcon firewall group update "Web servers" --name "Web servers (legacy)" --comment CHG-1234
+	`)
+)
+
+// NewCmdUpdate represents the firewall group update command
+func NewCmdUpdate(f *factory.Factory) *cobra.Command {
+	opts := &UpdateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "update <group>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Group = args[0]
+			opts.setName = cmd.Flags().Changed("name")
+			opts.setDescription = cmd.Flags().Changed("description")
+			opts.setEnabled = cmd.Flags().Changed("enabled")
+			if !opts.setName && !opts.setDescription && !opts.setEnabled {
+				return fmt.Errorf("nothing to update, use --name, --description or --enabled")
+			}
+
+			return updateRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", "", "New name of the rule group")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of the rule group")
+	cmd.Flags().BoolVar(&opts.Enabled, "enabled", false, "Enable the rule group, or disable it with --enabled=false")
+	utils.AddCommentFlag(cmd, &opts.Comment)
+
+	return cmd
+}
+
+func updateRun(ctx context.Context, opts *UpdateOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	g, err := firewall.FindGroup(ctx, c, opts.Group)
+	if err != nil {
+		return err
+	}
+
+	spec := g.Spec()
+	if opts.setName {
+		spec.Name = opts.Name
+	}
+	if opts.setDescription {
+		spec.Description = opts.Description
+	}
+	if opts.setEnabled {
+		spec.Enabled = &opts.Enabled
+	}
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	if err := firewall.UpdateGroup(ctx, c, g, spec, opts.Comment); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Updated firewall rule group %q (%s)\n", spec.Name, g.ID)
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package create
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/firewall/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type CreateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Group    string
+	Name     string
+	Rule     shared.RuleFlags
+	Disabled bool
+	Position int
+	Comment  string
+
+	flags *pflag.FlagSet
+}
+
+var (
+	shortDesc = `Add a rule to a firewall rule group`
+	longDesc  = templates.LongDesc(`
+		Add a rule to a firewall rule group, given by name or ID. The rule is
+		added with the lowest precedence unless --position is given.
+
+		Addresses are IP addresses or CIDR networks and ports are single
+		ports or ranges such as 8000-8080. Addresses and ports that are left
+		out match any. Ports require the tcp or udp protocol.`)
+	examples = templates.Examples(`
+		# Allow HTTPS from the internal network
+		falcon firewall rule create "Web servers" "allow https" --action allow --direction in \
+		  --protocol tcp --local-port 443 --remote-address 10.0.0.0/8
+
+		# Block telnet with the highest precedence
+		falcon firewall rule create "Web servers" "block telnet" --action deny --direction both \
+		  --protocol tcp --remote-port 23 --position 1
+	`)
+)
+
+// NewCmdCreate represents the firewall rule create command
+func NewCmdCreate(f *factory.Factory) *cobra.Command {
+	opts := &CreateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "create <group> <name>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"add"},
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Position < 0 {
+				return fmt.Errorf("--position must be 1 or more")
+			}
+
+			opts.Group = args[0]
+			opts.Name = args[1]
+			opts.flags = cmd.Flags()
+			return createRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddRuleFlags(cmd, &opts.Rule)
+	cmd.Flags().BoolVar(&opts.Disabled, "disabled", false, "Create the rule disabled")
+	cmd.Flags().IntVar(&opts.Position, "position", 0, "Precedence of the rule in the group, 1 being the highest")
+	utils.AddCommentFlag(cmd, &opts.Comment)
+	_ = cmd.MarkFlagRequired("action")
+	_ = cmd.MarkFlagRequired("direction")
+
+	return cmd
+}
+
+func createRun(ctx context.Context, opts *CreateOptions) error {
+	rule := firewall.RuleSpec{Name: opts.Name}
+	opts.Rule.Apply(opts.flags, &rule)
+	if opts.Disabled {
+		enabled := false
+		rule.Enabled = &enabled
+	}
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	g, err := firewall.FindGroup(ctx, c, opts.Group)
+	if err != nil {
+		return err
+	}
+	if _, err := g.FindRule(opts.Name); err == nil {
+		return fmt.Errorf("rule %q already exists in rule group %q", opts.Name, g.Name)
+	}
+
+	spec := g.Spec()
+	position := len(spec.Rules)
+	if opts.Position > 0 && opts.Position <= len(spec.Rules) {
+		position = opts.Position - 1
+	}
+	rules := append([]firewall.RuleSpec{}, spec.Rules[:position]...)
+	rules = append(rules, rule)
+	spec.Rules = append(rules, spec.Rules[position:]...)
+
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+	if err := firewall.UpdateGroup(ctx, c, g, spec, opts.Comment); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Added rule %q to rule group %q\n", opts.Name, g.Name)
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type DeleteOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Group   string
+	Rules   []string
+	Comment string
+}
+
+var (
+	shortDesc = `Delete rules of a firewall rule group`
+	longDesc  = templates.LongDesc(`
+		Delete rules, given by name or ID, from a firewall rule group.`)
+	examples = templates.Examples(`
+		falcon firewall rule delete "Web servers" "block telnet" --comment CHG-1234
+	`)
+)
+
+// NewCmdDelete represents the firewall rule delete command
+func NewCmdDelete(f *factory.Factory) *cobra.Command {
+	opts := &DeleteOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "delete <group> <rule>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"rm"},
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Group = args[0]
+			opts.Rules = args[1:]
+			return deleteRun(cmd.Context(), opts)
+		},
+	}
+
+	utils.AddCommentFlag(cmd, &opts.Comment)
+
+	return cmd
+}
+
+func deleteRun(ctx context.Context, opts *DeleteOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	g, err := firewall.FindGroup(ctx, c, opts.Group)
+	if err != nil {
+		return err
+	}
+
+	names := []string{}
+	remove := map[string]bool{}
+	for _, name := range opts.Rules {
+		r, err := g.FindRule(name)
+		if err != nil {
+			return err
+		}
+		if !remove[r.Name] {
+			names = append(names, r.Name)
+		}
+		remove[r.Name] = true
+	}
+
+	spec := g.Spec()
+	rules := []firewall.RuleSpec{}
+	for _, r := range spec.Rules {
+		if !remove[r.Name] {
+			rules = append(rules, r)
+		}
+	}
+	spec.Rules = rules
+
+	if err := firewall.UpdateGroup(ctx, c, g, spec, opts.Comment); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		fmt.Fprintf(opts.IO.Out, "Deleted rule %q from rule group %q\n", name, g.Name)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/firewall/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type UpdateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Group   string
+	Rule    string
+	Name    string
+	Fields  shared.RuleFlags
+	Enabled bool
+	Comment string
+
+	flags *pflag.FlagSet
+}
+
+var (
+	shortDesc = `Update a rule of a firewall rule group`
+	longDesc  = templates.LongDesc(`
+		Update a rule of a firewall rule group, given by name or ID. Only
+		the fields given on the command line are changed, and the changes
+		are shown once made. List flags such as --remote-address replace
+		the current list.`)
+	examples = templates.Examples(`
+		# Restrict a rule to two networks
+		falcon firewall rule update "Web servers" "allow https" --remote-address 10.1.0.0/16,10.2.0.0/16
+
+		# Disable a rule
+		falcon firewall rule update "Web servers" "block telnet" --enabled=false
+	`)
+)
+
+// NewCmdUpdate represents the firewall rule update command
+func NewCmdUpdate(f *factory.Factory) *cobra.Command {
+	opts := &UpdateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "update <group> <rule>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Group = args[0]
+			opts.Rule = args[1]
+			opts.flags = cmd.Flags()
+			return updateRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddRuleFlags(cmd, &opts.Fields)
+	cmd.Flags().StringVar(&opts.Name, "name", "", "New name of the rule")
+	cmd.Flags().BoolVar(&opts.Enabled, "enabled", false, "Enable the rule, or disable it with --enabled=false")
+	utils.AddCommentFlag(cmd, &opts.Comment)
+
+	return cmd
+}
+
+func updateRun(ctx context.Context, opts *UpdateOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	g, err := firewall.FindGroup(ctx, c, opts.Group)
+	if err != nil {
+		return err
+	}
+	current, err := g.FindRule(opts.Rule)
+	if err != nil {
+		return err
+	}
+
+	spec := g.Spec()
+	for i := range spec.Rules {
+		r := &spec.Rules[i]
+		if r.Name != current.Name {
+			continue
+		}
+
+		opts.Fields.Apply(opts.flags, r)
+		if opts.flags.Changed("name") {
+			r.Name = opts.Name
+		}
+		if opts.flags.Changed("enabled") {
+			r.Enabled = &opts.Enabled
+		}
+	}
+
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	changes, err := firewall.Changes(g, spec)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		fmt.Fprintf(opts.IO.Out, "Rule %q of rule group %q is up to date\n", current.Name, g.Name)
+		return nil
+	}
+
+	if err := firewall.UpdateGroup(ctx, c, g, spec, opts.Comment); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Updated rule %q of rule group %q:\n", current.Name, g.Name)
+	return diff.Print(opts.IO.Out, changes)
+}
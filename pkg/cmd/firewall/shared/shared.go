@@ -0,0 +1,148 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// PrintGroups writes a list of rule groups in the requested format
+func PrintGroups(w io.Writer, format string, list []*firewall.Group) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "NAME", "PLATFORM", "ENABLED", "RULES", "POLICIES", "MODIFIED")
+		for _, g := range list {
+			t.AddRow(
+				g.ID,
+				g.Name,
+				g.Platform,
+				strconv.FormatBool(g.Enabled),
+				strconv.Itoa(len(g.Rules)),
+				strconv.Itoa(len(g.PolicyIDs)),
+				output.Time(g.ModifiedOn),
+			)
+		}
+	})
+}
+
+// PrintRules writes the rules of a group in precedence order
+func PrintRules(w io.Writer, format string, list []*firewall.Rule) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("NAME", "ENABLED", "ACTION", "DIRECTION", "PROTOCOL", "LOCAL", "REMOTE")
+		for _, r := range list {
+			enabled := r.Enabled == nil || *r.Enabled
+			t.AddRow(
+				r.Name,
+				strconv.FormatBool(enabled),
+				r.Action,
+				r.Direction,
+				r.Protocol,
+				endpoint(r.LocalAddresses, r.LocalPorts),
+				endpoint(r.RemoteAddresses, r.RemotePorts),
+			)
+		}
+	})
+}
+
+// endpoint writes addresses and ports as in 10.0.0.0/8:443,8080
+func endpoint(addresses, ports []string) string {
+	s := "any"
+	if len(addresses) > 0 {
+		s = strings.Join(addresses, ",")
+	}
+	if len(ports) > 0 {
+		s += ":" + strings.Join(ports, ",")
+	}
+	return s
+}
+
+// RuleFlags are the flags setting the fields of a rule
+type RuleFlags struct {
+	Description     string
+	Action          string
+	Direction       string
+	Protocol        string
+	AddressFamily   string
+	LocalAddresses  []string
+	RemoteAddresses []string
+	LocalPorts      []string
+	RemotePorts     []string
+	Log             bool
+}
+
+// AddRuleFlags registers the flags setting the fields of a rule
+func AddRuleFlags(cmd *cobra.Command, f *RuleFlags) {
+	cmd.Flags().StringVar(&f.Description, "description", "", "Description of the rule")
+	cmd.Flags().StringVar(&f.Action, "action", "", fmt.Sprintf("Action of the rule: %s", strings.Join(firewall.Actions, ", ")))
+	cmd.Flags().StringVar(&f.Direction, "direction", "", fmt.Sprintf("Direction of the traffic: %s", strings.Join(firewall.Directions, ", ")))
+	cmd.Flags().StringVar(&f.Protocol, "protocol", "", "Protocol: any, tcp, udp, icmp, icmpv6 or a protocol number")
+	cmd.Flags().StringVar(&f.AddressFamily, "address-family", "", "Address family, inferred from the addresses when omitted: IP4, IP6 or NONE")
+	cmd.Flags().StringSliceVar(&f.LocalAddresses, "local-address", nil, "Local addresses or networks, such as 10.0.0.0/8")
+	cmd.Flags().StringSliceVar(&f.RemoteAddresses, "remote-address", nil, "Remote addresses or networks, such as 10.0.0.0/8")
+	cmd.Flags().StringSliceVar(&f.LocalPorts, "local-port", nil, "Local ports or port ranges, such as 8000-8080")
+	cmd.Flags().StringSliceVar(&f.RemotePorts, "remote-port", nil, "Remote ports or port ranges, such as 8000-8080")
+	cmd.Flags().BoolVar(&f.Log, "log", false, "Log the connections matching the rule")
+}
+
+// Apply sets the fields of a rule given on the command line. The address
+// family is inferred again when addresses change and it is not given.
+func (f *RuleFlags) Apply(flags *pflag.FlagSet, r *firewall.RuleSpec) {
+	if flags.Changed("description") {
+		r.Description = f.Description
+	}
+	if flags.Changed("action") {
+		r.Action = f.Action
+	}
+	if flags.Changed("direction") {
+		r.Direction = f.Direction
+	}
+	if flags.Changed("protocol") {
+		r.Protocol = f.Protocol
+	}
+	if flags.Changed("local-address") || flags.Changed("remote-address") {
+		r.AddressFamily = ""
+	}
+	if flags.Changed("address-family") {
+		r.AddressFamily = f.AddressFamily
+	}
+	if flags.Changed("local-address") {
+		r.LocalAddresses = f.LocalAddresses
+	}
+	if flags.Changed("remote-address") {
+		r.RemoteAddresses = f.RemoteAddresses
+	}
+	if flags.Changed("local-port") {
+		r.LocalPorts = f.LocalPorts
+	}
+	if flags.Changed("remote-port") {
+		r.RemotePorts = f.RemotePorts
+	}
+	if flags.Changed("log") {
+		r.Log = f.Log
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package validate
+
+import (
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ValidateOptions struct {
+	IO *iostreams.IOStreams
+
+	Files []string
+}
+
+var (
+	shortDesc = `Check firewall rule group specifications locally`
+	longDesc  = templates.LongDesc(`
+		Check YAML firewall rule group specifications without calling the
+		API: the syntax of addresses, networks and ports, that actions,
+		directions and protocols are known, that a rule does not mix IPv4
+		and IPv6 addresses and that rule names are unique within a group.
+
+		Every file is checked and the command fails if any is invalid, which
+		makes it suitable for continuous integration of pull requests.`)
+	examples = templates.Examples(`
+		falcon firewall validate firewall/*.yaml
+	`)
+)
+
+// NewCmdValidate represents the firewall validate command
+func NewCmdValidate(f *factory.Factory) *cobra.Command {
+	opts := &ValidateOptions{
+		IO: f.IOStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "validate <file>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"lint"},
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Files = args
+			return validateRun(opts)
+		},
+	}
+
+	return cmd
+}
+
+func validateRun(opts *ValidateOptions) error {
+	invalid := 0
+	for _, path := range opts.Files {
+		specs, err := manifest.ReadFiles([]string{path}, opts.IO.In, firewall.ReadSpecs)
+		if err != nil {
+			invalid++
+			fmt.Fprintln(opts.IO.ErrOut, err)
+			continue
+		}
+
+		rules := 0
+		for _, s := range specs {
+			rules += len(s.Rules)
+		}
+		fmt.Fprintf(opts.IO.Out, "%s: %d rule groups, %d rules\n", path, len(specs), rules)
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d files are invalid", invalid, len(opts.Files))
+	}
+	return nil
+}
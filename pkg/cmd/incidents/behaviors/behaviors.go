@@ -0,0 +1,98 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package behaviors
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/incidents/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List the behaviors of an incident`
+	longDesc  = templates.LongDesc(`
+		List the behaviors that make up an incident in chronological order.`)
+	examples = templates.Examples(`
+		# List the behaviors of an incident
+		falcon incidents behaviors <incident_id>
+
+		# Export the behaviors of an incident as YAML
+		falcon incidents behaviors <incident_id> -o yaml
+	`)
+)
+
+type BehaviorsOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	IncidentID string
+	Format     string
+}
+
+// NewCmdBehaviors represents the incidents behaviors command
+func NewCmdBehaviors(f *factory.Factory) *cobra.Command {
+	opts := &BehaviorsOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "behaviors <incident_id>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+			opts.IncidentID = args[0]
+
+			return behaviorsRun(cmd.Context(), opts)
+		},
+	}
+
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func behaviorsRun(ctx context.Context, opts *BehaviorsOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	behaviors, err := shared.GetBehaviors(ctx, c, opts.IncidentID)
+	if err != nil {
+		return err
+	}
+
+	return output.Print(opts.IO.Out, opts.Format, behaviors, func(t *output.Table) {
+		shared.BehaviorsTable(t, behaviors, nil)
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package incidents
+
+import (
+	behaviorsCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/incidents/behaviors"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/incidents/list"
+	showCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/incidents/show"
+	updateCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/incidents/update"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage CrowdScore incidents`
+	longDesc  = templates.LongDesc(`
+		Manage CrowdScore incidents.
+
+		Incidents group related behaviors across hosts into a single view of an
+		attack, scored by CrowdScore.`)
+	examples = templates.Examples(`
+		# Watch for new incidents
+		falcon incidents list --watch
+
+		# Show the hosts and timeline of an incident
+		falcon incidents show <incident_id>
+
+		# Start working on an incident
+		falcon incidents update <incident_id> --status in_progress --assign-uuid <user_uuid>
+	`)
+)
+
+// NewIncidentsCmd represents the incidents command
+func NewIncidentsCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "incidents <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		listCmd.NewCmdList(f),
+		showCmd.NewCmdShow(f),
+		behaviorsCmd.NewCmdBehaviors(f),
+		updateCmd.NewCmdUpdate(f),
+	)
+	return cmd
+}
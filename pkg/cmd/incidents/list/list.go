@@ -0,0 +1,295 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package list
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/incidents/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List CrowdScore incidents`
+	longDesc  = templates.LongDesc(`
+		List CrowdScore incidents matching the given criteria.
+
+		With --watch the command keeps running and prints incidents as they are
+		created or modified. In watch mode the JSON output format writes one
+		incident per line so it can be consumed as a stream, the YAML output
+		format writes one document per incident and the table has fixed
+		column widths so that the rows of every poll line up.`)
+	examples = templates.Examples(`
+		# List the 100 most recent incidents
+		falcon incidents list
+
+		# List open incidents with a score of at least 50
+		falcon incidents list --status new,in_progress --min-score 50
+
+		# Watch for new and updated incidents during an active response
+		falcon incidents list --watch --interval 30s
+	`)
+)
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Filter   string
+	Sort     string
+	Statuses []string
+	MinScore float64
+	Limit    int
+	All      bool
+	Watch    bool
+	Interval time.Duration
+	Format   string
+}
+
+// NewCmdList represents the incidents list command
+func NewCmdList(f *factory.Factory) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if err := utils.ValidateOneOf("status", shared.Statuses, opts.Statuses...); err != nil {
+				return err
+			}
+
+			if opts.Limit < 1 {
+				return fmt.Errorf("--limit must be greater than 0")
+			}
+
+			if opts.Watch && opts.Interval < time.Second {
+				return fmt.Errorf("--interval must be at least 1s")
+			}
+
+			if opts.Watch {
+				return watchRun(cmd.Context(), opts)
+			}
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter incidents using a Falcon Query Language (FQL) expression")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "start.desc", "Sort incidents by a field, e.g. score.desc")
+	cmd.Flags().StringSliceVar(&opts.Statuses, "status", nil, "Only include incidents with these statuses")
+	cmd.Flags().Float64Var(&opts.MinScore, "min-score", 0, "Only include incidents with at least this score (0-100)")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 100, "Maximum number of incidents to return")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Return all matching incidents, ignoring --limit")
+	cmd.Flags().BoolVarP(&opts.Watch, "watch", "w", false, "Keep running and print new or modified incidents")
+	cmd.Flags().DurationVar(&opts.Interval, "interval", time.Minute, "Polling interval used with --watch")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func (opts *ListOptions) filter() *fql.Filter {
+	filter := fql.New().Raw(opts.Filter)
+
+	codes := []string{}
+	for _, s := range opts.Statuses {
+		code, _ := shared.StatusCode(s)
+		codes = append(codes, code)
+	}
+	filter.In("status", codes...)
+
+	if opts.MinScore > 0 {
+		// fine_score is stored with one decimal place as an integer. The
+		// epsilon keeps scores such as 7.3 from rounding up past 73.
+		filter.Raw(fmt.Sprintf("fine_score:>=%d", int(math.Ceil(opts.MinScore*10-1e-9))))
+	}
+
+	return filter
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	limit := opts.Limit
+	if opts.All {
+		limit = 0
+	}
+
+	ids, err := shared.QueryIncidentIDs(ctx, c, opts.filter().Ptr(), opts.Sort, limit)
+	if err != nil {
+		return err
+	}
+
+	list, err := shared.GetIncidents(ctx, c, ids)
+	if err != nil {
+		return err
+	}
+
+	return output.Print(opts.IO.Out, opts.Format, list, func(t *output.Table) {
+		shared.IncidentsTable(t, list)
+	})
+}
+
+func watchRun(ctx context.Context, opts *ListOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	w := newWatcher(time.Now().UTC().Add(-opts.Interval), opts.Interval)
+	first := true
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		filter := opts.filter().Compare("modified_timestamp", ">", w.since.Format(time.RFC3339))
+
+		ids, err := shared.QueryIncidentIDs(ctx, c, filter.Ptr(), "modified_timestamp.asc", 0)
+		if err != nil {
+			return err
+		}
+
+		list, err := shared.GetIncidents(ctx, c, ids)
+		if err != nil {
+			return err
+		}
+
+		if changed := w.changed(list); len(changed) > 0 {
+			if err := printWatched(opts, changed, first); err != nil {
+				return err
+			}
+			first = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watcher tracks the incidents printed while watching, so that each change
+// is printed once even though polls overlap: the filter on the modification
+// time only has a precision of one second.
+type watcher struct {
+	// since is the latest modification time seen
+	since time.Time
+	// window is how long incidents are remembered before since
+	window time.Duration
+	// seen maps incident IDs to the modification time last printed
+	seen map[string]time.Time
+}
+
+func newWatcher(since time.Time, window time.Duration) *watcher {
+	return &watcher{since: since, window: window, seen: map[string]time.Time{}}
+}
+
+// changed returns the incidents of a poll that were not printed yet or were
+// modified since. Incidents modified before the poll window are forgotten
+// as no poll can return them again.
+func (w *watcher) changed(list []*models.DomainIncident) []*models.DomainIncident {
+	changed := []*models.DomainIncident{}
+	for _, i := range list {
+		modified := time.Time(i.ModifiedTimestamp)
+		id := utils.Deref(i.IncidentID)
+
+		if last, ok := w.seen[id]; ok && !modified.After(last) {
+			continue
+		}
+		w.seen[id] = modified
+		changed = append(changed, i)
+
+		if modified.After(w.since) {
+			w.since = modified
+		}
+	}
+
+	cutoff := w.since.Add(-w.window)
+	for id, modified := range w.seen {
+		if modified.Before(cutoff) {
+			delete(w.seen, id)
+		}
+	}
+
+	return changed
+}
+
+// watchWidths are the column widths of the table printed while watching,
+// which fit incident IDs and times, so that the rows of every poll line up
+var watchWidths = []int{69, 5, 11, 30, 30, 20, 20, 20}
+
+// printWatched prints the incidents of a poll. first is true for the first
+// incidents printed, which start the table or the YAML stream.
+func printWatched(opts *ListOptions, list []*models.DomainIncident, first bool) error {
+	switch opts.Format {
+	case output.FormatJSON:
+		for _, i := range list {
+			if err := output.PrintNDJSON(opts.IO.Out, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	case output.FormatYAML:
+		for n, i := range list {
+			if !first || n > 0 {
+				if _, err := fmt.Fprintln(opts.IO.Out, "---"); err != nil {
+					return err
+				}
+			}
+			if err := output.PrintYAML(opts.IO.Out, i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	t := output.NewTable()
+	shared.IncidentsTable(t, list)
+	if !first {
+		t.SetHeaders()
+	}
+	return t.RenderFixed(opts.IO.Out, watchWidths...)
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package list
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *ListOptions
+		want string
+	}{
+		{
+			name: "none",
+			opts: &ListOptions{},
+			want: "",
+		},
+		{
+			name: "statuses",
+			opts: &ListOptions{Statuses: []string{"new", "in_progress"}},
+			want: "status:['20','30']",
+		},
+		{
+			name: "whole score",
+			opts: &ListOptions{MinScore: 50},
+			want: "fine_score:>=500",
+		},
+		{
+			name: "decimal score",
+			opts: &ListOptions{MinScore: 7.3},
+			want: "fine_score:>=73",
+		},
+		{
+			name: "score between tenths",
+			opts: &ListOptions{MinScore: 7.35},
+			want: "fine_score:>=74",
+		},
+		{
+			name: "combined with raw filter",
+			opts: &ListOptions{Filter: "tags:'vip',tags:'prod'", Statuses: []string{"closed"}, MinScore: 0.1},
+			want: "(tags:'vip',tags:'prod')+status:'40'+(fine_score:>=1)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.opts.filter().String()); diff != "" {
+				t.Errorf("filter() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func incident(id string, modified time.Time) *models.DomainIncident {
+	return &models.DomainIncident{IncidentID: utils.Ptr(id), ModifiedTimestamp: strfmt.DateTime(modified)}
+}
+
+func ids(list []*models.DomainIncident) []string {
+	result := []string{}
+	for _, i := range list {
+		result = append(result, utils.Deref(i.IncidentID))
+	}
+	return result
+}
+
+func TestWatcher(t *testing.T) {
+	start := time.Date(2023, 3, 10, 12, 0, 0, 0, time.UTC)
+	w := newWatcher(start, time.Minute)
+
+	polls := []struct {
+		name string
+		list []*models.DomainIncident
+		want []string
+	}{
+		{
+			name: "first poll",
+			list: []*models.DomainIncident{incident("a", start.Add(time.Second)), incident("b", start.Add(1500*time.Millisecond))},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "overlapping poll",
+			list: []*models.DomainIncident{incident("b", start.Add(1500*time.Millisecond))},
+			want: []string{},
+		},
+		{
+			name: "modified again",
+			list: []*models.DomainIncident{incident("a", start.Add(30*time.Second)), incident("c", start.Add(30*time.Second))},
+			want: []string{"a", "c"},
+		},
+		{
+			name: "much later",
+			list: []*models.DomainIncident{incident("d", start.Add(5*time.Minute))},
+			want: []string{"d"},
+		},
+	}
+
+	for _, p := range polls {
+		if diff := cmp.Diff(p.want, ids(w.changed(p.list))); diff != "" {
+			t.Errorf("%s: changed() mismatch (-want +got):\n%s", p.name, diff)
+		}
+	}
+
+	if !w.since.Equal(start.Add(5 * time.Minute)) {
+		t.Errorf("since = %s, want %s", w.since, start.Add(5*time.Minute))
+	}
+
+	// incidents modified before the poll window are forgotten
+	if diff := cmp.Diff(map[string]time.Time{"d": start.Add(5 * time.Minute)}, w.seen); diff != "" {
+		t.Errorf("seen mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPrintWatched(t *testing.T) {
+	start := time.Date(2023, 3, 10, 12, 0, 0, 0, time.UTC)
+	polls := [][]*models.DomainIncident{
+		{incident("inc:short", start), incident("inc:"+strings.Repeat("a", 32)+":"+strings.Repeat("b", 32), start)},
+		{incident("inc:x", start)},
+	}
+
+	render := func(format string) string {
+		var out bytes.Buffer
+		opts := &ListOptions{IO: &iostreams.IOStreams{Out: &out}, Format: format}
+		for n, list := range polls {
+			if err := printWatched(opts, list, n == 0); err != nil {
+				t.Fatalf("printWatched() returned error: %v", err)
+			}
+		}
+		return out.String()
+	}
+
+	// the columns of every poll line up
+	lines := strings.Split(strings.TrimSpace(render(output.FormatTable)), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "INCIDENT ID") {
+		t.Fatalf("table output has %d lines, want a header and 3 rows:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	column := strings.Index(lines[0], "SCORE")
+	for _, line := range lines[1:] {
+		if got := strings.Index(line, "0.0"); got != column {
+			t.Errorf("score of %q is at column %d, want %d", line, got, column)
+		}
+	}
+
+	// the YAML output is a single stream of one document per incident
+	dec := yaml.NewDecoder(strings.NewReader(render(output.FormatYAML)))
+	got := []string{}
+	for {
+		var doc map[string]interface{}
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid YAML stream: %v", err)
+		}
+		got = append(got, doc["incident_id"].(string))
+	}
+	if diff := cmp.Diff([]string{"inc:short", "inc:" + strings.Repeat("a", 32) + ":" + strings.Repeat("b", 32), "inc:x"}, got); diff != "" {
+		t.Errorf("YAML documents mismatch (-want +got):\n%s", diff)
+	}
+}
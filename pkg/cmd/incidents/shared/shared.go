@@ -0,0 +1,251 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/incidents"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// maxQueryLimit is the maximum number of IDs returned by the incident and
+// behavior query endpoints
+const maxQueryLimit = 500
+
+// maxEntities is the maximum number of incidents or behaviors fetched per request
+const maxEntities = 500
+
+// statusCodes maps incident status names to their API values
+var statusCodes = map[string]string{
+	"new":         "20",
+	"reopened":    "25",
+	"in_progress": "30",
+	"closed":      "40",
+}
+
+// Statuses are the valid incident status names
+var Statuses = []string{"new", "reopened", "in_progress", "closed"}
+
+// StatusCode returns the API value of an incident status name
+func StatusCode(status string) (string, error) {
+	if err := utils.ValidateOneOf("status", Statuses, status); err != nil {
+		return "", err
+	}
+	return statusCodes[status], nil
+}
+
+// StatusName returns the name of an incident status API value
+func StatusName(code int32) string {
+	for name, c := range statusCodes {
+		if c == strconv.Itoa(int(code)) {
+			return name
+		}
+	}
+	return strconv.Itoa(int(code))
+}
+
+// QueryIncidentIDs returns the IDs of incidents matching filter, up to limit
+// results. A limit of 0 returns every matching incident.
+func QueryIncidentIDs(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter *string, sortBy string, limit int) ([]string, error) {
+	ids := []string{}
+	for {
+		pageSize := int64(maxQueryLimit)
+		if limit > 0 && limit-len(ids) < maxQueryLimit {
+			pageSize = int64(limit - len(ids))
+		}
+		offset := strconv.Itoa(len(ids))
+
+		res, err := c.Incidents.QueryIncidents(&incidents.QueryIncidentsParams{
+			Context: ctx,
+			Filter:  filter,
+			Sort:    &sortBy,
+			Limit:   &pageSize,
+			Offset:  &offset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query incidents: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, id := range res.Payload.Resources {
+			ids = append(ids, fmt.Sprint(id))
+		}
+
+		if len(res.Payload.Resources) == 0 || (limit > 0 && len(ids) >= limit) {
+			break
+		}
+
+		if p := res.Payload.Meta.Pagination; p != nil && p.Total != nil && int64(len(ids)) >= *p.Total {
+			break
+		}
+	}
+
+	return ids, nil
+}
+
+// GetIncidents fetches the incidents with the given IDs
+func GetIncidents(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) ([]*models.DomainIncident, error) {
+	result := []*models.DomainIncident{}
+
+	for _, chunk := range utils.Chunk(ids, maxEntities) {
+		res, err := c.Incidents.GetIncidents(&incidents.GetIncidentsParams{
+			Context: ctx,
+			Body: &models.MsaIdsRequest{
+				Ids: chunk,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get incidents: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		result = append(result, res.Payload.Resources...)
+	}
+
+	return result, nil
+}
+
+// GetBehaviors fetches every behavior of an incident, ordered by timestamp
+func GetBehaviors(ctx context.Context, c *client.CrowdStrikeAPISpecification, incidentID string) ([]*models.DomainBehavior, error) {
+	filter := fmt.Sprintf("incident_id:'%s'", incidentID)
+	ids := []string{}
+
+	for {
+		limit := int64(maxQueryLimit)
+		offset := strconv.Itoa(len(ids))
+
+		res, err := c.Incidents.QueryBehaviors(&incidents.QueryBehaviorsParams{
+			Context: ctx,
+			Filter:  &filter,
+			Limit:   &limit,
+			Offset:  &offset,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query behaviors: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, res.Payload.Resources...)
+
+		if len(res.Payload.Resources) < maxQueryLimit {
+			break
+		}
+	}
+
+	behaviors := []*models.DomainBehavior{}
+	for _, chunk := range utils.Chunk(ids, maxEntities) {
+		res, err := c.Incidents.GetBehaviors(&incidents.GetBehaviorsParams{
+			Context: ctx,
+			Body: &models.MsaIdsRequest{
+				Ids: chunk,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get behaviors: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		behaviors = append(behaviors, res.Payload.Resources...)
+	}
+
+	sort.SliceStable(behaviors, func(i, j int) bool {
+		return time.Time(utils.Deref(behaviors[i].Timestamp)).Before(time.Time(utils.Deref(behaviors[j].Timestamp)))
+	})
+
+	return behaviors, nil
+}
+
+// IncidentsTable fills t with a summary row for each incident
+func IncidentsTable(t *output.Table, list []*models.DomainIncident) {
+	t.SetHeaders("INCIDENT ID", "SCORE", "STATUS", "HOSTS", "TACTICS", "ASSIGNED", "START", "END")
+	for _, i := range list {
+		t.AddRow(
+			utils.Deref(i.IncidentID),
+			fmt.Sprintf("%.1f", FineScore(i)),
+			StatusName(i.Status),
+			strings.Join(Hostnames(i), ","),
+			strings.Join(i.Tactics, ","),
+			i.AssignedToName,
+			output.Time(utils.Deref(i.Start)),
+			output.Time(utils.Deref(i.End)),
+		)
+	}
+}
+
+// BehaviorsTable fills t with a row for each behavior
+func BehaviorsTable(t *output.Table, list []*models.DomainBehavior, hostnames map[string]string) {
+	t.SetHeaders("TIMESTAMP", "HOST", "USER", "TACTIC", "TECHNIQUE", "FILEPATH", "COMMAND LINE")
+	for _, b := range list {
+		host := hostnames[b.Aid]
+		if host == "" {
+			host = b.Aid
+		}
+
+		t.AddRow(
+			output.Time(utils.Deref(b.Timestamp)),
+			host,
+			b.UserName,
+			b.Tactic,
+			b.Technique,
+			b.Filepath,
+			b.Cmdline,
+		)
+	}
+}
+
+// FineScore returns the incident score on the 0-100 scale shown in the console
+func FineScore(i *models.DomainIncident) float64 {
+	if i.FineScore == nil {
+		return 0
+	}
+	return float64(*i.FineScore) / 10
+}
+
+// Hostnames returns the hostnames of the hosts involved in an incident
+func Hostnames(i *models.DomainIncident) []string {
+	names := []string{}
+	for _, h := range i.Hosts {
+		names = append(names, h.Hostname)
+	}
+	return names
+}
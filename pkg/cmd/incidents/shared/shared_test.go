@@ -0,0 +1,108 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		status  string
+		want    string
+		wantErr bool
+	}{
+		{status: "new", want: "20"},
+		{status: "reopened", want: "25"},
+		{status: "in_progress", want: "30"},
+		{status: "closed", want: "40"},
+		{status: "open", wantErr: true},
+		{status: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			got, err := StatusCode(tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("StatusCode(%q) error = %v, wantErr %v", tt.status, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("StatusCode(%q) = %q, want %q", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusName(t *testing.T) {
+	tests := []struct {
+		code int32
+		want string
+	}{
+		{code: 20, want: "new"},
+		{code: 25, want: "reopened"},
+		{code: 30, want: "in_progress"},
+		{code: 40, want: "closed"},
+		{code: 99, want: "99"},
+	}
+
+	for _, tt := range tests {
+		if got := StatusName(tt.code); got != tt.want {
+			t.Errorf("StatusName(%d) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+
+	// every status name maps back to itself
+	for _, status := range Statuses {
+		code, err := StatusCode(status)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n, err := strconv.Atoi(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := StatusName(int32(n)); got != status {
+			t.Errorf("StatusName(StatusCode(%q)) = %q", status, got)
+		}
+	}
+}
+
+func TestFineScore(t *testing.T) {
+	tests := []struct {
+		score *int32
+		want  float64
+	}{
+		{score: nil, want: 0},
+		{score: utils.Ptr(int32(0)), want: 0},
+		{score: utils.Ptr(int32(735)), want: 73.5},
+		{score: utils.Ptr(int32(1000)), want: 100},
+	}
+
+	for _, tt := range tests {
+		if got := FineScore(&models.DomainIncident{FineScore: tt.score}); got != tt.want {
+			t.Errorf("FineScore(%v) = %v, want %v", utils.Deref(tt.score), got, tt.want)
+		}
+	}
+}
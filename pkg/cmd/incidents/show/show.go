@@ -0,0 +1,159 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package show
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/incidents/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Show an incident with its hosts and behavior timeline`
+	longDesc  = templates.LongDesc(`
+		Show the details of an incident, the hosts involved and a timeline of
+		the behaviors that make up the incident.`)
+	examples = templates.Examples(`
+		# Show an incident
+		falcon incidents show <incident_id>
+
+		# Show an incident and its behaviors as JSON
+		falcon incidents show <incident_id> -o json
+	`)
+)
+
+type ShowOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	IncidentID string
+	Format     string
+}
+
+// incidentDetails is the structured output of the show command
+type incidentDetails struct {
+	Incident  *models.DomainIncident   `json:"incident"`
+	Behaviors []*models.DomainBehavior `json:"behaviors"`
+}
+
+// NewCmdShow represents the incidents show command
+func NewCmdShow(f *factory.Factory) *cobra.Command {
+	opts := &ShowOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "show <incident_id>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"get"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+			opts.IncidentID = args[0]
+
+			return showRun(cmd.Context(), opts)
+		},
+	}
+
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func showRun(ctx context.Context, opts *ShowOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := shared.GetIncidents(ctx, c, []string{opts.IncidentID})
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 0 {
+		return fmt.Errorf("incident %s not found", opts.IncidentID)
+	}
+	incident := list[0]
+
+	behaviors, err := shared.GetBehaviors(ctx, c, opts.IncidentID)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format != output.FormatTable {
+		details := &incidentDetails{Incident: incident, Behaviors: behaviors}
+		return output.Print(opts.IO.Out, opts.Format, details, nil)
+	}
+
+	return printDetails(opts.IO.Out, incident, behaviors)
+}
+
+func printDetails(w io.Writer, i *models.DomainIncident, behaviors []*models.DomainBehavior) error {
+	summary := output.NewTable()
+	summary.AddRow("Incident ID:", utils.Deref(i.IncidentID))
+	summary.AddRow("Name:", i.Name)
+	summary.AddRow("Score:", fmt.Sprintf("%.1f", shared.FineScore(i)))
+	summary.AddRow("Status:", shared.StatusName(i.Status))
+	summary.AddRow("Assigned To:", i.AssignedToName)
+	summary.AddRow("Start:", output.Time(utils.Deref(i.Start)))
+	summary.AddRow("End:", output.Time(utils.Deref(i.End)))
+	summary.AddRow("Tactics:", strings.Join(i.Tactics, ", "))
+	summary.AddRow("Techniques:", strings.Join(i.Techniques, ", "))
+	summary.AddRow("Objectives:", strings.Join(i.Objectives, ", "))
+	summary.AddRow("Users:", strings.Join(i.Users, ", "))
+	summary.AddRow("Tags:", strings.Join(i.Tags, ", "))
+	if err := summary.Render(w); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nHosts:")
+	hostnames := map[string]string{}
+	hosts := output.NewTable("HOSTNAME", "DEVICE ID", "PLATFORM", "OS VERSION", "LOCAL IP", "EXTERNAL IP", "LAST SEEN")
+	for _, h := range i.Hosts {
+		hostnames[utils.Deref(h.DeviceID)] = h.Hostname
+		hosts.AddRow(h.Hostname, utils.Deref(h.DeviceID), h.PlatformName, h.OsVersion, h.LocalIP, h.ExternalIP, h.LastSeen)
+	}
+	if err := hosts.Render(w); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nTimeline:")
+	timeline := output.NewTable()
+	shared.BehaviorsTable(timeline, behaviors, hostnames)
+	return timeline.Render(w)
+}
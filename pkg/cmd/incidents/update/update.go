@@ -0,0 +1,191 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/incidents/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/incidents"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Update incidents`
+	longDesc  = templates.LongDesc(`
+		Change the status, assignee, tags, name or description of one or more
+		incidents.
+
+		Pass "-" to read incident IDs from standard input, one per line.`)
+	examples = templates.Examples(`
+		# Mark an incident as in progress and assign it to a responder
+		falcon incidents update <incident_id> --status in_progress --assign-uuid <user_uuid>
+
+		# Tag several incidents
+		falcon incidents update <incident_id> <incident_id> --add-tag ir-2023-001
+
+		# Close an incident and remove a tag
+		falcon incidents update <incident_id> --status closed --remove-tag triage
+	`)
+)
+
+// maxUpdateIDs is the maximum number of incidents that can be updated per request
+const maxUpdateIDs = 500
+
+type UpdateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	IDs         []string
+	Status      string
+	AssignUUID  string
+	Unassign    bool
+	AddTags     []string
+	RemoveTags  []string
+	Name        string
+	Description string
+	Comment     string
+}
+
+// NewCmdUpdate represents the incidents update command
+func NewCmdUpdate(f *factory.Factory) *cobra.Command {
+	opts := &UpdateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "update <incident_id>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := utils.ReadIDs(args, opts.IO.In)
+			if err != nil {
+				return err
+			}
+			opts.IDs = ids
+
+			if opts.Unassign && opts.AssignUUID != "" {
+				return fmt.Errorf("--unassign cannot be combined with --assign-uuid")
+			}
+
+			return updateRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Status, "status", "", "Set the incident status: new, reopened, in_progress or closed")
+	cmd.Flags().StringVar(&opts.AssignUUID, "assign-uuid", "", "Assign the incidents to the user with this UUID")
+	cmd.Flags().BoolVar(&opts.Unassign, "unassign", false, "Remove the current assignee")
+	cmd.Flags().StringSliceVar(&opts.AddTags, "add-tag", nil, "Add tags to the incidents")
+	cmd.Flags().StringSliceVar(&opts.RemoveTags, "remove-tag", nil, "Remove tags from the incidents")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Rename the incidents")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Set the description of the incidents")
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Add a comment to the incidents")
+
+	return cmd
+}
+
+func (opts *UpdateOptions) actionParameters() ([]*models.MsaActionParameter, error) {
+	params := []*models.MsaActionParameter{}
+	add := func(name, value string) {
+		params = append(params, &models.MsaActionParameter{
+			Name:  &name,
+			Value: &value,
+		})
+	}
+
+	if opts.Status != "" {
+		code, err := shared.StatusCode(opts.Status)
+		if err != nil {
+			return nil, err
+		}
+		add("update_status", code)
+	}
+	if opts.AssignUUID != "" {
+		add("update_assigned_to_v2", opts.AssignUUID)
+	}
+	if opts.Unassign {
+		add("unassign", "")
+	}
+	for _, tag := range opts.AddTags {
+		add("add_tag", tag)
+	}
+	for _, tag := range opts.RemoveTags {
+		add("delete_tag", tag)
+	}
+	if opts.Name != "" {
+		add("update_name", opts.Name)
+	}
+	if opts.Description != "" {
+		add("update_description", opts.Description)
+	}
+	if opts.Comment != "" {
+		add("add_comment", opts.Comment)
+	}
+
+	return params, nil
+}
+
+func updateRun(ctx context.Context, opts *UpdateOptions) error {
+	params, err := opts.actionParameters()
+	if err != nil {
+		return err
+	}
+
+	if len(params) == 0 {
+		return fmt.Errorf("nothing to update, specify at least one change")
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	for _, chunk := range utils.Chunk(opts.IDs, maxUpdateIDs) {
+		res, err := c.Incidents.PerformIncidentAction(&incidents.PerformIncidentActionParams{
+			Context: ctx,
+			Body: &models.MsaEntityActionRequestV2{
+				ActionParameters: params,
+				Ids:              chunk,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update incidents: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Updated %d incident(s)\n", len(opts.IDs))
+	return nil
+}
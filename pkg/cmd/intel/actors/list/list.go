@@ -0,0 +1,110 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package list
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/intel/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/intel"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Search actors by keyword or FQL filter`
+	longDesc  = templates.LongDesc(`
+		Search the adversaries tracked by Falcon Intelligence, most recently
+		active first.
+
+		Keywords are matched against every field of the actors and are
+		combined with any FQL expression given with --filter, on fields
+		such as name, slug, motivations.slug, origins.slug,
+		target_countries.slug or target_industries.slug.`)
+	examples = templates.Examples(`
+		# Search actors by keyword
+		falcon intel actors list bear
+
+		# List the criminal actors targeting the financial sector
+		falcon intel actors list --filter "motivations.slug:'criminal'+target_industries.slug:'financial-services'"
+	`)
+)
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Keywords []string
+	Search   shared.SearchFlags
+	Format   string
+}
+
+// NewCmdList represents the intel actors list command
+func NewCmdList(f *factory.Factory) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list [<keyword>...]",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"ls", "search"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			opts.Keywords = args
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddSearchFlags(cmd, &opts.Search, "last_activity_date|desc")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	s, err := opts.Search.Search(opts.Keywords)
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := intel.QueryActors(ctx, c, s)
+	if err != nil {
+		return err
+	}
+
+	return shared.PrintActors(opts.IO.Out, opts.Format, list)
+}
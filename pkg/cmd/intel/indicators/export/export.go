@@ -0,0 +1,184 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/intel"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Export indicators as NDJSON`
+	longDesc  = templates.LongDesc(`
+		Export the indicators matching --type and --filter as newline
+		delimited JSON (NDJSON), in the order they were published and
+		updated.
+
+		Indicators are written page by page as they are received, so exports
+		of millions of indicators do not need to fit in memory. Deleted
+		indicators are included with "deleted": true so that they can be
+		removed downstream, unless --include-deleted=false is given.
+
+		With --marker-file, the marker of the last exported indicator is
+		saved to the file after every page, and the next export resumes
+		after it. This exports only what changed since the previous run,
+		and an interrupted export picks up where it stopped. --since only
+		bounds the first export, before the marker file exists. When
+		resuming from a marker, --file is appended to rather than
+		overwritten so that the pages of an interrupted run are kept.`)
+	examples = templates.Examples(`
+		# Export the indicators updated over the last week
+		falcon intel indicators export --since 7d --file indicators.ndjson
+
+		# Pull the changes since the previous run every night
+		falcon intel indicators export --since 30d --marker-file /var/lib/tip/falcon.marker --file "delta-$(date +%F).ndjson"
+	`)
+)
+
+type ExportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Filter         string
+	Types          []string
+	Since          string
+	MarkerFile     string
+	IncludeDeleted bool
+	File           string
+}
+
+// NewCmdExport represents the intel indicators export command
+func NewCmdExport(f *factory.Factory) *cobra.Command {
+	opts := &ExportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "FQL filter expression")
+	cmd.Flags().StringSliceVar(&opts.Types, "type", nil, "Only export indicators of these types, e.g. domain, ip_address, hash_sha256, url")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only export indicators updated since a duration ago such as 7d, a date or an RFC 3339 time")
+	cmd.Flags().StringVar(&opts.MarkerFile, "marker-file", "", "File to resume the export from and save its progress to")
+	cmd.Flags().BoolVar(&opts.IncludeDeleted, "include-deleted", true, "Include deleted indicators")
+	cmd.Flags().StringVar(&opts.File, "file", "", "Write the export to a file instead of standard output")
+
+	return cmd
+}
+
+func exportRun(ctx context.Context, opts *ExportOptions) error {
+	since, err := utils.ParseTime(opts.Since, time.Now())
+	if err != nil {
+		return err
+	}
+
+	marker := ""
+	if opts.MarkerFile != "" {
+		if marker, err = intel.ReadMarker(opts.MarkerFile); err != nil {
+			return fmt.Errorf("failed to read the marker file: %v", err)
+		}
+	}
+	if marker != "" {
+		since = time.Time{}
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = opts.IO.Out
+	var f *os.File
+	if opts.File != "" {
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if marker != "" {
+			// the pages before the marker may have been written to the file
+			// by an interrupted run
+			flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		}
+		if f, err = os.OpenFile(filepath.Clean(opts.File), flags, 0o644); err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	filter := fql.New().Raw(opts.Filter).In("type", opts.Types...).String()
+	count := 0
+
+	err = intel.ExportIndicators(ctx, c, filter, since, marker, opts.IncludeDeleted, func(page []*intel.Indicator, next string) error {
+		for _, i := range page {
+			if err := output.PrintNDJSON(w, i); err != nil {
+				return err
+			}
+		}
+		count += len(page)
+
+		if opts.MarkerFile == "" {
+			return nil
+		}
+		// the page must be stored before the marker moves past it
+		if f != nil {
+			if err := f.Sync(); err != nil {
+				return fmt.Errorf("failed to write %s: %v", opts.File, err)
+			}
+		}
+		if err := intel.WriteMarker(opts.MarkerFile, next); err != nil {
+			return fmt.Errorf("failed to save the marker file: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if f != nil {
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write %s: %v", opts.File, err)
+		}
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "Exported %d indicators\n", count)
+	return nil
+}
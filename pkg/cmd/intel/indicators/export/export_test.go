@@ -0,0 +1,108 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+)
+
+// TestExportRunResume checks that resuming from a marker appends to the
+// output file instead of dropping the pages of the interrupted run
+func TestExportRunResume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"resources": []map[string]interface{}{
+				{"id": "domain_evil.example.com", "indicator": "evil.example.com", "type": "domain", "_marker": "m2"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	falconClient := func() (*client.CrowdStrikeAPISpecification, error) {
+		return client.New(httptransport.New(u.Host, "/", []string{"http"}), strfmt.Default), nil
+	}
+
+	tests := []struct {
+		name     string
+		marker   string
+		wantKept bool
+	}{
+		{"resume", "m1\n", true},
+		{"first run", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, "delta.ndjson")
+			markerFile := filepath.Join(dir, "falcon.marker")
+			if err := os.WriteFile(file, []byte(`{"id":"interrupted"}`+"\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if tt.marker != "" {
+				if err := os.WriteFile(markerFile, []byte(tt.marker), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			opts := &ExportOptions{
+				IO:           &iostreams.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}},
+				FalconClient: falconClient,
+				MarkerFile:   markerFile,
+				File:         file,
+			}
+			if err := exportRun(context.Background(), opts); err != nil {
+				t.Fatalf("exportRun() returned error: %v", err)
+			}
+
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kept := strings.Contains(string(data), "interrupted"); kept != tt.wantKept {
+				t.Errorf("previous content kept = %v, want %v:\n%s", kept, tt.wantKept, data)
+			}
+			if !strings.Contains(string(data), "evil.example.com") {
+				t.Errorf("the exported page is missing:\n%s", data)
+			}
+
+			marker, err := os.ReadFile(markerFile)
+			if err != nil || string(marker) != "m2\n" {
+				t.Errorf("marker file = %q, %v, want m2", marker, err)
+			}
+		})
+	}
+}
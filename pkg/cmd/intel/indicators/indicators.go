@@ -0,0 +1,45 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package indicators
+
+import (
+	exportCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/intel/indicators/export"
+	getCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/intel/indicators/get"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/intel/indicators/list"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdIndicators represents the intel indicators command
+func NewCmdIndicators(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "indicators <command>",
+		Short:   "Search and export Falcon Intelligence indicators",
+		Aliases: []string{"indicator"},
+	}
+
+	cmd.AddCommand(
+		listCmd.NewCmdList(f),
+		getCmd.NewCmdGet(f),
+		exportCmd.NewCmdExport(f),
+	)
+	return cmd
+}
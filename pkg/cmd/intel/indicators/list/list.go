@@ -0,0 +1,119 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package list
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/intel/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/intel"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Search indicators by keyword or FQL filter`
+	longDesc  = templates.LongDesc(`
+		Search the indicators published by Falcon Intelligence, most
+		recently published first.
+
+		Keywords are matched against every field of the indicators and are
+		combined with --type and any FQL expression given with --filter, on
+		fields such as indicator, malicious_confidence, malware_families,
+		actors, reports, threat_types or last_updated.
+
+		Use "falcon intel indicators export" to export large numbers of
+		indicators.`)
+	examples = templates.Examples(`
+		# Search indicators by keyword
+		falcon intel indicators list evil.example.com
+
+		# List the high confidence domains of a malware family
+		falcon intel indicators list --type domain --filter "malware_families:'Emotet'+malicious_confidence:'high'"
+	`)
+)
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Keywords       []string
+	Types          []string
+	IncludeDeleted bool
+	Search         shared.SearchFlags
+	Format         string
+}
+
+// NewCmdList represents the intel indicators list command
+func NewCmdList(f *factory.Factory) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list [<keyword>...]",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"ls", "search"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			opts.Keywords = args
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddSearchFlags(cmd, &opts.Search, "published_date|desc")
+	cmd.Flags().StringSliceVar(&opts.Types, "type", nil, "Only return indicators of these types, e.g. domain, ip_address, hash_sha256, url")
+	cmd.Flags().BoolVar(&opts.IncludeDeleted, "include-deleted", false, "Include deleted indicators")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	s, err := opts.Search.Search(opts.Keywords)
+	if err != nil {
+		return err
+	}
+	s.Filter = fql.New().Raw(s.Filter).In("type", opts.Types...).String()
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := intel.QueryIndicators(ctx, c, s, opts.IncludeDeleted)
+	if err != nil {
+		return err
+	}
+
+	return shared.PrintIndicators(opts.IO.Out, opts.Format, list)
+}
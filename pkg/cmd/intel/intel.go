@@ -0,0 +1,65 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package intel
+
+import (
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/intel/actors"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/intel/indicators"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/intel/reports"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Search Falcon Intelligence`
+	longDesc  = templates.LongDesc(`
+		Search the actors, reports and indicators of Falcon Intelligence,
+		download reports as PDF and export indicators to threat intelligence
+		platforms.`)
+	examples = templates.Examples(`
+		# Search actors by keyword
+		falcon intel actors list bear
+
+		# Download a report as PDF
+		falcon intel reports download <slug>
+
+		# Export the indicators updated since the last export
+		falcon intel indicators export --marker-file indicators.marker --file indicators.ndjson
+	`)
+)
+
+// NewIntelCmd represents the intel command
+func NewIntelCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "intel <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		actors.NewCmdActors(f),
+		reports.NewCmdReports(f),
+		indicators.NewCmdIndicators(f),
+	)
+	return cmd
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package download
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/intel"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Download reports as PDF`
+	longDesc  = templates.LongDesc(`
+		Download the PDF of one or more reports, given by ID or slug, to
+		<slug>.pdf in the directory given with --dir.
+
+		Pass "-" to read IDs or slugs from standard input, one per line.`)
+	examples = templates.Examples(`
+		# Download a report to the current directory
+		falcon intel reports download CSIT-22001
+
+		# Download the latest reports about an actor
+		falcon intel reports list --filter "actors.slug:'fancy-bear'" --limit 5 -o json | jq -r '.[].slug' | falcon intel reports download - --dir reports
+	`)
+)
+
+type DownloadOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Refs []string
+	Dir  string
+}
+
+// NewCmdDownload represents the intel reports download command
+func NewCmdDownload(f *factory.Factory) *cobra.Command {
+	opts := &DownloadOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "download <id|slug>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			refs, err := utils.ReadIDs(args, opts.IO.In)
+			if err != nil {
+				return err
+			}
+			opts.Refs = refs
+
+			return downloadRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Dir, "dir", ".", "Directory to save the reports in")
+
+	return cmd
+}
+
+func downloadRun(ctx context.Context, opts *DownloadOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := intel.GetReports(ctx, c, opts.Refs)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(opts.Dir, 0700); err != nil {
+		return err
+	}
+
+	for _, r := range list {
+		name := utils.Deref(r.Slug)
+		if name == "" {
+			name = strconv.FormatInt(utils.Deref(r.ID), 10)
+		}
+		path := filepath.Join(opts.Dir, name+".pdf")
+
+		if err := download(ctx, c, r, path); err != nil {
+			return err
+		}
+		fmt.Fprintf(opts.IO.ErrOut, "Saved %q to %s\n", utils.Deref(r.Name), path)
+	}
+
+	return nil
+}
+
+func download(ctx context.Context, c *client.CrowdStrikeAPISpecification, r *intel.Report, path string) error {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := intel.DownloadReport(ctx, c, r, f); err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/intel"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// SearchFlags holds the flags of the intel list commands
+type SearchFlags struct {
+	Filter string
+	Sort   string
+	Limit  int
+	All    bool
+}
+
+// AddSearchFlags adds the flags of the intel list commands to cmd, sorting
+// by defaultSort unless told otherwise
+func AddSearchFlags(cmd *cobra.Command, flags *SearchFlags, defaultSort string) {
+	cmd.Flags().StringVar(&flags.Filter, "filter", "", "FQL filter expression")
+	cmd.Flags().StringVar(&flags.Sort, "sort", defaultSort, "Sort results by a field, e.g. name|asc")
+	cmd.Flags().IntVarP(&flags.Limit, "limit", "l", 50, "Maximum number of results to return")
+	cmd.Flags().BoolVar(&flags.All, "all", false, "Return all matching results, ignoring --limit")
+}
+
+// Search returns the search of the given keywords and flags
+func (f *SearchFlags) Search(keywords []string) (*intel.Search, error) {
+	if f.Limit < 1 {
+		return nil, fmt.Errorf("--limit must be greater than 0")
+	}
+
+	s := &intel.Search{
+		Query:  strings.Join(keywords, " "),
+		Filter: f.Filter,
+		Sort:   f.Sort,
+		Limit:  f.Limit,
+	}
+	if f.All {
+		s.Limit = 0
+	}
+	return s, nil
+}
+
+// PrintActors writes actors to w in the given output format
+func PrintActors(w io.Writer, format string, list []*intel.Actor) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "NAME", "SLUG", "ORIGINS", "MOTIVATIONS", "TARGET INDUSTRIES", "LAST ACTIVITY")
+		for _, a := range list {
+			t.AddRow(
+				fmt.Sprint(utils.Deref(a.ID)),
+				utils.Deref(a.Name),
+				utils.Deref(a.Slug),
+				intel.Names(a.Origins),
+				intel.Names(a.Motivations),
+				intel.Names(a.TargetIndustries),
+				intel.Date(a.LastActivityDate),
+			)
+		}
+	})
+}
+
+// PrintReports writes reports to w in the given output format
+func PrintReports(w io.Writer, format string, list []*intel.Report) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "NAME", "SLUG", "TYPE", "ACTORS", "CREATED")
+		for _, r := range list {
+			reportType := ""
+			if r.Type != nil {
+				reportType = r.Type.Name
+			}
+			actors := []string{}
+			for _, a := range r.Actors {
+				actors = append(actors, a.Name)
+			}
+			t.AddRow(
+				fmt.Sprint(utils.Deref(r.ID)),
+				utils.Deref(r.Name),
+				utils.Deref(r.Slug),
+				reportType,
+				strings.Join(actors, ", "),
+				intel.Date(r.CreatedDate),
+			)
+		}
+	})
+}
+
+// PrintIndicators writes indicators to w in the given output format
+func PrintIndicators(w io.Writer, format string, list []*intel.Indicator) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("INDICATOR", "TYPE", "CONFIDENCE", "MALWARE FAMILIES", "ACTORS", "LAST UPDATED", "DELETED")
+		for _, i := range list {
+			deleted := ""
+			if utils.Deref(i.Deleted) {
+				deleted = "yes"
+			}
+			t.AddRow(
+				utils.Deref(i.Indicator),
+				utils.Deref(i.Type),
+				utils.Deref(i.MaliciousConfidence),
+				strings.Join(i.MalwareFamilies, ", "),
+				strings.Join(i.Actors, ", "),
+				intel.Date(i.LastUpdated),
+				deleted,
+			)
+		}
+	})
+}
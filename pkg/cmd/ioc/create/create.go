@@ -0,0 +1,131 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package create
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/ioc"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Create custom indicators`
+	longDesc  = templates.LongDesc(`
+		Create one or more custom indicators of the same type.
+
+		All the values share the settings given with the flags. An indicator
+		must either be applied globally with --global or to the host groups
+		given with --host-group.`)
+	examples = templates.Examples(`
+		# Detect a domain on all Windows hosts
+		falcon ioc create domain evil.example.com --action detect --severity medium --platform windows --global
+
+		# Block two hashes on the hosts of a group until the end of the year
+		falcon ioc create sha256 <hash> <hash> --action prevent --severity high \
+		  --platform windows,mac --host-group <group id> --expiration 2026-12-31
+	`)
+)
+
+type CreateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Type           string
+	Values         []string
+	Settings       shared.Settings
+	Comment        string
+	IgnoreWarnings bool
+	Format         string
+}
+
+// NewCmdCreate represents the ioc create command
+func NewCmdCreate(f *factory.Factory) *cobra.Command {
+	opts := &CreateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "create <type> <value>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			opts.Type = args[0]
+			opts.Values = args[1:]
+
+			return createRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddSettingsFlags(cmd, &opts.Settings)
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Audit log comment for the change")
+	cmd.Flags().BoolVar(&opts.IgnoreWarnings, "ignore-warnings", false, "Create the indicators even if the API returns warnings")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func createRun(ctx context.Context, opts *CreateOptions) error {
+	rows := []*ioc.Row{}
+	for _, value := range opts.Values {
+		i := &ioc.Indicator{Type: opts.Type, Value: value}
+		if err := opts.Settings.Apply(i); err != nil {
+			return err
+		}
+
+		if err := i.Validate(); err != nil {
+			return fmt.Errorf("%s: %v", value, err)
+		}
+
+		rows = append(rows, &ioc.Row{Indicator: i})
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	results := shared.CreateIndicators(ctx, c, rows, opts.Comment, opts.IgnoreWarnings)
+	if err := shared.PrintResults(opts.IO.Out, opts.Format, results); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if r.Status != shared.StatusCreated {
+			return fmt.Errorf("failed to create some indicators")
+		}
+	}
+
+	return nil
+}
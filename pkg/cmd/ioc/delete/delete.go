@@ -0,0 +1,109 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package delete
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Delete custom indicators`
+	longDesc  = templates.LongDesc(`
+		Delete custom indicators by ID or every indicator matching an FQL
+		filter.
+
+		Pass "-" to read indicator IDs from standard input, one per line.`)
+	examples = templates.Examples(`
+		# Delete an indicator
+		falcon ioc delete <id>
+
+		# Delete every indicator from a source
+		falcon ioc delete --filter "source:'old-feed'" --comment "feed retired"
+	`)
+)
+
+type DeleteOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	IDs     []string
+	Filter  string
+	Comment string
+}
+
+// NewCmdDelete represents the ioc delete command
+func NewCmdDelete(f *factory.Factory) *cobra.Command {
+	opts := &DeleteOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "delete [<id>...]",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"rm"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 0) == (opts.Filter == "") {
+				return fmt.Errorf("specify either indicator IDs or --filter")
+			}
+
+			if len(args) > 0 {
+				ids, err := utils.ReadIDs(args, opts.IO.In)
+				if err != nil {
+					return err
+				}
+				opts.IDs = ids
+			}
+
+			return deleteRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Delete the indicators matching a Falcon Query Language (FQL) expression")
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Audit log comment for the change")
+
+	return cmd
+}
+
+func deleteRun(ctx context.Context, opts *DeleteOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	deleted, err := shared.DeleteIndicators(ctx, c, opts.IDs, opts.Filter, opts.Comment)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "Deleted %d indicators\n", len(deleted))
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package export
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/ioc"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Export custom indicators to a CSV file or STIX bundle`
+	longDesc  = templates.LongDesc(`
+		Export custom indicators to a CSV file or a STIX 2.1 bundle.
+
+		The CSV columns are the ones read by "falcon ioc import", so an
+		export can be edited and imported again.`)
+	examples = templates.Examples(`
+		# Export every indicator to a CSV file
+		falcon ioc export --file indicators.csv
+
+		# Export the blocked hashes as a STIX bundle
+		falcon ioc export --format stix --filter "action:'prevent'+type:'sha256'"
+	`)
+)
+
+type ExportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	FileFormat string
+	Filter     string
+	File       string
+}
+
+// NewCmdExport represents the ioc export command
+func NewCmdExport(f *factory.Factory) *cobra.Command {
+	opts := &ExportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateOneOf("format", []string{"csv", "stix"}, opts.FileFormat); err != nil {
+				return err
+			}
+
+			return exportRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.FileFormat, "format", "csv", "Format of the export: csv or stix")
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Only export the indicators matching a Falcon Query Language (FQL) expression")
+	cmd.Flags().StringVar(&opts.File, "file", "", "Write the export to a file instead of standard output")
+
+	return cmd
+}
+
+func exportRun(ctx context.Context, opts *ExportOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := shared.QueryIndicators(ctx, c, opts.Filter, "type.asc", 0)
+	if err != nil {
+		return err
+	}
+
+	indicators := []*ioc.Indicator{}
+	for _, in := range list {
+		indicators = append(indicators, ioc.FromAPI(in))
+	}
+
+	var w io.Writer = opts.IO.Out
+	if opts.File != "" {
+		f, err := os.Create(opts.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if opts.FileFormat == "stix" {
+		err = ioc.WriteSTIX(w, indicators, time.Now())
+	} else {
+		err = ioc.WriteCSV(w, indicators)
+	}
+	return err
+}
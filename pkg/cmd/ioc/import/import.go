@@ -0,0 +1,227 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package importcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/ioc"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+// File formats supported by import and export
+const (
+	formatCSV  = "csv"
+	formatSTIX = "stix"
+)
+
+var (
+	shortDesc = `Import custom indicators from a CSV file or STIX bundle`
+	longDesc  = templates.LongDesc(`
+		Import custom indicators from a CSV file or a STIX 2.1 bundle.
+
+		CSV files must have a header row naming the columns: type, value,
+		action, severity, platforms, expiration, host_groups,
+		applied_globally, description, tags and source. Lists are separated
+		with commas or semicolons. Only type and value are required, the
+		flags set the defaults of the other columns.
+
+		Every row is validated before anything is sent to the API and the
+		result of each row is reported with its line number. Use --dry-run to
+		only validate the file. Pass "-" to read from standard input.`)
+	examples = templates.Examples(`
+		# Validate a CSV file without importing it
+		falcon ioc import indicators.csv --dry-run
+
+		# Import a STIX bundle, detecting on all Windows hosts
+		falcon ioc import bundle.json --action detect --severity medium --platform windows --global
+	`)
+)
+
+type ImportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	File           string
+	FileFormat     string
+	Settings       shared.Settings
+	DryRun         bool
+	Comment        string
+	IgnoreWarnings bool
+	Format         string
+}
+
+// NewCmdImport represents the ioc import command
+func NewCmdImport(f *factory.Factory) *cobra.Command {
+	opts := &ImportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "import <file>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			opts.File = args[0]
+			if opts.FileFormat == "" {
+				opts.FileFormat = formatFromName(opts.File)
+			}
+
+			if err := utils.ValidateOneOf("format", []string{formatCSV, formatSTIX}, opts.FileFormat); err != nil {
+				return err
+			}
+
+			return importRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddSettingsFlags(cmd, &opts.Settings)
+	cmd.Flags().StringVar(&opts.FileFormat, "format", "", "Format of the file: csv or stix (default inferred from the file extension)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Validate the indicators without importing them")
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Audit log comment for the change")
+	cmd.Flags().BoolVar(&opts.IgnoreWarnings, "ignore-warnings", false, "Create the indicators even if the API returns warnings")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+// formatFromName infers the file format from its extension
+func formatFromName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".stix":
+		return formatSTIX
+	default:
+		return formatCSV
+	}
+}
+
+func importRun(ctx context.Context, opts *ImportOptions) error {
+	var r io.Reader = opts.IO.In
+	if opts.File != "-" {
+		f, err := os.Open(opts.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var rows []*ioc.Row
+	var err error
+	if opts.FileFormat == formatSTIX {
+		rows, err = ioc.ReadSTIX(r)
+	} else {
+		rows, err = ioc.ReadCSV(r)
+	}
+	if err != nil {
+		return err
+	}
+
+	valid, results := checkRows(rows, &opts.Settings, opts.DryRun)
+
+	if len(valid) > 0 {
+		c, err := opts.FalconClient()
+		if err != nil {
+			return err
+		}
+
+		results = append(results, shared.CreateIndicators(ctx, c, valid, opts.Comment, opts.IgnoreWarnings)...)
+	}
+
+	if err := shared.PrintResults(opts.IO.Out, opts.Format, results); err != nil {
+		return err
+	}
+
+	counts := map[string]int{}
+	for _, r := range results {
+		counts[r.Status]++
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(opts.IO.ErrOut, "%d valid, %d invalid\n", counts[shared.StatusValid], counts[shared.StatusInvalid])
+	} else {
+		fmt.Fprintf(opts.IO.ErrOut, "%d created, %d invalid, %d failed\n", counts[shared.StatusCreated], counts[shared.StatusInvalid], counts[shared.StatusFailed])
+	}
+
+	if counts[shared.StatusInvalid] > 0 || counts[shared.StatusFailed] > 0 {
+		return fmt.Errorf("%d of %d indicators were not imported", counts[shared.StatusInvalid]+counts[shared.StatusFailed], len(results))
+	}
+
+	return nil
+}
+
+// checkRows applies the settings to the rows and validates them. It returns
+// the rows to create and the results of the rows that are not created.
+func checkRows(rows []*ioc.Row, settings *shared.Settings, dryRun bool) ([]*ioc.Row, []*shared.Result) {
+	valid := []*ioc.Row{}
+	results := []*shared.Result{}
+	for _, row := range rows {
+		if row.Err == nil {
+			row.Err = settings.Apply(row.Indicator)
+		}
+		if row.Err == nil {
+			row.Err = row.Indicator.Validate()
+		}
+
+		if row.Err != nil {
+			results = append(results, &shared.Result{
+				Line:   row.Line,
+				Type:   row.Indicator.Type,
+				Value:  row.Indicator.Value,
+				Status: shared.StatusInvalid,
+				Error:  row.Err.Error(),
+			})
+			continue
+		}
+
+		if dryRun {
+			results = append(results, &shared.Result{
+				Line:   row.Line,
+				Type:   row.Indicator.Type,
+				Value:  row.Indicator.Value,
+				Status: shared.StatusValid,
+			})
+			continue
+		}
+
+		valid = append(valid, row)
+	}
+
+	return valid, results
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package importcmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/ioc"
+)
+
+func TestCheckRowsInvalid(t *testing.T) {
+	csvRows, err := ioc.ReadCSV(strings.NewReader("type,value,action\nsha256,\"abc\"x,detect\ndomain,evil.example.com,detect\n"))
+	if err != nil {
+		t.Fatalf("ReadCSV() returned error: %v", err)
+	}
+
+	stixRows, err := ioc.ReadSTIX(strings.NewReader(`{"type": "bundle", "objects": [
+	{"type": "indicator", "pattern": "title: rule", "pattern_type": "sigma"},
+	{"type": "indicator", "pattern": 42}
+]}`))
+	if err != nil {
+		t.Fatalf("ReadSTIX() returned error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		rows []*ioc.Row
+		want []string
+	}{
+		{"csv", csvRows, []string{shared.StatusInvalid, shared.StatusValid}},
+		{"stix", stixRows, []string{shared.StatusInvalid, shared.StatusInvalid}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, results := checkRows(tt.rows, &shared.Settings{Severity: "medium", Platforms: []string{"windows"}, AppliedGlobally: true}, true)
+			if len(valid) != 0 {
+				t.Errorf("checkRows() returned %d rows to create in dry-run mode", len(valid))
+			}
+
+			got := []string{}
+			for _, r := range results {
+				got = append(got, r.Status)
+				if r.Status == shared.StatusInvalid && r.Error == "" {
+					t.Errorf("line %d is invalid without an error", r.Line)
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("checkRows() statuses mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
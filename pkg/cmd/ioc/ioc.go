@@ -0,0 +1,72 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	createCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/create"
+	deleteCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/delete"
+	exportCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/export"
+	getCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/get"
+	importCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/import"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/list"
+	updateCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/update"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage custom indicators of compromise`
+	longDesc  = templates.LongDesc(`
+		Manage custom indicators of compromise (IOCs): SHA256 and MD5 hashes,
+		domains and IPv4 and IPv6 addresses that Falcon detects, blocks or
+		allows on your hosts.`)
+	examples = templates.Examples(`
+		# Block a file hash on Windows and macOS hosts
+		falcon ioc create sha256 <hash> --action prevent --severity high --platform windows,mac --global
+
+		# Import indicators from a CSV file
+		falcon ioc import indicators.csv --platform windows --global
+
+		# Export every indicator as a STIX 2.1 bundle
+		falcon ioc export --format stix > indicators.json
+	`)
+)
+
+// NewIOCCmd represents the ioc command
+func NewIOCCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "ioc <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		listCmd.NewCmdList(f),
+		getCmd.NewCmdGet(f),
+		createCmd.NewCmdCreate(f),
+		updateCmd.NewCmdUpdate(f),
+		deleteCmd.NewCmdDelete(f),
+		importCmd.NewCmdImport(f),
+		exportCmd.NewCmdExport(f),
+	)
+	return cmd
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/ioc"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List custom indicators`
+	longDesc  = templates.LongDesc(`
+		List custom indicators matching the given criteria.
+
+		The --type, --action and --value flags are combined with any FQL
+		expression given with --filter.`)
+	examples = templates.Examples(`
+		# List the 100 most recently modified indicators
+		falcon ioc list
+
+		# List the domains that are blocked
+		falcon ioc list --type domain --action prevent --all
+
+		# List the indicators from a source as JSON
+		falcon ioc list --filter "source:'threat-intel'" --all -o json
+	`)
+)
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Filter  string
+	Types   []string
+	Actions []string
+	Values  []string
+	Sort    string
+	Limit   int
+	All     bool
+	Format  string
+}
+
+// NewCmdList represents the ioc list command
+func NewCmdList(f *factory.Factory) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if err := utils.ValidateOneOf("type", ioc.Types, opts.Types...); err != nil {
+				return err
+			}
+
+			if err := utils.ValidateOneOf("action", ioc.Actions, opts.Actions...); err != nil {
+				return err
+			}
+
+			if opts.Limit < 1 {
+				return fmt.Errorf("--limit must be greater than 0")
+			}
+
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter indicators using a Falcon Query Language (FQL) expression")
+	cmd.Flags().StringSliceVar(&opts.Types, "type", nil, "Only include indicators of these types")
+	cmd.Flags().StringSliceVar(&opts.Actions, "action", nil, "Only include indicators with these actions")
+	cmd.Flags().StringSliceVar(&opts.Values, "value", nil, "Only include indicators with these values")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "modified_on.desc", "Sort indicators by a field, e.g. expiration.asc")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 100, "Maximum number of indicators to return")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Return all matching indicators, ignoring --limit")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	filter := fql.New().
+		Raw(opts.Filter).
+		In("type", opts.Types...).
+		In("action", opts.Actions...).
+		In("value", opts.Values...)
+
+	limit := opts.Limit
+	if opts.All {
+		limit = 0
+	}
+
+	list, err := shared.QueryIndicators(ctx, c, filter.String(), opts.Sort, limit)
+	if err != nil {
+		return err
+	}
+
+	return shared.PrintIndicators(opts.IO.Out, opts.Format, list)
+}
@@ -0,0 +1,411 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdstrike/falcon-cli/pkg/ioc"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	iocapi "github.com/crowdstrike/gofalcon/falcon/client/ioc"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/spf13/cobra"
+)
+
+// MaxPerRequest is the maximum number of indicators created or updated in a
+// single request
+const MaxPerRequest = 200
+
+// maxPerQuery is the maximum number of indicators returned per query
+const maxPerQuery = 2000
+
+// Statuses of indicators in the results of bulk operations
+const (
+	StatusCreated = "created"
+	StatusUpdated = "updated"
+	StatusValid   = "valid"
+	StatusInvalid = "invalid"
+	StatusFailed  = "failed"
+)
+
+// Result is the outcome of creating or updating a single indicator
+type Result struct {
+	Line   int    `json:"line,omitempty"`
+	Type   string `json:"type"`
+	Value  string `json:"value"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Settings are the indicator settings shared by the create, update and
+// import commands
+type Settings struct {
+	Action          string
+	Severity        string
+	Platforms       []string
+	HostGroups      []string
+	AppliedGlobally bool
+	Expiration      string
+	Description     string
+	Tags            []string
+	Source          string
+}
+
+// AddSettingsFlags registers the flags of the indicator settings
+func AddSettingsFlags(cmd *cobra.Command, s *Settings) {
+	cmd.Flags().StringVar(&s.Action, "action", "", fmt.Sprintf("Action taken when the indicator is observed: %s", strings.Join(ioc.Actions, ", ")))
+	cmd.Flags().StringVar(&s.Severity, "severity", "", fmt.Sprintf("Severity of detections: %s", strings.Join(ioc.Severities, ", ")))
+	cmd.Flags().StringSliceVar(&s.Platforms, "platform", nil, fmt.Sprintf("Platforms the indicator applies to: %s", strings.Join(ioc.Platforms, ", ")))
+	cmd.Flags().StringSliceVar(&s.HostGroups, "host-group", nil, "ID of a host group the indicator applies to (repeatable)")
+	cmd.Flags().BoolVar(&s.AppliedGlobally, "global", false, "Apply the indicator to all hosts")
+	cmd.Flags().StringVar(&s.Expiration, "expiration", "", "Expiration date (2006-01-02) or RFC 3339 timestamp of the indicator")
+	cmd.Flags().StringVar(&s.Description, "description", "", "Description of the indicator")
+	cmd.Flags().StringSliceVar(&s.Tags, "tag", nil, "Tag of the indicator (repeatable)")
+	cmd.Flags().StringVar(&s.Source, "source", "", "Source of the indicator")
+}
+
+// Apply sets the fields of i that are empty from the settings
+func (s *Settings) Apply(i *ioc.Indicator) error {
+	if i.Action == "" {
+		i.Action = s.Action
+	}
+	if i.Severity == "" {
+		i.Severity = s.Severity
+	}
+	if len(i.Platforms) == 0 {
+		i.Platforms = s.Platforms
+	}
+	if len(i.HostGroups) == 0 && !i.AppliedGlobally {
+		i.HostGroups = s.HostGroups
+		i.AppliedGlobally = s.AppliedGlobally
+	}
+	if i.Expiration.IsZero() {
+		expiration, err := ioc.ParseExpiration(s.Expiration)
+		if err != nil {
+			return err
+		}
+		i.Expiration = expiration
+	}
+	if i.Description == "" {
+		i.Description = s.Description
+	}
+	if len(i.Tags) == 0 {
+		i.Tags = s.Tags
+	}
+	if i.Source == "" {
+		i.Source = s.Source
+	}
+
+	i.Normalize()
+	return nil
+}
+
+// QueryIndicators returns the indicators matching filter, up to limit
+// results. A limit of 0 returns every matching indicator.
+func QueryIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter, sortBy string, limit int) ([]*models.APIIndicatorV1, error) {
+	list := []*models.APIIndicatorV1{}
+	var after *string
+
+	for {
+		pageSize := int64(maxPerQuery)
+		if limit > 0 && limit-len(list) < maxPerQuery {
+			pageSize = int64(limit - len(list))
+		}
+
+		params := &iocapi.IndicatorCombinedV1Params{
+			Context: ctx,
+			Limit:   &pageSize,
+			After:   after,
+			Filter:  utils.OptionalPtr(filter),
+			Sort:    utils.OptionalPtr(sortBy),
+		}
+
+		res, err := c.Ioc.IndicatorCombinedV1(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query indicators: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		list = append(list, res.Payload.Resources...)
+
+		if len(res.Payload.Resources) == 0 || (limit > 0 && len(list) >= limit) {
+			break
+		}
+
+		p := res.Payload.Meta.Pagination
+		if p == nil || p.After == "" || (p.Total != nil && int64(len(list)) >= *p.Total) {
+			break
+		}
+		after = &p.After
+	}
+
+	return list, nil
+}
+
+// GetIndicators fetches indicators by ID
+func GetIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) ([]*models.APIIndicatorV1, error) {
+	list := []*models.APIIndicatorV1{}
+
+	for _, chunk := range utils.Chunk(ids, MaxPerRequest) {
+		res, err := c.Ioc.IndicatorGetV1(&iocapi.IndicatorGetV1Params{
+			Context: ctx,
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get indicators: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		list = append(list, res.Payload.Resources...)
+	}
+
+	return list, nil
+}
+
+// CreateIndicators creates indicators in chunks of MaxPerRequest and returns
+// the result of each row. When a chunk is rejected the rows that were not
+// created are retried one at a time so that the error is reported against
+// the row that caused it.
+func CreateIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification, rows []*ioc.Row, comment string, ignoreWarnings bool) []*Result {
+	results := []*Result{}
+
+	for _, chunk := range utils.Chunk(rows, MaxPerRequest) {
+		created, err := create(ctx, c, chunk, comment, ignoreWarnings)
+		if err != nil && len(chunk) > 1 {
+			log.Debugf("Retrying the indicators that were not created one at a time: %v", err)
+			for i, r := range toResults(chunk, created, nil) {
+				if r.Status == StatusCreated {
+					results = append(results, r)
+					continue
+				}
+				results = append(results, createResults(ctx, c, chunk[i:i+1], comment, ignoreWarnings)...)
+			}
+			continue
+		}
+
+		results = append(results, toResults(chunk, created, err)...)
+	}
+
+	return results
+}
+
+func createResults(ctx context.Context, c *client.CrowdStrikeAPISpecification, rows []*ioc.Row, comment string, ignoreWarnings bool) []*Result {
+	created, err := create(ctx, c, rows, comment, ignoreWarnings)
+	return toResults(rows, created, err)
+}
+
+func create(ctx context.Context, c *client.CrowdStrikeAPISpecification, rows []*ioc.Row, comment string, ignoreWarnings bool) ([]*models.APIIndicatorV1, error) {
+	body := &models.APIIndicatorCreateReqsV1{Comment: comment}
+	for _, row := range rows {
+		body.Indicators = append(body.Indicators, row.Indicator.CreateRequest())
+	}
+
+	res, err := c.Ioc.IndicatorCreateV1(&iocapi.IndicatorCreateV1Params{
+		Context:        ctx,
+		Body:           body,
+		IgnoreWarnings: &ignoreWarnings,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create indicators: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return res.Payload.Resources, err
+	}
+
+	return res.Payload.Resources, nil
+}
+
+// toResults matches the indicators returned by the API with the rows sent
+func toResults(rows []*ioc.Row, list []*models.APIIndicatorV1, err error) []*Result {
+	byValue := map[string]*models.APIIndicatorV1{}
+	for _, in := range list {
+		byValue[in.Type+":"+strings.ToLower(in.Value)] = in
+	}
+
+	results := []*Result{}
+	for _, row := range rows {
+		r := &Result{Line: row.Line, Type: row.Indicator.Type, Value: row.Indicator.Value}
+
+		if in, ok := byValue[r.Type+":"+strings.ToLower(r.Value)]; ok && in.ID != "" {
+			r.Status = StatusCreated
+			r.ID = in.ID
+		} else {
+			r.Status = StatusFailed
+			r.Error = "indicator was not created"
+			if err != nil {
+				r.Error = err.Error()
+			}
+		}
+
+		results = append(results, r)
+	}
+
+	return results
+}
+
+// UpdateIndicators updates indicators in chunks of MaxPerRequest
+func UpdateIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification, updates []*models.APIIndicatorUpdateReqV1, comment string, ignoreWarnings bool) ([]*Result, error) {
+	results := []*Result{}
+
+	for _, chunk := range utils.Chunk(updates, MaxPerRequest) {
+		res, err := c.Ioc.IndicatorUpdateV1(&iocapi.IndicatorUpdateV1Params{
+			Context: ctx,
+			Body: &models.APIIndicatorUpdateReqsV1{
+				Comment:    comment,
+				Indicators: chunk,
+			},
+			IgnoreWarnings: &ignoreWarnings,
+		})
+		if err != nil {
+			return results, fmt.Errorf("failed to update indicators: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return results, err
+		}
+
+		for _, in := range res.Payload.Resources {
+			results = append(results, &Result{Type: in.Type, Value: in.Value, Status: StatusUpdated, ID: in.ID})
+		}
+	}
+
+	return results, nil
+}
+
+// UpdateRequest converts an indicator to an update request keeping all of
+// its current settings
+func UpdateRequest(in *models.APIIndicatorV1) *models.APIIndicatorUpdateReqV1 {
+	return &models.APIIndicatorUpdateReqV1{
+		ID:              in.ID,
+		Action:          in.Action,
+		Severity:        in.Severity,
+		Platforms:       in.Platforms,
+		Expiration:      in.Expiration,
+		HostGroups:      in.HostGroups,
+		AppliedGlobally: in.AppliedGlobally,
+		Description:     in.Description,
+		Tags:            in.Tags,
+		Source:          in.Source,
+		MobileAction:    in.MobileAction,
+	}
+}
+
+// PrintIndicators writes a list of indicators in the requested format
+func PrintIndicators(w io.Writer, format string, list []*models.APIIndicatorV1) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "TYPE", "VALUE", "ACTION", "SEVERITY", "PLATFORMS", "SCOPE", "EXPIRATION")
+		for _, in := range list {
+			t.AddRow(
+				in.ID,
+				in.Type,
+				in.Value,
+				in.Action,
+				in.Severity,
+				strings.Join(in.Platforms, ","),
+				Scope(in.AppliedGlobally, in.HostGroups),
+				output.Time(in.Expiration),
+			)
+		}
+	})
+}
+
+// PrintResults writes the results of a bulk operation in the requested format
+func PrintResults(w io.Writer, format string, results []*Result) error {
+	return output.Print(w, format, results, func(t *output.Table) {
+		t.SetHeaders("LINE", "TYPE", "VALUE", "STATUS", "ID", "ERROR")
+		for _, r := range results {
+			line := ""
+			if r.Line > 0 {
+				line = strconv.Itoa(r.Line)
+			}
+			t.AddRow(line, r.Type, r.Value, r.Status, r.ID, r.Error)
+		}
+	})
+}
+
+// Scope describes the hosts an indicator applies to
+func Scope(global bool, hostGroups []string) string {
+	if global {
+		return "global"
+	}
+	if len(hostGroups) == 1 {
+		return "1 host group"
+	}
+	return fmt.Sprintf("%d host groups", len(hostGroups))
+}
+
+// DeleteIndicators deletes indicators by ID or, when ids is empty, every
+// indicator matching filter. It returns the IDs of the deleted indicators.
+func DeleteIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string, filter, comment string) ([]string, error) {
+	deleted := []string{}
+
+	if len(ids) == 0 {
+		res, err := c.Ioc.IndicatorDeleteV1(&iocapi.IndicatorDeleteV1Params{
+			Context: ctx,
+			Filter:  &filter,
+			Comment: utils.OptionalPtr(comment),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete indicators: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		return res.Payload.Resources, nil
+	}
+
+	for _, chunk := range utils.Chunk(ids, MaxPerRequest) {
+		res, err := c.Ioc.IndicatorDeleteV1(&iocapi.IndicatorDeleteV1Params{
+			Context: ctx,
+			Ids:     chunk,
+			Comment: utils.OptionalPtr(comment),
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete indicators: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return deleted, err
+		}
+
+		deleted = append(deleted, res.Payload.Resources...)
+	}
+
+	return deleted, nil
+}
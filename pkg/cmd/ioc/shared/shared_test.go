@@ -0,0 +1,95 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/ioc"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestCreateIndicatorsRetry checks that only the rows that were not created
+// by a rejected chunk are retried
+func TestCreateIndicatorsRetry(t *testing.T) {
+	requests := [][]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Indicators []struct {
+				Type  string `json:"type"`
+				Value string `json:"value"`
+			} `json:"indicators"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+
+		values := []string{}
+		resources := []map[string]interface{}{}
+		for _, in := range body.Indicators {
+			values = append(values, in.Value)
+			if in.Value != "duplicate.example.com" {
+				resources = append(resources, map[string]interface{}{"id": "id-" + in.Value, "type": in.Type, "value": in.Value})
+			}
+		}
+		requests = append(requests, values)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"resources": resources,
+			"errors":    []map[string]interface{}{{"code": 400, "message": "Duplicate type: 'domain' and value: 'duplicate.example.com' combination."}},
+		})
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	c := client.New(httptransport.New(u.Host, "/", []string{"http"}), strfmt.Default)
+
+	rows := []*ioc.Row{
+		{Line: 2, Indicator: &ioc.Indicator{Type: ioc.TypeDomain, Value: "evil.example.com"}},
+		{Line: 3, Indicator: &ioc.Indicator{Type: ioc.TypeDomain, Value: "duplicate.example.com"}},
+	}
+	got := CreateIndicators(context.Background(), c, rows, "", false)
+
+	want := []*Result{
+		{Line: 2, Type: ioc.TypeDomain, Value: "evil.example.com", Status: StatusCreated, ID: "id-evil.example.com"},
+		{Line: 3, Type: ioc.TypeDomain, Value: "duplicate.example.com", Status: StatusFailed, Error: got[1].Error},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CreateIndicators() mismatch (-want +got):\n%s", diff)
+	}
+	if got[1].Error == "" {
+		t.Error("the failed row has no error")
+	}
+
+	wantRequests := [][]string{{"evil.example.com", "duplicate.example.com"}, {"duplicate.example.com"}}
+	if diff := cmp.Diff(wantRequests, requests); diff != "" {
+		t.Errorf("requests mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package update
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/ioc/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/ioc"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/strfmt"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Update custom indicators`
+	longDesc  = templates.LongDesc(`
+		Update the settings of one or more custom indicators.
+
+		Only the settings given with flags are changed, the others are kept.
+		Pass "-" to read indicator IDs from standard input, one per line.`)
+	examples = templates.Examples(`
+		# Change the action of an indicator to detect
+		falcon ioc update <id> --action detect --severity medium
+
+		# Extend the expiration of every indicator from a source
+		falcon ioc list --filter "source:'feed'" --all -o json | jq -r '.[].id' | falcon ioc update - --expiration 2027-01-31
+	`)
+)
+
+type UpdateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	IDs            []string
+	Settings       shared.Settings
+	Comment        string
+	IgnoreWarnings bool
+	Format         string
+
+	changed func(string) bool
+}
+
+// NewCmdUpdate represents the ioc update command
+func NewCmdUpdate(f *factory.Factory) *cobra.Command {
+	opts := &UpdateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "update <id>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			opts.changed = cmd.Flags().Changed
+			if !settingsChanged(opts.changed) {
+				return fmt.Errorf("nothing to update, set at least one indicator setting")
+			}
+
+			if opts.changed("global") && opts.changed("host-group") {
+				return fmt.Errorf("--global and --host-group cannot be used together")
+			}
+
+			ids, err := utils.ReadIDs(args, opts.IO.In)
+			if err != nil {
+				return err
+			}
+			opts.IDs = ids
+
+			return updateRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddSettingsFlags(cmd, &opts.Settings)
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Audit log comment for the change")
+	cmd.Flags().BoolVar(&opts.IgnoreWarnings, "ignore-warnings", false, "Update the indicators even if the API returns warnings")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+// settingsFlags are the flags of the indicator settings
+var settingsFlags = []string{"action", "severity", "platform", "host-group", "global", "expiration", "description", "tag", "source"}
+
+func settingsChanged(changed func(string) bool) bool {
+	for _, name := range settingsFlags {
+		if changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func updateRun(ctx context.Context, opts *UpdateOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	list, err := shared.GetIndicators(ctx, c, opts.IDs)
+	if err != nil {
+		return err
+	}
+
+	if len(list) != len(opts.IDs) {
+		return fmt.Errorf("found %d of %d indicators", len(list), len(opts.IDs))
+	}
+
+	updates := []*models.APIIndicatorUpdateReqV1{}
+	for _, in := range list {
+		u, err := merge(in, opts)
+		if err != nil {
+			return fmt.Errorf("%s: %v", in.ID, err)
+		}
+		updates = append(updates, u)
+	}
+
+	results, err := shared.UpdateIndicators(ctx, c, updates, opts.Comment, opts.IgnoreWarnings)
+	if perr := shared.PrintResults(opts.IO.Out, opts.Format, results); perr != nil {
+		return perr
+	}
+
+	return err
+}
+
+// merge applies the changed settings to an existing indicator and validates
+// the result
+func merge(in *models.APIIndicatorV1, opts *UpdateOptions) (*models.APIIndicatorUpdateReqV1, error) {
+	i := ioc.FromAPI(in)
+	s := opts.Settings
+
+	if opts.changed("action") {
+		i.Action = s.Action
+	}
+	if opts.changed("severity") {
+		i.Severity = s.Severity
+	}
+	if opts.changed("platform") {
+		i.Platforms = s.Platforms
+	}
+	if opts.changed("host-group") {
+		i.HostGroups = s.HostGroups
+		i.AppliedGlobally = false
+	}
+	if opts.changed("global") {
+		i.AppliedGlobally = s.AppliedGlobally
+		if s.AppliedGlobally {
+			i.HostGroups = nil
+		}
+	}
+	if opts.changed("expiration") {
+		expiration, err := ioc.ParseExpiration(s.Expiration)
+		if err != nil {
+			return nil, err
+		}
+		i.Expiration = expiration
+	}
+	if opts.changed("description") {
+		i.Description = s.Description
+	}
+	if opts.changed("tag") {
+		i.Tags = s.Tags
+	}
+	if opts.changed("source") {
+		i.Source = s.Source
+	}
+
+	i.Normalize()
+	if err := i.Validate(); err != nil {
+		return nil, err
+	}
+
+	u := shared.UpdateRequest(in)
+	u.Action = i.Action
+	u.Severity = i.Severity
+	u.Platforms = i.Platforms
+	u.HostGroups = i.HostGroups
+	u.AppliedGlobally = i.AppliedGlobally
+	u.Expiration = strfmt.DateTime(i.Expiration)
+	u.Description = i.Description
+	u.Tags = i.Tags
+	u.Source = i.Source
+
+	return u, nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package hash
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/lookup"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Look up the verdicts and metadata of file hashes`
+	longDesc  = templates.LongDesc(`
+		Look up SHA256 hashes in quick scan, for the verdict of the machine
+		learning engines, and in MalQuery, for the label, family and file
+		details of known samples.
+
+		Hashes are read from standard input when none are given or when
+		"-" is given, one per line, so that the command can sit in shell
+		pipelines.
+
+		Results are cached on disk in $HOME/.falcon/cache/lookup for
+		--cache-ttl so that large batches are not looked up again. Use
+		--cache-ttl 0 to always look hashes up.`)
+	examples = templates.Examples(`
+		# Look up the verdict of a hash
+		falcon lookup hash <sha256>
+
+		# Look up the hashes of a list and only keep the malicious ones
+		falcon lookup hash - -o json < hashes.txt | jq -r '.[] | select(.verdict == "malware") | .sha256'
+
+		# Only look up sample metadata, caching results for a week
+		sha256sum samples/* | cut -d' ' -f1 | falcon lookup hash --source malquery --cache-ttl 168h
+	`)
+)
+
+type HashOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Hashes   []string
+	Sources  []string
+	CacheTTL time.Duration
+	CacheDir string
+	Format   string
+}
+
+// NewCmdHash represents the lookup hash command
+func NewCmdHash(f *factory.Factory) *cobra.Command {
+	opts := &HashOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "hash [<sha256>...]",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if err := utils.ValidateOneOf("source", lookup.Sources, opts.Sources...); err != nil {
+				return err
+			}
+
+			if opts.CacheTTL < 0 {
+				return fmt.Errorf("--cache-ttl must not be negative")
+			}
+
+			if len(args) == 0 {
+				if opts.IO.IsStdinTTY() {
+					return fmt.Errorf("at least one hash is required, or hashes on standard input")
+				}
+				args = []string{"-"}
+			}
+
+			hashes, err := utils.ReadIDs(args, opts.IO.In)
+			if err != nil {
+				return err
+			}
+
+			if opts.Hashes, err = lookup.NormalizeHashes(hashes); err != nil {
+				return err
+			}
+
+			return hashRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Sources, "source", lookup.Sources, fmt.Sprintf("Sources to look hashes up in (%s)", strings.Join(lookup.Sources, ", ")))
+	cmd.Flags().DurationVar(&opts.CacheTTL, "cache-ttl", 24*time.Hour, "How long to use cached results for, 0 disables the cache")
+	cmd.Flags().StringVar(&opts.CacheDir, "cache-dir", "", "Directory to cache results in (default $HOME/.falcon/cache/lookup)")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func hashRun(ctx context.Context, opts *HashOptions) error {
+	var cache *lookup.Cache
+	if opts.CacheTTL > 0 {
+		dir := opts.CacheDir
+		if dir == "" {
+			var err error
+			if dir, err = lookup.DefaultCacheDir(); err != nil {
+				return err
+			}
+		}
+		cache = lookup.NewCache(dir, opts.CacheTTL)
+	}
+
+	results := map[string]*lookup.Result{}
+	missing := []string{}
+	for _, h := range opts.Hashes {
+		if cache != nil {
+			if r, ok := cache.Get(h, opts.Sources); ok {
+				results[h] = r
+				continue
+			}
+		}
+		missing = append(missing, h)
+	}
+
+	if len(missing) > 0 {
+		c, err := opts.FalconClient()
+		if err != nil {
+			return err
+		}
+
+		found, err := lookup.Lookup(ctx, c, missing, opts.Sources)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range found {
+			results[r.SHA256] = r
+			if cache == nil {
+				continue
+			}
+			if err := cache.Put(r, opts.Sources); err != nil {
+				fmt.Fprintf(opts.IO.ErrOut, "Failed to cache the result of %s: %v\n", r.SHA256, err)
+			}
+		}
+
+		if cache != nil {
+			if _, err := cache.Prune(); err != nil {
+				fmt.Fprintf(opts.IO.ErrOut, "Failed to prune the cache: %v\n", err)
+			}
+		}
+	}
+
+	list := []*lookup.Result{}
+	for _, h := range opts.Hashes {
+		list = append(list, results[h])
+	}
+
+	return output.Print(opts.IO.Out, opts.Format, list, func(t *output.Table) {
+		t.SetHeaders("SHA256", "VERDICT", "LABEL", "FAMILY", "FILE TYPE", "FIRST SEEN", "ERROR")
+		for _, r := range list {
+			t.AddRow(r.SHA256, r.Verdict, r.Label, r.Family, r.FileType, r.FirstSeen, r.Error)
+		}
+	})
+}
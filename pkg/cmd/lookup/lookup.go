@@ -0,0 +1,57 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package lookup
+
+import (
+	hashCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/lookup/hash"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Look up indicators in the Falcon threat intelligence`
+	longDesc  = templates.LongDesc(`
+		Look up the verdicts and metadata of indicators such as file hashes
+		in the malware intelligence of the Falcon platform.`)
+	examples = templates.Examples(`
+		# Look up the verdict of a hash
+		falcon lookup hash <sha256>
+
+		# Look up the hashes of a file, one per line
+		cat hashes.txt | falcon lookup hash
+	`)
+)
+
+// NewLookupCmd represents the lookup command
+func NewLookupCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "lookup <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		hashCmd.NewCmdHash(f),
+	)
+	return cmd
+}
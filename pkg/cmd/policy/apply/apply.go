@@ -0,0 +1,149 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ApplyOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *policy.Type
+
+	Files  []string
+	DryRun bool
+}
+
+// NewCmdApply represents the policy apply command of a policy type
+func NewCmdApply(f *factory.Factory, t *policy.Type) *cobra.Command {
+	opts := &ApplyOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "apply <file>...",
+		Short: fmt.Sprintf("Create or update %s from YAML files", t.Plural),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Create or update %[1]s from YAML specifications.
+
+			A policy is updated when a policy of the same name and platform
+			exists and created otherwise. Only the settings listed in the file
+			are changed. When the file sets enabled or host_groups, the policy
+			is enabled or disabled and assigned to exactly those host groups,
+			given by name.
+
+			Use --dry-run to show the changes without making them. Pass "-" to
+			read a specification from standard input.`, t.Plural)),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Copy a policy from one tenant to another
+			%[1]s get Workstations -o yaml > workstations.yaml
+			%[1]s apply workstations.yaml --cid <other cid>
+
+			# Show what applying a file would change
+			%[1]s apply workstations.yaml --dry-run
+		`, shared.Command(t))),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Files = args
+			return applyRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the changes without applying them")
+
+	return cmd
+}
+
+func applyRun(ctx context.Context, opts *ApplyOptions) error {
+	specs := []*policy.Spec{}
+	for _, file := range opts.Files {
+		spec, err := policy.ReadSpecFile(opts.Type, file, opts.IO.In)
+		if err != nil {
+			return err
+		}
+		specs = append(specs, spec)
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	for _, spec := range specs {
+		if opts.DryRun {
+			if err := plan(ctx, c, opts, spec); err != nil {
+				return err
+			}
+			continue
+		}
+
+		p, created, err := policy.Apply(ctx, c, opts.Type, spec)
+		if err != nil {
+			return fmt.Errorf("%s %q: %v", opts.Type.Title, spec.Name, err)
+		}
+
+		action := "Updated"
+		if created {
+			action = "Created"
+		}
+		fmt.Fprintf(opts.IO.Out, "%s %s %q (%s)\n", action, opts.Type.Title, p.Name, p.ID)
+	}
+
+	return nil
+}
+
+// plan prints the changes applying spec would make
+func plan(ctx context.Context, c *client.CrowdStrikeAPISpecification, opts *ApplyOptions, spec *policy.Spec) error {
+	current, err := policy.Find(ctx, opts.Type.New(c), opts.Type, spec.Name, spec.Platform)
+	if errors.Is(err, policy.ErrNotFound) {
+		fmt.Fprintf(opts.IO.Out, "%s %q (%s) would be created\n", opts.Type.Title, spec.Name, spec.Platform)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	changes, err := policy.Changes(opts.Type, current, spec)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(opts.IO.Out, "%s %q (%s) is up to date\n", opts.Type.Title, spec.Name, spec.Platform)
+		return nil
+	}
+
+	fmt.Fprintf(opts.IO.Out, "%s %q (%s) would be updated:\n", opts.Type.Title, spec.Name, spec.Platform)
+	return diff.Print(opts.IO.Out, changes)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package assign
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type AssignOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *policy.Type
+
+	Action   string
+	Policy   string
+	Groups   []string
+	Platform string
+}
+
+// NewCmdAssign represents the policy assign command of a policy type
+func NewCmdAssign(f *factory.Factory, t *policy.Type) *cobra.Command {
+	opts := &AssignOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+		Action:       policy.ActionAddGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "assign <policy> <host group>...",
+		Short: fmt.Sprintf("Assign a %s to host groups", t.Title),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Assign a %s to one or more host groups, given by name or ID.`, t.Title)),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Apply the Workstations policy to the laptops and desktops
+			%[1]s assign Workstations Laptops Desktops --platform windows
+		`, shared.Command(t))),
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Policy, opts.Groups = args[0], args[1:]
+			return assignRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddPlatformFlag(cmd, t, &opts.Platform)
+
+	return cmd
+}
+
+// NewCmdUnassign represents the policy unassign command of a policy type
+func NewCmdUnassign(f *factory.Factory, t *policy.Type) *cobra.Command {
+	opts := &AssignOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+		Action:       policy.ActionRemoveGroup,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "unassign <policy> <host group>...",
+		Short: fmt.Sprintf("Remove host groups from a %s", t.Title),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Remove one or more host groups, given by name or ID, from a %s.`, t.Title)),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Stop applying the Workstations policy to the kiosks
+			%[1]s unassign Workstations Kiosks --platform windows
+		`, shared.Command(t))),
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Policy, opts.Groups = args[0], args[1:]
+			return assignRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddPlatformFlag(cmd, t, &opts.Platform)
+
+	return cmd
+}
+
+func assignRun(ctx context.Context, opts *AssignOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	svc := opts.Type.New(c)
+	p, err := policy.Find(ctx, svc, opts.Type, opts.Policy, opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	groups, err := hosts.ResolveGroupIDs(ctx, c, opts.Groups)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range opts.Groups {
+		if err := svc.Action(ctx, opts.Action, []string{p.ID}, groups[name]); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	if opts.Action == policy.ActionRemoveGroup {
+		fmt.Fprintf(opts.IO.ErrOut, "Removed %d host groups from %q\n", len(opts.Groups), p.Name)
+	} else {
+		fmt.Fprintf(opts.IO.ErrOut, "Assigned %q to %d host groups\n", p.Name, len(opts.Groups))
+	}
+	return nil
+}
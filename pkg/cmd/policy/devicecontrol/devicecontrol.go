@@ -0,0 +1,89 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package devicecontrol
+
+import (
+	applyCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/apply"
+	assignCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/assign"
+	exceptionCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/devicecontrol/exception"
+	diffCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/diff"
+	enableCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/enable"
+	getCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/get"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/list"
+	precedenceCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/precedence"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage device control policies and USB exceptions`
+	longDesc  = templates.LongDesc(`
+		Manage device control policies, which allow, block or restrict to
+		read only the USB devices of each class on the hosts they are
+		assigned to.
+
+		Policies are exported to YAML specifications with "get -o yaml" and
+		applied back with "apply". The classes setting maps USB classes such
+		as MASS_STORAGE to their action and exceptions.
+
+		Exceptions allow the devices of a vendor, optionally narrowed to a
+		product and a serial number. Vendor and product IDs are hexadecimal,
+		as shown by the operating system, e.g. 0781 for SanDisk.`)
+	examples = templates.Examples(`
+		# Export a policy
+		falcon policy device-control get Workstations --platform windows -o yaml > workstations.yaml
+
+		# Allow a USB drive by serial number
+		falcon policy device-control exception add Workstations --platform windows \
+		  --vendor-id 0781 --product-id 5581 --serial 4C530001 --description "INC-1234 J. Doe"
+
+		# Add the exceptions of a CSV file
+		falcon policy device-control exception import Workstations exceptions.csv --platform windows
+	`)
+)
+
+// NewCmdDeviceControl represents the policy device-control command
+func NewCmdDeviceControl(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "device-control <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"devicecontrol"},
+	}
+
+	t := policy.DeviceControl
+	cmd.AddCommand(
+		listCmd.NewCmdList(f, t),
+		getCmd.NewCmdGet(f, t),
+		applyCmd.NewCmdApply(f, t),
+		diffCmd.NewCmdDiff(f, t),
+		precedenceCmd.NewCmdPrecedence(f, t),
+		enableCmd.NewCmdEnable(f, t),
+		enableCmd.NewCmdDisable(f, t),
+		assignCmd.NewCmdAssign(f, t),
+		assignCmd.NewCmdUnassign(f, t),
+		exceptionCmd.NewCmdException(f),
+	)
+	return cmd
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package add
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type AddOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Policy    string
+	Platform  string
+	Exception policy.USBException
+}
+
+var (
+	shortDesc = `Add a USB exception to a device control policy`
+	longDesc  = templates.LongDesc(`
+		Allow the USB devices of a vendor, optionally narrowed to a product
+		and a serial number, on the hosts of a device control policy given
+		by name or ID. IDs are hexadecimal, with or without a 0x prefix.
+
+		An exception for the same devices is updated instead of duplicated.`)
+	examples = templates.Examples(`
+		# Allow one USB drive
+		falcon policy device-control exception add Workstations --platform windows \
+		  --vendor-id 0781 --product-id 5581 --serial 4C530001 --description "INC-1234 J. Doe"
+
+		# Allow every device of a vendor to be read
+		falcon policy device-control exception add Workstations --platform windows \
+		  --vendor-id 0x05ac --action read_only
+	`)
+)
+
+// NewCmdAdd represents the policy device-control exception add command
+func NewCmdAdd(f *factory.Factory) *cobra.Command {
+	opts := &AddOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "add <policy>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Exception.Normalize(); err != nil {
+				return err
+			}
+
+			opts.Policy = args[0]
+			return addRun(cmd.Context(), opts)
+		},
+	}
+
+	e := &opts.Exception
+	cmd.Flags().StringVar(&e.VendorID, "vendor-id", "", "Hexadecimal vendor ID of the devices")
+	cmd.Flags().StringVar(&e.ProductID, "product-id", "", "Hexadecimal product ID of the devices")
+	cmd.Flags().StringVar(&e.SerialNumber, "serial", "", "Serial number of the device, requires --product-id")
+	cmd.Flags().StringVar(&e.Class, "class", "MASS_STORAGE", fmt.Sprintf("USB class of the devices: %s", strings.Join(policy.USBClasses, ", ")))
+	cmd.Flags().StringVar(&e.Action, "action", "FULL_ACCESS", "Access granted to the devices: FULL_ACCESS or READ_ONLY")
+	cmd.Flags().StringVar(&e.Description, "description", "", "Description of the exception, such as the ticket and owner")
+	shared.AddPlatformFlag(cmd, policy.DeviceControl, &opts.Platform)
+	_ = cmd.MarkFlagRequired("vendor-id")
+
+	return cmd
+}
+
+func addRun(ctx context.Context, opts *AddOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	t := policy.DeviceControl
+	p, err := policy.Find(ctx, t.New(c), t, opts.Policy, opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	if err := policy.AddUSBExceptions(ctx, c, p.ID, []*policy.USBException{&opts.Exception}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Added exception %s to %q\n", &opts.Exception, p.Name)
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package exception
+
+import (
+	addCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/devicecontrol/exception/add"
+	importCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/devicecontrol/exception/importcmd"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/devicecontrol/exception/list"
+	removeCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/devicecontrol/exception/remove"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdException represents the policy device-control exception command
+func NewCmdException(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "exception <command>",
+		Short:   "Manage the USB exceptions of device control policies",
+		Aliases: []string{"exceptions"},
+	}
+
+	cmd.AddCommand(
+		listCmd.NewCmdList(f),
+		addCmd.NewCmdAdd(f),
+		removeCmd.NewCmdRemove(f),
+		importCmd.NewCmdImport(f),
+	)
+	return cmd
+}
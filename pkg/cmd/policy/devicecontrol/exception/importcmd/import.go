@@ -0,0 +1,169 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package importcmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ImportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Policy   string
+	File     string
+	Platform string
+	Class    string
+	DryRun   bool
+}
+
+var (
+	shortDesc = `Add the USB exceptions of a CSV file to a device control policy`
+	longDesc  = templates.LongDesc(`
+		Add USB exceptions to a device control policy, given by name or ID,
+		from a CSV file with a header row. The columns are:
+
+		    class,vendor_id,product_id,serial_number,action,description
+
+		Only vendor_id is required. Rows without a class use --class and rows
+		without an action allow full access. Every row is checked before the
+		policy is changed, and exceptions for devices that already have one
+		are updated instead of duplicated. Pass "-" to read the file from
+		standard input.`)
+	examples = templates.Examples(`
+		# Check a file of exceptions, then add them
+		falcon policy device-control exception import Workstations exceptions.csv --platform windows --dry-run
+		falcon policy device-control exception import Workstations exceptions.csv --platform windows
+	`)
+)
+
+// NewCmdImport represents the policy device-control exception import command
+func NewCmdImport(f *factory.Factory) *cobra.Command {
+	opts := &ImportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "import <policy> <file>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Policy, opts.File = args[0], args[1]
+			return importRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Class, "class", "MASS_STORAGE", fmt.Sprintf("USB class of rows without one: %s", strings.Join(policy.USBClasses, ", ")))
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show the exceptions that would be added without adding them")
+	shared.AddPlatformFlag(cmd, policy.DeviceControl, &opts.Platform)
+
+	return cmd
+}
+
+func importRun(ctx context.Context, opts *ImportOptions) error {
+	list, err := readFile(opts.File, opts.IO.In, opts.Class)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return fmt.Errorf("%s holds no exceptions", opts.File)
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	t := policy.DeviceControl
+	p, err := policy.Find(ctx, t.New(c), t, opts.Policy, opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	current, err := policy.USBExceptions(ctx, c, p.ID)
+	if err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for _, e := range current {
+		existing[e.Key()] = true
+	}
+
+	added, updated := 0, 0
+	for _, e := range list {
+		verb := "Add"
+		if existing[e.Key()] {
+			verb = "Update"
+			updated++
+		} else {
+			added++
+		}
+		if opts.DryRun {
+			fmt.Fprintf(opts.IO.Out, "%s exception %s\n", verb, e)
+		}
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(opts.IO.ErrOut, "%d exceptions would be added to %q and %d updated\n", added, p.Name, updated)
+		return nil
+	}
+
+	if err := policy.AddUSBExceptions(ctx, c, p.ID, list); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "Added %d exceptions to %q and updated %d\n", added, p.Name, updated)
+	return nil
+}
+
+func readFile(path string, in io.Reader, class string) ([]*policy.USBException, error) {
+	if path == "-" {
+		return policy.ReadExceptionsCSV(in, class)
+	}
+
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	list, err := policy.ReadExceptionsCSV(f, class)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return list, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package list
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Policy   string
+	Platform string
+	Format   string
+}
+
+var (
+	shortDesc = `List the USB exceptions of a device control policy`
+	longDesc  = templates.LongDesc(`
+		List the USB exceptions of a device control policy, given by name or
+		ID, with the IDs used by "falcon policy device-control exception
+		remove".`)
+	examples = templates.Examples(`
+		falcon policy device-control exception list Workstations --platform windows
+	`)
+)
+
+// NewCmdList represents the policy device-control exception list command
+func NewCmdList(f *factory.Factory) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list <policy>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"ls"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			opts.Policy = args[0]
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddPlatformFlag(cmd, policy.DeviceControl, &opts.Platform)
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	t := policy.DeviceControl
+	p, err := policy.Find(ctx, t.New(c), t, opts.Policy, opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	list, err := policy.USBExceptions(ctx, c, p.ID)
+	if err != nil {
+		return err
+	}
+
+	return output.Print(opts.IO.Out, opts.Format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "CLASS", "VENDOR ID", "PRODUCT ID", "SERIAL NUMBER", "ACTION", "DESCRIPTION")
+		for _, e := range list {
+			t.AddRow(e.ID, e.Class, e.VendorID, e.ProductID, e.SerialNumber, e.Action, e.Description)
+		}
+	})
+}
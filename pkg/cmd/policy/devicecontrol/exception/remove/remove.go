@@ -0,0 +1,185 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package remove
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type RemoveOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Policy    string
+	IDs       []string
+	Platform  string
+	VendorID  string
+	ProductID string
+	Serial    string
+	Class     string
+}
+
+var (
+	shortDesc = `Remove USB exceptions from a device control policy`
+	longDesc  = templates.LongDesc(`
+		Remove USB exceptions from a device control policy given by name or
+		ID. Exceptions are given by ID, as listed by "falcon policy
+		device-control exception list", or selected with --vendor-id and
+		optionally --product-id, --serial and --class.`)
+	examples = templates.Examples(`
+		# Remove an exception by ID
+		falcon policy device-control exception remove Workstations 4f1c3a9e2b7d --platform windows
+
+		# Remove the exceptions of a USB drive
+		falcon policy device-control exception remove Workstations --platform windows \
+		  --vendor-id 0781 --product-id 5581 --serial 4C530001
+	`)
+)
+
+// NewCmdRemove represents the policy device-control exception remove command
+func NewCmdRemove(f *factory.Factory) *cobra.Command {
+	opts := &RemoveOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "remove <policy> [<exception id>...]",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"rm"},
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Policy, opts.IDs = args[0], args[1:]
+			if len(opts.IDs) > 0 && opts.VendorID != "" {
+				return fmt.Errorf("exception IDs and --vendor-id cannot be used together")
+			}
+			if len(opts.IDs) == 0 && opts.VendorID == "" {
+				return fmt.Errorf("give exception IDs or --vendor-id")
+			}
+
+			return removeRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.VendorID, "vendor-id", "", "Remove the exceptions of the devices of a vendor")
+	cmd.Flags().StringVar(&opts.ProductID, "product-id", "", "Only remove the exceptions of a product")
+	cmd.Flags().StringVar(&opts.Serial, "serial", "", "Only remove the exceptions of a serial number")
+	cmd.Flags().StringVar(&opts.Class, "class", "", "Only remove the exceptions of a USB class")
+	shared.AddPlatformFlag(cmd, policy.DeviceControl, &opts.Platform)
+
+	return cmd
+}
+
+func removeRun(ctx context.Context, opts *RemoveOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	t := policy.DeviceControl
+	p, err := policy.Find(ctx, t.New(c), t, opts.Policy, opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	current, err := policy.USBExceptions(ctx, c, p.ID)
+	if err != nil {
+		return err
+	}
+
+	selected, err := selectExceptions(opts, current)
+	if err != nil {
+		return err
+	}
+
+	ids := []string{}
+	for _, e := range selected {
+		ids = append(ids, e.ID)
+	}
+	if err := policy.RemoveUSBExceptions(ctx, c, p.ID, ids); err != nil {
+		return err
+	}
+
+	for _, e := range selected {
+		fmt.Fprintf(opts.IO.Out, "Removed exception %s (%s) from %q\n", e, e.ID, p.Name)
+	}
+	return nil
+}
+
+// selectExceptions returns the exceptions given by ID or matching the
+// device flags
+func selectExceptions(opts *RemoveOptions, current []*policy.USBException) ([]*policy.USBException, error) {
+	selected := []*policy.USBException{}
+
+	if len(opts.IDs) > 0 {
+		byID := map[string]*policy.USBException{}
+		for _, e := range current {
+			byID[e.ID] = e
+		}
+		for _, id := range opts.IDs {
+			e, ok := byID[id]
+			if !ok {
+				return nil, fmt.Errorf("exception %s %w", id, policy.ErrNotFound)
+			}
+			selected = append(selected, e)
+		}
+		return selected, nil
+	}
+
+	vendor, err := policy.NormalizeUSBID(opts.VendorID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --vendor-id: %v", err)
+	}
+	product := ""
+	if opts.ProductID != "" {
+		if product, err = policy.NormalizeUSBID(opts.ProductID); err != nil {
+			return nil, fmt.Errorf("invalid --product-id: %v", err)
+		}
+	}
+
+	for _, e := range current {
+		switch {
+		case !strings.EqualFold(e.VendorID, vendor):
+		case product != "" && !strings.EqualFold(e.ProductID, product):
+		case opts.Serial != "" && e.SerialNumber != opts.Serial:
+		case opts.Class != "" && !strings.EqualFold(e.Class, opts.Class):
+		default:
+			selected = append(selected, e)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no exception matches vendor ID %s", vendor)
+	}
+	return selected, nil
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package diff
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type DiffOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *policy.Type
+
+	Left     string
+	Right    string
+	Platform string
+	Format   string
+}
+
+// NewCmdDiff represents the policy diff command of a policy type
+func NewCmdDiff(f *factory.Factory, t *policy.Type) *cobra.Command {
+	opts := &DiffOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff <policy|file> <policy|file>",
+		Short: fmt.Sprintf("Compare two %s", t.Plural),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Compare the settings of two %[1]s, or of a policy and a YAML
+			specification.
+
+			Each argument is the name or ID of a policy, or the path of a
+			YAML file as written by "%[2]s get -o yaml". Arguments ending
+			in .yaml or .yml, or naming an existing file, are read as files.
+
+			Changes are listed one per line as "+ path: value" for settings
+			only on the right, "- path: value" for settings only on the left
+			and "~ path: left -> right" for settings that differ.`, t.Plural, shared.Command(t))),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Compare two policies
+			%[1]s diff Workstations Servers --platform windows
+
+			# Check a policy for drift from the file it was applied from
+			%[1]s diff workstations.yaml Workstations
+		`, shared.Command(t))),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Format != "text" && opts.Format != output.FormatJSON {
+				return fmt.Errorf("unsupported output format %q, must be one of: text|json", opts.Format)
+			}
+
+			opts.Left, opts.Right = args[0], args[1]
+			return diffRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddPlatformFlag(cmd, t, &opts.Platform)
+	cmd.Flags().StringVarP(&opts.Format, "output", "o", "text", "Output format. One of: text|json")
+
+	return cmd
+}
+
+func diffRun(ctx context.Context, opts *DiffOptions) error {
+	var c *client.CrowdStrikeAPISpecification
+	load := func(arg string) (*policy.Spec, error) {
+		if isFile(arg) {
+			return policy.ReadSpecFile(opts.Type, arg, opts.IO.In)
+		}
+
+		if c == nil {
+			var err error
+			if c, err = opts.FalconClient(); err != nil {
+				return nil, err
+			}
+		}
+
+		p, err := policy.Find(ctx, opts.Type.New(c), opts.Type, arg, opts.Platform)
+		if err != nil {
+			return nil, err
+		}
+		return p.Spec(opts.Type), nil
+	}
+
+	left, err := load(opts.Left)
+	if err != nil {
+		return err
+	}
+	right, err := load(opts.Right)
+	if err != nil {
+		return err
+	}
+
+	changes, err := policy.Compare(left, right)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == output.FormatJSON {
+		return output.PrintJSON(opts.IO.Out, changes)
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(opts.IO.ErrOut, "No differences")
+		return nil
+	}
+	return diff.Print(opts.IO.Out, changes)
+}
+
+// isFile reports whether an argument names a specification file rather
+// than a policy
+func isFile(arg string) bool {
+	if arg == "-" {
+		return true
+	}
+
+	switch strings.ToLower(filepath.Ext(arg)) {
+	case ".yaml", ".yml":
+		return true
+	}
+
+	info, err := os.Stat(arg)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package enable
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type EnableOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *policy.Type
+
+	Action   string
+	Policies []string
+	Platform string
+}
+
+// NewCmdEnable represents the policy enable command of a policy type
+func NewCmdEnable(f *factory.Factory, t *policy.Type) *cobra.Command {
+	return newCmd(f, t, policy.ActionEnable)
+}
+
+// NewCmdDisable represents the policy disable command of a policy type
+func NewCmdDisable(f *factory.Factory, t *policy.Type) *cobra.Command {
+	return newCmd(f, t, policy.ActionDisable)
+}
+
+func newCmd(f *factory.Factory, t *policy.Type, action string) *cobra.Command {
+	opts := &EnableOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+		Action:       action,
+	}
+
+	cmd := &cobra.Command{
+		Use:   action + " <policy>...",
+		Short: fmt.Sprintf("%s %s", capitalize(action), t.Plural),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			%s one or more %s, given by name or ID.`, capitalize(action), t.Plural)),
+		Example: templates.Examples(fmt.Sprintf(`
+			# %[3]s a policy
+			%[1]s %[2]s Workstations --platform windows
+		`, shared.Command(t), action, capitalize(action))),
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Policies = args
+			return enableRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddPlatformFlag(cmd, t, &opts.Platform)
+
+	return cmd
+}
+
+func enableRun(ctx context.Context, opts *EnableOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	svc := opts.Type.New(c)
+	ids := []string{}
+	for _, name := range opts.Policies {
+		p, err := policy.Find(ctx, svc, opts.Type, name, opts.Platform)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, p.ID)
+	}
+
+	if err := svc.Action(ctx, opts.Action, ids, ""); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "%sd %d %s\n", capitalize(opts.Action), len(ids), opts.Type.Plural)
+	return nil
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
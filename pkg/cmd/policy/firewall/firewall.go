@@ -0,0 +1,79 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package firewall
+
+import (
+	applyCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/apply"
+	assignCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/assign"
+	diffCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/diff"
+	enableCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/enable"
+	getCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/get"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/list"
+	precedenceCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/precedence"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage firewall policies`
+	longDesc  = templates.LongDesc(`
+		Manage firewall policies, which enforce firewall rule groups on the
+		hosts they are assigned to.
+
+		The rule_groups setting lists the rule groups of a policy by name, in
+		precedence order. default_inbound and default_outbound (ALLOW or
+		DENY) apply to traffic no rule matches, and test_mode only reports
+		the connections rules would block.`)
+	examples = templates.Examples(`
+		# Save a policy, edit its rule groups and apply it back
+		falcon policy firewall get Servers --platform windows -o yaml > servers.yaml
+		falcon policy firewall apply servers.yaml
+
+		# Assign a policy to a host group
+		falcon policy firewall assign Servers "Web servers" --platform windows
+	`)
+)
+
+// NewCmdFirewall represents the policy firewall command
+func NewCmdFirewall(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "firewall <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	t := policy.Firewall
+	cmd.AddCommand(
+		listCmd.NewCmdList(f, t),
+		getCmd.NewCmdGet(f, t),
+		applyCmd.NewCmdApply(f, t),
+		diffCmd.NewCmdDiff(f, t),
+		precedenceCmd.NewCmdPrecedence(f, t),
+		enableCmd.NewCmdEnable(f, t),
+		enableCmd.NewCmdDisable(f, t),
+		assignCmd.NewCmdAssign(f, t),
+		assignCmd.NewCmdUnassign(f, t),
+	)
+	return cmd
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package get
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type GetOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *policy.Type
+
+	Policy   string
+	Platform string
+	Format   string
+}
+
+// NewCmdGet represents the policy get command of a policy type
+func NewCmdGet(f *factory.Factory, t *policy.Type) *cobra.Command {
+	opts := &GetOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "get <policy>",
+		Short: fmt.Sprintf("Show the settings of a %s", t.Title),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Show every setting of a %[1]s, given by name or ID.
+
+			The YAML output is a specification that can be edited and passed
+			to "%[2]s apply", for instance to copy the policy to
+			another tenant.`, t.Title, shared.Command(t))),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Save the settings of a policy to a file
+			%[1]s get Workstations --platform windows -o yaml > workstations.yaml
+		`, shared.Command(t))),
+		Aliases: []string{"show"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			opts.Policy = args[0]
+			return getRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddPlatformFlag(cmd, t, &opts.Platform)
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func getRun(ctx context.Context, opts *GetOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	p, err := policy.Find(ctx, opts.Type.New(c), opts.Type, opts.Policy, opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	return shared.PrintSpec(opts.IO.Out, opts.Format, p.Spec(opts.Type))
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *policy.Type
+
+	Platform string
+	Filter   string
+	Format   string
+}
+
+// NewCmdList represents the policy list command of a policy type
+func NewCmdList(f *factory.Factory, t *policy.Type) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: fmt.Sprintf("List %s", t.Plural),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			List %s in precedence order, with the host groups they are
+			assigned to.`, t.Plural)),
+		Example: templates.Examples(fmt.Sprintf(`
+			# List the Windows %[2]s
+			%[1]s list --platform windows
+
+			# List the disabled policies as JSON
+			%[1]s list --filter "enabled:false" -o json
+		`, shared.Command(t), t.Plural)),
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if opts.Platform != "" {
+				platform, err := policy.NormalizePlatform(t, opts.Platform)
+				if err != nil {
+					return err
+				}
+				opts.Platform = platform
+			}
+
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddPlatformFlag(cmd, t, &opts.Platform)
+	cmd.Flags().StringVar(&opts.Filter, "filter", "", "Filter policies using a Falcon Query Language (FQL) expression")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	filter := fql.New().Raw(opts.Filter).Equal("platform_name", opts.Platform)
+
+	list, err := opts.Type.New(c).List(ctx, filter.String())
+	if err != nil {
+		return err
+	}
+
+	return shared.PrintPolicies(opts.IO.Out, opts.Format, list)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package policy
+
+import (
+	deviceControlCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/devicecontrol"
+	firewallCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/firewall"
+	preventionCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/prevention"
+	responseCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/response"
+	sensorUpdateCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/sensorupdate"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage Falcon policies`
+	longDesc  = templates.LongDesc(`
+		Manage the policies applied to the hosts of your tenant.`)
+)
+
+// NewPolicyCmd represents the policy command
+func NewPolicyCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy <command>",
+		Short: shortDesc,
+		Long:  longDesc,
+	}
+
+	cmd.AddCommand(
+		deviceControlCmd.NewCmdDeviceControl(f),
+		firewallCmd.NewCmdFirewall(f),
+		preventionCmd.NewCmdPrevention(f),
+		responseCmd.NewCmdResponse(f),
+		sensorUpdateCmd.NewCmdSensorUpdate(f),
+	)
+	return cmd
+}
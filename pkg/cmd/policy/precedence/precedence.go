@@ -0,0 +1,120 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package precedence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type PrecedenceOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+	Type         *policy.Type
+
+	Platform string
+	Policies []string
+}
+
+// NewCmdPrecedence represents the policy precedence command of a policy type
+func NewCmdPrecedence(f *factory.Factory, t *policy.Type) *cobra.Command {
+	opts := &PrecedenceOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Type:         t,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "precedence <platform> <policy>...",
+		Short: fmt.Sprintf("Set the precedence of %s", t.Plural),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			Set the order in which the %s of a platform are applied.
+
+			The given policies, by name or ID, are moved to the top in the
+			given order. The other policies keep their relative order below
+			them and the default policy always comes last.`, t.Plural)),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Give the Servers policy the highest precedence, followed by Workstations
+			%[1]s precedence windows Servers Workstations
+		`, shared.Command(t))),
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			platform, err := policy.NormalizePlatform(t, args[0])
+			if err != nil {
+				return err
+			}
+
+			opts.Platform = platform
+			opts.Policies = args[1:]
+			return precedenceRun(cmd.Context(), opts)
+		},
+	}
+
+	return cmd
+}
+
+func precedenceRun(ctx context.Context, opts *PrecedenceOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	svc := opts.Type.New(c)
+	current, err := svc.List(ctx, fql.New().Equal("platform_name", opts.Platform).String())
+	if err != nil {
+		return err
+	}
+
+	first := []string{}
+	for _, name := range opts.Policies {
+		p, err := policy.Find(ctx, svc, opts.Type, name, opts.Platform)
+		if err != nil {
+			return err
+		}
+		first = append(first, p.ID)
+	}
+
+	order, err := policy.Precedence(current, first)
+	if err != nil {
+		return err
+	}
+
+	if err := svc.SetPrecedence(ctx, opts.Platform, order); err != nil {
+		return err
+	}
+
+	names := map[string]string{}
+	for _, p := range current {
+		names[p.ID] = p.Name
+	}
+	for n, id := range order {
+		fmt.Fprintf(opts.IO.Out, "%d. %s\n", n+1, names[id])
+	}
+	return nil
+}
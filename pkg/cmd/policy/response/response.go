@@ -0,0 +1,85 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package response
+
+import (
+	applyCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/apply"
+	assignCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/assign"
+	diffCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/diff"
+	enableCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/enable"
+	getCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/get"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/list"
+	precedenceCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/precedence"
+	createCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/response/create"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage real time response policies`
+	longDesc  = templates.LongDesc(`
+		Manage real time response policies, which control whether responders
+		can connect to the hosts they are assigned to and which commands
+		they can run there.
+
+		Policies can be saved to YAML specifications with "get -o yaml",
+		compared with "diff" and created or updated with "apply". Settings
+		are booleans keyed by setting ID: RealTimeFunctionality enables real
+		time response, and CustomScripts, GetCommand, PutCommand and
+		ExecCommand allow the commands of the same name, which require it.`)
+	examples = templates.Examples(`
+		# Allow every command on the hosts of the incident response group only
+		falcon policy response create "IR admin" --platform windows --custom-scripts --get --put --exec --host-group IR --enable
+		falcon policy response precedence windows "IR admin"
+
+		# Check who can run commands where
+		falcon policy response list
+		falcon policy response get "IR admin" --platform windows -o yaml
+	`)
+)
+
+// NewCmdResponse represents the policy response command
+func NewCmdResponse(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "response <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"rtr"},
+	}
+
+	t := policy.Response
+	cmd.AddCommand(
+		listCmd.NewCmdList(f, t),
+		getCmd.NewCmdGet(f, t),
+		createCmd.NewCmdCreate(f),
+		applyCmd.NewCmdApply(f, t),
+		diffCmd.NewCmdDiff(f, t),
+		precedenceCmd.NewCmdPrecedence(f, t),
+		enableCmd.NewCmdEnable(f, t),
+		enableCmd.NewCmdDisable(f, t),
+		assignCmd.NewCmdAssign(f, t),
+		assignCmd.NewCmdUnassign(f, t),
+	)
+	return cmd
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package builds
+
+import (
+	"context"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List the sensor builds available to policies`
+	longDesc  = templates.LongDesc(`
+		List the sensor builds that sensor update policies can deploy, with
+		their release tag when they are tagged n, n-1 or n-2.`)
+	examples = templates.Examples(`
+		# List the Linux builds
+		falcon policy sensor-update builds --platform linux
+	`)
+)
+
+type BuildsOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Platform string
+	Format   string
+}
+
+// NewCmdBuilds represents the policy sensor-update builds command
+func NewCmdBuilds(f *factory.Factory) *cobra.Command {
+	opts := &BuildsOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "builds",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			return buildsRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Platform, "platform", "", "Only list the builds of a platform, e.g. windows or linuxarm64")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func buildsRun(ctx context.Context, opts *BuildsOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	builds, err := policy.ListBuilds(ctx, c, opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	return output.Print(opts.IO.Out, opts.Format, builds, func(t *output.Table) {
+		t.SetHeaders("PLATFORM", "VERSION", "BUILD", "NAME")
+		for _, b := range builds {
+			build := utils.Deref(b.Build)
+			t.AddRow(utils.Deref(b.Platform), utils.Deref(b.SensorVersion), build, policy.BuildName(build))
+		}
+	})
+}
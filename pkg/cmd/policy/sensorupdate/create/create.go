@@ -0,0 +1,136 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package create
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Create a sensor update policy`
+	longDesc  = templates.LongDesc(`
+		Create a sensor update policy.
+
+		The build is a release tag (n, n-1 or n-2), which the policy keeps
+		following as new sensors are released, a build number or sensor
+		version, which pins the policy to a build, or "off" to stop updating
+		sensors. Run "falcon policy sensor-update builds" to list the builds.`)
+	examples = templates.Examples(`
+		# Create a disabled policy for the first rollout ring
+		falcon policy sensor-update create "Ring 1" --platform windows --build n --uninstall-protection enabled
+
+		# Create and enable a policy for the second ring, assigned to a host group
+		falcon policy sensor-update create "Ring 2" --platform windows --build n-1 --host-group ring2 --enable
+	`)
+)
+
+type CreateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Name                string
+	Description         string
+	Platform            string
+	Build               string
+	UninstallProtection string
+	HostGroups          []string
+	Enable              bool
+}
+
+// NewCmdCreate represents the policy sensor-update create command
+func NewCmdCreate(f *factory.Factory) *cobra.Command {
+	opts := &CreateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "create <name>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Name = args[0]
+			return createRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Platform, "platform", "", fmt.Sprintf("Platform of the policy: %s", strings.Join(policy.Platforms, ", ")))
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of the policy")
+	cmd.Flags().StringVar(&opts.Build, "build", "n-1", "Sensor build: a release tag (n, n-1, n-2), build number, sensor version or off")
+	cmd.Flags().StringVar(&opts.UninstallProtection, "uninstall-protection", "enabled", fmt.Sprintf("Uninstall protection: %s", strings.ToLower(strings.Join(policy.UninstallProtections, ", "))))
+	cmd.Flags().StringSliceVar(&opts.HostGroups, "host-group", nil, "Name or ID of a host group to assign the policy to (repeatable)")
+	cmd.Flags().BoolVar(&opts.Enable, "enable", false, "Enable the policy once created")
+	_ = cmd.MarkFlagRequired("platform")
+
+	return cmd
+}
+
+func createRun(ctx context.Context, opts *CreateOptions) error {
+	t := policy.SensorUpdate
+	enabled := opts.Enable
+	spec := &policy.Spec{
+		Kind:        t.Kind,
+		Name:        opts.Name,
+		Description: opts.Description,
+		Platform:    opts.Platform,
+		Enabled:     &enabled,
+		HostGroups:  opts.HostGroups,
+		Settings: map[string]interface{}{
+			policy.SettingBuild:               opts.Build,
+			policy.SettingUninstallProtection: opts.UninstallProtection,
+		},
+	}
+	if err := spec.Validate(t); err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = policy.Find(ctx, t.New(c), t, spec.Name, spec.Platform)
+	if err == nil {
+		return fmt.Errorf("a %s named %q already exists for %s", t.Title, spec.Name, spec.Platform)
+	}
+	if !errors.Is(err, policy.ErrNotFound) {
+		return err
+	}
+
+	p, _, err := policy.Apply(ctx, c, t, spec)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Created %s %q (%s)\n", t.Title, p.Name, p.ID)
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package sensorupdate
+
+import (
+	applyCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/apply"
+	assignCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/assign"
+	diffCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/diff"
+	enableCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/enable"
+	getCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/get"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/list"
+	precedenceCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/precedence"
+	buildsCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/sensorupdate/builds"
+	createCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/sensorupdate/create"
+	tokenCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/sensorupdate/token"
+	updateCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/policy/sensorupdate/update"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage sensor update policies`
+	longDesc  = templates.LongDesc(`
+		Manage sensor update policies, which control the sensor build and
+		uninstall protection of the hosts they are assigned to.
+
+		Rollouts are usually staged across rings of host groups, each
+		assigned to a policy following a different release tag, e.g. n for
+		the first ring and n-1 for the rest of the fleet.`)
+	examples = templates.Examples(`
+		# Move the first rollout ring to the latest release
+		falcon policy sensor-update update "Ring 1" --platform windows --build n
+
+		# Reveal the maintenance token of a host
+		falcon policy sensor-update token WIN-DC01
+	`)
+)
+
+// NewCmdSensorUpdate represents the policy sensor-update command
+func NewCmdSensorUpdate(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sensor-update <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	t := policy.SensorUpdate
+	cmd.AddCommand(
+		listCmd.NewCmdList(f, t),
+		getCmd.NewCmdGet(f, t),
+		createCmd.NewCmdCreate(f),
+		updateCmd.NewCmdUpdate(f),
+		applyCmd.NewCmdApply(f, t),
+		diffCmd.NewCmdDiff(f, t),
+		precedenceCmd.NewCmdPrecedence(f, t),
+		enableCmd.NewCmdEnable(f, t),
+		enableCmd.NewCmdDisable(f, t),
+		assignCmd.NewCmdAssign(f, t),
+		assignCmd.NewCmdUnassign(f, t),
+		buildsCmd.NewCmdBuilds(f),
+		tokenCmd.NewCmdToken(f),
+	)
+	return cmd
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Reveal the maintenance token of a host`
+	longDesc  = templates.LongDesc(`
+		Reveal the maintenance token needed to uninstall or downgrade the
+		sensor of a host protected by uninstall protection.
+
+		With --bulk the bulk maintenance token is revealed instead, which is
+		valid for every host of the tenant. Revealing a token is recorded in
+		the audit log together with the --audit-message.`)
+	examples = templates.Examples(`
+		# Reveal the maintenance token of a host
+		falcon policy sensor-update token WIN-DC01 --audit-message "CHG-1234 sensor reinstall"
+
+		# Reveal the bulk maintenance token
+		falcon policy sensor-update token --bulk --audit-message "CHG-1234 fleet migration"
+	`)
+)
+
+type TokenOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Host         string
+	Bulk         bool
+	AuditMessage string
+}
+
+// NewCmdToken represents the policy sensor-update token command
+func NewCmdToken(f *factory.Factory) *cobra.Command {
+	opts := &TokenOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "token [<host>]",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 1) == opts.Bulk {
+				return fmt.Errorf("specify either a host or --bulk")
+			}
+
+			if len(args) == 1 {
+				opts.Host = args[0]
+			}
+			return tokenRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Bulk, "bulk", false, "Reveal the bulk maintenance token")
+	cmd.Flags().StringVar(&opts.AuditMessage, "audit-message", "", "Reason recorded in the audit log")
+
+	return cmd
+}
+
+func tokenRun(ctx context.Context, opts *TokenOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	deviceID := policy.BulkMaintenanceDeviceID
+	if !opts.Bulk {
+		ids, err := hosts.ResolveDeviceIDs(ctx, c, []string{opts.Host})
+		if err != nil {
+			return err
+		}
+		deviceID = ids[opts.Host]
+	}
+
+	token, err := policy.RevealUninstallToken(ctx, c, deviceID, opts.AuditMessage)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(opts.IO.Out, token)
+	return nil
+}
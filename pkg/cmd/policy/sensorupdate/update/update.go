@@ -0,0 +1,133 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Update a sensor update policy`
+	longDesc  = templates.LongDesc(`
+		Change the build, uninstall protection, name or description of a
+		sensor update policy. Settings that are not given are kept.`)
+	examples = templates.Examples(`
+		# Move the second rollout ring to the latest release
+		falcon policy sensor-update update "Ring 2" --platform windows --build n
+
+		# Pin a policy to a sensor version
+		falcon policy sensor-update update Servers --build 7.10
+
+		# Put hosts in maintenance mode so the sensor can be removed
+		falcon policy sensor-update update Decommission --uninstall-protection maintenance_mode
+	`)
+)
+
+type UpdateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Policy              string
+	Platform            string
+	Name                string
+	Description         string
+	Build               string
+	UninstallProtection string
+}
+
+// NewCmdUpdate represents the policy sensor-update update command
+func NewCmdUpdate(f *factory.Factory) *cobra.Command {
+	opts := &UpdateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "update <policy>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Name == "" && opts.Description == "" && opts.Build == "" && opts.UninstallProtection == "" {
+				return fmt.Errorf("nothing to update, set at least one of --name, --description, --build or --uninstall-protection")
+			}
+
+			opts.Policy = args[0]
+			return updateRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddPlatformFlag(cmd, policy.SensorUpdate, &opts.Platform)
+	cmd.Flags().StringVar(&opts.Name, "name", "", "New name of the policy")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "New description of the policy")
+	cmd.Flags().StringVar(&opts.Build, "build", "", "Sensor build: a release tag (n, n-1, n-2), build number, sensor version or off")
+	cmd.Flags().StringVar(&opts.UninstallProtection, "uninstall-protection", "", fmt.Sprintf("Uninstall protection: %s", strings.ToLower(strings.Join(policy.UninstallProtections, ", "))))
+
+	return cmd
+}
+
+func updateRun(ctx context.Context, opts *UpdateOptions) error {
+	t := policy.SensorUpdate
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	svc := t.New(c)
+	p, err := policy.Find(ctx, svc, t, opts.Policy, opts.Platform)
+	if err != nil {
+		return err
+	}
+
+	spec := &policy.Spec{
+		Kind:        t.Kind,
+		Name:        opts.Name,
+		Description: opts.Description,
+		Platform:    p.Platform,
+		Settings:    map[string]interface{}{},
+	}
+	if opts.Build != "" {
+		spec.Settings[policy.SettingBuild] = opts.Build
+	}
+	if opts.UninstallProtection != "" {
+		spec.Settings[policy.SettingUninstallProtection] = opts.UninstallProtection
+	}
+
+	updated, err := svc.Update(ctx, p.ID, spec)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Updated %s %q: build %v, uninstall protection %v\n", t.Title, updated.Name,
+		updated.Settings[policy.SettingBuild], updated.Settings[policy.SettingUninstallProtection])
+	return nil
+}
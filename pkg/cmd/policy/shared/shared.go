@@ -0,0 +1,89 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package shared
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/policy"
+	"github.com/spf13/cobra"
+)
+
+// AddPlatformFlag registers the --platform flag used to pick between
+// policies of the same name
+func AddPlatformFlag(cmd *cobra.Command, t *policy.Type, platform *string) {
+	cmd.Flags().StringVar(platform, "platform", "", fmt.Sprintf("Platform of the policy: %s", strings.Join(t.Platforms, ", ")))
+}
+
+// Command returns the command line of a policy type for use in help texts
+func Command(t *policy.Type) string {
+	return "falcon policy " + t.Name
+}
+
+// PrintPolicies writes a list of policies in the requested format
+func PrintPolicies(w io.Writer, format string, list []*policy.Policy) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "NAME", "PLATFORM", "ENABLED", "HOST GROUPS", "MODIFIED")
+		for _, p := range list {
+			names := []string{}
+			for _, g := range p.Groups {
+				names = append(names, g.Name)
+			}
+			t.AddRow(
+				p.ID,
+				p.Name,
+				p.Platform,
+				strconv.FormatBool(p.Enabled),
+				strings.Join(names, ","),
+				output.Time(p.ModifiedTimestamp),
+			)
+		}
+	})
+}
+
+// PrintSpec writes a policy specification in the requested format. The
+// table format lists one field or setting per row.
+func PrintSpec(w io.Writer, format string, spec *policy.Spec) error {
+	return output.Print(w, format, spec, func(t *output.Table) {
+		t.SetHeaders("FIELD", "VALUE")
+		t.AddRow("name", spec.Name)
+		t.AddRow("description", spec.Description)
+		t.AddRow("platform", spec.Platform)
+		if spec.Enabled != nil {
+			t.AddRow("enabled", strconv.FormatBool(*spec.Enabled))
+		}
+		t.AddRow("host_groups", strings.Join(spec.HostGroups, ","))
+
+		ids := []string{}
+		for id := range spec.Settings {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			t.AddRow("settings."+id, diff.Format(spec.Settings[id]))
+		}
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package action
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/quarantine/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/quarantine"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+type ActionOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Action  string
+	IDs     []string
+	Query   shared.QueryFlags
+	Comment string
+	DryRun  bool
+}
+
+// action describes a command performing an action on quarantined files
+type action struct {
+	name string
+	// done is the past participle of the action, e.g. released
+	done string
+	// long is the first paragraph of the long description
+	long string
+}
+
+var (
+	release = &action{
+		name: quarantine.ActionRelease,
+		done: "released",
+		long: "Release quarantined files, restoring them so that they can run again.",
+	}
+	unrelease = &action{
+		name: quarantine.ActionUnrelease,
+		done: "unreleased",
+		long: "Quarantine released files again.",
+	}
+	remove = &action{
+		name: quarantine.ActionDelete,
+		done: "deleted",
+		long: "Delete quarantined files from their hosts. Deleted files cannot be released.",
+	}
+)
+
+// NewCmdRelease represents the quarantine release command
+func NewCmdRelease(f *factory.Factory) *cobra.Command {
+	return newCmd(f, release)
+}
+
+// NewCmdUnrelease represents the quarantine unrelease command
+func NewCmdUnrelease(f *factory.Factory) *cobra.Command {
+	return newCmd(f, unrelease)
+}
+
+// NewCmdDelete represents the quarantine delete command
+func NewCmdDelete(f *factory.Factory) *cobra.Command {
+	return newCmd(f, remove)
+}
+
+func newCmd(f *factory.Factory, a *action) *cobra.Command {
+	opts := &ActionOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+		Action:       a.name,
+	}
+
+	cmd := &cobra.Command{
+		Use:   a.name + " [<id>...]",
+		Short: fmt.Sprintf("%s quarantined files", capitalize(a.name)),
+		Long: templates.LongDesc(fmt.Sprintf(`
+			%s
+
+			Files are given by ID, as listed by "falcon quarantine list", or
+			selected with the same filters as the list command to act on
+			every matching file of every host at once. Pass "-" to read IDs
+			from standard input, one per line. Use --dry-run to list the
+			files first.`, a.long)),
+		Example: templates.Examples(fmt.Sprintf(`
+			# Show the files that would be %[2]s
+			falcon quarantine %[1]s --sha256 <sha256> --state quarantined --dry-run
+
+			# %[3]s them
+			falcon quarantine %[1]s --sha256 <sha256> --state quarantined --comment "INC-1234 false positive"
+		`, a.name, a.done, capitalize(a.name))),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				ids, err := utils.ReadIDs(args, opts.IO.In)
+				if err != nil {
+					return err
+				}
+				opts.IDs = ids
+			}
+
+			return actionRun(cmd.Context(), opts, a)
+		},
+	}
+
+	shared.AddQueryFlags(cmd, &opts.Query)
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Comment recorded with the action")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, fmt.Sprintf("List the files that would be %s without changing them", a.done))
+
+	return cmd
+}
+
+func actionRun(ctx context.Context, opts *ActionOptions, a *action) error {
+	q, err := opts.Query.Query()
+	if err != nil {
+		return err
+	}
+
+	filter := q.FQL()
+	switch {
+	case len(opts.IDs) > 0 && filter != "":
+		return fmt.Errorf("file IDs cannot be combined with filters")
+	case len(opts.IDs) == 0 && filter == "":
+		return fmt.Errorf("give file IDs or at least one filter")
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	ids := opts.IDs
+	if filter != "" {
+		if ids, err = quarantine.QueryIDs(ctx, c, filter, "", 0); err != nil {
+			return err
+		}
+	}
+
+	if len(ids) == 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "No quarantined files match\n")
+		return nil
+	}
+
+	if opts.DryRun {
+		list, err := quarantine.Get(ctx, c, ids)
+		if err != nil {
+			return err
+		}
+		if err := shared.PrintFiles(opts.IO.Out, output.FormatTable, list); err != nil {
+			return err
+		}
+		fmt.Fprintf(opts.IO.ErrOut, "%d file(s) would be %s\n", len(ids), a.done)
+		return nil
+	}
+
+	// files matching a filter are changed by the IDs of the query so that
+	// exactly the files listed by --dry-run are changed
+	if err := quarantine.Update(ctx, c, a.name, opts.Comment, ids); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "%s %d file(s)\n", capitalize(a.done), len(ids))
+	return nil
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return string(s[0]-'a'+'A') + s[1:]
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package action
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/quarantine/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestActionRunFilter checks that files selected by a filter are changed by
+// the IDs of the query, the files that --dry-run lists
+func TestActionRunFilter(t *testing.T) {
+	var updated []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/quarantine/queries/quarantined-files/v1":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"meta":      map[string]interface{}{"pagination": map[string]interface{}{"total": 2}},
+				"resources": []string{"file1", "file2"},
+			})
+		case r.Method == http.MethodPatch && r.URL.Path == "/quarantine/entities/quarantined-files/v1":
+			var body struct {
+				Action string   `json:"action"`
+				IDs    []string `json:"ids"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("invalid request body: %v", err)
+			}
+			if body.Action != release.name {
+				t.Errorf("action = %q, want %q", body.Action, release.name)
+			}
+			updated = append(updated, body.IDs...)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"resources": body.IDs})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	var out, errOut bytes.Buffer
+	opts := &ActionOptions{
+		IO: &iostreams.IOStreams{Out: &out, ErrOut: &errOut},
+		FalconClient: func() (*client.CrowdStrikeAPISpecification, error) {
+			return client.New(httptransport.New(u.Host, "/", []string{"http"}), strfmt.Default), nil
+		},
+		Action: release.name,
+		Query:  shared.QueryFlags{States: []string{"quarantined"}},
+	}
+
+	if err := actionRun(context.Background(), opts, release); err != nil {
+		t.Fatalf("actionRun() returned error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"file1", "file2"}, updated); diff != "" {
+		t.Errorf("updated files mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := out.String(), "Released 2 file(s)\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/quarantine/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/quarantine"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List quarantined files`
+	longDesc  = templates.LongDesc(`
+		List the files quarantined on hosts, most recent first.
+
+		The --host, --sha256, --state, --since and --until flags are
+		combined with any FQL expression given with --filter.`)
+	examples = templates.Examples(`
+		# List the files quarantined over the last day
+		falcon quarantine list --since 24h
+
+		# List the quarantined copies of a file on every host
+		falcon quarantine list --sha256 <sha256> --state quarantined --all
+	`)
+)
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Query  shared.QueryFlags
+	Sort   string
+	Limit  int
+	All    bool
+	Format string
+}
+
+// NewCmdList represents the quarantine list command
+func NewCmdList(f *factory.Factory) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if opts.Limit < 1 {
+				return fmt.Errorf("--limit must be greater than 0")
+			}
+
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddQueryFlags(cmd, &opts.Query)
+	cmd.Flags().StringVar(&opts.Sort, "sort", "date_created|desc", "Sort files by a field, e.g. hostname|asc")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 100, "Maximum number of files to return")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Return all matching files, ignoring --limit")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	q, err := opts.Query.Query()
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	limit := opts.Limit
+	if opts.All {
+		limit = 0
+	}
+
+	ids, err := quarantine.QueryIDs(ctx, c, q.FQL(), opts.Sort, limit)
+	if err != nil {
+		return err
+	}
+
+	list, err := quarantine.Get(ctx, c, ids)
+	if err != nil {
+		return err
+	}
+
+	return shared.PrintFiles(opts.IO.Out, opts.Format, list)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package quarantine
+
+import (
+	actionCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/quarantine/action"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/quarantine/list"
+	summaryCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/quarantine/summary"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage quarantined files`
+	longDesc  = templates.LongDesc(`
+		Manage the files quarantined by the sensor on hosts.
+
+		Files can be released, quarantined again with unrelease, or deleted,
+		either by ID or in bulk by filter, for instance to release a false
+		positive on every host at once.`)
+	examples = templates.Examples(`
+		# Count the files of the last week by state
+		falcon quarantine summary --since 7d
+
+		# Release a false positive on every host
+		falcon quarantine release --sha256 <sha256> --state quarantined --dry-run
+		falcon quarantine release --sha256 <sha256> --state quarantined --comment "INC-1234 false positive"
+	`)
+)
+
+// NewQuarantineCmd represents the quarantine command
+func NewQuarantineCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "quarantine <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		listCmd.NewCmdList(f),
+		summaryCmd.NewCmdSummary(f),
+		actionCmd.NewCmdRelease(f),
+		actionCmd.NewCmdUnrelease(f),
+		actionCmd.NewCmdDelete(f),
+	)
+	return cmd
+}
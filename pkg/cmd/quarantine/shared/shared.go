@@ -0,0 +1,94 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/quarantine"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// QueryFlags holds the flags selecting quarantined files
+type QueryFlags struct {
+	Hosts  []string
+	Hashes []string
+	States []string
+	Since  string
+	Until  string
+	Filter string
+}
+
+// AddQueryFlags adds the flags selecting quarantined files to cmd
+func AddQueryFlags(cmd *cobra.Command, flags *QueryFlags) {
+	cmd.Flags().StringSliceVar(&flags.Hosts, "host", nil, "Only include files of these hosts, by hostname or device ID")
+	cmd.Flags().StringSliceVar(&flags.Hashes, "sha256", nil, "Only include files with these SHA256 hashes")
+	cmd.Flags().StringSliceVar(&flags.States, "state", nil, "Only include files in these states, e.g. quarantined or released")
+	cmd.Flags().StringVar(&flags.Since, "since", "", "Only include files quarantined since a time, e.g. 24h, 7d or 2006-01-02")
+	cmd.Flags().StringVar(&flags.Until, "until", "", "Only include files quarantined before a time, e.g. 24h, 7d or 2006-01-02")
+	cmd.Flags().StringVar(&flags.Filter, "filter", "", "Filter files using a Falcon Query Language (FQL) expression")
+}
+
+// Query returns the validated query of the flags
+func (f *QueryFlags) Query() (*quarantine.Query, error) {
+	now := time.Now().UTC()
+
+	since, err := utils.ParseTime(f.Since, now)
+	if err != nil {
+		return nil, err
+	}
+
+	until, err := utils.ParseTime(f.Until, now)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &quarantine.Query{
+		Hosts:  f.Hosts,
+		Hashes: f.Hashes,
+		States: f.States,
+		Since:  since,
+		Until:  until,
+		Filter: f.Filter,
+	}
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// PrintFiles writes quarantined files to w in the given output format
+func PrintFiles(w io.Writer, format string, list []*quarantine.File) error {
+	return output.Print(w, format, list, func(t *output.Table) {
+		t.SetHeaders("ID", "HOSTNAME", "USER", "STATE", "SHA256", "PATH", "QUARANTINED")
+		for _, f := range list {
+			paths := []string{}
+			for _, p := range f.Paths {
+				paths = append(paths, p.Path)
+			}
+			t.AddRow(f.ID, f.Hostname, f.Username, f.State, f.Sha256, strings.Join(paths, ", "), f.DateCreated)
+		}
+	})
+}
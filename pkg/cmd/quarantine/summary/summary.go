@@ -0,0 +1,120 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package summary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/aggregates"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/quarantine/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/quarantine"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Show quarantined file counts grouped by field`
+	longDesc  = templates.LongDesc(`
+		Show the number of quarantined files grouped by one or more fields,
+		such as state, hostname or sha256.
+
+		The files are selected with the same filters as the list command.`)
+	examples = templates.Examples(`
+		# Count the files of the last week by state
+		falcon quarantine summary --since 7d
+
+		# Find the files quarantined on the most hosts
+		falcon quarantine summary --by sha256 --state quarantined
+	`)
+)
+
+type SummaryOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	By     []string
+	Query  shared.QueryFlags
+	Size   int32
+	Format string
+}
+
+// NewCmdSummary represents the quarantine summary command
+func NewCmdSummary(f *factory.Factory) *cobra.Command {
+	opts := &SummaryOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "summary",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if len(opts.By) == 0 {
+				return fmt.Errorf("at least one field is required with --by")
+			}
+
+			return summaryRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.By, "by", []string{"state"}, "Fields to group files by")
+	cmd.Flags().Int32Var(&opts.Size, "size", 20, "Maximum number of values to show per field")
+	shared.AddQueryFlags(cmd, &opts.Query)
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func summaryRun(ctx context.Context, opts *SummaryOptions) error {
+	q, err := opts.Query.Query()
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	results := []*models.MsaAggregationResult{}
+	for _, field := range opts.By {
+		r, err := quarantine.Summary(ctx, c, q.FQL(), field, opts.Size)
+		if err != nil {
+			return err
+		}
+		results = append(results, r...)
+	}
+
+	return aggregates.Print(opts.IO.Out, opts.Format, results)
+}
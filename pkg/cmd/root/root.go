@@ -23,9 +23,28 @@ package root
 import (
 	"fmt"
 
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/alerts"
+	applyCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/apply"
 	"github.com/crowdstrike/falcon-cli/pkg/cmd/auth"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/customioa"
+	diffCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/discover"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/exclusions"
+	exportCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/export"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/incidents"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/intel"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/ioc"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/lookup"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/policy"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/quarantine"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/rtr"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/sandbox"
 	"github.com/crowdstrike/falcon-cli/pkg/cmd/sensor"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/spotlight"
+	streamCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/stream"
 	versionCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/version"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/zta"
 	"github.com/crowdstrike/falcon-cli/pkg/config"
 	"github.com/crowdstrike/falcon-cli/pkg/factory"
 	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
@@ -85,6 +104,25 @@ func NewCmdRoot(f *factory.Factory, version string) *cobra.Command {
 	cmd.AddCommand(versionCmd.NewCmdVersion(f))
 	cmd.AddCommand(sensor.NewSensorCmd(f))
 	cmd.AddCommand(auth.NewAuthCmd(f))
+	cmd.AddCommand(alerts.NewAlertsCmd(f))
+	cmd.AddCommand(incidents.NewIncidentsCmd(f))
+	cmd.AddCommand(rtr.NewRTRCmd(f))
+	cmd.AddCommand(ioc.NewIOCCmd(f))
+	cmd.AddCommand(policy.NewPolicyCmd(f))
+	cmd.AddCommand(spotlight.NewSpotlightCmd(f))
+	cmd.AddCommand(exclusions.NewExclusionsCmd(f))
+	cmd.AddCommand(customioa.NewCustomIOACmd(f))
+	cmd.AddCommand(firewall.NewFirewallCmd(f))
+	cmd.AddCommand(applyCmd.NewCmdApply(f))
+	cmd.AddCommand(diffCmd.NewCmdDiff(f))
+	cmd.AddCommand(exportCmd.NewCmdExport(f))
+	cmd.AddCommand(quarantine.NewQuarantineCmd(f))
+	cmd.AddCommand(sandbox.NewSandboxCmd(f))
+	cmd.AddCommand(lookup.NewLookupCmd(f))
+	cmd.AddCommand(intel.NewIntelCmd(f))
+	cmd.AddCommand(streamCmd.NewCmdStream(f))
+	cmd.AddCommand(discover.NewDiscoverCmd(f))
+	cmd.AddCommand(zta.NewZTACmd(f))
 
 	utils.DisableAuthCheck(cmd)
 
@@ -0,0 +1,223 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package run
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/rtr"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Run a Real Time Response command on many hosts`
+	longDesc  = templates.LongDesc(`
+		Run a Real Time Response (RTR) command on many hosts and report the
+		output of each host.
+
+		Hosts are given with --host or listed in a file with --hosts-file,
+		which contains one hostname or device ID per line. Blank lines and
+		lines starting with # are ignored. Use "-" to read the hosts from
+		standard input. Hosts are split into batch sessions of at most
+		10000 hosts, which are refreshed in the background until every batch
+		has run.
+
+		The permission level used is the lowest one that allows the command.
+		Hosts that do not respond within --timeout are reported with the
+		timeout status.
+
+		The table output is printed once every host has reported. The ndjson
+		output writes one line per host as soon as its batch completes.`)
+	examples = templates.Examples(`
+		# Check a registry key on every host listed in a file
+		falcon rtr batch run --hosts-file hosts.txt -- reg query 'HKLM\Software\Example'
+
+		# Stream the results as NDJSON and keep the hosts that failed
+		falcon rtr batch run --hosts-file hosts.txt -o ndjson -- ls 'C:\Temp' | jq -r 'select(.status != "success") | .host'
+	`)
+)
+
+// outputFormats are the formats supported by the run command
+var outputFormats = []string{output.FormatTable, output.FormatNDJSON}
+
+type RunOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Hosts        shared.HostFlags
+	Command      string
+	Format       string
+	QueueOffline bool
+	Timeout      time.Duration
+}
+
+// hostResult is the result of the command on a host as it was named in the
+// hosts file
+type hostResult struct {
+	Host string `json:"host"`
+	*rtr.HostResult
+}
+
+// NewCmdRun represents the rtr batch run command
+func NewCmdRun(f *factory.Factory) *cobra.Command {
+	opts := &RunOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "run (--host <host>... | --hosts-file <file>) -- <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Command = rtr.JoinArgs(args)
+
+			if err := utils.ValidateOneOf("output", outputFormats, opts.Format); err != nil {
+				return err
+			}
+
+			if opts.Timeout < time.Second || opts.Timeout > rtr.MaxBatchTimeout {
+				return fmt.Errorf("--timeout must be between 1s and %s", rtr.MaxBatchTimeout)
+			}
+
+			return runRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddHostFlags(cmd, &opts.Hosts)
+	cmd.Flags().StringVarP(&opts.Format, "output", "o", output.FormatTable, fmt.Sprintf("Output format. One of: %s", strings.Join(outputFormats, "|")))
+	cmd.Flags().BoolVar(&opts.QueueOffline, "queue-offline", false, "Queue the command on offline hosts and run it when they connect")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 2*time.Minute, "Maximum time to wait for the hosts to respond")
+
+	return cmd
+}
+
+func runRun(ctx context.Context, opts *RunOptions) error {
+	names, err := shared.ReadHosts(&opts.Hosts, opts.IO.In)
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	targets, err := shared.ResolveTargets(ctx, c, names)
+	if err != nil {
+		return err
+	}
+
+	w := &resultWriter{opts: opts, table: output.NewTable("HOST", "DEVICE ID", "STATUS", "STDOUT", "STDERR"), counts: map[string]int{}}
+
+	for _, name := range targets.Missing {
+		if err := w.write(name, &rtr.HostResult{Status: rtr.StatusError, Error: "host not found"}); err != nil {
+			return err
+		}
+	}
+
+	batches := []*rtr.Batch{}
+	for _, chunk := range utils.Chunk(targets.DeviceIDs, rtr.MaxBatchHosts) {
+		batch, err := rtr.NewBatch(ctx, c, chunk, opts.QueueOffline, opts.Timeout)
+		if err != nil {
+			return err
+		}
+		batches = append(batches, batch)
+	}
+
+	// batches waiting for their turn must not expire
+	keepAliveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for _, batch := range batches {
+		go batch.KeepAlive(keepAliveCtx, rtr.PulseInterval)
+	}
+
+	for _, batch := range batches {
+		results, err := batch.Run(ctx, opts.Command, opts.Timeout)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range append(batch.Failed, results...) {
+			if err := w.write(targets.Names[r.DeviceID], r); err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.flush()
+}
+
+// resultWriter streams NDJSON results or collects them in a table
+type resultWriter struct {
+	opts   *RunOptions
+	table  *output.Table
+	counts map[string]int
+}
+
+func (w *resultWriter) write(host string, r *rtr.HostResult) error {
+	w.counts[r.Status]++
+
+	if w.opts.Format == output.FormatNDJSON {
+		return output.PrintNDJSON(w.opts.IO.Out, hostResult{Host: host, HostResult: r})
+	}
+
+	stderr := r.Stderr
+	if r.Error != "" {
+		stderr = r.Error
+	}
+	w.table.AddRow(host, r.DeviceID, r.Status, strings.TrimSpace(r.Stdout), strings.TrimSpace(stderr))
+	return nil
+}
+
+func (w *resultWriter) flush() error {
+	if w.opts.Format == output.FormatTable {
+		if err := w.table.Render(w.opts.IO.Out); err != nil {
+			return err
+		}
+	}
+
+	summary := []string{}
+	for _, status := range []string{rtr.StatusSuccess, rtr.StatusFailed, rtr.StatusError, rtr.StatusTimeout, rtr.StatusQueued} {
+		if n := w.counts[status]; n > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	fmt.Fprintf(w.opts.IO.ErrOut, "\n%s\n", strings.Join(summary, ", "))
+
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package connect
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/rtr"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Open an interactive Real Time Response shell on a host`
+	longDesc  = templates.LongDesc(`
+		Open an interactive Real Time Response (RTR) shell on a host.
+
+		The host can be given as a hostname or a device ID. The shell supports
+		command history with the arrow keys and tab completion of RTR commands.
+		The session is kept alive in the background until you exit with
+		"exit", "quit" or Ctrl-D.
+
+		Commands are limited to the permission level chosen with --level:
+		read-only, active (active responder) or admin. Run "help" in the shell
+		to list the commands and the level each one requires.
+
+		When standard input is not a terminal, commands are read from it one
+		per line and run in order.`)
+	examples = templates.Examples(`
+		# Connect to a host by name
+		falcon rtr connect WIN-DC01
+
+		# Connect with admin permissions to run put and runscript
+		falcon rtr connect <device_id> --level admin
+
+		# Run a list of commands non-interactively
+		printf 'ps\nnetstat\n' | falcon rtr connect WIN-DC01
+	`)
+)
+
+type ConnectOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Host         string
+	Level        string
+	QueueOffline bool
+	Timeout      time.Duration
+}
+
+// NewCmdConnect represents the rtr connect command
+func NewCmdConnect(f *factory.Factory) *cobra.Command {
+	opts := &ConnectOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "connect <host>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Host = args[0]
+
+			return connectRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Level, "level", "active", fmt.Sprintf("Permission level of the session: %s", strings.Join(rtr.LevelNames, ", ")))
+	cmd.Flags().BoolVar(&opts.QueueOffline, "queue-offline", false, "Queue commands if the host is offline and run them when it connects")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 10*time.Minute, "Maximum time to wait for each command to complete")
+
+	return cmd
+}
+
+func connectRun(ctx context.Context, opts *ConnectOptions) error {
+	level, err := rtr.ParseLevel(opts.Level)
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	resolved, err := hosts.ResolveDeviceIDs(ctx, c, []string{opts.Host})
+	if err != nil {
+		return err
+	}
+	deviceID := resolved[opts.Host]
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	log.Debugf("Initializing RTR session with %s (%s)", opts.Host, deviceID)
+	session, err := rtr.NewSession(ctx, c, deviceID, level, opts.QueueOffline)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		// the session context may already be cancelled by an interrupt
+		closeCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := session.Close(closeCtx); err != nil {
+			log.Warnf("Unable to close RTR session: %v", err)
+		}
+	}()
+
+	keepAliveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go session.KeepAlive(keepAliveCtx, rtr.PulseInterval)
+
+	sh := &shell{
+		io:       opts.IO,
+		session:  session,
+		hostname: opts.Host,
+		timeout:  opts.Timeout,
+	}
+
+	if opts.IO.IsStdinTTY() && opts.IO.IsStdoutTTY() {
+		return sh.interactive(ctx)
+	}
+	return sh.batch(ctx)
+}
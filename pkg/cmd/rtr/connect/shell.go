@@ -0,0 +1,221 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package connect
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/rtr"
+	"golang.org/x/term"
+)
+
+// shell reads command lines and runs them in an RTR session
+type shell struct {
+	io       *iostreams.IOStreams
+	session  *rtr.Session
+	hostname string
+	timeout  time.Duration
+}
+
+// interactive runs a line editing shell on the terminal with command
+// history and tab completion
+func (sh *shell) interactive(ctx context.Context) error {
+	stdin, ok := sh.io.In.(*os.File)
+	if !ok {
+		return sh.batch(ctx)
+	}
+
+	state, err := term.MakeRaw(int(stdin.Fd()))
+	if err != nil {
+		return fmt.Errorf("unable to configure terminal: %v", err)
+	}
+	defer func() { _ = term.Restore(int(stdin.Fd()), state) }()
+
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{sh.io.In, sh.io.Out}, sh.prompt())
+
+	if width, height, err := term.GetSize(int(stdin.Fd())); err == nil {
+		_ = t.SetSize(width, height)
+	}
+
+	t.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		return complete(line, pos, sh.session.Level)
+	}
+
+	sh.banner(t)
+
+	for {
+		line, err := t.ReadLine()
+		if err == io.EOF {
+			fmt.Fprintln(t)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if done := sh.execute(ctx, t, t, line); done {
+			return nil
+		}
+		t.SetPrompt(sh.prompt())
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// batch runs command lines read from standard input
+func (sh *shell) batch(ctx context.Context) error {
+	scanner := bufio.NewScanner(sh.io.In)
+	for scanner.Scan() {
+		if done := sh.execute(ctx, sh.io.Out, sh.io.ErrOut, scanner.Text()); done {
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+
+	return scanner.Err()
+}
+
+// execute runs a single command line and reports whether the shell should exit
+func (sh *shell) execute(ctx context.Context, stdout, stderr io.Writer, line string) bool {
+	line = strings.TrimSpace(line)
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+
+	switch fields[0] {
+	case "exit", "quit":
+		return true
+	case "help":
+		// help for a specific command is answered by the host
+		if len(fields) == 1 {
+			sh.help(stdout)
+			return false
+		}
+	}
+
+	cmdCtx, cancel := context.WithTimeout(ctx, sh.timeout)
+	defer cancel()
+
+	result, err := sh.session.Run(cmdCtx, line)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return false
+	}
+
+	if result.Queued {
+		fmt.Fprintf(stdout, "Command queued until the host is online (cloud request ID %s)\n", result.CloudRequestID)
+		return false
+	}
+
+	writeOutput(stdout, result.Stdout)
+	writeOutput(stderr, result.Stderr)
+
+	return false
+}
+
+func (sh *shell) banner(w io.Writer) {
+	fmt.Fprintf(w, "Connected to %s (device %s)\n", sh.hostname, sh.session.DeviceID)
+	fmt.Fprintf(w, "Session %s, permission level: %s\n", sh.session.ID, sh.session.Level)
+	if sh.session.Offline {
+		fmt.Fprintln(w, "The host is offline, commands will be queued until it connects")
+	}
+	fmt.Fprintln(w, `Type "help" to list the available commands and "exit" to quit.`)
+	fmt.Fprintln(w)
+}
+
+func (sh *shell) prompt() string {
+	return fmt.Sprintf("[%s] %s %s> ", sh.session.Level, sh.hostname, sh.session.Pwd)
+}
+
+// help lists the RTR commands with the level each one requires
+func (sh *shell) help(w io.Writer) {
+	t := output.NewTable("COMMAND", "LEVEL", "AVAILABLE", "DESCRIPTION")
+	for _, c := range rtr.Commands {
+		available := "yes"
+		if c.Level > sh.session.Level {
+			available = "no"
+		}
+		t.AddRow(c.Name, c.Level.String(), available, c.Description)
+	}
+	_ = t.Render(w)
+
+	fmt.Fprintln(w, "\nRun \"help <command>\" for the usage of a command. Use exit or quit to end the session.")
+}
+
+// complete expands the word under the cursor to the longest common prefix of
+// the matching RTR commands
+func complete(line string, pos int, level rtr.Level) (string, int, bool) {
+	head, tail := line[:pos], line[pos:]
+
+	matches := rtr.Complete(head, level)
+	if len(matches) == 0 {
+		return "", 0, false
+	}
+
+	word := head
+	if i := strings.LastIndex(head, " "); i >= 0 {
+		word = head[i+1:]
+	}
+
+	completion := rtr.CommonPrefix(matches)
+	if len(matches) == 1 {
+		completion += " "
+	}
+
+	if len(completion) <= len(word) {
+		return "", 0, false
+	}
+
+	head = head[:len(head)-len(word)] + completion
+	return head + tail, len(head), true
+}
+
+// writeOutput writes command output, making sure it ends with a newline
+func writeOutput(w io.Writer, s string) {
+	if s == "" {
+		return
+	}
+
+	fmt.Fprint(w, s)
+	if !strings.HasSuffix(s, "\n") {
+		fmt.Fprintln(w)
+	}
+}
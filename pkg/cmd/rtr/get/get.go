@@ -0,0 +1,348 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/rtr"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Retrieve a file from one or many hosts`
+	longDesc  = templates.LongDesc(`
+		Retrieve a file from one or many hosts with the Real Time Response
+		(RTR) get command.
+
+		The file is uploaded by each host to the Falcon cloud, which can take a
+		while for large files. Once a host has uploaded the file, it is
+		downloaded into a directory named after the host as the password
+		protected 7z archive RTR wraps retrieved files in. With --extract the
+		file is also extracted from the archive using the standard password
+		"infected" and its SHA256 hash is checked against the one reported by
+		the host.
+
+		A manifest.json file listing the host, status, SHA256 hash and local
+		path of every retrieved file is written to the output directory.
+
+		Retrieved files may be malicious. Only extract them on a system meant
+		for handling malware.`)
+	examples = templates.Examples(`
+		# Retrieve a file from a host
+		falcon rtr get 'C:\Windows\Temp\evil.exe' --host WIN-DC01
+
+		# Retrieve and extract a file from every host listed in a file
+		falcon rtr get 'C:\Windows\Temp\evil.exe' --hosts-file hosts.txt --dir ./evidence --extract
+	`)
+)
+
+// pollInterval is the delay between upload status checks
+const pollInterval = 5 * time.Second
+
+// manifestFile is the name of the manifest written to the output directory
+const manifestFile = "manifest.json"
+
+type GetOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Path         string
+	Hosts        shared.HostFlags
+	Dir          string
+	Extract      bool
+	QueueOffline bool
+	Timeout      time.Duration
+	Wait         time.Duration
+	Format       string
+}
+
+// entry is the manifest entry of the file retrieved from a host
+type entry struct {
+	Host     string `json:"host"`
+	DeviceID string `json:"device_id,omitempty"`
+	Path     string `json:"path"`
+	Status   string `json:"status"`
+	SHA256   string `json:"sha256,omitempty"`
+	Size     int64  `json:"size,omitempty"`
+	Archive  string `json:"archive,omitempty"`
+	File     string `json:"file,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// NewCmdGet represents the rtr get command
+func NewCmdGet(f *factory.Factory) *cobra.Command {
+	opts := &GetOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "get <path> (--host <host>... | --hosts-file <file>)",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Path = args[0]
+
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if opts.Timeout < time.Second || opts.Timeout > rtr.MaxBatchTimeout {
+				return fmt.Errorf("--timeout must be between 1s and %s", rtr.MaxBatchTimeout)
+			}
+
+			return getRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddHostFlags(cmd, &opts.Hosts)
+	cmd.Flags().StringVarP(&opts.Dir, "dir", "d", ".", "Directory to write the retrieved files to")
+	cmd.Flags().BoolVar(&opts.Extract, "extract", false, "Extract the retrieved files from their 7z archive")
+	cmd.Flags().BoolVar(&opts.QueueOffline, "queue-offline", false, "Queue the command on offline hosts and run it when they connect")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 2*time.Minute, "Maximum time to wait for the hosts to run the get command")
+	cmd.Flags().DurationVar(&opts.Wait, "wait", 10*time.Minute, "Maximum time to wait for the hosts to upload the file")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func getRun(ctx context.Context, opts *GetOptions) error {
+	names, err := shared.ReadHosts(&opts.Hosts, opts.IO.In)
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	targets, err := shared.ResolveTargets(ctx, c, names)
+	if err != nil {
+		return err
+	}
+
+	manifest := []*entry{}
+	for _, name := range targets.Missing {
+		manifest = append(manifest, &entry{Host: name, Path: opts.Path, Status: rtr.StatusError, Error: "host not found"})
+	}
+
+	for _, chunk := range utils.Chunk(targets.DeviceIDs, rtr.MaxBatchHosts) {
+		entries, err := getBatch(ctx, c, opts, targets, chunk)
+		if err != nil {
+			return err
+		}
+		manifest = append(manifest, entries...)
+	}
+
+	if err := writeManifest(opts.Dir, manifest); err != nil {
+		return err
+	}
+
+	return output.Print(opts.IO.Out, opts.Format, manifest, func(t *output.Table) {
+		t.SetHeaders("HOST", "STATUS", "SHA256", "FILE")
+		for _, e := range manifest {
+			file := e.File
+			if file == "" {
+				file = e.Archive
+			}
+			if e.Error != "" {
+				file = e.Error
+			}
+			t.AddRow(e.Host, e.Status, e.SHA256, file)
+		}
+	})
+}
+
+// getBatch retrieves the file from the hosts of a single batch session
+func getBatch(ctx context.Context, c *client.CrowdStrikeAPISpecification, opts *GetOptions, targets *shared.Targets, deviceIDs []string) ([]*entry, error) {
+	batch, err := rtr.NewBatch(ctx, c, deviceIDs, opts.QueueOffline, opts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	keepAliveCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go batch.KeepAlive(keepAliveCtx, rtr.PulseInterval)
+
+	requestID, results, err := batch.Get(ctx, opts.Path, opts.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*entry{}
+	pending := map[string]*entry{}
+	for _, r := range append(batch.Failed, results...) {
+		e := &entry{
+			Host:     targets.Names[r.DeviceID],
+			DeviceID: r.DeviceID,
+			Path:     opts.Path,
+			Status:   r.Status,
+			Error:    r.Error,
+		}
+		if e.Error == "" {
+			e.Error = strings.TrimSpace(r.Stderr)
+		}
+
+		entries = append(entries, e)
+		if r.Status == rtr.StatusSuccess {
+			pending[r.DeviceID] = e
+		}
+	}
+
+	if len(pending) > 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "Waiting for %d hosts to upload %s\n", len(pending), opts.Path)
+	}
+
+	deadline := time.After(opts.Wait)
+	for len(pending) > 0 {
+		files, err := batch.GetStatus(ctx, requestID)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, e := range pending {
+			file, ok := files[id]
+			if !ok || utils.Deref(file.Sha256) == "" {
+				continue
+			}
+
+			delete(pending, id)
+			if err := download(ctx, c, opts, e, file); err != nil {
+				e.Status = rtr.StatusError
+				e.Error = err.Error()
+			}
+		}
+
+		if len(pending) == 0 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline:
+			for _, e := range pending {
+				e.Status = rtr.StatusTimeout
+				e.Error = fmt.Sprintf("upload did not complete within %s", opts.Wait)
+			}
+			pending = nil
+		case <-time.After(pollInterval):
+		}
+	}
+
+	return entries, nil
+}
+
+// download writes the archive uploaded by a host to the host's directory and
+// extracts it when requested
+func download(ctx context.Context, c *client.CrowdStrikeAPISpecification, opts *GetOptions, e *entry, file models.ModelFile) error {
+	e.SHA256 = utils.Deref(file.Sha256)
+	e.Size = utils.Deref(file.Size)
+
+	dir := filepath.Join(opts.Dir, hostDir(e.Host))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// RTR reports the full path on the host, which may use either separator
+	name := filepath.Base(strings.ReplaceAll(utils.Deref(file.Name), `\`, "/"))
+	e.Archive = filepath.Join(dir, name+".7z")
+
+	out, err := os.OpenFile(e.Archive, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	log.Debugf("Downloading %s from %s to %s", name, e.Host, e.Archive)
+	if err := rtr.DownloadFile(ctx, c, utils.Deref(file.SessionID), e.SHA256, name, out); err != nil {
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	if !opts.Extract {
+		return nil
+	}
+
+	extracted, err := rtr.ExtractArchive(e.Archive, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range extracted {
+		if strings.EqualFold(f.SHA256, e.SHA256) {
+			e.File = f.Path
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no file matching SHA256 %s found in %s", e.SHA256, e.Archive)
+}
+
+// hostDir returns a directory name for a host that is safe to use as a path
+// element
+func hostDir(host string) string {
+	return strings.NewReplacer("/", "_", `\`, "_", ":", "_").Replace(host)
+}
+
+// writeManifest writes the manifest of retrieved files to the output directory
+func writeManifest(dir string, manifest []*entry) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := output.PrintJSON(f, manifest); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
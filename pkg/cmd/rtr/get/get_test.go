@@ -0,0 +1,147 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package get
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/runtime"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+)
+
+// testArchive is a 7z archive as retrieved with get, holding a file with
+// the SHA256 testSHA256
+const (
+	testArchive = "../../../rtr/testdata/evil.exe.7z"
+	testSHA256  = "a9a706b34719a7cf0f5e5341521df2247c61c68df30b8cbc6c017f3002a0aaa4"
+)
+
+// newTestClient serves testArchive as the content of every extracted file
+func newTestClient(t *testing.T) *client.CrowdStrikeAPISpecification {
+	archive, err := os.ReadFile(testArchive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/real-time-response/entities/extracted-file-contents/v1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-7z-compressed")
+		_, _ = w.Write(archive)
+	}))
+	t.Cleanup(server.Close)
+
+	// archives are consumed as falcon.NewClient does
+	u, _ := url.Parse(server.URL)
+	transport := httptransport.New(u.Host, "/", []string{"http"})
+	transport.Consumers["application/x-7z-compressed"] = runtime.ByteStreamConsumer()
+	return client.New(transport, strfmt.Default)
+}
+
+func TestDownload(t *testing.T) {
+	tests := []struct {
+		name     string
+		extract  bool
+		sha256   string
+		wantFile bool
+		wantErr  bool
+	}{
+		{name: "archive only", sha256: testSHA256},
+		{name: "extracted", extract: true, sha256: testSHA256, wantFile: true},
+		{name: "hash mismatch", extract: true, sha256: "0000000000000000000000000000000000000000000000000000000000000000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &GetOptions{Dir: t.TempDir(), Extract: tt.extract}
+			e := &entry{Host: "WIN:01", Path: `C:\Windows\Temp\evil.exe`}
+			file := models.ModelFile{
+				Name:      utils.Ptr(`C:\Windows\Temp\evil.exe`),
+				SessionID: utils.Ptr("session"),
+				Sha256:    utils.Ptr(tt.sha256),
+				Size:      utils.Ptr(int64(30)),
+			}
+
+			err := download(context.Background(), newTestClient(t), opts, e, file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("download() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			dir := filepath.Join(opts.Dir, "WIN_01")
+			if e.Archive != filepath.Join(dir, "evil.exe.7z") {
+				t.Errorf("archive = %s", e.Archive)
+			}
+			if _, err := os.Stat(e.Archive); err != nil {
+				t.Errorf("archive not written: %v", err)
+			}
+
+			wantFile := ""
+			if tt.wantFile {
+				wantFile = filepath.Join(dir, "evil.exe")
+			}
+			if e.File != wantFile {
+				t.Errorf("file = %q, want %q", e.File, wantFile)
+			}
+			if e.SHA256 != tt.sha256 || e.Size != 30 {
+				t.Errorf("unexpected entry %+v", e)
+			}
+		})
+	}
+}
+
+func TestWriteManifest(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	manifest := []*entry{
+		{Host: "web-01", DeviceID: "aid1", Path: "/etc/passwd", Status: "success", SHA256: testSHA256, Size: 30, Archive: "out/web-01/passwd.7z", File: "out/web-01/passwd"},
+		{Host: "web-02", Path: "/etc/passwd", Status: "error", Error: "host not found"},
+	}
+
+	if err := writeManifest(dir, manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []*entry{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(manifest, got); diff != "" {
+		t.Errorf("manifest mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package putfiles
+
+import (
+	deleteCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/putfiles/delete"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/putfiles/list"
+	uploadCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/putfiles/upload"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage Real Time Response put-files`
+	longDesc  = templates.LongDesc(`
+		Manage the files stored in the Falcon cloud that can be copied to hosts
+		with the RTR put command.`)
+	examples = templates.Examples(`
+		# List the put-files
+		falcon rtr put-files list
+
+		# Upload a tool
+		falcon rtr put-files upload ./procdump64.exe --description "Sysinternals ProcDump"
+	`)
+)
+
+// NewCmdPutFiles represents the rtr put-files command
+func NewCmdPutFiles(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "put-files <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		listCmd.NewCmdList(f),
+		uploadCmd.NewCmdUpload(f),
+		deleteCmd.NewCmdDelete(f),
+	)
+	return cmd
+}
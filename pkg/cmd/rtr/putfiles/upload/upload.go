@@ -0,0 +1,102 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package upload
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/rtr"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Upload a put-file`
+	longDesc  = templates.LongDesc(`
+		Upload a file that can be copied to hosts with the RTR put command.
+
+		The put-file is named after the file unless --name is given.`)
+	examples = templates.Examples(`
+		# Upload a tool
+		falcon rtr put-files upload ./procdump64.exe --description "Sysinternals ProcDump"
+	`)
+)
+
+type UploadOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	File        string
+	Name        string
+	Description string
+	Comment     string
+}
+
+// NewCmdUpload represents the rtr put-files upload command
+func NewCmdUpload(f *factory.Factory) *cobra.Command {
+	opts := &UploadOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "upload <file>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"create"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.File = args[0]
+
+			return uploadRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Name of the put-file (default the file name)")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of the put-file")
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Comment for the audit log")
+
+	return cmd
+}
+
+func uploadRun(ctx context.Context, opts *UploadOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	if err := rtr.CreatePutFile(ctx, c, opts.File, opts.Name, opts.Description, opts.Comment); err != nil {
+		return err
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = filepath.Base(opts.File)
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Uploaded put-file %s\n", name)
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package rtr
+
+import (
+	batchCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/batch"
+	connectCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/connect"
+	getCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/get"
+	putFilesCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/putfiles"
+	scriptsCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/scripts"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Run Real Time Response commands on hosts`
+	longDesc  = templates.LongDesc(`
+		Run Real Time Response (RTR) commands on hosts managed by the
+		CrowdStrike Falcon platform.`)
+	examples = templates.Examples(`
+		# Open an interactive RTR shell on a host
+		falcon rtr connect WIN-DC01
+
+		# Run a command on every host listed in a file
+		falcon rtr batch run --hosts-file hosts.txt -- ps
+
+		# Retrieve a file from a host
+		falcon rtr get 'C:\Windows\Temp\evil.exe' --host WIN-DC01 --extract
+
+		# Sync a directory of custom scripts to the cloud
+		falcon rtr scripts sync ./scripts/
+	`)
+)
+
+// NewRTRCmd represents the rtr command
+func NewRTRCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rtr <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		batchCmd.NewCmdBatch(f),
+		connectCmd.NewCmdConnect(f),
+		getCmd.NewCmdGet(f),
+		putFilesCmd.NewCmdPutFiles(f),
+		scriptsCmd.NewCmdScripts(f),
+	)
+	return cmd
+}
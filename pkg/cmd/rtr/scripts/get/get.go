@@ -0,0 +1,125 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package get
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/rtr"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Show a custom script`
+	longDesc  = templates.LongDesc(`
+		Print the content of a custom script, identified by name or ID.
+
+		With --output json or yaml the details of the script are printed
+		instead, including its content.`)
+	examples = templates.Examples(`
+		# Print a script
+		falcon rtr scripts get collect.ps1
+
+		# Save a script to a file
+		falcon rtr scripts get collect.ps1 --file ./scripts/collect.ps1
+
+		# Show the details of a script
+		falcon rtr scripts get collect.ps1 -o yaml
+	`)
+)
+
+// detailFormats are the formats printing the script details
+var detailFormats = []string{output.FormatJSON, output.FormatYAML}
+
+type GetOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Script string
+	File   string
+	Format string
+}
+
+// NewCmdGet represents the rtr scripts get command
+func NewCmdGet(f *factory.Factory) *cobra.Command {
+	opts := &GetOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "get <name|id>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"show"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Script = args[0]
+
+			if opts.Format != "" {
+				if err := utils.ValidateOneOf("output", detailFormats, opts.Format); err != nil {
+					return err
+				}
+			}
+
+			return getRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.File, "file", "", "Write the script content to a file instead of standard output")
+	cmd.Flags().StringVarP(&opts.Format, "output", "o", "", "Print the script details instead of its content. One of: json|yaml")
+
+	return cmd
+}
+
+func getRun(ctx context.Context, opts *GetOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	script, err := rtr.FindScript(ctx, c, opts.Script)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format != "" {
+		return output.Print(opts.IO.Out, opts.Format, script, nil)
+	}
+
+	if opts.File != "" {
+		if err := os.WriteFile(opts.File, []byte(script.Content), 0644); err != nil {
+			return fmt.Errorf("unable to write script: %v", err)
+		}
+		return nil
+	}
+
+	_, err = fmt.Fprint(opts.IO.Out, script.Content)
+	return err
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package scripts
+
+import (
+	deleteCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/scripts/delete"
+	getCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/scripts/get"
+	listCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/scripts/list"
+	syncCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/scripts/sync"
+	updateCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/scripts/update"
+	uploadCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/scripts/upload"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Manage Real Time Response custom scripts`
+	longDesc  = templates.LongDesc(`
+		Manage the custom scripts stored in the Falcon cloud that can be run on
+		hosts with the RTR runscript command.`)
+	examples = templates.Examples(`
+		# List the custom scripts
+		falcon rtr scripts list
+
+		# Sync a directory of scripts kept in git to the cloud
+		falcon rtr scripts sync ./scripts/
+	`)
+)
+
+// NewCmdScripts represents the rtr scripts command
+func NewCmdScripts(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "scripts <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		listCmd.NewCmdList(f),
+		getCmd.NewCmdGet(f),
+		uploadCmd.NewCmdUpload(f),
+		updateCmd.NewCmdUpdate(f),
+		deleteCmd.NewCmdDelete(f),
+		syncCmd.NewCmdSync(f),
+	)
+	return cmd
+}
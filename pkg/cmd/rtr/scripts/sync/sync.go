@@ -0,0 +1,255 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/rtr"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Sync a directory of scripts to the cloud`
+	longDesc  = templates.LongDesc(`
+		Sync a directory of scripts, for example one kept in git, to the custom
+		scripts stored in the Falcon cloud.
+
+		Every script in the directory is compared with the cloud script of the
+		same name. Scripts that only exist locally are uploaded and scripts
+		whose content or settings differ are updated. Cloud scripts that do not
+		exist locally are left alone unless --prune is given.
+
+		Scripts are recognized by their extension: .ps1 scripts run on
+		Windows, .sh and .bash scripts on Linux and macOS and .zsh scripts on
+		macOS. An optional scripts.yaml file in the directory sets the
+		description, platforms and permission of individual scripts:
+
+		    collect.ps1:
+		      description: Collect triage data
+		      platform: [windows]
+		      permission: group
+
+		Files with another extension are only synced when they are listed in
+		scripts.yaml with their platforms.
+
+		Use --dry-run to print the changes without applying them.`)
+	examples = templates.Examples(`
+		# Show what would change
+		falcon rtr scripts sync ./scripts/ --dry-run
+
+		# Sync the scripts and delete the cloud scripts that were removed from git
+		falcon rtr scripts sync ./scripts/ --prune --comment "Sync from $(git rev-parse --short HEAD)"
+	`)
+)
+
+// metadataFile is the name of the file holding the settings of the scripts
+const metadataFile = "scripts.yaml"
+
+// metadata holds the settings of a script in scripts.yaml
+type metadata struct {
+	Description string   `yaml:"description"`
+	Platforms   []string `yaml:"platform"`
+	Permission  string   `yaml:"permission"`
+}
+
+type SyncOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Dir        string
+	Prune      bool
+	DryRun     bool
+	Permission string
+	Comment    string
+	Format     string
+}
+
+// NewCmdSync represents the rtr scripts sync command
+func NewCmdSync(f *factory.Factory) *cobra.Command {
+	opts := &SyncOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "sync <dir>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Dir = args[0]
+
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if err := utils.ValidateOneOf("permission", rtr.Permissions, opts.Permission); err != nil {
+				return err
+			}
+
+			return syncRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Prune, "prune", false, "Delete cloud scripts that do not exist in the directory")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Print the changes without applying them")
+	cmd.Flags().StringVar(&opts.Permission, "permission", "private", "Permission of new scripts not set in scripts.yaml: private, group or public")
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Comment for the audit log")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func syncRun(ctx context.Context, opts *SyncOptions) error {
+	local, err := readScripts(opts.Dir, opts.Comment)
+	if err != nil {
+		return err
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	remote, err := rtr.ListScripts(ctx, c, "")
+	if err != nil {
+		return err
+	}
+
+	changes := rtr.PlanScriptSync(local, remote, opts.Prune)
+
+	err = output.Print(opts.IO.Out, opts.Format, changes, func(t *output.Table) {
+		t.SetHeaders("ACTION", "NAME", "FIELDS")
+		for _, change := range changes {
+			t.AddRow(change.Action, change.Name, strings.Join(change.Fields, ","))
+		}
+	})
+	if err != nil || opts.DryRun {
+		return err
+	}
+
+	applied := 0
+	for _, change := range changes {
+		switch change.Action {
+		case rtr.SyncCreate:
+			if change.Spec.Permission == "" {
+				change.Spec.Permission = opts.Permission
+			}
+			err = rtr.CreateScript(ctx, c, change.Spec)
+		case rtr.SyncUpdate:
+			err = rtr.UpdateScript(ctx, c, change.ID, change.Spec)
+		case rtr.SyncDelete:
+			err = rtr.DeleteScript(ctx, c, change.ID)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+		applied++
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "Applied %d changes\n", applied)
+	return nil
+}
+
+// readScripts reads the scripts in dir along with their settings in
+// scripts.yaml
+func readScripts(dir, comment string) ([]*rtr.ScriptSpec, error) {
+	settings := map[string]metadata{}
+	if b, err := os.ReadFile(filepath.Join(dir, metadataFile)); err == nil {
+		if err := yaml.Unmarshal(b, &settings); err != nil {
+			return nil, fmt.Errorf("unable to parse %s: %v", metadataFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for name, m := range settings {
+		if err := utils.ValidateOneOf("platform", rtr.Platforms, m.Platforms...); err != nil {
+			return nil, fmt.Errorf("%s: %s: %v", metadataFile, name, err)
+		}
+		if m.Permission != "" {
+			if err := utils.ValidateOneOf("permission", rtr.Permissions, m.Permission); err != nil {
+				return nil, fmt.Errorf("%s: %s: %v", metadataFile, name, err)
+			}
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	scripts := []*rtr.ScriptSpec{}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || name == metadataFile || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		m := settings[name]
+		platforms := m.Platforms
+		if len(platforms) == 0 {
+			platforms = shared.ScriptPlatforms(name)
+		}
+		if len(platforms) == 0 {
+			log.Debugf("Skipping %s, it is not a known script type", name)
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		scripts = append(scripts, &rtr.ScriptSpec{
+			Name:        name,
+			Description: m.Description,
+			Content:     string(content),
+			Platforms:   platforms,
+			Permission:  m.Permission,
+			Comment:     comment,
+		})
+	}
+
+	if len(scripts) == 0 {
+		return nil, fmt.Errorf("no scripts found in %s", dir)
+	}
+
+	return scripts, nil
+}
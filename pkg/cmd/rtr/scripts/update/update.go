@@ -0,0 +1,142 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package update
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/rtr"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Update a custom script`
+	longDesc  = templates.LongDesc(`
+		Update a custom script, identified by name or ID.
+
+		Only the given fields are changed. Use --file to replace the content of
+		the script.`)
+	examples = templates.Examples(`
+		# Replace the content of a script
+		falcon rtr scripts update collect.ps1 --file ./collect.ps1 --comment "Collect prefetch files"
+
+		# Share a script with all RTR administrators
+		falcon rtr scripts update collect.ps1 --permission group
+	`)
+)
+
+type UpdateOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Script      string
+	File        string
+	Name        string
+	Description string
+	Platforms   []string
+	Permission  string
+	Comment     string
+}
+
+// NewCmdUpdate represents the rtr scripts update command
+func NewCmdUpdate(f *factory.Factory) *cobra.Command {
+	opts := &UpdateOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "update <name|id>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Script = args[0]
+
+			if opts.File == "" && opts.Name == "" && opts.Description == "" && len(opts.Platforms) == 0 && opts.Permission == "" {
+				return fmt.Errorf("nothing to update, use --file, --name, --description, --platform or --permission")
+			}
+
+			if err := utils.ValidateOneOf("platform", rtr.Platforms, opts.Platforms...); err != nil {
+				return err
+			}
+
+			if opts.Permission != "" {
+				if err := utils.ValidateOneOf("permission", rtr.Permissions, opts.Permission); err != nil {
+					return err
+				}
+			}
+
+			return updateRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.File, "file", "", "File with the new content of the script")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "New name of the script")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of the script")
+	cmd.Flags().StringSliceVar(&opts.Platforms, "platform", nil, "Platforms the script runs on: windows, mac, linux")
+	cmd.Flags().StringVar(&opts.Permission, "permission", "", "Who can run the script: private, group or public")
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Comment for the audit log")
+
+	return cmd
+}
+
+func updateRun(ctx context.Context, opts *UpdateOptions) error {
+	spec := &rtr.ScriptSpec{
+		Name:        opts.Name,
+		Description: opts.Description,
+		Platforms:   opts.Platforms,
+		Permission:  opts.Permission,
+		Comment:     opts.Comment,
+	}
+
+	if opts.File != "" {
+		content, err := os.ReadFile(opts.File)
+		if err != nil {
+			return fmt.Errorf("unable to read script: %v", err)
+		}
+		spec.Content = string(content)
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	script, err := rtr.FindScript(ctx, c, opts.Script)
+	if err != nil {
+		return err
+	}
+
+	if err := rtr.UpdateScript(ctx, c, script.ID, spec); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Updated script %s\n", script.Name)
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package upload
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/rtr/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/rtr"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Upload a custom script`
+	longDesc  = templates.LongDesc(`
+		Upload a file as a new custom script.
+
+		The script is named after the file unless --name is given. When
+		--platform is not given, the platform is derived from the file
+		extension: .ps1 scripts run on Windows, .sh and .bash scripts on Linux
+		and macOS and .zsh scripts on macOS.
+
+		The permission controls who can run the script: private scripts only
+		by you, group scripts by RTR administrators and public scripts by
+		active responders as well.`)
+	examples = templates.Examples(`
+		# Upload a PowerShell script
+		falcon rtr scripts upload ./collect.ps1 --description "Collect triage data"
+
+		# Upload a script usable by all active responders
+		falcon rtr scripts upload ./triage.sh --permission public
+	`)
+)
+
+type UploadOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	File        string
+	Name        string
+	Description string
+	Platforms   []string
+	Permission  string
+	Comment     string
+}
+
+// NewCmdUpload represents the rtr scripts upload command
+func NewCmdUpload(f *factory.Factory) *cobra.Command {
+	opts := &UploadOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "upload <file>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"create"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.File = args[0]
+
+			if err := utils.ValidateOneOf("platform", rtr.Platforms, opts.Platforms...); err != nil {
+				return err
+			}
+
+			if err := utils.ValidateOneOf("permission", rtr.Permissions, opts.Permission); err != nil {
+				return err
+			}
+
+			return uploadRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Name of the script (default the file name)")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of the script")
+	cmd.Flags().StringSliceVar(&opts.Platforms, "platform", nil, "Platforms the script runs on: windows, mac, linux (default from the file extension)")
+	cmd.Flags().StringVar(&opts.Permission, "permission", "private", "Who can run the script: private, group or public")
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Comment for the audit log")
+
+	return cmd
+}
+
+func uploadRun(ctx context.Context, opts *UploadOptions) error {
+	content, err := os.ReadFile(opts.File)
+	if err != nil {
+		return fmt.Errorf("unable to read script: %v", err)
+	}
+
+	spec := &rtr.ScriptSpec{
+		Name:        opts.Name,
+		Description: opts.Description,
+		Content:     string(content),
+		Platforms:   opts.Platforms,
+		Permission:  opts.Permission,
+		Comment:     opts.Comment,
+	}
+	if spec.Name == "" {
+		spec.Name = filepath.Base(opts.File)
+	}
+	if len(spec.Platforms) == 0 {
+		spec.Platforms = shared.ScriptPlatforms(spec.Name)
+	}
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	if err := rtr.CreateScript(ctx, c, spec); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.Out, "Uploaded script %s\n", spec.Name)
+	return nil
+}
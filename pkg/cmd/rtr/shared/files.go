@@ -0,0 +1,62 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package shared
+
+import (
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/rtr"
+)
+
+// scriptPlatforms maps script file extensions to the platforms they run on
+var scriptPlatforms = map[string][]string{
+	".ps1":  {"windows"},
+	".sh":   {"linux", "mac"},
+	".bash": {"linux", "mac"},
+	".zsh":  {"mac"},
+}
+
+// ScriptPlatforms returns the platforms a script runs on based on its file
+// extension, or nil when the extension is not known
+func ScriptPlatforms(name string) []string {
+	return scriptPlatforms[strings.ToLower(filepath.Ext(name))]
+}
+
+// PrintCloudFiles writes a list of scripts or put-files in the requested format
+func PrintCloudFiles(w io.Writer, format string, files []*rtr.CloudFile) error {
+	return output.Print(w, format, files, func(t *output.Table) {
+		t.SetHeaders("NAME", "ID", "PLATFORM", "PERMISSION", "SIZE", "MODIFIED", "DESCRIPTION")
+		for _, f := range files {
+			t.AddRow(
+				f.Name,
+				f.ID,
+				strings.Join(f.Platform, ","),
+				f.PermissionType,
+				strconv.FormatInt(f.Size, 10),
+				output.Time(f.ModifiedTimestamp),
+				f.Description,
+			)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package shared
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+)
+
+// HostFlags are the flags selecting the hosts of a batch command
+type HostFlags struct {
+	Hosts     []string
+	HostsFile string
+}
+
+// AddHostFlags registers the --host and --hosts-file flags on a command
+func AddHostFlags(cmd *cobra.Command, flags *HostFlags) {
+	cmd.Flags().StringSliceVar(&flags.Hosts, "host", nil, "Hostname or device ID of a target host (repeatable)")
+	cmd.Flags().StringVar(&flags.HostsFile, "hosts-file", "", `File with one hostname or device ID per line, or "-" for standard input`)
+}
+
+// ReadHosts returns the hosts given with --host and read from --hosts-file.
+// Blank lines and lines starting with # in the hosts file are ignored.
+func ReadHosts(flags *HostFlags, in io.Reader) ([]string, error) {
+	names := append([]string{}, flags.Hosts...)
+
+	if flags.HostsFile != "" {
+		r := in
+		if flags.HostsFile != "-" {
+			file, err := os.Open(flags.HostsFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read hosts file: %v", err)
+			}
+			defer file.Close()
+			r = file
+		}
+
+		lines, err := utils.ReadLines(r)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read hosts file: %v", err)
+		}
+		names = append(names, lines...)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no hosts given, use --host or --hosts-file")
+	}
+
+	return names, nil
+}
+
+// Targets are the hosts of a batch command resolved to device IDs
+type Targets struct {
+	// DeviceIDs are the unique device IDs of the hosts that were found
+	DeviceIDs []string
+	// Names maps each device ID to the name it was first given as
+	Names map[string]string
+	// Missing are the hosts that could not be found
+	Missing []string
+}
+
+// ResolveTargets resolves host names to device IDs. Hosts that cannot be found
+// are logged and returned as missing rather than failing the command.
+func ResolveTargets(ctx context.Context, c *client.CrowdStrikeAPISpecification, names []string) (*Targets, error) {
+	resolved, err := hosts.ResolveDeviceIDs(ctx, c, names)
+	if resolved == nil {
+		return nil, err
+	}
+	if err != nil {
+		log.Warn(err)
+	}
+
+	t := &Targets{Names: map[string]string{}, Missing: []string{}}
+	for _, name := range names {
+		id, ok := resolved[name]
+		if !ok {
+			t.Missing = append(t.Missing, name)
+			continue
+		}
+
+		// several names may refer to the same device, which is only used once
+		if _, ok := t.Names[id]; !ok {
+			t.Names[id] = name
+			t.DeviceIDs = append(t.DeviceIDs, id)
+		}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/sandbox/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/sandbox"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Show the report of a sandbox submission`
+	longDesc  = templates.LongDesc(`
+		Show the verdict and threat score of a sandbox submission, given by
+		the ID printed by "falcon sandbox submit", and optionally save the
+		full report, IOCs and network capture.
+
+		Use -o json for the full report. An error is returned while the
+		analysis is running unless --wait is given.`)
+	examples = templates.Examples(`
+		# Show the verdict of a submission
+		falcon sandbox report <id>
+
+		# Wait for the analysis and save every file of the report
+		falcon sandbox report <id> --wait --download report,iocs,pcap --dir reports
+	`)
+)
+
+type ReportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	ID       string
+	Wait     bool
+	Timeout  time.Duration
+	Download shared.DownloadFlags
+	Format   string
+}
+
+// NewCmdReport represents the sandbox report command
+func NewCmdReport(f *factory.Factory) *cobra.Command {
+	opts := &ReportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "report <id>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if err := opts.Download.Validate(); err != nil {
+				return err
+			}
+
+			opts.ID = args[0]
+			return reportRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Wait, "wait", false, "Wait for the analysis to complete")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 30*time.Minute, "Maximum time to wait for the analysis")
+	shared.AddDownloadFlags(cmd, &opts.Download)
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func reportRun(ctx context.Context, opts *ReportOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	s, err := sandbox.GetSubmission(ctx, c, opts.ID)
+	if err != nil {
+		return err
+	}
+
+	if !sandbox.Done(s) {
+		if !opts.Wait {
+			return fmt.Errorf("submission %s is %s, use --wait to wait for it to complete", opts.ID, s.State)
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+
+		spinner := opts.IO.StartSpinner(fmt.Sprintf("Waiting for %s (%s)", opts.ID, s.State))
+		_, err = sandbox.Wait(waitCtx, c, opts.ID, func(state string) {
+			spinner.SetLabel(fmt.Sprintf("Waiting for %s (%s)", opts.ID, state))
+		})
+		spinner.Stop()
+		if err != nil && waitCtx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("the analysis did not complete within %s", opts.Timeout)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	r, err := sandbox.GetReport(ctx, c, opts.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := shared.PrintReport(opts.IO.Out, opts.Format, r); err != nil {
+		return err
+	}
+
+	return shared.Download(ctx, c, opts.IO.ErrOut, r, &opts.Download)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sandbox
+
+import (
+	reportCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/sandbox/report"
+	submitCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/sandbox/submit"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Detonate samples in the Falcon sandbox`
+	longDesc  = templates.LongDesc(`
+		Submit files and URLs to the Falcon sandbox for detonation and
+		retrieve the verdict, threat score, IOCs and network capture of the
+		analysis.`)
+	examples = templates.Examples(`
+		# Detonate a file and wait for the verdict
+		falcon sandbox submit invoice.doc
+
+		# Save the report and IOCs of an earlier submission
+		falcon sandbox report <id> --download report,iocs
+	`)
+)
+
+// NewSandboxCmd represents the sandbox command
+func NewSandboxCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sandbox <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		submitCmd.NewCmdSubmit(f),
+		reportCmd.NewCmdReport(f),
+	)
+	return cmd
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/sandbox"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+)
+
+// DownloadFlags holds the flags selecting the files saved from a report
+type DownloadFlags struct {
+	Kinds []string
+	Dir   string
+}
+
+// AddDownloadFlags adds the flags selecting the files saved from a report
+// to cmd
+func AddDownloadFlags(cmd *cobra.Command, flags *DownloadFlags) {
+	cmd.Flags().StringSliceVar(&flags.Kinds, "download", nil, fmt.Sprintf("Save files of the report: %s", strings.Join(sandbox.ArtifactKinds, ", ")))
+	cmd.Flags().StringVar(&flags.Dir, "dir", ".", "Directory to save the files of the report in")
+}
+
+// Validate checks the kinds of files to save
+func (f *DownloadFlags) Validate() error {
+	kinds := []string{}
+	for _, k := range f.Kinds {
+		kinds = append(kinds, strings.ToLower(k))
+	}
+	return utils.ValidateOneOf("download", sandbox.ArtifactKinds, kinds...)
+}
+
+// PrintReport writes the verdict and threat score of a report to w in the
+// given output format, with one row per environment
+func PrintReport(w io.Writer, format string, r *sandbox.Report) error {
+	return output.Print(w, format, r, func(t *output.Table) {
+		t.SetHeaders("ID", "VERDICT", "ENVIRONMENT", "THREAT SCORE", "FILE TYPE", "SUBMITTED", "ERROR")
+		for _, s := range r.Sandbox {
+			submitted := s.SubmitName
+			if s.SubmitURL != "" {
+				submitted = s.SubmitURL
+			}
+			verdict := s.Verdict
+			if verdict == "" {
+				verdict = r.Verdict
+			}
+			t.AddRow(
+				r.ID,
+				verdict,
+				sandbox.EnvironmentName(s.EnvironmentID),
+				strconv.Itoa(int(s.ThreatScore)),
+				s.FileType,
+				submitted,
+				s.ErrorMessage,
+			)
+		}
+	})
+}
+
+// Download saves the files of a report selected by flags, the report
+// itself as JSON and artifacts as returned by the API. The saved files are
+// listed on errOut.
+func Download(ctx context.Context, c *client.CrowdStrikeAPISpecification, errOut io.Writer, r *sandbox.Report, flags *DownloadFlags) error {
+	if len(flags.Kinds) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(flags.Dir, 0700); err != nil {
+		return err
+	}
+
+	for _, k := range flags.Kinds {
+		if strings.EqualFold(k, sandbox.ArtifactReport) {
+			path := filepath.Join(flags.Dir, r.ID+"-report.json")
+			if err := writeReport(path, r); err != nil {
+				return err
+			}
+			fmt.Fprintf(errOut, "Saved the report to %s\n", path)
+		}
+	}
+
+	artifacts := sandbox.Artifacts(r, flags.Kinds)
+	for _, a := range artifacts {
+		path := filepath.Join(flags.Dir, a.Name)
+		if err := downloadArtifact(ctx, c, a, path); err != nil {
+			return err
+		}
+		fmt.Fprintf(errOut, "Saved the %s to %s\n", a.Kind, path)
+	}
+
+	return nil
+}
+
+func writeReport(path string, r *sandbox.Report) error {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func downloadArtifact(ctx context.Context, c *client.CrowdStrikeAPISpecification, a sandbox.Artifact, path string) error {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := sandbox.DownloadArtifact(ctx, c, a, f); err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
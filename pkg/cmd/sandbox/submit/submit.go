@@ -0,0 +1,237 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package submit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/sandbox/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/sandbox"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Detonate a file or URL in the sandbox`
+	longDesc  = templates.LongDesc(`
+		Upload a sample and submit it for detonation in a sandbox
+		environment, or submit a URL, then wait for the analysis to complete
+		and print the verdict and threat score.
+
+		A SHA256 hash submits a sample uploaded before. With --wait=false
+		only the submission ID is printed, and the report is retrieved later
+		with "falcon sandbox report". Analyses usually take several minutes.`)
+	examples = templates.Examples(`
+		# Detonate a file on Windows 10 and save the IOCs and network capture
+		falcon sandbox submit invoice.doc --download iocs,pcap --dir reports
+
+		# Detonate a URL without network access to the internet
+		falcon sandbox submit https://example.com/payload --network simulated
+
+		# Submit without waiting and fetch the report later
+		id=$(falcon sandbox submit sample.exe --environment win7 --wait=false)
+		falcon sandbox report "$id" --wait
+	`)
+)
+
+var sha256Regex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+type SubmitOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Target       string
+	Environment  string
+	Network      string
+	ActionScript string
+	CommandLine  string
+	Password     string
+	Name         string
+	Tags         []string
+	Comment      string
+	Confidential bool
+	Wait         bool
+	Timeout      time.Duration
+	Download     shared.DownloadFlags
+	Format       string
+
+	environmentID int32
+}
+
+// NewCmdSubmit represents the sandbox submit command
+func NewCmdSubmit(f *factory.Factory) *cobra.Command {
+	opts := &SubmitOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "submit <file|url|sha256>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			id, err := sandbox.ParseEnvironment(opts.Environment)
+			if err != nil {
+				return err
+			}
+			opts.environmentID = id
+
+			if err := utils.ValidateOneOf("network", sandbox.NetworkSettings, opts.Network); err != nil {
+				return err
+			}
+			if opts.ActionScript != "" {
+				if err := utils.ValidateOneOf("action script", sandbox.ActionScripts, opts.ActionScript); err != nil {
+					return err
+				}
+			}
+
+			if err := opts.Download.Validate(); err != nil {
+				return err
+			}
+			if !opts.Wait && len(opts.Download.Kinds) > 0 {
+				return fmt.Errorf("--download cannot be used with --wait=false")
+			}
+
+			opts.Target = args[0]
+			return submitRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Environment, "environment", "e", "win10", fmt.Sprintf("Sandbox environment: %s", environmentNames()))
+	cmd.Flags().StringVar(&opts.Network, "network", "default", fmt.Sprintf("Network settings: %s", strings.Join(sandbox.NetworkSettings, ", ")))
+	cmd.Flags().StringVar(&opts.ActionScript, "action-script", "", fmt.Sprintf("Runtime script: %s", strings.Join(sandbox.ActionScripts, ", ")))
+	cmd.Flags().StringVar(&opts.CommandLine, "command-line", "", "Command line passed to the sample")
+	cmd.Flags().StringVar(&opts.Password, "password", "", "Password of the document")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Name of the sample used to detect its file type, defaults to the file name")
+	cmd.Flags().StringSliceVar(&opts.Tags, "tag", nil, "Tag the submission (repeatable)")
+	cmd.Flags().StringVar(&opts.Comment, "comment", "", "Comment stored with the uploaded sample")
+	cmd.Flags().BoolVar(&opts.Confidential, "confidential", true, "Keep the uploaded sample private to your tenant")
+	cmd.Flags().BoolVar(&opts.Wait, "wait", true, "Wait for the analysis to complete")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 30*time.Minute, "Maximum time to wait for the analysis")
+	shared.AddDownloadFlags(cmd, &opts.Download)
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func environmentNames() string {
+	names := []string{}
+	for _, e := range sandbox.Environments {
+		names = append(names, fmt.Sprintf("%s (%s)", e.Name, e.Description))
+	}
+	return strings.Join(names, ", ")
+}
+
+func submitRun(ctx context.Context, opts *SubmitOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	params := &models.FalconxSandboxParametersV1{
+		EnvironmentID:    opts.environmentID,
+		NetworkSettings:  opts.Network,
+		ActionScript:     opts.ActionScript,
+		CommandLine:      opts.CommandLine,
+		DocumentPassword: opts.Password,
+		SubmitName:       opts.Name,
+	}
+
+	switch {
+	case sandbox.IsURL(opts.Target):
+		params.URL = opts.Target
+	case sha256Regex.MatchString(opts.Target) && !exists(opts.Target):
+		params.Sha256 = strings.ToLower(opts.Target)
+	default:
+		spinner := opts.IO.StartSpinner(fmt.Sprintf("Uploading %s", opts.Target))
+		sha256, err := sandbox.Upload(ctx, c, opts.Target, opts.Comment, opts.Confidential)
+		spinner.Stop()
+		if err != nil {
+			return err
+		}
+		params.Sha256 = sha256
+		if params.SubmitName == "" {
+			params.SubmitName = filepath.Base(opts.Target)
+		}
+	}
+
+	s, err := sandbox.Submit(ctx, c, params, opts.Tags)
+	if err != nil {
+		return err
+	}
+
+	if !opts.Wait {
+		fmt.Fprintln(opts.IO.Out, s.ID)
+		return nil
+	}
+	fmt.Fprintf(opts.IO.ErrOut, "Submitted %s as %s\n", opts.Target, s.ID)
+
+	waitCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	id := s.ID
+	env := sandbox.EnvironmentName(opts.environmentID)
+	spinner := opts.IO.StartSpinner(fmt.Sprintf("Detonating %s in %s", opts.Target, env))
+	_, err = sandbox.Wait(waitCtx, c, id, func(state string) {
+		spinner.SetLabel(fmt.Sprintf("Detonating %s in %s (%s)", opts.Target, env, state))
+	})
+	spinner.Stop()
+	if err != nil && waitCtx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("the analysis did not complete within %s, run \"falcon sandbox report %s --wait\" later", opts.Timeout, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	r, err := sandbox.GetReport(ctx, c, id)
+	if err != nil {
+		return err
+	}
+
+	if err := shared.PrintReport(opts.IO.Out, opts.Format, r); err != nil {
+		return err
+	}
+
+	return shared.Download(ctx, c, opts.IO.ErrOut, r, &opts.Download)
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
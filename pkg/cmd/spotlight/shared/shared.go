@@ -0,0 +1,42 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/spotlight"
+	"github.com/spf13/cobra"
+)
+
+// DefaultSort orders vulnerabilities by when they were last updated
+const DefaultSort = "updated_timestamp|desc"
+
+// AddQueryFlags registers the flags selecting vulnerabilities
+func AddQueryFlags(cmd *cobra.Command, q *spotlight.Query) {
+	cmd.Flags().StringSliceVar(&q.CVEs, "cve", nil, "Only include these CVEs, e.g. CVE-2021-44228")
+	cmd.Flags().StringSliceVar(&q.Hosts, "host", nil, "Only include these hosts, given as hostnames or device IDs")
+	cmd.Flags().StringSliceVar(&q.Products, "product", nil, "Only include these products, given as name and version, e.g. 'Google Chrome 108.0.5359.125'")
+	cmd.Flags().StringSliceVar(&q.Severities, "severity", nil, fmt.Sprintf("Only include these CVSS severities: %s", strings.Join(spotlight.Severities, ", ")))
+	cmd.Flags().StringSliceVar(&q.ExPRTRatings, "exprt", nil, fmt.Sprintf("Only include these ExPRT ratings: %s", strings.Join(spotlight.ExPRTRatings, ", ")))
+	cmd.Flags().StringSliceVar(&q.Statuses, "status", []string{"open", "reopen"}, fmt.Sprintf("Only include these statuses: %s", strings.Join(spotlight.Statuses, ", ")))
+	cmd.Flags().StringVar(&q.Filter, "filter", "", "Filter vulnerabilities using a Falcon Query Language (FQL) expression")
+}
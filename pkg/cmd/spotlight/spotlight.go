@@ -0,0 +1,53 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package spotlight
+
+import (
+	vulnsCmd "github.com/crowdstrike/falcon-cli/pkg/cmd/spotlight/vulns"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Query Falcon Spotlight vulnerability data`
+	longDesc  = templates.LongDesc(`
+		Query the vulnerabilities that Falcon Spotlight found on your hosts.`)
+	examples = templates.Examples(`
+		# List the open vulnerabilities of a host
+		falcon spotlight vulns list --host WIN-DC01
+
+		# Export the critical ExPRT rated vulnerabilities to a CSV file
+		falcon spotlight vulns export --exprt critical --file vulns.csv
+	`)
+)
+
+// NewSpotlightCmd represents the spotlight command
+func NewSpotlightCmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "spotlight <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(vulnsCmd.NewCmdVulns(f))
+	return cmd
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/spotlight/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/spotlight"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+const formatCSV = "csv"
+
+var (
+	shortDesc = `Export vulnerabilities to CSV or NDJSON`
+	longDesc  = templates.LongDesc(`
+		Export every vulnerability matching the given criteria as CSV or as
+		newline delimited JSON (NDJSON).
+
+		Results are written page by page as they are received, so exports of
+		millions of vulnerabilities do not need to fit in memory. The CSV
+		export flattens each vulnerability to one row with its host, CVE,
+		product and remediations; the NDJSON export keeps every field.`)
+	examples = templates.Examples(`
+		# Export the open vulnerabilities to a CSV file
+		falcon spotlight vulns export --file vulns.csv
+
+		# Export the vulnerabilities closed in the last week as NDJSON
+		falcon spotlight vulns export --status closed --filter "closed_timestamp:>'2026-10-12'" --format ndjson
+	`)
+)
+
+type ExportOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Query      spotlight.Query
+	Sort       string
+	FileFormat string
+	File       string
+}
+
+// NewCmdExport represents the spotlight vulns export command
+func NewCmdExport(f *factory.Factory) *cobra.Command {
+	opts := &ExportOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "export",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateOneOf("format", []string{formatCSV, output.FormatNDJSON}, opts.FileFormat); err != nil {
+				return err
+			}
+
+			if err := opts.Query.Validate(); err != nil {
+				return err
+			}
+
+			return exportRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddQueryFlags(cmd, &opts.Query)
+	cmd.Flags().StringVar(&opts.Sort, "sort", shared.DefaultSort, "Sort vulnerabilities by a field, e.g. created_timestamp|asc")
+	cmd.Flags().StringVar(&opts.FileFormat, "format", formatCSV, "Format of the export: csv or ndjson")
+	cmd.Flags().StringVar(&opts.File, "file", "", "Write the export to a file instead of standard output")
+
+	return cmd
+}
+
+func exportRun(ctx context.Context, opts *ExportOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = opts.IO.Out
+	if opts.File != "" {
+		f, err := os.Create(opts.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	count := 0
+	write := func(page []*spotlight.Vulnerability) error {
+		for _, v := range page {
+			if err := output.PrintNDJSON(w, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var csvWriter *spotlight.CSVWriter
+	if opts.FileFormat == formatCSV {
+		csvWriter = spotlight.NewCSVWriter(w)
+		write = csvWriter.Write
+	}
+
+	err = spotlight.Iterate(ctx, c, opts.Query.FQL(), opts.Sort, 0, func(page []*spotlight.Vulnerability) error {
+		count += len(page)
+		return write(page)
+	})
+	if err != nil {
+		return err
+	}
+
+	if csvWriter != nil {
+		if err := csvWriter.Close(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "Exported %d vulnerabilities\n", count)
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/spotlight/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/spotlight"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `List vulnerabilities`
+	longDesc  = templates.LongDesc(`
+		List the vulnerabilities matching the given criteria.
+
+		For large result sets use "falcon spotlight vulns export", which
+		streams the results instead of holding them in memory.`)
+	examples = templates.Examples(`
+		# List the open vulnerabilities of a host
+		falcon spotlight vulns list --host WIN-DC01
+
+		# List the hosts affected by a CVE, including closed vulnerabilities
+		falcon spotlight vulns list --cve CVE-2021-44228 --status open,reopen,closed --all
+
+		# List the high and critical ExPRT rated vulnerabilities as JSON
+		falcon spotlight vulns list --exprt high,critical -o json
+	`)
+)
+
+type ListOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Query  spotlight.Query
+	Sort   string
+	Limit  int
+	All    bool
+	Format string
+}
+
+// NewCmdList represents the spotlight vulns list command
+func NewCmdList(f *factory.Factory) *cobra.Command {
+	opts := &ListOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+
+			if err := opts.Query.Validate(); err != nil {
+				return err
+			}
+
+			if opts.Limit < 1 {
+				return fmt.Errorf("--limit must be greater than 0")
+			}
+
+			return listRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddQueryFlags(cmd, &opts.Query)
+	cmd.Flags().StringVar(&opts.Sort, "sort", shared.DefaultSort, "Sort vulnerabilities by a field, e.g. created_timestamp|asc")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 100, "Maximum number of vulnerabilities to return")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Return all matching vulnerabilities, ignoring --limit")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func listRun(ctx context.Context, opts *ListOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	limit := opts.Limit
+	if opts.All {
+		limit = 0
+	}
+
+	list := []*spotlight.Vulnerability{}
+	err = spotlight.Iterate(ctx, c, opts.Query.FQL(), opts.Sort, limit, func(page []*spotlight.Vulnerability) error {
+		list = append(list, page...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return output.Print(opts.IO.Out, opts.Format, list, func(t *output.Table) {
+		t.SetHeaders("HOST", "CVE", "SEVERITY", "SCORE", "EXPRT", "PRODUCT", "STATUS", "CREATED")
+		for _, v := range list {
+			var hostname, cve, severity, score, exprt string
+			if v.HostInfo != nil {
+				hostname = utils.Deref(v.HostInfo.Hostname)
+			}
+			if v.Cve != nil {
+				cve = utils.Deref(v.Cve.ID)
+				severity = v.Cve.Severity
+				score = fmt.Sprintf("%.1f", v.Cve.BaseScore)
+				exprt = v.Cve.ExprtRating
+			}
+			t.AddRow(
+				hostname,
+				cve,
+				severity,
+				score,
+				exprt,
+				spotlight.Product(v),
+				utils.Deref(v.Status),
+				utils.Deref(v.CreatedTimestamp),
+			)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"syscall"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/stream"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var appIDRegex = regexp.MustCompile(`^[a-zA-Z0-9]{1,32}$`)
+
+var (
+	shortDesc = `Consume the Falcon event streams`
+	longDesc  = templates.LongDesc(`
+		Consume the event streams of the Falcon platform, such as
+		detections, authentication and audit events, and forward every
+		event to the sinks given with --sink, standard output by default.
+
+		Sinks are given as:
+
+		    stdout                       newline delimited JSON (NDJSON)
+		    file:<path>                  NDJSON appended to a file
+		    syslog+udp://<host>:<port>   RFC 5424 syslog over UDP
+		    syslog+tcp://<host>:<port>   RFC 5424 syslog over TCP
+		    syslog+tls://<host>:<port>   RFC 5424 syslog over TLS
+		    https://<url>                batches of NDJSON posted to a URL
+
+		Files are rotated once they reach --file-max-size or once they are
+		older than --file-max-age. Syslog messages carry the event type as
+		message ID and the event as JSON. HTTP batches are posted once they
+		hold --http-batch-size events or every --http-batch-wait, and
+		failed posts are retried with a growing delay.
+
+		Use --event-type to only forward some types of events, such as
+		DetectionSummaryEvent or AuthActivityAuditEvent.
+
+		The streams are discovered for the app ID given with --app-id,
+		which names the consumer and must be unique to each running
+		consumer. Sessions are refreshed before they expire and dropped
+		connections are retried with a new session.
+
+		The offset of the last event forwarded is saved to --offset-file,
+		by default a file named after the app ID in $HOME/.falcon/streams,
+		and the consumer resumes after it when restarted. Events are delivered to every sink
+		before their offset is saved, so an event may be forwarded twice
+		after a crash but is never lost.
+
+		The consumer runs until interrupted. The API client needs the
+		Event streams read scope.`)
+	examples = templates.Examples(`
+		# Print the events of the stream
+		falcon stream
+
+		# Forward the detections of a site to a syslog server over TLS
+		falcon stream --app-id site42 --sink syslog+tls://siem.example.com:6514 --event-type DetectionSummaryEvent
+
+		# Post the events to a webhook and keep a week of daily files
+		falcon stream --sink https://hooks.example.com/falcon --http-header "Authorization: Bearer $TOKEN" --sink file:/var/log/falcon/events.ndjson --file-max-age 24h --file-max-backups 7
+	`)
+)
+
+type StreamOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	AppID       string
+	Sinks       []string
+	File        string
+	EventTypes  []string
+	OffsetFile  string
+	MaxSize     string
+	SinkOptions stream.SinkOptions
+}
+
+// NewCmdStream represents the stream command
+func NewCmdStream(f *factory.Factory) *cobra.Command {
+	opts := &StreamOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "stream",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !appIDRegex.MatchString(opts.AppID) {
+				return fmt.Errorf("invalid app ID %q, must be 1 to 32 letters and digits", opts.AppID)
+			}
+
+			size, err := stream.ParseSize(opts.MaxSize)
+			if err != nil {
+				return err
+			}
+			opts.SinkOptions.MaxSize = size
+
+			if opts.File != "" {
+				opts.Sinks = append(opts.Sinks, "file:"+opts.File)
+			}
+			if len(opts.Sinks) == 0 {
+				opts.Sinks = []string{stream.SchemeStdout}
+			}
+
+			if opts.OffsetFile == "" {
+				home, err := os.UserHomeDir()
+				if err != nil {
+					return err
+				}
+				opts.OffsetFile = filepath.Join(home, ".falcon", "streams", opts.AppID+".json")
+			}
+
+			return streamRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.AppID, "app-id", "falconcli", "Name of the consumer, unique to each running consumer")
+	cmd.Flags().StringArrayVar(&opts.Sinks, "sink", nil, "Sink to forward events to, may be repeated (default stdout)")
+	cmd.Flags().StringVar(&opts.File, "file", "", "Append the events to a file, same as --sink file:<path>")
+	cmd.Flags().StringSliceVar(&opts.EventTypes, "event-type", nil, "Only forward events of these types")
+	cmd.Flags().StringVar(&opts.OffsetFile, "offset-file", "", "File to save the offset of the last event to (default $HOME/.falcon/streams/<app id>.json)")
+
+	cmd.Flags().StringVar(&opts.MaxSize, "file-max-size", "", "Rotate files once they reach a size such as 100MB")
+	cmd.Flags().DurationVar(&opts.SinkOptions.MaxAge, "file-max-age", 0, "Rotate files once they are older than a duration such as 24h")
+	cmd.Flags().IntVar(&opts.SinkOptions.MaxBackups, "file-max-backups", 0, "Maximum number of rotated files to keep, 0 keeps them all")
+
+	cmd.Flags().StringVar(&opts.SinkOptions.AppName, "syslog-app-name", "falcon", "Application name of syslog messages")
+	cmd.Flags().StringVar(&opts.SinkOptions.CAFile, "syslog-ca-file", "", "PEM file of certificate authorities trusted for syslog over TLS")
+	cmd.Flags().BoolVar(&opts.SinkOptions.InsecureSkipVerify, "syslog-insecure", false, "Do not verify the certificate of the syslog server")
+
+	cmd.Flags().StringArrayVar(&opts.SinkOptions.Headers, "http-header", nil, "Header of HTTP posts such as \"Authorization: Bearer <token>\", may be repeated")
+	cmd.Flags().IntVar(&opts.SinkOptions.BatchSize, "http-batch-size", 100, "Maximum number of events per HTTP post")
+	cmd.Flags().DurationVar(&opts.SinkOptions.BatchWait, "http-batch-wait", 5*time.Second, "Maximum time events wait before being posted")
+	cmd.Flags().IntVar(&opts.SinkOptions.Retries, "http-retries", 5, "Number of times failed HTTP posts are retried")
+
+	return cmd
+}
+
+func streamRun(ctx context.Context, opts *StreamOptions) error {
+	offsets, err := stream.LoadOffsets(opts.OffsetFile)
+	if err != nil {
+		return fmt.Errorf("failed to read the offset file: %v", err)
+	}
+
+	sinks := stream.MultiSink{}
+	defer func() {
+		_ = sinks.Close()
+	}()
+	for _, spec := range opts.Sinks {
+		s, err := stream.NewSink(spec, &opts.SinkOptions, opts.IO.Out)
+		if err != nil {
+			return err
+		}
+		sinks = append(sinks, s)
+	}
+	sink := stream.NewFilterSink(sinks, opts.EventTypes)
+
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	count := 0
+	consumer := &stream.Consumer{
+		AppID:   opts.AppID,
+		Client:  c,
+		Offsets: offsets,
+		Handle: func(e *stream.Event) error {
+			count++
+			return sink.Write(e)
+		},
+		Flush: sink.Flush,
+		Logf: func(format string, args ...interface{}) {
+			fmt.Fprintf(opts.IO.ErrOut, format+"\n", args...)
+		},
+	}
+
+	if err := consumer.Run(ctx); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(opts.IO.ErrOut, "Consumed %d events\n", count)
+	return nil
+}
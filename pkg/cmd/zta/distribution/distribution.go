@@ -0,0 +1,135 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package distribution
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/zta/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/falcon-cli/pkg/zta"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Show how the scores of the fleet are spread`
+	longDesc  = templates.LongDesc(`
+		Count the assessed hosts by range of score, from 0 to 100, over
+		every host or the hosts matching --filter. The number of assessed
+		hosts and their average score are written to standard error.
+
+		Use "falcon zta scores --lowest" to list the hosts at the bottom of
+		the distribution.`)
+	examples = templates.Examples(`
+		# Show how the overall scores of the fleet are spread
+		falcon zta distribution
+
+		# Show the sensor configuration scores of Linux hosts by ranges of 20
+		falcon zta distribution --filter "platform_name:'Linux'" --score sensor_config --width 20
+	`)
+)
+
+type DistributionOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Filter string
+	Score  string
+	Width  int32
+	Format string
+}
+
+// NewCmdDistribution represents the zta distribution command
+func NewCmdDistribution(f *factory.Factory) *cobra.Command {
+	opts := &DistributionOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "distribution",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Aliases: []string{"dist"},
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+			if err := utils.ValidateOneOf("--score", zta.ScoreNames, opts.Score); err != nil {
+				return err
+			}
+			if opts.Width < 1 || opts.Width > 100 {
+				return fmt.Errorf("--width must be between 1 and 100")
+			}
+
+			return distributionRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddFilterFlag(cmd, &opts.Filter)
+	shared.AddScoreFlag(cmd, &opts.Score, "Score to count hosts by")
+	cmd.Flags().Int32Var(&opts.Width, "width", 10, "Width of the ranges of scores")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func distributionRun(ctx context.Context, opts *DistributionOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	ids, err := hosts.QueryDeviceIDs(ctx, c, opts.Filter, 0)
+	if err != nil {
+		return err
+	}
+
+	assessments, err := zta.GetAssessments(ctx, c, ids)
+	if err != nil {
+		return err
+	}
+
+	average, n := zta.Average(assessments, opts.Score)
+	fmt.Fprintf(opts.IO.ErrOut, "Assessed %d of %d hosts, average %s score %.1f\n", n, len(ids), opts.Score, average)
+
+	buckets := zta.Distribution(assessments, opts.Score, opts.Width)
+	return output.Print(opts.IO.Out, opts.Format, buckets, func(t *output.Table) {
+		t.SetHeaders("SCORE", "HOSTS", "PERCENT")
+		for _, b := range buckets {
+			t.AddRow(
+				fmt.Sprintf("%d-%d", b.Min, b.Max),
+				strconv.Itoa(b.Hosts),
+				fmt.Sprintf("%.1f%%", b.Percent),
+			)
+		}
+	})
+}
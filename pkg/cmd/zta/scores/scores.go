@@ -0,0 +1,189 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scores
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/zta/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/falcon-cli/pkg/zta"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Show the Zero Trust Assessment scores of hosts`
+	longDesc  = templates.LongDesc(`
+		Show the overall, sensor configuration and operating system scores
+		of hosts, given by hostname or device ID, or of every host matching
+		--filter when none are given. Give "-" to read hosts from standard
+		input.
+
+		Hosts are sorted by the score chosen with --score, lowest first.
+		Use --lowest to only keep the lowest scoring hosts and --below to
+		only keep hosts scoring under a threshold.
+
+		Hosts that have not been assessed, such as hosts with sensors too
+		old to report signals, are left out.`)
+	examples = templates.Examples(`
+		# Show the scores of hosts
+		falcon zta scores web-01 db-01
+
+		# List the 20 hosts of the fleet with the lowest overall score
+		falcon zta scores --lowest 20
+
+		# Export the Windows servers with an OS score under 50
+		falcon zta scores --filter "platform_name:'Windows'+product_type_desc:'Server'" --score os --below 50 -o json
+	`)
+)
+
+type ScoresOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Hosts  []string
+	Filter string
+	Score  string
+	Lowest int
+	Below  int32
+	Format string
+}
+
+// NewCmdScores represents the zta scores command
+func NewCmdScores(f *factory.Factory) *cobra.Command {
+	opts := &ScoresOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "scores [<host>...]",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+			if err := utils.ValidateOneOf("--score", zta.ScoreNames, opts.Score); err != nil {
+				return err
+			}
+			if opts.Lowest < 0 {
+				return fmt.Errorf("--lowest must not be negative")
+			}
+
+			hosts, err := utils.ReadIDs(args, opts.IO.In)
+			if err != nil {
+				return err
+			}
+			opts.Hosts = hosts
+
+			return scoresRun(cmd.Context(), opts)
+		},
+	}
+
+	shared.AddFilterFlag(cmd, &opts.Filter)
+	shared.AddScoreFlag(cmd, &opts.Score, "Score to sort and select hosts by")
+	cmd.Flags().IntVar(&opts.Lowest, "lowest", 0, "Only show this many hosts with the lowest scores")
+	cmd.Flags().Int32Var(&opts.Below, "below", 0, "Only show hosts scoring under this value")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func scoresRun(ctx context.Context, opts *ScoresOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	ids, err := shared.DeviceIDs(ctx, c, opts.Hosts, opts.Filter)
+	if err != nil {
+		return err
+	}
+
+	list, err := zta.Assess(ctx, c, ids)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.Hosts) > 0 {
+		reportUnassessed(opts, ids, list)
+	}
+
+	zta.SortByScore(list, opts.Score)
+
+	if opts.Below > 0 {
+		below := []*zta.HostAssessment{}
+		for _, a := range list {
+			if score, ok := zta.Score(a.Assessment, opts.Score); ok && score < opts.Below {
+				below = append(below, a)
+			}
+		}
+		list = below
+	}
+
+	if opts.Lowest > 0 && len(list) > opts.Lowest {
+		list = list[:opts.Lowest]
+	}
+
+	return output.Print(opts.IO.Out, opts.Format, list, func(t *output.Table) {
+		t.SetHeaders("HOSTNAME", "DEVICE ID", "PLATFORM", "OVERALL", "SENSOR CONFIG", "OS", "MODIFIED")
+		for _, a := range list {
+			t.AddRow(
+				a.Hostname,
+				utils.Deref(a.Aid),
+				utils.Deref(a.EventPlatform),
+				shared.FormatScore(a.Assessment, zta.ScoreOverall),
+				shared.FormatScore(a.Assessment, zta.ScoreSensorConfig),
+				shared.FormatScore(a.Assessment, zta.ScoreOS),
+				output.Time(utils.Deref(a.ModifiedTime)),
+			)
+		}
+	})
+}
+
+// reportUnassessed warns about the hosts given by name that have no
+// assessment
+func reportUnassessed(opts *ScoresOptions, ids []string, list []*zta.HostAssessment) {
+	assessed := map[string]bool{}
+	for _, a := range list {
+		assessed[utils.Deref(a.Aid)] = true
+	}
+
+	missing := []string{}
+	for _, id := range ids {
+		if !assessed[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		fmt.Fprintf(opts.IO.ErrOut, "No assessment for hosts: %s\n", strings.Join(missing, ", "))
+	}
+}
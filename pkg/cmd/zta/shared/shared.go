@@ -0,0 +1,78 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/zta"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+)
+
+// AddFilterFlag adds the flag selecting hosts by FQL filter to cmd
+func AddFilterFlag(cmd *cobra.Command, filter *string) {
+	cmd.Flags().StringVar(filter, "filter", "", "Only include hosts matching an FQL filter on host fields, e.g. platform_name:'Windows'")
+}
+
+// AddScoreFlag adds the flag choosing a score of the assessments to cmd
+func AddScoreFlag(cmd *cobra.Command, score *string, usage string) {
+	cmd.Flags().StringVar(score, "score", zta.ScoreOverall, usage+": overall, sensor_config or os")
+}
+
+// DeviceIDs returns the device IDs of hosts given by hostname or device ID,
+// or of every host matching filter when none are given
+func DeviceIDs(ctx context.Context, c *client.CrowdStrikeAPISpecification, names []string, filter string) ([]string, error) {
+	if len(names) == 0 {
+		return hosts.QueryDeviceIDs(ctx, c, filter, 0)
+	}
+
+	if filter != "" {
+		return nil, fmt.Errorf("--filter cannot be used with hosts")
+	}
+
+	resolved, err := hosts.ResolveDeviceIDs(ctx, c, names)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		if id := resolved[name]; !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// FormatScore writes a score of an assessment, or nothing when not set
+func FormatScore(a *zta.Assessment, name string) string {
+	score, ok := zta.Score(a, name)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatInt(int64(score), 10)
+}
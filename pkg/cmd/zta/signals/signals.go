@@ -0,0 +1,179 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package signals
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/zta/shared"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/falcon-cli/pkg/zta"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Show the signals behind the scores of hosts`
+	longDesc  = templates.LongDesc(`
+		Show the signals making the sensor configuration and operating
+		system scores of hosts, given by hostname or device ID, with
+		whether each host meets their criteria. Give "-" to read hosts
+		from standard input.`)
+	examples = templates.Examples(`
+		# Show the signals a host fails to meet
+		falcon zta signals web-01 --failed
+
+		# Show the operating system signals of hosts
+		falcon zta signals web-01 db-01 --type os
+	`)
+)
+
+// Types of signals
+const (
+	typeSensor = "sensor"
+	typeOS     = "os"
+)
+
+var signalTypes = []string{typeSensor, typeOS}
+
+// met is the value of meets_criteria for signals a host meets
+const met = "yes"
+
+type SignalsOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Hosts  []string
+	Types  []string
+	Failed bool
+	Format string
+}
+
+// hostSignal is a signal of a host
+type hostSignal struct {
+	Hostname string `json:"hostname"`
+	Aid      string `json:"aid"`
+	Type     string `json:"type"`
+	*zta.Signal
+}
+
+// NewCmdSignals represents the zta signals command
+func NewCmdSignals(f *factory.Factory) *cobra.Command {
+	opts := &SignalsOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "signals <host>...",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+			if err := utils.ValidateOneOf("--type", signalTypes, opts.Types...); err != nil {
+				return err
+			}
+
+			hosts, err := utils.ReadIDs(args, opts.IO.In)
+			if err != nil {
+				return err
+			}
+			opts.Hosts = hosts
+
+			return signalsRun(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Types, "type", nil, "Only show signals of these types: sensor or os")
+	cmd.Flags().BoolVar(&opts.Failed, "failed", false, "Only show signals whose criteria are not met")
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func signalsRun(ctx context.Context, opts *SignalsOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	ids, err := shared.DeviceIDs(ctx, c, opts.Hosts, "")
+	if err != nil {
+		return err
+	}
+
+	assessments, err := zta.Assess(ctx, c, ids)
+	if err != nil {
+		return err
+	}
+
+	list := []*hostSignal{}
+	for _, a := range assessments {
+		if a.AssessmentItems == nil {
+			continue
+		}
+		list = append(list, signalsOf(opts, a, typeSensor, a.AssessmentItems.SensorSignals)...)
+		list = append(list, signalsOf(opts, a, typeOS, a.AssessmentItems.OsSignals)...)
+	}
+
+	return output.Print(opts.IO.Out, opts.Format, list, func(t *output.Table) {
+		t.SetHeaders("HOSTNAME", "TYPE", "GROUP", "SIGNAL", "MET", "CRITERIA")
+		for _, s := range list {
+			t.AddRow(
+				s.Hostname,
+				s.Type,
+				utils.Deref(s.GroupName),
+				utils.Deref(s.SignalName),
+				utils.Deref(s.MeetsCriteria),
+				utils.Deref(s.Criteria),
+			)
+		}
+	})
+}
+
+// signalsOf returns the signals of a type of a host selected by the flags
+func signalsOf(opts *SignalsOptions, a *zta.HostAssessment, signalType string, signals []*zta.Signal) []*hostSignal {
+	wanted := len(opts.Types) == 0
+	for _, t := range opts.Types {
+		wanted = wanted || t == signalType
+	}
+	if !wanted {
+		return nil
+	}
+
+	list := []*hostSignal{}
+	for _, s := range signals {
+		if opts.Failed && strings.EqualFold(utils.Deref(s.MeetsCriteria), met) {
+			continue
+		}
+		list = append(list, &hostSignal{Hostname: a.Hostname, Aid: utils.Deref(a.Aid), Type: signalType, Signal: s})
+	}
+	return list
+}
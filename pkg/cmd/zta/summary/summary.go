@@ -0,0 +1,121 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package summary
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/crowdstrike/falcon-cli/pkg/iostreams"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/falcon-cli/pkg/zta"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Show the average scores of the fleet by platform`
+	longDesc  = templates.LongDesc(`
+		Show the number of assessed hosts and their average overall score,
+		for the whole customer and by platform, as computed by the Zero
+		Trust Assessment service.
+
+		The JSON and YAML formats also include the share of hosts meeting
+		each signal by platform.`)
+	examples = templates.Examples(`
+		# Show the average scores of the fleet
+		falcon zta summary
+
+		# Keep the monthly compliance figures
+		falcon zta summary -o json > zta-$(date +%Y-%m).json
+	`)
+)
+
+type SummaryOptions struct {
+	IO           *iostreams.IOStreams
+	FalconClient func() (*client.CrowdStrikeAPISpecification, error)
+
+	Format string
+}
+
+// NewCmdSummary represents the zta summary command
+func NewCmdSummary(f *factory.Factory) *cobra.Command {
+	opts := &SummaryOptions{
+		IO:           f.IOStreams,
+		FalconClient: f.FalconClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "summary",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := output.ValidateFormat(opts.Format); err != nil {
+				return err
+			}
+			return summaryRun(cmd.Context(), opts)
+		},
+	}
+
+	output.AddFormatFlag(cmd, &opts.Format)
+
+	return cmd
+}
+
+func summaryRun(ctx context.Context, opts *SummaryOptions) error {
+	c, err := opts.FalconClient()
+	if err != nil {
+		return err
+	}
+
+	compliance, err := zta.GetCompliance(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	platforms := compliance.Platforms
+	sort.SliceStable(platforms, func(i, j int) bool {
+		return utils.Deref(platforms[i].Name) < utils.Deref(platforms[j].Name)
+	})
+
+	return output.Print(opts.IO.Out, opts.Format, compliance, func(t *output.Table) {
+		t.SetHeaders("PLATFORM", "HOSTS", "AVERAGE SCORE")
+		for _, p := range platforms {
+			t.AddRow(
+				utils.Deref(p.Name),
+				strconv.FormatInt(utils.Deref(p.NumAids), 10),
+				fmt.Sprintf("%.1f", utils.Deref(p.AverageOverallScore)),
+			)
+		}
+		t.AddRow(
+			"(all)",
+			strconv.FormatInt(utils.Deref(compliance.NumAids), 10),
+			fmt.Sprintf("%.1f", utils.Deref(compliance.AverageOverallScore)),
+		)
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package zta
+
+import (
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/zta/distribution"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/zta/scores"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/zta/signals"
+	"github.com/crowdstrike/falcon-cli/pkg/cmd/zta/summary"
+	"github.com/crowdstrike/falcon-cli/pkg/factory"
+	"github.com/spf13/cobra"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	shortDesc = `Report Zero Trust Assessment scores`
+	longDesc  = templates.LongDesc(`
+		Report the Zero Trust Assessment (ZTA) scores of hosts.
+
+		Each assessed host has a sensor configuration score, an operating
+		system configuration score and an overall score made of both, from
+		0 to 100. The scores come from signals, checks on the settings of
+		the sensor and of the operating system, that a host meets or not.`)
+	examples = templates.Examples(`
+		# Show the scores of hosts
+		falcon zta scores web-01 db-01
+
+		# List the 20 lowest scoring Windows hosts
+		falcon zta scores --filter "platform_name:'Windows'" --lowest 20
+
+		# Show how the overall scores of the fleet are spread
+		falcon zta distribution
+
+		# Show the signals a host fails to meet
+		falcon zta signals web-01 --failed
+	`)
+)
+
+// NewZTACmd represents the zta command
+func NewZTACmd(f *factory.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "zta <command>",
+		Short:   shortDesc,
+		Long:    longDesc,
+		Example: examples,
+	}
+
+	cmd.AddCommand(
+		scores.NewCmdScores(f),
+		signals.NewCmdSignals(f),
+		distribution.NewCmdDistribution(f),
+		summary.NewCmdSummary(f),
+	)
+	return cmd
+}
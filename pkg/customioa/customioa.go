@@ -0,0 +1,565 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package customioa manages custom IOA rule groups and rules and converts
+// rules to and from YAML specifications.
+package customioa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/custom_ioa"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/strfmt"
+)
+
+// ErrNotFound is returned when a rule group, rule or rule type does not
+// exist
+var ErrNotFound = errors.New("not found")
+
+// maxPerQuery is the maximum number of IDs returned per query
+const maxPerQuery = 500
+
+// maxPerRequest is the maximum number of resources fetched or deleted per
+// request
+const maxPerRequest = 100
+
+// Platform is a platform rule groups can be created for
+type Platform struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// Disposition is an action a rule of a rule type can take
+type Disposition struct {
+	ID    int32  `json:"id"`
+	Label string `json:"label"`
+}
+
+// RuleType is a kind of rule, such as process creation, with the fields its
+// rules match on
+type RuleType struct {
+	ID           string        `json:"id"`
+	Name         string        `json:"name"`
+	Platform     string        `json:"platform"`
+	Description  string        `json:"description"`
+	Fields       []string      `json:"fields"`
+	Dispositions []Disposition `json:"dispositions"`
+}
+
+// Group is a custom IOA rule group
+type Group struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Platform    string          `json:"platform"`
+	Enabled     bool            `json:"enabled"`
+	Version     int64           `json:"version"`
+	Rules       []*Rule         `json:"rules"`
+	ModifiedBy  string          `json:"modified_by"`
+	ModifiedOn  strfmt.DateTime `json:"modified_on"`
+}
+
+// Rule is a rule of a rule group
+type Rule struct {
+	ID            string                     `json:"id"`
+	Name          string                     `json:"name"`
+	Description   string                     `json:"description"`
+	RuleTypeID    string                     `json:"rule_type_id"`
+	RuleTypeName  string                     `json:"rule_type_name"`
+	Severity      string                     `json:"severity"`
+	DispositionID int32                      `json:"disposition_id"`
+	Action        string                     `json:"action"`
+	Enabled       bool                       `json:"enabled"`
+	FieldValues   []*models.DomainFieldValue `json:"field_values"`
+	ModifiedBy    string                     `json:"modified_by"`
+	ModifiedOn    strfmt.DateTime            `json:"modified_on"`
+}
+
+// Platforms returns the platforms rule groups can be created for
+func Platforms(ctx context.Context, c *client.CrowdStrikeAPISpecification) ([]*Platform, error) {
+	ids, err := queryAll(func(offset string, limit int64) (*models.MsaQueryResponse, error) {
+		res, err := c.CustomIoa.QueryPlatformsMixin0(&custom_ioa.QueryPlatformsMixin0Params{
+			Context: ctx,
+			Offset:  &offset,
+			Limit:   &limit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query custom IOA platforms: %s", falcon.ErrorExplain(err))
+		}
+		return res.Payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*Platform{}
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := c.CustomIoa.GetPlatformsMixin0(&custom_ioa.GetPlatformsMixin0Params{
+			Context: ctx,
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get custom IOA platforms: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, p := range res.Payload.Resources {
+			list = append(list, &Platform{ID: utils.Deref(p.ID), Label: utils.Deref(p.Label)})
+		}
+	}
+
+	return list, nil
+}
+
+// RuleTypes returns the rule types of every platform
+func RuleTypes(ctx context.Context, c *client.CrowdStrikeAPISpecification) ([]*RuleType, error) {
+	ids, err := queryAll(func(offset string, limit int64) (*models.MsaQueryResponse, error) {
+		res, err := c.CustomIoa.QueryRuleTypes(&custom_ioa.QueryRuleTypesParams{
+			Context: ctx,
+			Offset:  &offset,
+			Limit:   &limit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query custom IOA rule types: %s", falcon.ErrorExplain(err))
+		}
+		return res.Payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*RuleType{}
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := c.CustomIoa.GetRuleTypes(&custom_ioa.GetRuleTypesParams{
+			Context: ctx,
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get custom IOA rule types: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, t := range res.Payload.Resources {
+			list = append(list, ruleType(t))
+		}
+	}
+
+	return list, nil
+}
+
+func ruleType(t *models.APIRuleTypeV1) *RuleType {
+	rt := &RuleType{
+		ID:          utils.Deref(t.ID),
+		Name:        utils.Deref(t.Name),
+		Platform:    utils.Deref(t.Platform),
+		Description: utils.Deref(t.LongDesc),
+	}
+	for _, f := range t.Fields {
+		rt.Fields = append(rt.Fields, utils.Deref(f.Name))
+	}
+	for _, d := range t.DispositionMap {
+		rt.Dispositions = append(rt.Dispositions, Disposition{ID: utils.Deref(d.ID), Label: utils.Deref(d.Label)})
+	}
+	return rt
+}
+
+// FindRuleType returns the rule type with the given ID, or with the given
+// name on a platform. Names are matched case insensitively.
+func FindRuleType(types []*RuleType, nameOrID, platform string) (*RuleType, error) {
+	for _, t := range types {
+		if t.ID == nameOrID {
+			return t, nil
+		}
+	}
+	for _, t := range types {
+		if strings.EqualFold(t.Name, nameOrID) && (platform == "" || strings.EqualFold(t.Platform, platform)) {
+			return t, nil
+		}
+	}
+	if platform != "" {
+		return nil, fmt.Errorf("rule type %q of platform %s %w", nameOrID, platform, ErrNotFound)
+	}
+	return nil, fmt.Errorf("rule type %q %w", nameOrID, ErrNotFound)
+}
+
+// Disposition returns the action of a rule type with the given label,
+// matched case insensitively
+func (t *RuleType) Disposition(label string) (*Disposition, error) {
+	labels := []string{}
+	for i, d := range t.Dispositions {
+		if strings.EqualFold(d.Label, label) {
+			return &t.Dispositions[i], nil
+		}
+		labels = append(labels, d.Label)
+	}
+	return nil, fmt.Errorf("invalid action %q for rule type %q, must be one of: %s", label, t.Name, strings.Join(labels, ", "))
+}
+
+// ListGroups returns the rule groups matching filter, with their rules
+func ListGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter string) ([]*Group, error) {
+	ids, err := queryAll(func(offset string, limit int64) (*models.MsaQueryResponse, error) {
+		sort := "name.asc"
+		params := &custom_ioa.QueryRuleGroupsMixin0Params{
+			Context: ctx,
+			Offset:  &offset,
+			Limit:   &limit,
+			Sort:    &sort,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := c.CustomIoa.QueryRuleGroupsMixin0(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query custom IOA rule groups: %s", falcon.ErrorExplain(err))
+		}
+		return res.Payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getGroups(ctx, c, ids)
+}
+
+func getGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) ([]*Group, error) {
+	list := []*Group{}
+
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := c.CustomIoa.GetRuleGroupsMixin0(&custom_ioa.GetRuleGroupsMixin0Params{
+			Context: ctx,
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get custom IOA rule groups: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, g := range res.Payload.Resources {
+			list = append(list, group(g))
+		}
+	}
+
+	return list, nil
+}
+
+// FindGroup returns the rule group with the given ID or name. Names are
+// matched case insensitively.
+func FindGroup(ctx context.Context, c *client.CrowdStrikeAPISpecification, nameOrID string) (*Group, error) {
+	list, err := ListGroups(ctx, c, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range list {
+		if g.ID == nameOrID {
+			return g, nil
+		}
+	}
+	for _, g := range list {
+		if strings.EqualFold(g.Name, nameOrID) {
+			return g, nil
+		}
+	}
+
+	return nil, fmt.Errorf("rule group %q %w", nameOrID, ErrNotFound)
+}
+
+// FindRule returns the rule of a group with the given ID or name
+func (g *Group) FindRule(nameOrID string) (*Rule, error) {
+	for _, r := range g.Rules {
+		if r.ID == nameOrID || strings.EqualFold(r.Name, nameOrID) {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("rule %q of rule group %q %w", nameOrID, g.Name, ErrNotFound)
+}
+
+// CreateGroup creates a disabled rule group
+func CreateGroup(ctx context.Context, c *client.CrowdStrikeAPISpecification, name, platform, description, comment string) (*Group, error) {
+	res, err := c.CustomIoa.CreateRuleGroupMixin0(&custom_ioa.CreateRuleGroupMixin0Params{
+		Context: ctx,
+		Body: &models.APIRuleGroupCreateRequestV1{
+			Name:        &name,
+			Platform:    &platform,
+			Description: &description,
+			Comment:     &comment,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create custom IOA rule group: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstGroup(res.Payload)
+}
+
+// SetGroupEnabled enables or disables a rule group
+func SetGroupEnabled(ctx context.Context, c *client.CrowdStrikeAPISpecification, g *Group, enabled bool, comment string) (*Group, error) {
+	res, err := c.CustomIoa.UpdateRuleGroupMixin0(&custom_ioa.UpdateRuleGroupMixin0Params{
+		Context: ctx,
+		Body: &models.APIRuleGroupModifyRequestV1{
+			ID:               &g.ID,
+			Name:             &g.Name,
+			Description:      &g.Description,
+			Enabled:          &enabled,
+			RulegroupVersion: &g.Version,
+			Comment:          &comment,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update custom IOA rule group: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstGroup(res.Payload)
+}
+
+// DeleteGroups deletes rule groups and their rules
+func DeleteGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string, comment string) error {
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := c.CustomIoa.DeleteRuleGroupsMixin0(&custom_ioa.DeleteRuleGroupsMixin0Params{
+			Context: ctx,
+			Ids:     chunk,
+			Comment: utils.OptionalPtr(comment),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete custom IOA rule groups: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateRule adds a rule to a group
+func CreateRule(ctx context.Context, c *client.CrowdStrikeAPISpecification, g *Group, t *RuleType, spec *RuleSpec, comment string) (*Rule, error) {
+	disposition, err := t.Disposition(spec.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.CustomIoa.CreateRule(&custom_ioa.CreateRuleParams{
+		Context: ctx,
+		Body: &models.APIRuleCreateV1{
+			Name:            &spec.Name,
+			Description:     &spec.Description,
+			RulegroupID:     &g.ID,
+			RuletypeID:      &t.ID,
+			PatternSeverity: &spec.Severity,
+			DispositionID:   &disposition.ID,
+			FieldValues:     spec.FieldValues(),
+			Comment:         &comment,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create custom IOA rule: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstRule(res.Payload)
+}
+
+// UpdateRule replaces the settings of a rule with those of spec. The rule
+// type of a rule cannot be changed.
+func UpdateRule(ctx context.Context, c *client.CrowdStrikeAPISpecification, g *Group, r *Rule, t *RuleType, spec *RuleSpec, comment string) (*Rule, error) {
+	if r.RuleTypeID != t.ID {
+		return nil, fmt.Errorf("rule type of rule %q cannot be changed, delete and add it again", r.Name)
+	}
+
+	disposition, err := t.Disposition(spec.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := r.Enabled
+	if spec.Enabled != nil {
+		enabled = *spec.Enabled
+	}
+
+	return updateRules(ctx, c, g, comment, &models.APIRuleUpdateV1{
+		InstanceID:       &r.ID,
+		Name:             &spec.Name,
+		Description:      &spec.Description,
+		PatternSeverity:  &spec.Severity,
+		DispositionID:    &disposition.ID,
+		Enabled:          &enabled,
+		FieldValues:      spec.FieldValues(),
+		RulegroupVersion: &g.Version,
+	})
+}
+
+// SetRulesEnabled enables or disables rules of a group, keeping their other
+// settings
+func SetRulesEnabled(ctx context.Context, c *client.CrowdStrikeAPISpecification, g *Group, rules []*Rule, enabled bool, comment string) error {
+	updates := []*models.APIRuleUpdateV1{}
+	for _, r := range rules {
+		r := r
+		updates = append(updates, &models.APIRuleUpdateV1{
+			InstanceID:       &r.ID,
+			Name:             &r.Name,
+			Description:      &r.Description,
+			PatternSeverity:  &r.Severity,
+			DispositionID:    &r.DispositionID,
+			Enabled:          &enabled,
+			FieldValues:      r.FieldValues,
+			RulegroupVersion: &g.Version,
+		})
+	}
+
+	_, err := updateRules(ctx, c, g, comment, updates...)
+	return err
+}
+
+func updateRules(ctx context.Context, c *client.CrowdStrikeAPISpecification, g *Group, comment string, updates ...*models.APIRuleUpdateV1) (*Rule, error) {
+	res, err := c.CustomIoa.UpdateRules(&custom_ioa.UpdateRulesParams{
+		Context: ctx,
+		Body: &models.APIRuleUpdatesRequestV1{
+			RulegroupID:      &g.ID,
+			RulegroupVersion: &g.Version,
+			RuleUpdates:      updates,
+			Comment:          &comment,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update custom IOA rules: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstRule(res.Payload)
+}
+
+// DeleteRules deletes rules of a group
+func DeleteRules(ctx context.Context, c *client.CrowdStrikeAPISpecification, g *Group, ids []string, comment string) error {
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := c.CustomIoa.DeleteRules(&custom_ioa.DeleteRulesParams{
+			Context:     ctx,
+			RuleGroupID: g.ID,
+			Ids:         chunk,
+			Comment:     utils.OptionalPtr(comment),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete custom IOA rules: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func firstGroup(payload *models.APIRuleGroupsResponse) (*Group, error) {
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Resources) == 0 {
+		return nil, fmt.Errorf("no rule group returned")
+	}
+	return group(payload.Resources[0]), nil
+}
+
+func firstRule(payload *models.APIRulesResponse) (*Rule, error) {
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Resources) == 0 {
+		return nil, fmt.Errorf("no rule returned")
+	}
+	return rule(payload.Resources[0]), nil
+}
+
+func group(g *models.APIRuleGroupV1) *Group {
+	out := &Group{
+		ID:          utils.Deref(g.ID),
+		Name:        utils.Deref(g.Name),
+		Description: utils.Deref(g.Description),
+		Platform:    utils.Deref(g.Platform),
+		Enabled:     utils.Deref(g.Enabled),
+		Version:     utils.Deref(g.Version),
+		Rules:       []*Rule{},
+		ModifiedBy:  utils.Deref(g.ModifiedBy),
+		ModifiedOn:  utils.Deref(g.ModifiedOn),
+	}
+	for _, r := range g.Rules {
+		if !utils.Deref(r.Deleted) {
+			out.Rules = append(out.Rules, rule(r))
+		}
+	}
+	return out
+}
+
+func rule(r *models.APIRuleV1) *Rule {
+	return &Rule{
+		ID:            utils.Deref(r.InstanceID),
+		Name:          utils.Deref(r.Name),
+		Description:   utils.Deref(r.Description),
+		RuleTypeID:    utils.Deref(r.RuletypeID),
+		RuleTypeName:  utils.Deref(r.RuletypeName),
+		Severity:      utils.Deref(r.PatternSeverity),
+		DispositionID: utils.Deref(r.DispositionID),
+		Action:        utils.Deref(r.ActionLabel),
+		Enabled:       utils.Deref(r.Enabled),
+		FieldValues:   r.FieldValues,
+		ModifiedBy:    utils.Deref(r.ModifiedBy),
+		ModifiedOn:    utils.Deref(r.ModifiedOn),
+	}
+}
+
+// queryAll pages through the results of a query. The custom IOA API takes
+// offsets as strings.
+func queryAll(query func(offset string, limit int64) (*models.MsaQueryResponse, error)) ([]string, error) {
+	ids := []string{}
+
+	for {
+		payload, err := query(strconv.Itoa(len(ids)), maxPerQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = falcon.AssertNoError(payload.Errors); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, payload.Resources...)
+
+		if len(payload.Resources) == 0 || payload.Meta == nil || payload.Meta.Pagination == nil ||
+			int64(len(ids)) >= utils.Deref(payload.Meta.Pagination.Total) {
+			return ids, nil
+		}
+	}
+}
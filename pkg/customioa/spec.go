@@ -0,0 +1,328 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package customioa
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Kind identifies rule specifications
+const Kind = "CustomIOARule"
+
+// Types of rule fields
+const (
+	// FieldExcludable fields match a regular expression and can exclude
+	// values matching a second one
+	FieldExcludable = "excludable"
+	// FieldSet fields match one of a set of values
+	FieldSet = "set"
+)
+
+// Labels of the values of excludable fields
+const (
+	labelInclude = "include"
+	labelExclude = "exclude"
+)
+
+// Severities lists the severities of rules
+var Severities = []string{"critical", "high", "medium", "low", "informational"}
+
+// RuleSpec is the specification of a rule as written to and read from YAML
+// files. The rule group and rule type are referenced by name. The rule
+// group can be left out and given on the command line instead.
+type RuleSpec struct {
+	Kind        string  `json:"kind" yaml:"kind"`
+	Name        string  `json:"name" yaml:"name"`
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	RuleGroup   string  `json:"rule_group,omitempty" yaml:"rule_group,omitempty"`
+	RuleType    string  `json:"rule_type" yaml:"rule_type"`
+	Severity    string  `json:"severity" yaml:"severity"`
+	Action      string  `json:"action" yaml:"action"`
+	Enabled     *bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Fields      []Field `json:"fields" yaml:"fields"`
+}
+
+// Field is the value of a rule field. Excludable fields are matched with
+// the include and exclude regular expressions, other fields with values.
+// The type is inferred when omitted.
+type Field struct {
+	Name    string   `json:"name" yaml:"name"`
+	Type    string   `json:"type,omitempty" yaml:"type,omitempty"`
+	Include string   `json:"include,omitempty" yaml:"include,omitempty"`
+	Exclude string   `json:"exclude,omitempty" yaml:"exclude,omitempty"`
+	Values  []string `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// matchAll is the expression of an excludable field matching anything
+const matchAll = ".*"
+
+// Validate checks that the specification is complete and that its regular
+// expressions compile
+func (s *RuleSpec) Validate() error {
+	if s.Kind != Kind {
+		return fmt.Errorf("expected kind %s, got %q", Kind, s.Kind)
+	}
+
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(s.RuleType) == "" {
+		return fmt.Errorf("rule_type is required")
+	}
+	if strings.TrimSpace(s.Action) == "" {
+		return fmt.Errorf("action is required")
+	}
+
+	s.Severity = strings.ToLower(s.Severity)
+	if err := utils.ValidateOneOf("severity", Severities, s.Severity); err != nil {
+		return err
+	}
+
+	if len(s.Fields) == 0 {
+		return fmt.Errorf("at least one field is required")
+	}
+
+	seen := map[string]bool{}
+	narrowed := false
+	for i := range s.Fields {
+		f := &s.Fields[i]
+		if f.Name == "" {
+			return fmt.Errorf("field #%d has no name", i+1)
+		}
+		if seen[strings.ToLower(f.Name)] {
+			return fmt.Errorf("field %s is given more than once", f.Name)
+		}
+		seen[strings.ToLower(f.Name)] = true
+
+		if err := f.validate(); err != nil {
+			return err
+		}
+		if f.Type != FieldExcludable || f.Include != matchAll || f.Exclude != "" {
+			narrowed = true
+		}
+	}
+
+	if !narrowed {
+		return fmt.Errorf("every field matches anything, at least one must narrow the rule")
+	}
+
+	return nil
+}
+
+func (f *Field) validate() error {
+	if f.Type == "" {
+		f.Type = FieldExcludable
+		if len(f.Values) > 0 {
+			f.Type = FieldSet
+		}
+	}
+
+	if f.Type != FieldExcludable {
+		if f.Include != "" || f.Exclude != "" {
+			return fmt.Errorf("field %s of type %s takes values, not include and exclude", f.Name, f.Type)
+		}
+		if len(f.Values) == 0 {
+			return fmt.Errorf("field %s has no values", f.Name)
+		}
+		return nil
+	}
+
+	if len(f.Values) > 0 {
+		return fmt.Errorf("field %s of type %s takes include and exclude, not values", f.Name, f.Type)
+	}
+	if f.Include == "" {
+		f.Include = matchAll
+	}
+
+	if _, err := compile(f.Include); err != nil {
+		return fmt.Errorf("invalid include regex of field %s: %v", f.Name, err)
+	}
+	if f.Exclude != "" {
+		if _, err := compile(f.Exclude); err != nil {
+			return fmt.Errorf("invalid exclude regex of field %s: %v", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// CheckFields checks that the fields of the specification are fields of
+// the rule type
+func (s *RuleSpec) CheckFields(t *RuleType) error {
+	if len(t.Fields) == 0 {
+		return nil
+	}
+
+	for _, f := range s.Fields {
+		known := false
+		for _, name := range t.Fields {
+			if strings.EqualFold(name, f.Name) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%s is not a field of rule type %q, must be one of: %s", f.Name, t.Name, strings.Join(t.Fields, ", "))
+		}
+	}
+	return nil
+}
+
+// Resolve finds the rule type of the specification on a platform and checks
+// the fields and action against it. The rule type and action are then set
+// to their names as returned by the API.
+func (s *RuleSpec) Resolve(types []*RuleType, platform string) (*RuleType, error) {
+	t, err := FindRuleType(types, s.RuleType, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.CheckFields(t); err != nil {
+		return nil, err
+	}
+
+	d, err := t.Disposition(s.Action)
+	if err != nil {
+		return nil, err
+	}
+
+	s.RuleType, s.Action = t.Name, d.Label
+	return t, nil
+}
+
+// FieldValues returns the field values of the specification as sent to the
+// API
+func (s *RuleSpec) FieldValues() []*models.DomainFieldValue {
+	out := []*models.DomainFieldValue{}
+	for _, f := range s.Fields {
+		name, fieldType := f.Name, f.Type
+		fv := &models.DomainFieldValue{Name: &name, Type: &fieldType}
+
+		if f.Type == FieldExcludable {
+			fv.Values = append(fv.Values, valueItem(labelInclude, f.Include))
+			if f.Exclude != "" {
+				fv.Values = append(fv.Values, valueItem(labelExclude, f.Exclude))
+			}
+		} else {
+			for _, v := range f.Values {
+				fv.Values = append(fv.Values, valueItem(v, v))
+			}
+		}
+
+		out = append(out, fv)
+	}
+	return out
+}
+
+func valueItem(label, value string) *models.DomainValueItem {
+	return &models.DomainValueItem{Label: &label, Value: &value}
+}
+
+// Spec returns the specification of a rule of the given group
+func (r *Rule) Spec(group string) *RuleSpec {
+	enabled := r.Enabled
+	spec := &RuleSpec{
+		Kind:        Kind,
+		Name:        r.Name,
+		Description: r.Description,
+		RuleGroup:   group,
+		RuleType:    r.RuleTypeName,
+		Severity:    r.Severity,
+		Action:      r.Action,
+		Enabled:     &enabled,
+		Fields:      []Field{},
+	}
+
+	for _, fv := range r.FieldValues {
+		f := Field{Name: utils.Deref(fv.Name), Type: utils.Deref(fv.Type)}
+		for _, v := range fv.Values {
+			value := utils.Deref(v.Value)
+			switch {
+			case f.Type != FieldExcludable:
+				f.Values = append(f.Values, value)
+			case utils.Deref(v.Label) == labelInclude:
+				f.Include = value
+			case utils.Deref(v.Label) == labelExclude:
+				f.Exclude = value
+			}
+		}
+
+		if f.Include == "" && f.Type == FieldExcludable {
+			f.Include = matchAll
+		}
+		spec.Fields = append(spec.Fields, f)
+	}
+	spec.Fields = narrowing(spec.Fields)
+
+	return spec
+}
+
+// narrowing returns the fields that do not match anything, as the API
+// returns every field of the rule type
+func narrowing(fields []Field) []Field {
+	out := []Field{}
+	for _, f := range fields {
+		if f.Type == FieldExcludable && f.Include == matchAll && f.Exclude == "" {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// Changes returns the differences between a rule and a specification
+func Changes(current *Rule, spec *RuleSpec) ([]diff.Change, error) {
+	have := current.Spec(spec.RuleGroup)
+	if spec.Enabled == nil {
+		have.Enabled = nil
+	}
+
+	want := *spec
+	want.Fields = narrowing(spec.Fields)
+
+	a, err := output.ToGeneric(have)
+	if err != nil {
+		return nil, err
+	}
+	b, err := output.ToGeneric(&want)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Compare(a, b), nil
+}
+
+// ReadSpecs decodes and validates a stream of YAML rule specifications
+func ReadSpecs(r io.Reader) ([]*RuleSpec, error) {
+	return manifest.ReadStream(r, "rule specification", (*RuleSpec).Validate)
+}
+
+// WriteSpecs writes specifications as a stream of YAML documents
+func WriteSpecs(w io.Writer, specs []*RuleSpec) error {
+	return manifest.WriteStream(w, specs)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+// Package diff compares structured documents such as policy specifications.
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Change is a difference between two documents at a path such as
+// settings.CloudAntiMalware.detection. Old is nil for added values and New
+// is nil for removed values.
+type Change struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// Compare returns the differences between two documents made of maps,
+// slices and scalar values, as produced by decoding JSON or YAML. Maps are
+// compared key by key and slices of maps element by element; any other
+// slice is compared as a whole.
+func Compare(a, b interface{}) []Change {
+	changes := []Change{}
+	compare("", a, b, &changes)
+	return changes
+}
+
+func compare(path string, a, b interface{}, changes *[]Change) {
+	ma, aIsMap := a.(map[string]interface{})
+	mb, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		for _, k := range keys(ma, mb) {
+			va, inA := ma[k]
+			vb, inB := mb[k]
+			p := join(path, k)
+			switch {
+			case !inA:
+				*changes = append(*changes, Change{Path: p, New: vb})
+			case !inB:
+				*changes = append(*changes, Change{Path: p, Old: va})
+			default:
+				compare(p, va, vb, changes)
+			}
+		}
+		return
+	}
+
+	sa, aIsSlice := a.([]interface{})
+	sb, bIsSlice := b.([]interface{})
+	if aIsSlice && bIsSlice && hasMaps(sa) && hasMaps(sb) {
+		for n := 0; n < len(sa) || n < len(sb); n++ {
+			p := path + "[" + strconv.Itoa(n) + "]"
+			switch {
+			case n >= len(sa):
+				*changes = append(*changes, Change{Path: p, New: sb[n]})
+			case n >= len(sb):
+				*changes = append(*changes, Change{Path: p, Old: sa[n]})
+			default:
+				compare(p, sa[n], sb[n], changes)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*changes = append(*changes, Change{Path: path, Old: a, New: b})
+	}
+}
+
+// Print writes the changes, one per line, as "+ path: value" for additions,
+// "- path: value" for removals and "~ path: old -> new" for modifications
+func Print(w io.Writer, changes []Change) error {
+	for _, c := range changes {
+		var err error
+		switch {
+		case c.Old == nil:
+			_, err = fmt.Fprintf(w, "+ %s: %s\n", c.Path, Format(c.New))
+		case c.New == nil:
+			_, err = fmt.Fprintf(w, "- %s: %s\n", c.Path, Format(c.Old))
+		default:
+			_, err = fmt.Fprintf(w, "~ %s: %s -> %s\n", c.Path, Format(c.Old), Format(c.New))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Format renders a value compactly on a single line
+func Format(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func keys(a, b map[string]interface{}) []string {
+	seen := map[string]bool{}
+	list := []string{}
+	for _, m := range []map[string]interface{}{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				list = append(list, k)
+			}
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+func hasMaps(s []interface{}) bool {
+	for _, v := range s {
+		if _, ok := v.(map[string]interface{}); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v3"
+)
+
+func decode(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestCompare(t *testing.T) {
+	a := decode(t, `
+name: Workstations
+enabled: true
+host_groups: [laptops, desktops]
+settings:
+  CloudAntiMalware:
+    detection: MODERATE
+    prevention: CAUTIOUS
+  ScriptBasedExecutionMonitoring: true
+rules:
+  - name: allow-dns
+    port: 53
+`)
+	b := decode(t, `
+name: Workstations
+enabled: false
+host_groups: [laptops]
+settings:
+  CloudAntiMalware:
+    detection: AGGRESSIVE
+    prevention: CAUTIOUS
+  QuarantineOnWrite: true
+rules:
+  - name: allow-dns
+    port: 5353
+  - name: allow-ntp
+`)
+
+	want := []Change{
+		{Path: "enabled", Old: true, New: false},
+		{Path: "host_groups", Old: []interface{}{"laptops", "desktops"}, New: []interface{}{"laptops"}},
+		{Path: "rules[0].port", Old: 53, New: 5353},
+		{Path: "rules[1]", New: map[string]interface{}{"name": "allow-ntp"}},
+		{Path: "settings.CloudAntiMalware.detection", Old: "MODERATE", New: "AGGRESSIVE"},
+		{Path: "settings.QuarantineOnWrite", New: true},
+		{Path: "settings.ScriptBasedExecutionMonitoring", Old: true},
+	}
+
+	if diff := cmp.Diff(want, Compare(a, b)); diff != "" {
+		t.Errorf("Compare() mismatch (-want +got):\n%s", diff)
+	}
+
+	if changes := Compare(a, a); len(changes) != 0 {
+		t.Errorf("Compare() of equal documents = %v", changes)
+	}
+}
+
+func TestPrint(t *testing.T) {
+	var buf bytes.Buffer
+	err := Print(&buf, []Change{
+		{Path: "enabled", Old: true, New: false},
+		{Path: "host_groups", New: []interface{}{"laptops"}},
+		{Path: "description", Old: "old"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "~ enabled: true -> false\n+ host_groups: [\"laptops\"]\n- description: old\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Print() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package discover queries the hosts, applications, accounts and logins seen
+// by Falcon Discover.
+package discover
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/discover"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// Host is an asset seen on the network, managed by Falcon or not
+type Host = models.DomainDiscoverAPIHost
+
+// Account is a user account seen on hosts
+type Account = models.DomainDiscoverAPIAccount
+
+// Login is a login event of an account on a host
+type Login = models.DomainDiscoverAPILogin
+
+// Application is an application installed on a host. The pinned API client
+// has no applications endpoints, so they are declared here.
+type Application struct {
+	ID                    string           `json:"id"`
+	Name                  string           `json:"name"`
+	Vendor                string           `json:"vendor,omitempty"`
+	Version               string           `json:"version,omitempty"`
+	NameVendor            string           `json:"name_vendor,omitempty"`
+	NameVendorVersion     string           `json:"name_vendor_version,omitempty"`
+	SoftwareType          string           `json:"software_type,omitempty"`
+	Category              string           `json:"category,omitempty"`
+	Architectures         []string         `json:"architectures,omitempty"`
+	InstallationPaths     []string         `json:"installation_paths,omitempty"`
+	InstallationTimestamp string           `json:"installation_timestamp,omitempty"`
+	FirstSeenTimestamp    string           `json:"first_seen_timestamp,omitempty"`
+	LastUpdatedTimestamp  string           `json:"last_updated_timestamp,omitempty"`
+	LastUsedTimestamp     string           `json:"last_used_timestamp,omitempty"`
+	LastUsedUserName      string           `json:"last_used_user_name,omitempty"`
+	LastUsedFileName      string           `json:"last_used_file_name,omitempty"`
+	IsSuspicious          bool             `json:"is_suspicious,omitempty"`
+	IsNormalized          bool             `json:"is_normalized,omitempty"`
+	Groups                []string         `json:"groups,omitempty"`
+	Host                  *ApplicationHost `json:"host,omitempty"`
+}
+
+// ApplicationHost is the host an application is installed on
+type ApplicationHost struct {
+	ID              string `json:"id,omitempty"`
+	Aid             string `json:"aid,omitempty"`
+	Hostname        string `json:"hostname,omitempty"`
+	PlatformName    string `json:"platform_name,omitempty"`
+	OsVersion       string `json:"os_version,omitempty"`
+	ProductTypeDesc string `json:"product_type_desc,omitempty"`
+	MachineDomain   string `json:"machine_domain,omitempty"`
+	Ou              string `json:"ou,omitempty"`
+	SiteName        string `json:"site_name,omitempty"`
+}
+
+// Query selects Discover entities. A Limit of 0 returns every match.
+type Query struct {
+	Filter string
+	Sort   string
+	Limit  int
+}
+
+// maxPage is the most IDs the query and entities endpoints take at once
+const maxPage = 100
+
+// Hosts returns the hosts matching q
+func Hosts(ctx context.Context, c *client.CrowdStrikeAPISpecification, q Query) ([]*Host, error) {
+	ids, err := queryIDs(q, func(offset, size int64) (*models.MsaQueryResponse, error) {
+		res, err := c.Discover.QueryHosts(&discover.QueryHostsParams{
+			Context: ctx,
+			Filter:  utils.OptionalPtr(q.Filter),
+			Sort:    utils.OptionalPtr(q.Sort),
+			Offset:  &offset,
+			Limit:   &size,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query hosts: %s", falcon.ErrorExplain(err))
+		}
+		return res.Payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getEntities(ids, func(ids []string) ([]*Host, error) {
+		res, err := c.Discover.GetHosts(&discover.GetHostsParams{
+			Context: ctx,
+			Ids:     ids,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hosts: %s", falcon.ErrorExplain(err))
+		}
+		if err := falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+		return res.Payload.Resources, nil
+	})
+}
+
+// Accounts returns the accounts matching q
+func Accounts(ctx context.Context, c *client.CrowdStrikeAPISpecification, q Query) ([]*Account, error) {
+	ids, err := queryIDs(q, func(offset, size int64) (*models.MsaQueryResponse, error) {
+		res, err := c.Discover.QueryAccounts(&discover.QueryAccountsParams{
+			Context: ctx,
+			Filter:  utils.OptionalPtr(q.Filter),
+			Sort:    utils.OptionalPtr(q.Sort),
+			Offset:  &offset,
+			Limit:   &size,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query accounts: %s", falcon.ErrorExplain(err))
+		}
+		return res.Payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getEntities(ids, func(ids []string) ([]*Account, error) {
+		res, err := c.Discover.GetAccounts(&discover.GetAccountsParams{
+			Context: ctx,
+			Ids:     ids,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get accounts: %s", falcon.ErrorExplain(err))
+		}
+		if err := falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+		return res.Payload.Resources, nil
+	})
+}
+
+// Logins returns the logins matching q
+func Logins(ctx context.Context, c *client.CrowdStrikeAPISpecification, q Query) ([]*Login, error) {
+	ids, err := queryIDs(q, func(offset, size int64) (*models.MsaQueryResponse, error) {
+		res, err := c.Discover.QueryLogins(&discover.QueryLoginsParams{
+			Context: ctx,
+			Filter:  utils.OptionalPtr(q.Filter),
+			Sort:    utils.OptionalPtr(q.Sort),
+			Offset:  &offset,
+			Limit:   &size,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query logins: %s", falcon.ErrorExplain(err))
+		}
+		return res.Payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getEntities(ids, func(ids []string) ([]*Login, error) {
+		res, err := c.Discover.GetLogins(&discover.GetLoginsParams{
+			Context: ctx,
+			Ids:     ids,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get logins: %s", falcon.ErrorExplain(err))
+		}
+		if err := falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+		return res.Payload.Resources, nil
+	})
+}
+
+// applicationsResponse is the reply of the applications entities endpoint
+type applicationsResponse struct {
+	Errors    []*models.MsaAPIError `json:"errors"`
+	Resources []*Application        `json:"resources"`
+}
+
+// Applications returns the applications matching q
+func Applications(ctx context.Context, c *client.CrowdStrikeAPISpecification, q Query) ([]*Application, error) {
+	ids, err := queryIDs(q, func(offset, size int64) (*models.MsaQueryResponse, error) {
+		payload := &models.MsaQueryResponse{}
+		err := submit(ctx, c, "query-applications", "/discover/queries/applications/v1", func(r runtime.ClientRequest) error {
+			for name, value := range map[string]string{"filter": q.Filter, "sort": q.Sort} {
+				if value != "" {
+					if err := r.SetQueryParam(name, value); err != nil {
+						return err
+					}
+				}
+			}
+			if err := r.SetQueryParam("offset", strconv.FormatInt(offset, 10)); err != nil {
+				return err
+			}
+			return r.SetQueryParam("limit", strconv.FormatInt(size, 10))
+		}, payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query applications: %s", falcon.ErrorExplain(err))
+		}
+		return payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return getEntities(ids, func(ids []string) ([]*Application, error) {
+		payload := &applicationsResponse{}
+		err := submit(ctx, c, "get-applications", "/discover/entities/applications/v1", func(r runtime.ClientRequest) error {
+			return r.SetQueryParam("ids", ids...)
+		}, payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get applications: %s", falcon.ErrorExplain(err))
+		}
+		if err := falcon.AssertNoError(payload.Errors); err != nil {
+			return nil, err
+		}
+		return payload.Resources, nil
+	})
+}
+
+// submit sends a GET request through the transport of the client and reads
+// the JSON reply into payload
+func submit(ctx context.Context, c *client.CrowdStrikeAPISpecification, name, path string, params func(runtime.ClientRequest) error, payload interface{}) error {
+	_, err := c.Transport.Submit(&runtime.ClientOperation{
+		ID:                 name,
+		Method:             http.MethodGet,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Context:            ctx,
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			return params(r)
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(res runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if res.Code() != http.StatusOK {
+				return nil, runtime.NewAPIError(name, res.Message(), res.Code())
+			}
+			return payload, consumer.Consume(res.Body(), payload)
+		}),
+	})
+	return err
+}
+
+// queryIDs pages through the IDs matching q
+func queryIDs(q Query, page func(offset, size int64) (*models.MsaQueryResponse, error)) ([]string, error) {
+	ids := []string{}
+
+	for {
+		size := maxPage
+		if q.Limit > 0 && q.Limit-len(ids) < maxPage {
+			size = q.Limit - len(ids)
+		}
+
+		payload, err := page(int64(len(ids)), int64(size))
+		if err != nil {
+			return nil, err
+		}
+		if err := falcon.AssertNoError(payload.Errors); err != nil {
+			return nil, err
+		}
+		ids = append(ids, payload.Resources...)
+
+		total := int64(0)
+		if payload.Meta != nil && payload.Meta.Pagination != nil {
+			total = utils.Deref(payload.Meta.Pagination.Total)
+		}
+		if len(payload.Resources) == 0 || int64(len(ids)) >= total || (q.Limit > 0 && len(ids) >= q.Limit) {
+			return ids, nil
+		}
+	}
+}
+
+// getEntities gets the entities of IDs in chunks the API accepts
+func getEntities[T any](ids []string, get func(ids []string) ([]T, error)) ([]T, error) {
+	list := []T{}
+	for _, chunk := range utils.Chunk(ids, maxPage) {
+		entities, err := get(chunk)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, entities...)
+	}
+	return list, nil
+}
+
+// FieldValues returns the values of a field for each entity of list, which
+// is a slice of entities. Fields are named as in the JSON output and nested
+// fields are separated by dots, such as host.platform_name. Entities
+// without the field count as an empty value and array fields give one
+// value per element.
+func FieldValues(list interface{}, field string) ([]string, error) {
+	generic, err := output.ToGeneric(list)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, ok := generic.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot read field %q of %T", field, list)
+	}
+
+	values := []string{}
+	for _, e := range entities {
+		found := lookup(e, strings.Split(field, "."))
+		if len(found) == 0 {
+			values = append(values, "")
+		}
+		values = append(values, found...)
+	}
+	return values, nil
+}
+
+func lookup(v interface{}, path []string) []string {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		values := []string{}
+		for _, e := range v {
+			values = append(values, lookup(e, path)...)
+		}
+		return values
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return nil
+		}
+		return lookup(v[path[0]], path[1:])
+	case float64:
+		if len(path) > 0 {
+			return nil
+		}
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	default:
+		if len(path) > 0 {
+			return nil
+		}
+		return []string{fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package discover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+)
+
+// newTestClient serves 150 applications, app-0 to app-149, and records the
+// query parameters of every request
+func newTestClient(t *testing.T, requests *[]url.Values) *client.CrowdStrikeAPISpecification {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		*requests = append(*requests, q)
+
+		switch r.URL.Path {
+		case "/discover/queries/applications/v1":
+			offset, _ := strconv.Atoi(q.Get("offset"))
+			limit, _ := strconv.Atoi(q.Get("limit"))
+			ids := []string{}
+			for i := offset; i < offset+limit && i < 150; i++ {
+				ids = append(ids, fmt.Sprintf("app-%d", i))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"meta":      map[string]interface{}{"pagination": map[string]interface{}{"total": 150}},
+				"resources": ids,
+			})
+		case "/discover/entities/applications/v1":
+			apps := []map[string]interface{}{}
+			for _, id := range q["ids"] {
+				apps = append(apps, map[string]interface{}{"id": id, "name": "Chrome", "host": map[string]string{"hostname": "h-" + id}})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"resources": apps})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[{"code":404,"message":"not found"}]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	u, _ := url.Parse(server.URL)
+	return client.New(httptransport.New(u.Host, "/", []string{"http"}), strfmt.Default)
+}
+
+func TestApplications(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		want     int
+		requests int
+	}{
+		{name: "all", limit: 0, want: 150, requests: 4},
+		{name: "limited", limit: 120, want: 120, requests: 4},
+		{name: "one page", limit: 10, want: 10, requests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := []url.Values{}
+			c := newTestClient(t, &requests)
+
+			apps, err := Applications(context.Background(), c, Query{Filter: "name:'Chrome'", Sort: "name|asc", Limit: tt.limit})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(apps) != tt.want {
+				t.Errorf("got %d applications, want %d", len(apps), tt.want)
+			}
+			if len(requests) != tt.requests {
+				t.Errorf("got %d requests, want %d", len(requests), tt.requests)
+			}
+			if got := requests[0].Get("filter"); got != "name:'Chrome'" {
+				t.Errorf("got filter %q", got)
+			}
+			if got := apps[len(apps)-1]; got.ID != fmt.Sprintf("app-%d", tt.want-1) || got.Host.Hostname != "h-"+got.ID {
+				t.Errorf("unexpected last application %+v", got)
+			}
+		})
+	}
+}
+
+func TestApplicationsError(t *testing.T) {
+	requests := []url.Values{}
+	c := newTestClient(t, &requests)
+	c.Transport.(*httptransport.Runtime).BasePath = "/missing"
+
+	if _, err := Applications(context.Background(), c, Query{}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestFieldValues(t *testing.T) {
+	apps := []*Application{
+		{Name: "Chrome", Version: "118.0", Architectures: []string{"x64", "arm64"}, Host: &ApplicationHost{PlatformName: "Windows"}},
+		{Name: "Chrome", Version: "119.0"},
+	}
+
+	tests := []struct {
+		field string
+		want  []string
+	}{
+		{field: "version", want: []string{"118.0", "119.0"}},
+		{field: "host.platform_name", want: []string{"Windows", ""}},
+		{field: "architectures", want: []string{"x64", "arm64", ""}},
+		{field: "is_suspicious", want: []string{"", ""}},
+		{field: "name.vendor", want: []string{"", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, err := FieldValues(apps, tt.field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("FieldValues() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	logins := []*Login{{LoginEventCount: 1500000}}
+	got, err := FieldValues(logins, "login_event_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"1500000"}, got); diff != "" {
+		t.Errorf("FieldValues() mismatch (-want +got):\n%s", diff)
+	}
+}
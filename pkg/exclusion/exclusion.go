@@ -0,0 +1,287 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package exclusion manages machine learning, IOA and sensor visibility
+// exclusions and converts them to and from YAML specifications.
+package exclusion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/strfmt"
+)
+
+// GlobalGroup is the group ID that applies an exclusion to every host
+const GlobalGroup = "all"
+
+// ErrNotFound is returned when an exclusion does not exist
+var ErrNotFound = errors.New("not found")
+
+// Type describes a kind of exclusion and how to manage it
+type Type struct {
+	// Kind identifies the exclusion type in specifications, e.g. MLExclusion
+	Kind string
+	// Name is the name of the command managing the type, e.g. ml
+	Name string
+	// Title is the human readable name, e.g. machine learning exclusion
+	Title string
+	// Plural is the plural of Title
+	Plural string
+	// New returns the service managing exclusions of this type
+	New func(c *client.CrowdStrikeAPISpecification) Service
+
+	// key identifies an exclusion within the type
+	key func(s *Spec) string
+	// validate checks the fields specific to the type
+	validate func(s *Spec) error
+}
+
+// Service manages the exclusions of one type through the Falcon API. Host
+// groups are given by ID, or as GlobalGroup alone for every host.
+type Service interface {
+	// List returns the exclusions matching filter
+	List(ctx context.Context, filter string) ([]*Exclusion, error)
+	// Create creates an exclusion from a specification
+	Create(ctx context.Context, spec *Spec, groups []string, comment string) (*Exclusion, error)
+	// Update replaces the settings of an exclusion
+	Update(ctx context.Context, id string, spec *Spec, groups []string, comment string) (*Exclusion, error)
+	// Delete deletes exclusions
+	Delete(ctx context.Context, ids []string, comment string) error
+}
+
+// Group is a host group an exclusion applies to
+type Group struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Exclusion is an exclusion as returned by the API. Only the fields of its
+// type are set.
+type Exclusion struct {
+	ID              string          `json:"id"`
+	Value           string          `json:"value,omitempty"`
+	ExcludedFrom    []string        `json:"excluded_from,omitempty"`
+	Name            string          `json:"name,omitempty"`
+	Description     string          `json:"description,omitempty"`
+	PatternID       string          `json:"pattern_id,omitempty"`
+	PatternName     string          `json:"pattern_name,omitempty"`
+	CommandLine     string          `json:"cl_regex,omitempty"`
+	ImageFilename   string          `json:"ifn_regex,omitempty"`
+	AppliedGlobally bool            `json:"applied_globally"`
+	Groups          []Group         `json:"groups"`
+	ModifiedBy      string          `json:"modified_by"`
+	LastModified    strfmt.DateTime `json:"last_modified"`
+}
+
+// Spec is the specification of an exclusion as written to and read from
+// YAML files. Machine learning and sensor visibility exclusions are
+// identified by their value, a glob pattern, and IOA exclusions by their
+// name. Host groups are referenced by name.
+type Spec struct {
+	Kind            string   `json:"kind" yaml:"kind"`
+	Value           string   `json:"value,omitempty" yaml:"value,omitempty"`
+	ExcludedFrom    []string `json:"excluded_from,omitempty" yaml:"excluded_from,omitempty"`
+	Name            string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Description     string   `json:"description,omitempty" yaml:"description,omitempty"`
+	PatternID       string   `json:"pattern_id,omitempty" yaml:"pattern_id,omitempty"`
+	PatternName     string   `json:"pattern_name,omitempty" yaml:"pattern_name,omitempty"`
+	CommandLine     string   `json:"cl_regex,omitempty" yaml:"cl_regex,omitempty"`
+	ImageFilename   string   `json:"ifn_regex,omitempty" yaml:"ifn_regex,omitempty"`
+	AppliedGlobally bool     `json:"applied_globally,omitempty" yaml:"applied_globally,omitempty"`
+	HostGroups      []string `json:"host_groups,omitempty" yaml:"host_groups,omitempty"`
+}
+
+// Key identifies the exclusion of a specification within its type
+func (t *Type) Key(s *Spec) string {
+	return t.key(s)
+}
+
+// Validate checks that the specification is complete, of the given type and
+// that its patterns are valid
+func (t *Type) Validate(s *Spec) error {
+	if s.Kind != t.Kind {
+		return fmt.Errorf("expected kind %s, got %q", t.Kind, s.Kind)
+	}
+
+	if s.AppliedGlobally == (len(s.HostGroups) > 0) {
+		return fmt.Errorf("an exclusion must either be applied globally or to host groups")
+	}
+
+	sort.Strings(s.HostGroups)
+	return t.validate(s)
+}
+
+// Spec returns the specification of the exclusion
+func (e *Exclusion) Spec(t *Type) *Spec {
+	spec := &Spec{
+		Kind:            t.Kind,
+		Value:           e.Value,
+		ExcludedFrom:    sorted(e.ExcludedFrom),
+		Name:            e.Name,
+		Description:     e.Description,
+		PatternID:       e.PatternID,
+		PatternName:     e.PatternName,
+		CommandLine:     e.CommandLine,
+		ImageFilename:   e.ImageFilename,
+		AppliedGlobally: e.AppliedGlobally,
+	}
+
+	if !e.AppliedGlobally {
+		for _, g := range e.Groups {
+			spec.HostGroups = append(spec.HostGroups, g.Name)
+		}
+		sort.Strings(spec.HostGroups)
+	}
+
+	return spec
+}
+
+// Scope describes the hosts an exclusion applies to
+func (e *Exclusion) Scope() string {
+	if e.AppliedGlobally {
+		return "global"
+	}
+
+	names := []string{}
+	for _, g := range e.Groups {
+		names = append(names, g.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// ReadSpecs decodes and validates the YAML specifications of a stream,
+// which may hold several documents separated by "---"
+func ReadSpecs(t *Type, r io.Reader) ([]*Spec, error) {
+	return manifest.ReadStream(r, t.Title+" specification", t.Validate)
+}
+
+// WriteSpecs writes specifications as a stream of YAML documents
+func WriteSpecs(w io.Writer, specs []*Spec) error {
+	return manifest.WriteStream(w, specs)
+}
+
+// Find returns the exclusion with the given ID, or with the given value or
+// name depending on the type
+func Find(ctx context.Context, svc Service, t *Type, keyOrID string) (*Exclusion, error) {
+	list, err := svc.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range list {
+		if e.ID == keyOrID || t.Key(e.Spec(t)) == keyOrID {
+			return e, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s %q %w", t.Title, keyOrID, ErrNotFound)
+}
+
+// GroupIDs returns the host group IDs to send to the API for a
+// specification
+func GroupIDs(ctx context.Context, c *client.CrowdStrikeAPISpecification, spec *Spec) ([]string, error) {
+	if spec.AppliedGlobally {
+		return []string{GlobalGroup}, nil
+	}
+
+	resolved, err := hosts.ResolveGroupIDs(ctx, c, spec.HostGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, name := range spec.HostGroups {
+		ids = append(ids, resolved[name])
+	}
+	return ids, nil
+}
+
+// Apply creates the exclusion described by spec, or updates the exclusion
+// with the same value or name. It reports whether the exclusion was created.
+func Apply(ctx context.Context, c *client.CrowdStrikeAPISpecification, t *Type, spec *Spec, comment string) (*Exclusion, bool, error) {
+	svc := t.New(c)
+
+	existing, err := Find(ctx, svc, t, t.Key(spec))
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, false, err
+	}
+
+	groups, err := GroupIDs(ctx, c, spec)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if existing == nil {
+		e, err := svc.Create(ctx, spec, groups, comment)
+		return e, true, err
+	}
+
+	e, err := svc.Update(ctx, existing.ID, spec, groups, comment)
+	return e, false, err
+}
+
+// Changes returns the differences between an exclusion and a specification
+func Changes(t *Type, current *Exclusion, spec *Spec) ([]diff.Change, error) {
+	a, err := output.ToGeneric(current.Spec(t))
+	if err != nil {
+		return nil, err
+	}
+	b, err := output.ToGeneric(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Compare(a, b), nil
+}
+
+// groups converts the host groups returned by the API
+func groups(list []*models.ResponsesHostGroupV1) []Group {
+	out := []Group{}
+	for _, g := range list {
+		if g == nil {
+			continue
+		}
+		out = append(out, Group{ID: utils.Deref(g.ID), Name: utils.Deref(g.Name)})
+	}
+	return out
+}
+
+// sorted returns a sorted copy of list, or nil for an empty list
+func sorted(list []string) []string {
+	if len(list) == 0 {
+		return nil
+	}
+	s := append([]string{}, list...)
+	sort.Strings(s)
+	return s
+}
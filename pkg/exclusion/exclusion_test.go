@@ -0,0 +1,192 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package exclusion
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		err     string
+	}{
+		{pattern: "/home/build/**/node_modules/**"},
+		{pattern: `Users\*\AppData\Local\Temp\*.tmp`},
+		{pattern: "/opt/app/bin/worker-??"},
+		{pattern: "", err: "pattern is required"},
+		{pattern: " /tmp/*", err: "leading or trailing spaces"},
+		{pattern: `C:\build\**`, err: "drive letter"},
+		{pattern: "/home/**.log", err: "within a path segment"},
+		{pattern: "/**/*", err: "every file"},
+		{pattern: "*", err: "every file"},
+	}
+
+	for _, tt := range tests {
+		err := ValidateGlob(tt.pattern)
+		if tt.err == "" && err != nil {
+			t.Errorf("ValidateGlob(%q) = %v", tt.pattern, err)
+		}
+		if tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("ValidateGlob(%q) = %v, want error containing %q", tt.pattern, err, tt.err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		t    *Type
+		spec Spec
+		want *Spec
+		err  string
+	}{
+		{
+			name: "ml defaults to blocking",
+			t:    ML,
+			spec: Spec{Kind: "MLExclusion", Value: "/builds/**", HostGroups: []string{"linux", "build"}},
+			want: &Spec{Kind: "MLExclusion", Value: "/builds/**", ExcludedFrom: []string{"blocking"}, HostGroups: []string{"build", "linux"}},
+		},
+		{
+			name: "ml unknown excluded_from",
+			t:    ML,
+			spec: Spec{Kind: "MLExclusion", Value: "/builds/**", ExcludedFrom: []string{"uploads"}, AppliedGlobally: true},
+			err:  "excluded_from",
+		},
+		{
+			name: "scope required",
+			t:    SV,
+			spec: Spec{Kind: "SVExclusion", Value: "/builds/**"},
+			err:  "applied globally or to host groups",
+		},
+		{
+			name: "sv rejects excluded_from",
+			t:    SV,
+			spec: Spec{Kind: "SVExclusion", Value: "/builds/**", ExcludedFrom: []string{"blocking"}, AppliedGlobally: true},
+			err:  "only valid for machine learning",
+		},
+		{
+			name: "ioa defaults regexes",
+			t:    IOA,
+			spec: Spec{Kind: "IOAExclusion", Name: "msbuild", PatternID: "10197", ImageFilename: `.*\\msbuild\.exe`, AppliedGlobally: true},
+			want: &Spec{Kind: "IOAExclusion", Name: "msbuild", PatternID: "10197", CommandLine: ".*", ImageFilename: `.*\\msbuild\.exe`, AppliedGlobally: true},
+		},
+		{
+			name: "ioa invalid regex",
+			t:    IOA,
+			spec: Spec{Kind: "IOAExclusion", Name: "msbuild", PatternID: "10197", CommandLine: "--target=(release", AppliedGlobally: true},
+			err:  "invalid cl_regex",
+		},
+		{
+			name: "ioa matching everything",
+			t:    IOA,
+			spec: Spec{Kind: "IOAExclusion", Name: "all", PatternID: "10197", AppliedGlobally: true},
+			err:  "every process",
+		},
+		{
+			name: "ioa rejects value",
+			t:    IOA,
+			spec: Spec{Kind: "IOAExclusion", Name: "msbuild", Value: "/x", PatternID: "1", AppliedGlobally: true},
+			err:  "not valid for IOA",
+		},
+		{
+			name: "wrong kind",
+			t:    ML,
+			spec: Spec{Kind: "SVExclusion", Value: "/builds/**", AppliedGlobally: true},
+			err:  "expected kind MLExclusion",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := tt.spec
+			err := tt.t.Validate(&spec)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Validate() = %v, want error containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, &spec); diff != "" {
+				t.Errorf("Validate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSpecsRoundTrip(t *testing.T) {
+	e := &Exclusion{
+		ID:           "1",
+		Value:        "/builds/**",
+		ExcludedFrom: []string{"extraction", "blocking"},
+		Groups:       []Group{{ID: "b", Name: "linux"}, {ID: "a", Name: "build"}},
+	}
+	spec := e.Spec(ML)
+
+	var buf bytes.Buffer
+	if err := WriteSpecs(&buf, []*Spec{spec, {Kind: "MLExclusion", Value: "/tmp/*.o", AppliedGlobally: true}}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `kind: MLExclusion
+value: /builds/**
+excluded_from:
+  - blocking
+  - extraction
+host_groups:
+  - build
+  - linux
+---
+kind: MLExclusion
+value: /tmp/*.o
+applied_globally: true
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("WriteSpecs() mismatch (-want +got):\n%s", diff)
+	}
+
+	specs, err := ReadSpecs(ML, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(spec, specs[0]); diff != "" {
+		t.Errorf("ReadSpecs() mismatch (-want +got):\n%s", diff)
+	}
+
+	changes, err := Changes(ML, e, specs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) == 0 {
+		t.Errorf("Changes() found no differences")
+	}
+
+	if _, err := ReadSpecs(SV, strings.NewReader("kind: SVExclusion\nvalue: /x/*\napplied_globally: true\npath: /x\n")); err == nil || !strings.Contains(err.Error(), "path") {
+		t.Errorf("ReadSpecs() with unknown field error = %v", err)
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package exclusion
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/ioa_exclusions"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// MatchAll is the regular expression matching any command line or image
+// file name, used when an IOA exclusion leaves one of them out
+const MatchAll = ".*"
+
+// IOA manages IOA exclusions, which stop a behavioral detection pattern from
+// triggering for processes matching a command line and image file name
+var IOA = &Type{
+	Kind:   "IOAExclusion",
+	Name:   "ioa",
+	Title:  "IOA exclusion",
+	Plural: "IOA exclusions",
+	New: func(c *client.CrowdStrikeAPISpecification) Service {
+		return &ioaService{c: c}
+	},
+	key: func(s *Spec) string {
+		return s.Name
+	},
+	validate: validateIOA,
+}
+
+func validateIOA(s *Spec) error {
+	if err := checkFields(s, true); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if _, err := strconv.ParseUint(s.PatternID, 10, 64); err != nil {
+		return fmt.Errorf("pattern_id must be the numeric ID of the detection pattern, got %q", s.PatternID)
+	}
+
+	if s.CommandLine == "" {
+		s.CommandLine = MatchAll
+	}
+	if s.ImageFilename == "" {
+		s.ImageFilename = MatchAll
+	}
+	if s.CommandLine == MatchAll && s.ImageFilename == MatchAll {
+		return fmt.Errorf("cl_regex or ifn_regex is required, the exclusion would match every process")
+	}
+
+	if err := ValidateRegex("cl_regex", s.CommandLine); err != nil {
+		return err
+	}
+	return ValidateRegex("ifn_regex", s.ImageFilename)
+}
+
+type ioaService struct {
+	c *client.CrowdStrikeAPISpecification
+}
+
+func (s *ioaService) List(ctx context.Context, filter string) ([]*Exclusion, error) {
+	ids, err := queryAll(func(offset, limit int64) (*models.MsaQueryResponse, error) {
+		params := &ioa_exclusions.QueryIOAExclusionsV1Params{
+			Context: ctx,
+			Offset:  &offset,
+			Limit:   &limit,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := s.c.IoaExclusions.QueryIOAExclusionsV1(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query IOA exclusions: %s", falcon.ErrorExplain(err))
+		}
+		return res.Payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*Exclusion{}
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := s.c.IoaExclusions.GetIOAExclusionsV1(&ioa_exclusions.GetIOAExclusionsV1Params{
+			Context: ctx,
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get IOA exclusions: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, e := range res.Payload.Resources {
+			list = append(list, ioaExclusion(e))
+		}
+	}
+
+	return list, nil
+}
+
+func (s *ioaService) Create(ctx context.Context, spec *Spec, groups []string, comment string) (*Exclusion, error) {
+	res, err := s.c.IoaExclusions.CreateIOAExclusionsV1(&ioa_exclusions.CreateIOAExclusionsV1Params{
+		Context: ctx,
+		Body: &models.RequestsIoaExclusionCreateReqV1{
+			Name:          &spec.Name,
+			Description:   &spec.Description,
+			PatternID:     &spec.PatternID,
+			PatternName:   &spec.PatternName,
+			ClRegex:       &spec.CommandLine,
+			IfnRegex:      &spec.ImageFilename,
+			DetectionJSON: utils.Ptr(""),
+			Groups:        groups,
+			Comment:       comment,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create IOA exclusion: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstIOAExclusion(res.Payload)
+}
+
+func (s *ioaService) Update(ctx context.Context, id string, spec *Spec, groups []string, comment string) (*Exclusion, error) {
+	res, err := s.c.IoaExclusions.UpdateIOAExclusionsV1(&ioa_exclusions.UpdateIOAExclusionsV1Params{
+		Context: ctx,
+		Body: &models.RequestsIoaExclusionUpdateReqV1{
+			ID:            &id,
+			Name:          &spec.Name,
+			Description:   &spec.Description,
+			PatternID:     &spec.PatternID,
+			PatternName:   &spec.PatternName,
+			ClRegex:       &spec.CommandLine,
+			IfnRegex:      &spec.ImageFilename,
+			DetectionJSON: utils.Ptr(""),
+			Groups:        groups,
+			Comment:       comment,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update IOA exclusion: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstIOAExclusion(res.Payload)
+}
+
+func (s *ioaService) Delete(ctx context.Context, ids []string, comment string) error {
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := s.c.IoaExclusions.DeleteIOAExclusionsV1(&ioa_exclusions.DeleteIOAExclusionsV1Params{
+			Context: ctx,
+			Ids:     chunk,
+			Comment: utils.OptionalPtr(comment),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete IOA exclusions: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func firstIOAExclusion(payload *models.ResponsesIoaExclusionRespV1) (*Exclusion, error) {
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Resources) == 0 {
+		return nil, fmt.Errorf("no exclusion returned")
+	}
+	return ioaExclusion(payload.Resources[0]), nil
+}
+
+func ioaExclusion(e *models.ResponsesIoaExclusionV1) *Exclusion {
+	return &Exclusion{
+		ID:              utils.Deref(e.ID),
+		Name:            utils.Deref(e.Name),
+		Description:     utils.Deref(e.Description),
+		PatternID:       utils.Deref(e.PatternID),
+		PatternName:     utils.Deref(e.PatternName),
+		CommandLine:     utils.Deref(e.ClRegex),
+		ImageFilename:   utils.Deref(e.IfnRegex),
+		AppliedGlobally: utils.Deref(e.AppliedGlobally),
+		Groups:          groups(e.Groups),
+		ModifiedBy:      utils.Deref(e.ModifiedBy),
+		LastModified:    utils.Deref(e.LastModified),
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package exclusion
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/ml_exclusions"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// What machine learning exclusions exclude files from
+const (
+	// ExcludeBlocking stops detections and preventions
+	ExcludeBlocking = "blocking"
+	// ExcludeExtraction stops uploads of the files to the cloud
+	ExcludeExtraction = "extraction"
+)
+
+// ExcludedFrom lists the values of the excluded_from field
+var ExcludedFrom = []string{ExcludeBlocking, ExcludeExtraction}
+
+// ML manages machine learning exclusions, which stop files matching a glob
+// pattern from being detected or uploaded
+var ML = &Type{
+	Kind:   "MLExclusion",
+	Name:   "ml",
+	Title:  "machine learning exclusion",
+	Plural: "machine learning exclusions",
+	New: func(c *client.CrowdStrikeAPISpecification) Service {
+		return &mlService{c: c}
+	},
+	key:      valueKey,
+	validate: validateML,
+}
+
+func valueKey(s *Spec) string {
+	return s.Value
+}
+
+func validateML(s *Spec) error {
+	if err := checkFields(s, false); err != nil {
+		return err
+	}
+
+	if err := ValidateGlob(s.Value); err != nil {
+		return err
+	}
+
+	if len(s.ExcludedFrom) == 0 {
+		s.ExcludedFrom = []string{ExcludeBlocking}
+	}
+	if err := utils.ValidateOneOf("excluded_from", ExcludedFrom, s.ExcludedFrom...); err != nil {
+		return err
+	}
+	s.ExcludedFrom = sorted(s.ExcludedFrom)
+
+	return nil
+}
+
+// checkFields rejects the fields of the other types of exclusions
+func checkFields(s *Spec, ioa bool) error {
+	if ioa {
+		if s.Value != "" || len(s.ExcludedFrom) > 0 {
+			return fmt.Errorf("value and excluded_from are not valid for IOA exclusions")
+		}
+		return nil
+	}
+
+	if s.Name != "" || s.Description != "" || s.PatternID != "" || s.PatternName != "" || s.CommandLine != "" || s.ImageFilename != "" {
+		return fmt.Errorf("name, description, pattern_id, pattern_name, cl_regex and ifn_regex are only valid for IOA exclusions")
+	}
+	return nil
+}
+
+type mlService struct {
+	c *client.CrowdStrikeAPISpecification
+}
+
+func (s *mlService) List(ctx context.Context, filter string) ([]*Exclusion, error) {
+	ids, err := queryAll(func(offset, limit int64) (*models.MsaQueryResponse, error) {
+		params := &ml_exclusions.QueryMLExclusionsV1Params{
+			Context: ctx,
+			Offset:  &offset,
+			Limit:   &limit,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := s.c.MlExclusions.QueryMLExclusionsV1(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query machine learning exclusions: %s", falcon.ErrorExplain(err))
+		}
+		return res.Payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.get(ctx, ids)
+}
+
+func (s *mlService) get(ctx context.Context, ids []string) ([]*Exclusion, error) {
+	list := []*Exclusion{}
+
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := s.c.MlExclusions.GetMLExclusionsV1(&ml_exclusions.GetMLExclusionsV1Params{
+			Context: ctx,
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get machine learning exclusions: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, e := range res.Payload.Resources {
+			list = append(list, mlExclusion(e))
+		}
+	}
+
+	return list, nil
+}
+
+func (s *mlService) Create(ctx context.Context, spec *Spec, groups []string, comment string) (*Exclusion, error) {
+	excludedFrom := []models.RequestsMlExclusionType{}
+	for _, v := range spec.ExcludedFrom {
+		excludedFrom = append(excludedFrom, v)
+	}
+
+	res, err := s.c.MlExclusions.CreateMLExclusionsV1(&ml_exclusions.CreateMLExclusionsV1Params{
+		Context: ctx,
+		Body: &models.RequestsMlExclusionCreateReqV1{
+			Value:        spec.Value,
+			ExcludedFrom: excludedFrom,
+			Groups:       groups,
+			Comment:      comment,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create machine learning exclusion: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstMLExclusion(res.Payload)
+}
+
+func (s *mlService) Update(ctx context.Context, id string, spec *Spec, groups []string, comment string) (*Exclusion, error) {
+	// the update API does not accept excluded_from
+	current, err := s.get(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(current) == 1 && !reflect.DeepEqual(sorted(current[0].ExcludedFrom), spec.ExcludedFrom) {
+		return nil, fmt.Errorf("excluded_from of machine learning exclusion %q cannot be changed, delete and create it again", spec.Value)
+	}
+
+	res, err := s.c.MlExclusions.UpdateMLExclusionsV1(&ml_exclusions.UpdateMLExclusionsV1Params{
+		Context: ctx,
+		Body: &models.RequestsSvExclusionUpdateReqV1{
+			ID:      &id,
+			Value:   spec.Value,
+			Groups:  groups,
+			Comment: comment,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update machine learning exclusion: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstMLExclusion(res.Payload)
+}
+
+func (s *mlService) Delete(ctx context.Context, ids []string, comment string) error {
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := s.c.MlExclusions.DeleteMLExclusionsV1(&ml_exclusions.DeleteMLExclusionsV1Params{
+			Context: ctx,
+			Ids:     chunk,
+			Comment: utils.OptionalPtr(comment),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete machine learning exclusions: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func firstMLExclusion(payload *models.ResponsesMlExclusionRespV1) (*Exclusion, error) {
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Resources) == 0 {
+		return nil, fmt.Errorf("no exclusion returned")
+	}
+	return mlExclusion(payload.Resources[0]), nil
+}
+
+func mlExclusion(e *models.ResponsesMlExclusionV1) *Exclusion {
+	return &Exclusion{
+		ID:              utils.Deref(e.ID),
+		Value:           utils.Deref(e.Value),
+		ExcludedFrom:    e.ExcludedFrom,
+		AppliedGlobally: utils.Deref(e.AppliedGlobally),
+		Groups:          groups(e.Groups),
+		ModifiedBy:      utils.Deref(e.ModifiedBy),
+		LastModified:    utils.Deref(e.LastModified),
+	}
+}
+
+// maxPerQuery is the maximum number of exclusion IDs returned per query
+const maxPerQuery = 500
+
+// maxPerRequest is the maximum number of exclusions fetched or deleted per
+// request
+const maxPerRequest = 100
+
+// queryAll pages through the results of a query with offsets
+func queryAll(query func(offset, limit int64) (*models.MsaQueryResponse, error)) ([]string, error) {
+	ids := []string{}
+
+	for {
+		payload, err := query(int64(len(ids)), maxPerQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = falcon.AssertNoError(payload.Errors); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, payload.Resources...)
+
+		if len(payload.Resources) == 0 || payload.Meta == nil || payload.Meta.Pagination == nil ||
+			int64(len(ids)) >= utils.Deref(payload.Meta.Pagination.Total) {
+			return ids, nil
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package exclusion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/sensor_visibility_exclusions"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// SV manages sensor visibility exclusions, which stop the sensor from
+// monitoring the processes started from files matching a glob pattern
+var SV = &Type{
+	Kind:   "SVExclusion",
+	Name:   "sv",
+	Title:  "sensor visibility exclusion",
+	Plural: "sensor visibility exclusions",
+	New: func(c *client.CrowdStrikeAPISpecification) Service {
+		return &svService{c: c}
+	},
+	key:      valueKey,
+	validate: validateSV,
+}
+
+func validateSV(s *Spec) error {
+	if err := checkFields(s, false); err != nil {
+		return err
+	}
+
+	if len(s.ExcludedFrom) > 0 {
+		return fmt.Errorf("excluded_from is only valid for machine learning exclusions")
+	}
+
+	return ValidateGlob(s.Value)
+}
+
+type svService struct {
+	c *client.CrowdStrikeAPISpecification
+}
+
+func (s *svService) List(ctx context.Context, filter string) ([]*Exclusion, error) {
+	ids, err := queryAll(func(offset, limit int64) (*models.MsaQueryResponse, error) {
+		params := &sensor_visibility_exclusions.QuerySensorVisibilityExclusionsV1Params{
+			Context: ctx,
+			Offset:  &offset,
+			Limit:   &limit,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := s.c.SensorVisibilityExclusions.QuerySensorVisibilityExclusionsV1(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query sensor visibility exclusions: %s", falcon.ErrorExplain(err))
+		}
+		return res.Payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*Exclusion{}
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := s.c.SensorVisibilityExclusions.GetSensorVisibilityExclusionsV1(&sensor_visibility_exclusions.GetSensorVisibilityExclusionsV1Params{
+			Context: ctx,
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sensor visibility exclusions: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, e := range res.Payload.Resources {
+			list = append(list, svExclusion(e))
+		}
+	}
+
+	return list, nil
+}
+
+func (s *svService) Create(ctx context.Context, spec *Spec, groups []string, comment string) (*Exclusion, error) {
+	res, err := s.c.SensorVisibilityExclusions.CreateSVExclusionsV1(&sensor_visibility_exclusions.CreateSVExclusionsV1Params{
+		Context: ctx,
+		Body: &models.RequestsSvExclusionCreateReqV1{
+			Value:   spec.Value,
+			Groups:  groups,
+			Comment: comment,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sensor visibility exclusion: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstMLExclusion(res.Payload)
+}
+
+func (s *svService) Update(ctx context.Context, id string, spec *Spec, groups []string, comment string) (*Exclusion, error) {
+	res, err := s.c.SensorVisibilityExclusions.UpdateSensorVisibilityExclusionsV1(&sensor_visibility_exclusions.UpdateSensorVisibilityExclusionsV1Params{
+		Context: ctx,
+		Body: &models.RequestsSvExclusionUpdateReqV1{
+			ID:      &id,
+			Value:   spec.Value,
+			Groups:  groups,
+			Comment: comment,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sensor visibility exclusion: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+	if len(res.Payload.Resources) == 0 {
+		return nil, fmt.Errorf("no exclusion returned")
+	}
+	return svExclusion(res.Payload.Resources[0]), nil
+}
+
+func (s *svService) Delete(ctx context.Context, ids []string, comment string) error {
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := s.c.SensorVisibilityExclusions.DeleteSensorVisibilityExclusionsV1(&sensor_visibility_exclusions.DeleteSensorVisibilityExclusionsV1Params{
+			Context: ctx,
+			Ids:     chunk,
+			Comment: utils.OptionalPtr(comment),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete sensor visibility exclusions: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func svExclusion(e *models.ResponsesSvExclusionV1) *Exclusion {
+	return &Exclusion{
+		ID:              utils.Deref(e.ID),
+		Value:           utils.Deref(e.Value),
+		AppliedGlobally: utils.Deref(e.AppliedGlobally),
+		Groups:          groups(e.Groups),
+		ModifiedBy:      utils.Deref(e.ModifiedBy),
+		LastModified:    utils.Deref(e.LastModified),
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package exclusion
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ValidateGlob checks a glob pattern of a machine learning or sensor
+// visibility exclusion. Patterns match paths without the drive letter, with
+// * matching within a path segment and ** matching any number of segments.
+func ValidateGlob(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("pattern is required")
+	}
+
+	if pattern != strings.TrimSpace(pattern) {
+		return fmt.Errorf("pattern %q has leading or trailing spaces", pattern)
+	}
+
+	for _, r := range pattern {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("pattern %q contains control characters", pattern)
+		}
+	}
+
+	if len(pattern) > 1 && pattern[1] == ':' && unicode.IsLetter(rune(pattern[0])) {
+		return fmt.Errorf("pattern %q must not start with a drive letter", pattern)
+	}
+
+	segments := strings.FieldsFunc(pattern, func(r rune) bool {
+		return r == '/' || r == '\\'
+	})
+
+	literal := false
+	for _, s := range segments {
+		if strings.Contains(s, "**") && s != "**" {
+			return fmt.Errorf("pattern %q uses ** within a path segment, ** must be a whole segment such as /**/", pattern)
+		}
+		if strings.Trim(s, "*?") != "" {
+			literal = true
+		}
+	}
+
+	if !literal {
+		return fmt.Errorf("pattern %q would exclude every file", pattern)
+	}
+
+	return nil
+}
+
+// ValidateRegex checks a regular expression of an IOA exclusion
+func ValidateRegex(field, expr string) error {
+	if expr == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+
+	if _, err := regexp.Compile(expr); err != nil {
+		return fmt.Errorf("invalid %s %q: %v", field, expr, err)
+	}
+	return nil
+}
@@ -0,0 +1,454 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package firewall manages firewall rule groups and their rules and
+// converts them to and from YAML specifications.
+package firewall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/firewall_management"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/strfmt"
+)
+
+// ErrNotFound is returned when a rule group or rule does not exist
+var ErrNotFound = errors.New("not found")
+
+// maxPerQuery is the maximum number of IDs returned per query
+const maxPerQuery = 500
+
+// maxPerRequest is the maximum number of resources fetched or deleted per
+// request
+const maxPerRequest = 100
+
+// Platform is a platform rule groups can be created for
+type Platform struct {
+	ID    string `json:"id"`
+	Label string `json:"label"`
+}
+
+// Group is a firewall rule group with its rules in precedence order
+type Group struct {
+	ID          string          `json:"id"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Platform    string          `json:"platform"`
+	Enabled     bool            `json:"enabled"`
+	Rules       []*Rule         `json:"rules"`
+	PolicyIDs   []string        `json:"policy_ids"`
+	ModifiedBy  string          `json:"modified_by"`
+	ModifiedOn  strfmt.DateTime `json:"modified_on"`
+
+	// tracking is the version of the group expected by updates
+	tracking string
+}
+
+// Rule is a rule of a rule group
+type Rule struct {
+	ID       string `json:"id" yaml:"id"`
+	Version  int64  `json:"version" yaml:"version"`
+	RuleSpec `yaml:",inline"`
+}
+
+// Spec returns the specification of the rule group
+func (g *Group) Spec() *GroupSpec {
+	enabled := g.Enabled
+	spec := &GroupSpec{
+		Kind:        Kind,
+		Name:        g.Name,
+		Description: g.Description,
+		Platform:    strings.ToLower(g.Platform),
+		Enabled:     &enabled,
+		Rules:       []RuleSpec{},
+	}
+	for _, r := range g.Rules {
+		spec.Rules = append(spec.Rules, r.RuleSpec)
+	}
+	return spec
+}
+
+// FindRule returns the rule of the group with the given ID or name
+func (g *Group) FindRule(nameOrID string) (*Rule, error) {
+	for _, r := range g.Rules {
+		if r.ID == nameOrID || strings.EqualFold(r.Name, nameOrID) {
+			return r, nil
+		}
+	}
+	return nil, fmt.Errorf("rule %q of rule group %q %w", nameOrID, g.Name, ErrNotFound)
+}
+
+// Platforms returns the platforms rule groups can be created for
+func Platforms(ctx context.Context, c *client.CrowdStrikeAPISpecification) ([]*Platform, error) {
+	offset := "0"
+	limit := int64(maxPerQuery)
+	res, err := c.FirewallManagement.QueryPlatforms(&firewall_management.QueryPlatformsParams{
+		Context: ctx,
+		Offset:  &offset,
+		Limit:   &limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query firewall platforms: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = assertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return []*Platform{}, nil
+	}
+
+	platforms, err := c.FirewallManagement.GetPlatforms(&firewall_management.GetPlatformsParams{
+		Context: ctx,
+		Ids:     res.Payload.Resources,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get firewall platforms: %s", falcon.ErrorExplain(err))
+	}
+
+	for _, e := range platforms.Payload.Errors {
+		return nil, fmt.Errorf("API Error %s: %s", e.ID, utils.Deref(e.Message))
+	}
+
+	list := []*Platform{}
+	for _, p := range platforms.Payload.Resources {
+		list = append(list, &Platform{ID: utils.Deref(p.ID), Label: utils.Deref(p.Label)})
+	}
+	return list, nil
+}
+
+// FindPlatform returns the platform with the given ID or label
+func FindPlatform(platforms []*Platform, platform string) (*Platform, error) {
+	labels := []string{}
+	for _, p := range platforms {
+		if p.ID == platform || strings.EqualFold(p.Label, platform) {
+			return p, nil
+		}
+		labels = append(labels, strings.ToLower(p.Label))
+	}
+	return nil, fmt.Errorf("invalid platform %q, must be one of: %s", platform, strings.Join(labels, ", "))
+}
+
+// ListGroups returns the rule groups matching filter, with their rules
+func ListGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter string) ([]*Group, error) {
+	ids := []string{}
+	limit := int64(maxPerQuery)
+	sort := "name.asc"
+
+	for {
+		offset := strconv.Itoa(len(ids))
+		params := &firewall_management.QueryRuleGroupsParams{
+			Context: ctx,
+			Offset:  &offset,
+			Limit:   &limit,
+			Sort:    &sort,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := c.FirewallManagement.QueryRuleGroups(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query firewall rule groups: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = assertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, res.Payload.Resources...)
+
+		meta := res.Payload.Meta
+		if len(res.Payload.Resources) == 0 || meta == nil || meta.Pagination == nil ||
+			int64(len(ids)) >= utils.Deref(meta.Pagination.Total) {
+			break
+		}
+	}
+
+	platforms, err := Platforms(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*Group{}
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := c.FirewallManagement.GetRuleGroups(&firewall_management.GetRuleGroupsParams{
+			Context: ctx,
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get firewall rule groups: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = assertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, g := range res.Payload.Resources {
+			group, err := getGroup(ctx, c, g, platforms)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, group)
+		}
+	}
+
+	return list, nil
+}
+
+// getGroup converts a rule group and fetches its rules
+func getGroup(ctx context.Context, c *client.CrowdStrikeAPISpecification, g *models.FwmgrAPIRuleGroupV1, platforms []*Platform) (*Group, error) {
+	group := &Group{
+		ID:          utils.Deref(g.ID),
+		Name:        utils.Deref(g.Name),
+		Description: utils.Deref(g.Description),
+		Platform:    utils.Deref(g.Platform),
+		Enabled:     utils.Deref(g.Enabled),
+		Rules:       []*Rule{},
+		PolicyIDs:   g.PolicyIds,
+		ModifiedBy:  utils.Deref(g.ModifiedBy),
+		tracking:    utils.Deref(g.Tracking),
+	}
+	if modified, err := strfmt.ParseDateTime(utils.Deref(g.ModifiedOn)); err == nil {
+		group.ModifiedOn = modified
+	}
+	if p, err := FindPlatform(platforms, group.Platform); err == nil {
+		group.Platform = strings.ToLower(p.Label)
+	}
+
+	rules := map[string]*models.FwmgrFirewallRuleV1{}
+	for _, chunk := range utils.Chunk(g.RuleIds, maxPerRequest) {
+		res, err := c.FirewallManagement.GetRules(&firewall_management.GetRulesParams{
+			Context: ctx,
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get firewall rules: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = assertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, r := range res.Payload.Resources {
+			rules[utils.Deref(r.ID)] = r
+		}
+	}
+
+	// rule IDs are listed in precedence order
+	for _, id := range g.RuleIds {
+		r, ok := rules[id]
+		if !ok || utils.Deref(r.Deleted) {
+			continue
+		}
+		group.Rules = append(group.Rules, &Rule{ID: id, Version: utils.Deref(r.Version), RuleSpec: ruleSpec(r)})
+	}
+
+	return group, nil
+}
+
+// FindGroup returns the rule group with the given ID or name. Names are
+// matched case insensitively.
+func FindGroup(ctx context.Context, c *client.CrowdStrikeAPISpecification, nameOrID string) (*Group, error) {
+	list, err := ListGroups(ctx, c, "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range list {
+		if g.ID == nameOrID {
+			return g, nil
+		}
+	}
+	for _, g := range list {
+		if strings.EqualFold(g.Name, nameOrID) {
+			return g, nil
+		}
+	}
+
+	return nil, fmt.Errorf("firewall rule group %q %w", nameOrID, ErrNotFound)
+}
+
+// CreateGroup creates a rule group with the rules of a specification and
+// returns its ID
+func CreateGroup(ctx context.Context, c *client.CrowdStrikeAPISpecification, spec *GroupSpec, comment string) (string, error) {
+	platforms, err := Platforms(ctx, c)
+	if err != nil {
+		return "", err
+	}
+	platform, err := FindPlatform(platforms, spec.Platform)
+	if err != nil {
+		return "", err
+	}
+
+	rules := []*models.FwmgrAPIRuleCreateRequestV1{}
+	for i := range spec.Rules {
+		rules = append(rules, spec.Rules[i].request(strconv.Itoa(i+1)))
+	}
+
+	enabled := spec.Enabled != nil && *spec.Enabled
+	res, err := c.FirewallManagement.CreateRuleGroup(&firewall_management.CreateRuleGroupParams{
+		Context: ctx,
+		Comment: utils.OptionalPtr(comment),
+		Body: &models.FwmgrAPIRuleGroupCreateRequestV1{
+			Name:        &spec.Name,
+			Description: &spec.Description,
+			Platform:    &platform.ID,
+			Enabled:     &enabled,
+			Rules:       rules,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create firewall rule group: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = assertNoError(res.Payload.Errors); err != nil {
+		return "", err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return "", fmt.Errorf("firewall rule group %q was not created", spec.Name)
+	}
+	return res.Payload.Resources[0], nil
+}
+
+// UpdateGroup replaces the name, description, enabled state and rules of a
+// rule group with those of a specification. Rules keep their ID when a
+// rule of the same name exists. The enabled state is left unchanged when
+// the specification does not set it.
+func UpdateGroup(ctx context.Context, c *client.CrowdStrikeAPISpecification, g *Group, spec *GroupSpec, comment string) error {
+	enabled := g.Enabled
+	if spec.Enabled != nil {
+		enabled = *spec.Enabled
+	}
+
+	rules := []interface{}{}
+	for i := range spec.Rules {
+		req := spec.Rules[i].request(strconv.Itoa(i + 1))
+
+		v, err := output.ToGeneric(req)
+		if err != nil {
+			return err
+		}
+		rule := v.(map[string]interface{})
+
+		if existing, err := g.FindRule(spec.Rules[i].Name); err == nil {
+			delete(rule, "temp_id")
+			rule["id"] = existing.ID
+		}
+		rules = append(rules, rule)
+	}
+
+	ids := []string{}
+	versions := []int64{}
+	for _, r := range g.Rules {
+		ids = append(ids, r.ID)
+		versions = append(versions, r.Version)
+	}
+
+	diffType := "application/json-patch+json"
+	res, err := c.FirewallManagement.UpdateRuleGroup(&firewall_management.UpdateRuleGroupParams{
+		Context: ctx,
+		Comment: utils.OptionalPtr(comment),
+		Body: &models.FwmgrAPIRuleGroupModifyRequestV1{
+			ID:           &g.ID,
+			Tracking:     &g.tracking,
+			DiffType:     &diffType,
+			RuleIds:      ids,
+			RuleVersions: versions,
+			DiffOperations: []*models.FwmgrAPIJSONDiff{
+				replace("/name", spec.Name),
+				replace("/description", spec.Description),
+				replace("/enabled", enabled),
+				replace("/rules", rules),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update firewall rule group: %s", falcon.ErrorExplain(err))
+	}
+
+	return assertNoError(res.Payload.Errors)
+}
+
+func replace(path string, value interface{}) *models.FwmgrAPIJSONDiff {
+	op := "replace"
+	return &models.FwmgrAPIJSONDiff{Op: &op, Path: &path, Value: value}
+}
+
+// DeleteGroups deletes rule groups and their rules
+func DeleteGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string, comment string) error {
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := c.FirewallManagement.DeleteRuleGroups(&firewall_management.DeleteRuleGroupsParams{
+			Context: ctx,
+			Ids:     chunk,
+			Comment: utils.OptionalPtr(comment),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete firewall rule groups: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = assertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Apply creates the rule group described by spec, or updates the rule
+// group of the same name. It reports whether the group was created.
+func Apply(ctx context.Context, c *client.CrowdStrikeAPISpecification, spec *GroupSpec, comment string) (bool, error) {
+	g, err := FindGroup(ctx, c, spec.Name)
+	if errors.Is(err, ErrNotFound) {
+		_, err := CreateGroup(ctx, c, spec, comment)
+		return true, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if !strings.EqualFold(g.Platform, spec.Platform) {
+		return false, fmt.Errorf("firewall rule group %q is for %s and cannot be moved to %s, delete it first", g.Name, g.Platform, spec.Platform)
+	}
+	return false, UpdateGroup(ctx, c, g, spec, comment)
+}
+
+// assertNoError returns the errors of a firewall management response
+func assertNoError(errs []*models.FwmgrMsaspecError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := []string{}
+	for _, e := range errs {
+		messages = append(messages, fmt.Sprintf("API Error %s: %s", e.ID, utils.Deref(e.Message)))
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
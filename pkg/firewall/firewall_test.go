@@ -0,0 +1,342 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package firewall
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+const webServers = `
+kind: FirewallRuleGroup
+name: Web servers
+platform: Windows
+rules:
+  - name: allow https
+    action: allow
+    direction: in
+    protocol: TCP
+    local_ports: ["443", "8000-8080"]
+    remote_addresses: [10.0.0.0/8, 192.168.1.10/32]
+  - name: block telnet
+    action: deny
+    direction: both
+    protocol: "6"
+    remote_ports: ["23"]
+  - name: allow ping
+    action: allow
+    direction: in
+    protocol: icmp
+    icmp:
+      type: "8"
+`
+
+// ruleFromRequest returns the rule the API would store for a request, as
+// both share their JSON representation
+func ruleFromRequest(t *testing.T, req *models.FwmgrAPIRuleCreateRequestV1) *models.FwmgrFirewallRuleV1 {
+	t.Helper()
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := &models.FwmgrFirewallRuleV1{}
+	if err := json.Unmarshal(b, rule); err != nil {
+		t.Fatal(err)
+	}
+	return rule
+}
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *Address
+		family  string
+		wantErr string
+	}{
+		{in: "any", family: FamilyAny},
+		{in: "*", family: FamilyAny},
+		{in: "10.1.2.3", want: &Address{"10.1.2.3", 32}, family: FamilyIPv4},
+		{in: "10.0.0.0/8", want: &Address{"10.0.0.0", 8}, family: FamilyIPv4},
+		{in: "2001:db8::/32", want: &Address{"2001:db8::", 32}, family: FamilyIPv6},
+		{in: "::1", want: &Address{"::1", 128}, family: FamilyIPv6},
+		{in: "10.0.0.1/8", wantErr: "did you mean 10.0.0.0/8"},
+		{in: "10.0.0.0/33", wantErr: "invalid network"},
+		{in: "300.1.1.1", wantErr: "invalid address"},
+		{in: "example.com", wantErr: "invalid address"},
+	}
+
+	for _, tt := range tests {
+		got, family, err := ParseAddress(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseAddress(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAddress(%q) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" || family != tt.family {
+			t.Errorf("ParseAddress(%q) = %v, %s, want %v, %s", tt.in, got, family, tt.want, tt.family)
+		}
+	}
+}
+
+func TestFormatAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		netmask int64
+		want    string
+	}{
+		{"", 0, "any"},
+		{"*", 0, "any"},
+		{"10.1.2.3", 32, "10.1.2.3"},
+		{"10.0.0.0", 8, "10.0.0.0/8"},
+		{"0.0.0.0", 0, "0.0.0.0/0"},
+		{"::", 0, "::/0"},
+		{"::1", 128, "::1"},
+	}
+
+	for _, tt := range tests {
+		got := FormatAddress(tt.address, tt.netmask)
+		if got != tt.want {
+			t.Errorf("FormatAddress(%q, %d) = %q, want %q", tt.address, tt.netmask, got, tt.want)
+		}
+
+		if addr, _, err := ParseAddress(got); err != nil || (addr != nil && (addr.Address != tt.address || addr.Netmask != tt.netmask)) {
+			t.Errorf("ParseAddress(%q) = %v, %v, want %s with netmask %d", got, addr, err, tt.address, tt.netmask)
+		}
+	}
+}
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    *Port
+		wantErr string
+	}{
+		{in: "443", want: &Port{Start: 443}},
+		{in: "8000-8080", want: &Port{Start: 8000, End: 8080}},
+		{in: "0", wantErr: "not between 1 and 65535"},
+		{in: "65536", wantErr: "not between 1 and 65535"},
+		{in: "http", wantErr: "not a number"},
+		{in: "8080-8000", wantErr: "greater than the start"},
+		{in: "80-", wantErr: "invalid port range"},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePort(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParsePort(%q) error = %v, want %q", tt.in, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePort(%q) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("ParsePort(%q) mismatch (-want +got):\n%s", tt.in, diff)
+		}
+	}
+}
+
+func TestReadSpecs(t *testing.T) {
+	specs, err := ReadSpecs(strings.NewReader(webServers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 1 {
+		t.Fatalf("got %d specs, want 1", len(specs))
+	}
+
+	want := &GroupSpec{
+		Kind:     Kind,
+		Name:     "Web servers",
+		Platform: "windows",
+		Rules: []RuleSpec{{
+			Name:            "allow https",
+			Action:          "ALLOW",
+			Direction:       "IN",
+			AddressFamily:   FamilyIPv4,
+			Protocol:        "tcp",
+			LocalPorts:      []string{"443", "8000-8080"},
+			RemoteAddresses: []string{"10.0.0.0/8", "192.168.1.10"},
+		}, {
+			Name:          "block telnet",
+			Action:        "DENY",
+			Direction:     "BOTH",
+			AddressFamily: FamilyAny,
+			Protocol:      "tcp",
+			RemotePorts:   []string{"23"},
+		}, {
+			Name:          "allow ping",
+			Action:        "ALLOW",
+			Direction:     "IN",
+			AddressFamily: FamilyAny,
+			Protocol:      "icmp",
+			ICMP:          &ICMP{Type: "8"},
+		}},
+	}
+	if diff := cmp.Diff(want, specs[0]); diff != "" {
+		t.Errorf("ReadSpecs() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	rule := func(edit func(r *RuleSpec)) RuleSpec {
+		r := RuleSpec{Name: "r", Action: "ALLOW", Direction: "IN"}
+		edit(&r)
+		return r
+	}
+
+	tests := []struct {
+		name    string
+		rule    RuleSpec
+		wantErr string
+	}{
+		{"action", rule(func(r *RuleSpec) { r.Action = "drop" }), "action"},
+		{"direction", rule(func(r *RuleSpec) { r.Direction = "sideways" }), "direction"},
+		{"protocol", rule(func(r *RuleSpec) { r.Protocol = "sctp" }), "invalid protocol"},
+		{"protocol number", rule(func(r *RuleSpec) { r.Protocol = "256" }), "invalid protocol"},
+		{"mixed families", rule(func(r *RuleSpec) {
+			r.LocalAddresses = []string{"10.0.0.1"}
+			r.RemoteAddresses = []string{"::1"}
+		}), "mixes IPv4 and IPv6"},
+		{"family mismatch", rule(func(r *RuleSpec) {
+			r.AddressFamily = FamilyIPv6
+			r.RemoteAddresses = []string{"10.0.0.1"}
+		}), "not an IP6 address"},
+		{"any family with addresses", rule(func(r *RuleSpec) {
+			r.AddressFamily = FamilyAny
+			r.RemoteAddresses = []string{"10.0.0.1"}
+		}), "must be for address family"},
+		{"any with others", rule(func(r *RuleSpec) { r.RemoteAddresses = []string{"any", "10.0.0.1"} }), "cannot be combined"},
+		{"ports without protocol", rule(func(r *RuleSpec) { r.LocalPorts = []string{"80"} }), "only valid for the tcp and udp"},
+		{"bad port", rule(func(r *RuleSpec) {
+			r.Protocol = "udp"
+			r.LocalPorts = []string{"99999"}
+		}), "invalid port"},
+		{"icmp for tcp", rule(func(r *RuleSpec) {
+			r.Protocol = "tcp"
+			r.ICMP = &ICMP{Type: "8"}
+		}), "icmp is only valid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &GroupSpec{Kind: Kind, Name: "g", Platform: "windows", Rules: []RuleSpec{tt.rule}}
+			err := s.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	s := &GroupSpec{Kind: Kind, Name: "g", Platform: "windows", Rules: []RuleSpec{
+		{Name: "a", Action: "ALLOW", Direction: "IN"},
+		{Name: "A", Action: "DENY", Direction: "OUT"},
+	}}
+	if err := s.Validate(); err == nil || !strings.Contains(err.Error(), "more than once") {
+		t.Errorf("Validate() error = %v, want duplicate rule", err)
+	}
+}
+
+func TestRuleRoundTrip(t *testing.T) {
+	specs, err := ReadSpecs(strings.NewReader(webServers))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range specs[0].Rules {
+		req := want.request("1")
+
+		if got := len(req.LocalAddress); got != 1 || *req.LocalAddress[0].Address != anyAddress {
+			t.Errorf("rule %q: omitted local addresses should match any", want.Name)
+		}
+
+		rule := ruleFromRequest(t, req)
+		got := ruleSpec(rule)
+		want.Enabled = got.Enabled
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("rule %q round trip mismatch (-want +got):\n%s", want.Name, diff)
+		}
+	}
+}
+
+func TestChanges(t *testing.T) {
+	specs, err := ReadSpecs(strings.NewReader(webServers))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := specs[0]
+
+	group := &Group{ID: "1", Name: spec.Name, Platform: "windows", Enabled: true}
+	for i, r := range spec.Rules {
+		rule := ruleSpec(ruleFromRequest(t, r.request("")))
+		group.Rules = append(group.Rules, &Rule{ID: string(rune('a' + i)), RuleSpec: rule})
+	}
+
+	changes, err := Changes(group, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Changes() = %v, want none", changes)
+	}
+
+	spec.Rules[0].LocalPorts = []string{"443"}
+	spec.Enabled = new(bool)
+	changes, err = Changes(group, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 {
+		t.Errorf("Changes() = %v, want enabled and local_ports", changes)
+	}
+}
+
+func TestSpecRoundTrip(t *testing.T) {
+	specs, err := ReadSpecs(strings.NewReader(webServers))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSpecs(&buf, specs); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadSpecs(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(specs, got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package firewall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/firewall_management"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// PolicySettings are the firewall settings of a policy. RuleGroupIDs lists
+// the rule groups of the policy in precedence order.
+type PolicySettings struct {
+	PolicyID        string
+	PlatformID      string
+	DefaultInbound  string
+	DefaultOutbound string
+	Enforce         bool
+	LocalLogging    bool
+	TestMode        bool
+	RuleGroupIDs    []string
+
+	tracking string
+}
+
+// GetPolicySettings returns the firewall settings of policies keyed by
+// policy ID
+func GetPolicySettings(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) (map[string]*PolicySettings, error) {
+	settings := map[string]*PolicySettings{}
+	for _, chunk := range utils.Chunk(ids, maxPerRequest) {
+		res, err := c.FirewallManagement.GetPolicyContainers(&firewall_management.GetPolicyContainersParams{
+			Context: ctx,
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get firewall policy settings: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = assertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, p := range res.Payload.Resources {
+			s := &PolicySettings{
+				PolicyID:        utils.Deref(p.PolicyID),
+				PlatformID:      utils.Deref(p.PlatformID),
+				DefaultInbound:  utils.Deref(p.DefaultInbound),
+				DefaultOutbound: utils.Deref(p.DefaultOutbound),
+				Enforce:         utils.Deref(p.Enforce),
+				LocalLogging:    utils.Deref(p.LocalLogging),
+				TestMode:        utils.Deref(p.TestMode),
+				RuleGroupIDs:    p.RuleGroupIds,
+				tracking:        p.Tracking,
+			}
+			if s.RuleGroupIDs == nil {
+				s.RuleGroupIDs = []string{}
+			}
+			settings[s.PolicyID] = s
+		}
+	}
+	return settings, nil
+}
+
+// UpdatePolicySettings replaces the firewall settings of a policy
+func UpdatePolicySettings(ctx context.Context, c *client.CrowdStrikeAPISpecification, s *PolicySettings) error {
+	ok, created, err := c.FirewallManagement.UpdatePolicyContainer(&firewall_management.UpdatePolicyContainerParams{
+		Context: ctx,
+		Body: &models.FwmgrAPIPolicyContainerUpsertRequestV1{
+			PolicyID:        &s.PolicyID,
+			PlatformID:      &s.PlatformID,
+			DefaultInbound:  &s.DefaultInbound,
+			DefaultOutbound: &s.DefaultOutbound,
+			Enforce:         &s.Enforce,
+			LocalLogging:    &s.LocalLogging,
+			TestMode:        &s.TestMode,
+			RuleGroupIds:    s.RuleGroupIDs,
+			Tracking:        s.tracking,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update firewall policy settings: %s", falcon.ErrorExplain(err))
+	}
+
+	// the settings are created on the first update of a policy
+	if created != nil {
+		return assertNoError(created.Payload.Errors)
+	}
+	return assertNoError(ok.Payload.Errors)
+}
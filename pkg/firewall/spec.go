@@ -0,0 +1,461 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package firewall
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/crowdstrike/falcon-cli/pkg/manifest"
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Kind identifies rule group specifications
+const Kind = "FirewallRuleGroup"
+
+// Actions, directions and address families of rules
+var (
+	Actions    = []string{"ALLOW", "DENY"}
+	Directions = []string{"IN", "OUT", "BOTH"}
+	Families   = []string{FamilyIPv4, FamilyIPv6, FamilyAny}
+)
+
+// ProtocolAny matches every protocol
+const ProtocolAny = "any"
+
+// protocols maps the names of common protocols to their numbers
+var protocols = map[string]string{
+	ProtocolAny: anyAddress,
+	"icmp":      "1",
+	"tcp":       "6",
+	"udp":       "17",
+	"icmpv6":    "58",
+}
+
+// GroupSpec is the specification of a rule group as written to and read
+// from YAML files. Rules are listed in precedence order.
+type GroupSpec struct {
+	Kind        string     `json:"kind" yaml:"kind"`
+	Name        string     `json:"name" yaml:"name"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Platform    string     `json:"platform" yaml:"platform"`
+	Enabled     *bool      `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Rules       []RuleSpec `json:"rules" yaml:"rules"`
+}
+
+// RuleSpec is the specification of a rule. Addresses are IP addresses or
+// CIDR networks and ports are single ports or ranges such as 8000-8080.
+// Omitted addresses and ports match any. Fields holds the other matching
+// criteria, such as the network location or the executable.
+type RuleSpec struct {
+	Name            string   `json:"name" yaml:"name"`
+	Description     string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Enabled         *bool    `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Action          string   `json:"action" yaml:"action"`
+	Direction       string   `json:"direction" yaml:"direction"`
+	AddressFamily   string   `json:"address_family,omitempty" yaml:"address_family,omitempty"`
+	Protocol        string   `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	LocalAddresses  []string `json:"local_addresses,omitempty" yaml:"local_addresses,omitempty"`
+	RemoteAddresses []string `json:"remote_addresses,omitempty" yaml:"remote_addresses,omitempty"`
+	LocalPorts      []string `json:"local_ports,omitempty" yaml:"local_ports,omitempty"`
+	RemotePorts     []string `json:"remote_ports,omitempty" yaml:"remote_ports,omitempty"`
+	ICMP            *ICMP    `json:"icmp,omitempty" yaml:"icmp,omitempty"`
+	Monitor         *Monitor `json:"monitor,omitempty" yaml:"monitor,omitempty"`
+	Log             bool     `json:"log,omitempty" yaml:"log,omitempty"`
+	Fields          []Field  `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// ICMP restricts a rule to an ICMP type and code
+type ICMP struct {
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	Code string `json:"code,omitempty" yaml:"code,omitempty"`
+}
+
+// Monitor sets how many connections in a period trigger a rule
+type Monitor struct {
+	Count    string `json:"count,omitempty" yaml:"count,omitempty"`
+	PeriodMs string `json:"period_ms,omitempty" yaml:"period_ms,omitempty"`
+}
+
+// Field is a matching criterion of a rule as named by the API, such as
+// network_location or image_name
+type Field struct {
+	Name   string   `json:"name" yaml:"name"`
+	Type   string   `json:"type,omitempty" yaml:"type,omitempty"`
+	Value  string   `json:"value,omitempty" yaml:"value,omitempty"`
+	Values []string `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// Validate checks that the specification is complete and that the
+// addresses and ports of its rules are valid. Values are normalized so
+// that specifications can be compared.
+func (s *GroupSpec) Validate() error {
+	if s.Kind != Kind {
+		return fmt.Errorf("expected kind %s, got %q", Kind, s.Kind)
+	}
+
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.TrimSpace(s.Platform) == "" {
+		return fmt.Errorf("platform is required")
+	}
+	s.Platform = strings.ToLower(s.Platform)
+
+	seen := map[string]bool{}
+	for i := range s.Rules {
+		r := &s.Rules[i]
+		if err := r.Validate(); err != nil {
+			if r.Name == "" {
+				return fmt.Errorf("rule #%d: %v", i+1, err)
+			}
+			return fmt.Errorf("rule %q: %v", r.Name, err)
+		}
+
+		key := strings.ToLower(r.Name)
+		if seen[key] {
+			return fmt.Errorf("rule %q is described more than once", r.Name)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// Validate checks and normalizes a rule
+func (r *RuleSpec) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	r.Action = strings.ToUpper(r.Action)
+	if err := utils.ValidateOneOf("action", Actions, r.Action); err != nil {
+		return err
+	}
+	r.Direction = strings.ToUpper(r.Direction)
+	if err := utils.ValidateOneOf("direction", Directions, r.Direction); err != nil {
+		return err
+	}
+
+	protocol, err := normalizeProtocol(r.Protocol)
+	if err != nil {
+		return err
+	}
+	r.Protocol = protocol
+
+	if err := r.validateAddresses(); err != nil {
+		return err
+	}
+
+	ports := r.Protocol == "tcp" || r.Protocol == "udp"
+	if !ports && (len(r.LocalPorts) > 0 || len(r.RemotePorts) > 0) {
+		return fmt.Errorf("ports are only valid for the tcp and udp protocols, not %s", r.Protocol)
+	}
+	if r.LocalPorts, err = normalizePorts(r.LocalPorts); err != nil {
+		return err
+	}
+	if r.RemotePorts, err = normalizePorts(r.RemotePorts); err != nil {
+		return err
+	}
+
+	if r.ICMP != nil && r.Protocol != "icmp" && r.Protocol != "icmpv6" {
+		return fmt.Errorf("icmp is only valid for the icmp and icmpv6 protocols, not %s", r.Protocol)
+	}
+
+	for _, f := range r.Fields {
+		if f.Name == "" {
+			return fmt.Errorf("every field needs a name")
+		}
+	}
+
+	return nil
+}
+
+// validateAddresses normalizes the addresses of a rule and checks that
+// they belong to its address family, which is inferred when omitted
+func (r *RuleSpec) validateAddresses() error {
+	want := strings.ToUpper(r.AddressFamily)
+	if want != "" {
+		if err := utils.ValidateOneOf("address_family", Families, want); err != nil {
+			return err
+		}
+	}
+
+	families := map[string]bool{}
+	normalize := func(list []string) ([]string, error) {
+		out := []string{}
+		for _, s := range list {
+			addr, fam, err := ParseAddress(s)
+			if err != nil {
+				return nil, err
+			}
+			if addr == nil {
+				if len(list) > 1 {
+					return nil, fmt.Errorf("address %q matches any address and cannot be combined with others", s)
+				}
+				continue
+			}
+			if want != "" && want != FamilyAny && want != fam {
+				return nil, fmt.Errorf("address %q is not an %s address", s, want)
+			}
+			families[fam] = true
+			out = append(out, FormatAddress(addr.Address, addr.Netmask))
+		}
+		if len(out) == 0 {
+			return nil, nil
+		}
+		return out, nil
+	}
+
+	var err error
+	if r.LocalAddresses, err = normalize(r.LocalAddresses); err != nil {
+		return err
+	}
+	if r.RemoteAddresses, err = normalize(r.RemoteAddresses); err != nil {
+		return err
+	}
+
+	switch {
+	case len(families) > 1:
+		return fmt.Errorf("rule mixes IPv4 and IPv6 addresses, split it into a rule per address family")
+	case want == FamilyAny && len(families) > 0:
+		return fmt.Errorf("rules with addresses must be for address family %s or %s", FamilyIPv4, FamilyIPv6)
+	case want == "" && families[FamilyIPv4]:
+		want = FamilyIPv4
+	case want == "" && families[FamilyIPv6]:
+		want = FamilyIPv6
+	case want == "":
+		want = FamilyAny
+	}
+	r.AddressFamily = want
+
+	return nil
+}
+
+func normalizeProtocol(p string) (string, error) {
+	p = strings.ToLower(strings.TrimSpace(p))
+	if p == "" || p == anyAddress {
+		return ProtocolAny, nil
+	}
+	if _, ok := protocols[p]; ok {
+		return p, nil
+	}
+
+	n, err := strconv.Atoi(p)
+	if err != nil || n < 0 || n > 255 {
+		return "", fmt.Errorf("invalid protocol %q, must be any, tcp, udp, icmp, icmpv6 or a protocol number", p)
+	}
+	return protocolName(p), nil
+}
+
+// protocolName returns the name of a protocol number, or the number for
+// protocols without a name
+func protocolName(number string) string {
+	for name, n := range protocols {
+		if n == number {
+			return name
+		}
+	}
+	return number
+}
+
+func protocolNumber(name string) string {
+	if n, ok := protocols[name]; ok {
+		return n
+	}
+	return name
+}
+
+func normalizePorts(list []string) ([]string, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	out := []string{}
+	for _, s := range list {
+		p, err := ParsePort(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, FormatPort(p.Start, p.End))
+	}
+	return out, nil
+}
+
+// request returns the API representation of a rule
+func (r *RuleSpec) request(tempID string) *models.FwmgrAPIRuleCreateRequestV1 {
+	enabled := r.Enabled == nil || *r.Enabled
+	req := &models.FwmgrAPIRuleCreateRequestV1{
+		TempID:        utils.Ptr(tempID),
+		Name:          utils.Ptr(r.Name),
+		Description:   utils.Ptr(r.Description),
+		Enabled:       &enabled,
+		Action:        utils.Ptr(r.Action),
+		Direction:     utils.Ptr(r.Direction),
+		AddressFamily: utils.Ptr(r.AddressFamily),
+		Protocol:      utils.Ptr(protocolNumber(r.Protocol)),
+		LocalAddress:  addressRanges(r.LocalAddresses),
+		RemoteAddress: addressRanges(r.RemoteAddresses),
+		LocalPort:     portRanges(r.LocalPorts),
+		RemotePort:    portRanges(r.RemotePorts),
+		Log:           utils.Ptr(r.Log),
+		Fields:        []*models.FwmgrAPIWorkaroundUIFieldValue{},
+	}
+
+	if r.ICMP != nil {
+		req.Icmp = &models.FwmgrDomainICMP{IcmpType: utils.Ptr(r.ICMP.Type), IcmpCode: utils.Ptr(r.ICMP.Code)}
+	}
+	if r.Monitor != nil {
+		req.Monitor = &models.FwmgrDomainMonitoring{Count: utils.Ptr(r.Monitor.Count), PeriodMs: utils.Ptr(r.Monitor.PeriodMs)}
+	}
+	for _, f := range r.Fields {
+		req.Fields = append(req.Fields, &models.FwmgrAPIWorkaroundUIFieldValue{
+			Name:   utils.Ptr(f.Name),
+			Type:   f.Type,
+			Value:  f.Value,
+			Values: f.Values,
+		})
+	}
+
+	return req
+}
+
+// addressRanges converts validated addresses, with no addresses matching
+// any
+func addressRanges(list []string) []*models.FwmgrDomainAddressRange {
+	if len(list) == 0 {
+		return []*models.FwmgrDomainAddressRange{{Address: utils.Ptr(anyAddress)}}
+	}
+
+	out := []*models.FwmgrDomainAddressRange{}
+	for _, s := range list {
+		addr, _, _ := ParseAddress(s)
+		out = append(out, &models.FwmgrDomainAddressRange{Address: &addr.Address, Netmask: addr.Netmask})
+	}
+	return out
+}
+
+// portRanges converts validated ports
+func portRanges(list []string) []*models.FwmgrDomainPortRange {
+	out := []*models.FwmgrDomainPortRange{}
+	for _, s := range list {
+		p, _ := ParsePort(s)
+		out = append(out, &models.FwmgrDomainPortRange{Start: &p.Start, End: &p.End})
+	}
+	return out
+}
+
+// ruleSpec returns the specification of a rule returned by the API
+func ruleSpec(r *models.FwmgrFirewallRuleV1) RuleSpec {
+	enabled := utils.Deref(r.Enabled)
+	spec := RuleSpec{
+		Name:          utils.Deref(r.Name),
+		Description:   utils.Deref(r.Description),
+		Enabled:       &enabled,
+		Action:        utils.Deref(r.Action),
+		Direction:     utils.Deref(r.Direction),
+		AddressFamily: utils.Deref(r.AddressFamily),
+		Protocol:      protocolName(utils.Deref(r.Protocol)),
+	}
+
+	for _, a := range r.LocalAddress {
+		if addr := utils.Deref(a.Address); addr != "" && addr != anyAddress {
+			spec.LocalAddresses = append(spec.LocalAddresses, FormatAddress(addr, a.Netmask))
+		}
+	}
+	for _, a := range r.RemoteAddress {
+		if addr := utils.Deref(a.Address); addr != "" && addr != anyAddress {
+			spec.RemoteAddresses = append(spec.RemoteAddresses, FormatAddress(addr, a.Netmask))
+		}
+	}
+	for _, p := range r.LocalPort {
+		spec.LocalPorts = append(spec.LocalPorts, FormatPort(utils.Deref(p.Start), utils.Deref(p.End)))
+	}
+	for _, p := range r.RemotePort {
+		spec.RemotePorts = append(spec.RemotePorts, FormatPort(utils.Deref(p.Start), utils.Deref(p.End)))
+	}
+
+	if r.Icmp != nil && (utils.Deref(r.Icmp.IcmpType) != "" || utils.Deref(r.Icmp.IcmpCode) != "") {
+		spec.ICMP = &ICMP{Type: utils.Deref(r.Icmp.IcmpType), Code: utils.Deref(r.Icmp.IcmpCode)}
+	}
+	if r.Monitor != nil && (utils.Deref(r.Monitor.Count) != "" || utils.Deref(r.Monitor.PeriodMs) != "") {
+		spec.Monitor = &Monitor{Count: utils.Deref(r.Monitor.Count), PeriodMs: utils.Deref(r.Monitor.PeriodMs)}
+	}
+	for _, f := range r.Fields {
+		spec.Fields = append(spec.Fields, Field{
+			Name:   utils.Deref(f.Name),
+			Type:   utils.Deref(f.Type),
+			Value:  utils.Deref(f.Value),
+			Values: f.Values,
+		})
+	}
+
+	return spec
+}
+
+// Changes returns the differences between a rule group and a
+// specification. The enabled state of the group is only compared when the
+// specification sets it, and the log setting of rules is not compared as
+// the API does not return it.
+func Changes(current *Group, spec *GroupSpec) ([]diff.Change, error) {
+	have := current.Spec()
+	if spec.Enabled == nil {
+		have.Enabled = nil
+	}
+
+	want := *spec
+	want.Rules = []RuleSpec{}
+	for _, r := range spec.Rules {
+		// rules are enabled unless the specification says otherwise
+		if r.Enabled == nil {
+			r.Enabled = utils.Ptr(true)
+		}
+		r.Log = false
+		want.Rules = append(want.Rules, r)
+	}
+
+	a, err := output.ToGeneric(have)
+	if err != nil {
+		return nil, err
+	}
+	b, err := output.ToGeneric(&want)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Compare(a, b), nil
+}
+
+// ReadSpecs decodes and validates a stream of YAML rule group
+// specifications
+func ReadSpecs(r io.Reader) ([]*GroupSpec, error) {
+	return manifest.ReadStream(r, "rule group specification", (*GroupSpec).Validate)
+}
+
+// WriteSpecs writes specifications as a stream of YAML documents
+func WriteSpecs(w io.Writer, specs []*GroupSpec) error {
+	return manifest.WriteStream(w, specs)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package firewall
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Address families of rules
+const (
+	FamilyIPv4 = "IP4"
+	FamilyIPv6 = "IP6"
+	FamilyAny  = "NONE"
+)
+
+// anyAddress is how the API represents any address
+const anyAddress = "*"
+
+// Address is an address or network of a rule as sent to the API
+type Address struct {
+	Address string
+	Netmask int64
+}
+
+// ParseAddress checks an IP address or CIDR network and returns it with
+// its family. "any" and "*" match any address and are returned as nil.
+func ParseAddress(s string) (*Address, string, error) {
+	s = strings.TrimSpace(s)
+	if s == anyAddress || strings.EqualFold(s, "any") {
+		return nil, FamilyAny, nil
+	}
+
+	if strings.Contains(s, "/") {
+		ip, network, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid network %q, must be an address with a prefix length such as 10.0.0.0/8", s)
+		}
+		if !ip.Equal(network.IP) {
+			return nil, "", fmt.Errorf("invalid network %q, host bits are set, did you mean %s?", s, network)
+		}
+		ones, _ := network.Mask.Size()
+		return &Address{Address: network.IP.String(), Netmask: int64(ones)}, family(ip), nil
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, "", fmt.Errorf("invalid address %q, must be an IP address, a network such as 10.0.0.0/8 or any", s)
+	}
+
+	bits := int64(128)
+	if ip.To4() != nil {
+		bits = 32
+	}
+	return &Address{Address: ip.String(), Netmask: bits}, family(ip), nil
+}
+
+func family(ip net.IP) string {
+	if ip.To4() != nil {
+		return FamilyIPv4
+	}
+	return FamilyIPv6
+}
+
+// FormatAddress writes an address as in specifications: a plain address
+// for a single host and a CIDR network otherwise, including /0 networks
+func FormatAddress(address string, netmask int64) string {
+	if address == "" || address == anyAddress {
+		return "any"
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return address
+	}
+	if (ip.To4() != nil && netmask == 32) || (ip.To4() == nil && netmask == 128) {
+		return address
+	}
+	return fmt.Sprintf("%s/%d", address, netmask)
+}
+
+// Port is a port or range of ports of a rule. End is zero for single
+// ports.
+type Port struct {
+	Start int64
+	End   int64
+}
+
+// ParsePort checks a port such as 443 or a range of ports such as
+// 8000-8080
+func ParsePort(s string) (*Port, error) {
+	s = strings.TrimSpace(s)
+	start, end, isRange := strings.Cut(s, "-")
+
+	first, err := parsePortNumber(start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q: %v", s, err)
+	}
+	if !isRange {
+		return &Port{Start: first}, nil
+	}
+
+	last, err := parsePortNumber(end)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port range %q: %v", s, err)
+	}
+	if last <= first {
+		return nil, fmt.Errorf("invalid port range %q, the end must be greater than the start", s)
+	}
+	return &Port{Start: first, End: last}, nil
+}
+
+func parsePortNumber(s string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	if n < 1 || n > 65535 {
+		return 0, fmt.Errorf("%d is not between 1 and 65535", n)
+	}
+	return n, nil
+}
+
+// FormatPort writes a port as in specifications
+func FormatPort(start, end int64) string {
+	if end == 0 || end == start {
+		return strconv.FormatInt(start, 10)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package fql builds Falcon Query Language filter expressions.
+package fql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter accumulates FQL conditions which are joined with the AND operator
+type Filter struct {
+	parts []part
+}
+
+// part is a condition of a filter. Raw expressions may contain operators of
+// lower precedence than AND, such as the OR operator, so they are grouped
+// when joined with other conditions.
+type part struct {
+	expr string
+	raw  bool
+}
+
+// New returns an empty filter
+func New() *Filter {
+	return &Filter{}
+}
+
+// Raw adds a raw FQL expression to the filter. Empty expressions are ignored.
+func (f *Filter) Raw(expr string) *Filter {
+	return f.add(expr, true)
+}
+
+func (f *Filter) add(expr string, raw bool) *Filter {
+	expr = strings.TrimSpace(expr)
+	if expr != "" {
+		f.parts = append(f.parts, part{expr: expr, raw: raw})
+	}
+	return f
+}
+
+// In matches field against any of the given values. Empty values are ignored.
+func (f *Filter) In(field string, values ...string) *Filter {
+	quoted := []string{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			quoted = append(quoted, Quote(v))
+		}
+	}
+
+	switch len(quoted) {
+	case 0:
+		return f
+	case 1:
+		return f.add(fmt.Sprintf("%s:%s", field, quoted[0]), false)
+	}
+	return f.add(fmt.Sprintf("%s:[%s]", field, strings.Join(quoted, ",")), false)
+}
+
+// Equal matches field against a single value. Empty values are ignored.
+func (f *Filter) Equal(field, value string) *Filter {
+	return f.In(field, value)
+}
+
+// Or matches any of the given filters, grouped in parentheses so that the
+// group is joined with the other conditions by AND. Empty filters are
+// ignored.
+func (f *Filter) Or(filters ...*Filter) *Filter {
+	exprs := []string{}
+	for _, o := range filters {
+		switch {
+		case o.Empty():
+		case len(o.parts) > 1 || o.parts[0].raw:
+			exprs = append(exprs, "("+o.String()+")")
+		default:
+			exprs = append(exprs, o.String())
+		}
+	}
+
+	if len(exprs) < 2 {
+		return f.add(strings.Join(exprs, ""), false)
+	}
+	return f.add("("+strings.Join(exprs, ",")+")", false)
+}
+
+// Compare adds a comparison such as field:>='value'. Empty values are ignored.
+func (f *Filter) Compare(field, operator, value string) *Filter {
+	if value == "" {
+		return f
+	}
+	return f.add(fmt.Sprintf("%s:%s%s", field, operator, Quote(value)), false)
+}
+
+// String returns the FQL expression. Raw expressions are wrapped in
+// parentheses when the filter has more than one condition.
+func (f *Filter) String() string {
+	exprs := []string{}
+	for _, p := range f.parts {
+		if p.raw && len(f.parts) > 1 {
+			exprs = append(exprs, "("+p.expr+")")
+		} else {
+			exprs = append(exprs, p.expr)
+		}
+	}
+	return strings.Join(exprs, "+")
+}
+
+// Empty reports whether the filter has no conditions
+func (f *Filter) Empty() bool {
+	return len(f.parts) == 0
+}
+
+// Ptr returns the FQL expression as a pointer, or nil if the filter is empty.
+// This suits the optional filter parameters of the Falcon API.
+func (f *Filter) Ptr() *string {
+	if f.Empty() {
+		return nil
+	}
+	s := f.String()
+	return &s
+}
+
+// Quote wraps a value in single quotes, escaping embedded quotes
+func Quote(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", `\'`) + "'"
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package fql
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *Filter
+		want   string
+	}{
+		{
+			name:   "empty",
+			filter: New().Raw(" ").In("status"),
+			want:   "",
+		},
+		{
+			name:   "single value",
+			filter: New().Equal("status", "new"),
+			want:   "status:'new'",
+		},
+		{
+			name:   "multiple values",
+			filter: New().In("product", "epp", "", "idp"),
+			want:   "product:['epp','idp']",
+		},
+		{
+			name: "combined",
+			filter: New().
+				Raw("severity:>50").
+				In("status", "new").
+				Compare("created_timestamp", ">=", "2023-01-01"),
+			want: "(severity:>50)+status:'new'+created_timestamp:>='2023-01-01'",
+		},
+		{
+			name:   "single raw expression",
+			filter: New().Raw("sha256:'a',sha256:'b'"),
+			want:   "sha256:'a',sha256:'b'",
+		},
+		{
+			name:   "grouped raw expressions",
+			filter: New().Raw("sha256:'a',sha256:'b'").Equal("state", "quarantined").Raw("hostname:'web-01'"),
+			want:   "(sha256:'a',sha256:'b')+state:'quarantined'+(hostname:'web-01')",
+		},
+		{
+			name:   "or",
+			filter: New().Equal("state", "quarantined").Or(New().In("aid", "a1", "a2"), New(), New().Equal("hostname", "web-01")),
+			want:   "state:'quarantined'+(aid:['a1','a2'],hostname:'web-01')",
+		},
+		{
+			name:   "or with a single filter",
+			filter: New().Or(New().In("aid"), New().Equal("hostname", "web-01")),
+			want:   "hostname:'web-01'",
+		},
+		{
+			name:   "or with grouped filters",
+			filter: New().Or(New().Equal("state", "released").Equal("hostname", "web-01"), New().Raw("aid:'a1'")),
+			want:   "((state:'released'+hostname:'web-01'),(aid:'a1'))",
+		},
+		{
+			name:   "quoted",
+			filter: New().Equal("name", "o'brien"),
+			want:   `name:'o\'brien'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.filter.String()); diff != "" {
+				t.Errorf("String() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFilterPtr(t *testing.T) {
+	if got := New().Ptr(); got != nil {
+		t.Errorf("Ptr() = %q, want nil", *got)
+	}
+
+	got := New().Equal("status", "new").Ptr()
+	if got == nil || *got != "status:'new'" {
+		t.Errorf("Ptr() = %v, want status:'new'", got)
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package hosts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/host_group"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// maxGroups is the maximum number of host groups returned per request
+const maxGroups = 500
+
+// QueryGroups returns every host group matching filter
+func QueryGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter string) ([]*models.ResponsesHostGroupV1, error) {
+	groups := []*models.ResponsesHostGroupV1{}
+	limit := int64(maxGroups)
+	sort := "name.asc"
+
+	for {
+		offset := int64(len(groups))
+		params := &host_group.QueryCombinedHostGroupsParams{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offset,
+			Sort:    &sort,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := c.HostGroup.QueryCombinedHostGroups(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query host groups: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, res.Payload.Resources...)
+
+		if len(res.Payload.Resources) == 0 || res.Payload.Meta == nil || res.Payload.Meta.Pagination == nil ||
+			int64(len(groups)) >= utils.Deref(res.Payload.Meta.Pagination.Total) {
+			return groups, nil
+		}
+	}
+}
+
+// ResolveGroupIDs maps each host group, given as a name or ID, to its ID.
+// Names are matched case insensitively. Unknown groups are an error.
+func ResolveGroupIDs(ctx context.Context, c *client.CrowdStrikeAPISpecification, names []string) (map[string]string, error) {
+	resolved := map[string]string{}
+	if len(names) == 0 {
+		return resolved, nil
+	}
+
+	groups, err := QueryGroups(ctx, c, "")
+	if err != nil {
+		return nil, err
+	}
+
+	missing := []string{}
+	for _, name := range names {
+		for _, g := range groups {
+			id := utils.Deref(g.ID)
+			if strings.EqualFold(id, name) || strings.EqualFold(utils.Deref(g.Name), name) {
+				resolved[name] = id
+				break
+			}
+		}
+		if _, ok := resolved[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("unknown host groups: %s", strings.Join(missing, ", "))
+	}
+
+	return resolved, nil
+}
+
+// Host group types
+const (
+	GroupTypeStatic     = "static"
+	GroupTypeDynamic    = "dynamic"
+	GroupTypeStaticByID = "staticByID"
+)
+
+// GroupTypes lists the types of host groups
+var GroupTypes = []string{GroupTypeStatic, GroupTypeDynamic, GroupTypeStaticByID}
+
+// CreateGroup creates a host group. The assignment rule only applies to
+// dynamic groups.
+func CreateGroup(ctx context.Context, c *client.CrowdStrikeAPISpecification, name, groupType, description, rule string) (*models.ResponsesHostGroupV1, error) {
+	res, err := c.HostGroup.CreateHostGroups(&host_group.CreateHostGroupsParams{
+		Context: ctx,
+		Body: &models.RequestsCreateGroupsV1{
+			Resources: []*models.RequestsCreateGroupV1{{
+				Name:           &name,
+				GroupType:      &groupType,
+				Description:    description,
+				AssignmentRule: rule,
+			}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create host group: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return nil, fmt.Errorf("host group %q was not created", name)
+	}
+	return res.Payload.Resources[0], nil
+}
+
+// UpdateGroup updates the name, description and assignment rule of a host
+// group. Empty values are left unchanged.
+func UpdateGroup(ctx context.Context, c *client.CrowdStrikeAPISpecification, id, name, description, rule string) error {
+	res, err := c.HostGroup.UpdateHostGroups(&host_group.UpdateHostGroupsParams{
+		Context: ctx,
+		Body: &models.RequestsUpdateGroupsV1{
+			Resources: []*models.RequestsUpdateGroupV1{{
+				ID:             &id,
+				Name:           name,
+				Description:    description,
+				AssignmentRule: rule,
+			}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update host group: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+// DeleteGroups deletes host groups by ID
+func DeleteGroups(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) error {
+	res, err := c.HostGroup.DeleteHostGroups(&host_group.DeleteHostGroupsParams{
+		Context: ctx,
+		Ids:     ids,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete host groups: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
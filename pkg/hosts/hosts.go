@@ -0,0 +1,184 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package hosts looks up hosts managed by the Falcon platform.
+package hosts
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/hosts"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// maxDetails is the maximum number of hosts fetched per details request
+const maxDetails = 5000
+
+// maxHostnamesPerQuery bounds the size of the FQL filter used to look up hostnames
+const maxHostnamesPerQuery = 100
+
+var deviceIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+
+// IsDeviceID reports whether s looks like a Falcon device ID (AID)
+func IsDeviceID(s string) bool {
+	return deviceIDRegex.MatchString(s)
+}
+
+// ResolveDeviceIDs maps each host, given as a hostname or device ID, to its
+// device ID. When several devices share a hostname the most recently seen
+// one is used. If any host cannot be resolved, the hosts that were found are
+// returned together with an error listing the unknown hostnames.
+func ResolveDeviceIDs(ctx context.Context, c *client.CrowdStrikeAPISpecification, names []string) (map[string]string, error) {
+	resolved := map[string]string{}
+	hostnames := []string{}
+
+	for _, name := range names {
+		if IsDeviceID(name) {
+			resolved[name] = strings.ToLower(name)
+		} else {
+			hostnames = append(hostnames, name)
+		}
+	}
+
+	for _, chunk := range utils.Chunk(hostnames, maxHostnamesPerQuery) {
+		ids, err := QueryDeviceIDs(ctx, c, fql.New().In("hostname", chunk...).String(), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		devices, err := GetDevices(ctx, c, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		// devices are sorted by last seen, so the first match wins
+		for _, name := range chunk {
+			for _, d := range devices {
+				if strings.EqualFold(d.Hostname, name) {
+					resolved[name] = utils.Deref(d.DeviceID)
+					break
+				}
+			}
+		}
+	}
+
+	missing := []string{}
+	for _, name := range names {
+		if _, ok := resolved[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return resolved, fmt.Errorf("unable to find hosts: %s", strings.Join(missing, ", "))
+	}
+
+	return resolved, nil
+}
+
+// QueryDeviceIDs returns the IDs of hosts matching filter, most recently seen
+// first, up to limit results. A limit of 0 returns every matching host.
+func QueryDeviceIDs(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter string, limit int) ([]string, error) {
+	sort := "last_seen.desc"
+	ids := []string{}
+	var offset *string
+
+	for {
+		pageSize := int64(maxDetails)
+		if limit > 0 && limit-len(ids) < maxDetails {
+			pageSize = int64(limit - len(ids))
+		}
+
+		params := &hosts.QueryDevicesByFilterScrollParams{
+			Context: ctx,
+			Sort:    &sort,
+			Limit:   &pageSize,
+			Offset:  offset,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := c.Hosts.QueryDevicesByFilterScroll(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query hosts: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, res.Payload.Resources...)
+
+		if len(res.Payload.Resources) == 0 || (limit > 0 && len(ids) >= limit) {
+			break
+		}
+
+		p := res.Payload.Meta.Pagination
+		if p == nil || utils.Deref(p.Offset) == "" || (p.Total != nil && int64(len(ids)) >= *p.Total) {
+			break
+		}
+		offset = p.Offset
+	}
+
+	return ids, nil
+}
+
+// GetDevices fetches the details of the hosts with the given device IDs,
+// preserving the order of ids
+func GetDevices(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) ([]*models.DeviceapiDeviceSwagger, error) {
+	byID := map[string]*models.DeviceapiDeviceSwagger{}
+
+	for _, chunk := range utils.Chunk(ids, maxDetails) {
+		res, err := c.Hosts.PostDeviceDetailsV2(&hosts.PostDeviceDetailsV2Params{
+			Context: ctx,
+			Body: &models.MsaIdsRequest{
+				Ids: chunk,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get host details: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, d := range res.Payload.Resources {
+			byID[utils.Deref(d.DeviceID)] = d
+		}
+	}
+
+	devices := []*models.DeviceapiDeviceSwagger{}
+	for _, id := range ids {
+		if d, ok := byID[id]; ok {
+			devices = append(devices, d)
+		}
+	}
+
+	return devices, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package intel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/intel"
+)
+
+// markerSort orders indicators by their marker, which increases as
+// indicators are published and updated
+const markerSort = "_marker|asc"
+
+// ExportFilter returns the FQL filter of an indicator export: the
+// indicators matching filter, updated since since if it is set, and coming
+// after marker if it is set
+func ExportFilter(filter string, since time.Time, marker string) string {
+	f := fql.New().Raw(filter)
+	if !since.IsZero() {
+		f.Raw(fmt.Sprintf("last_updated:>=%d", since.Unix()))
+	}
+	return f.Compare("_marker", ">", marker).String()
+}
+
+// ExportIndicators pages through the indicators matching filter in the order
+// of their marker, starting after marker, and calls fn with each page and the
+// marker of its last indicator. Saving that marker once a page is written
+// allows a later export to resume where this one stopped.
+func ExportIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter string, since time.Time, marker string, includeDeleted bool, fn func(page []*Indicator, marker string) error) error {
+	for {
+		pageSize := int64(maxIndicatorPage)
+		res, err := c.Intel.QueryIntelIndicatorEntities(&intel.QueryIntelIndicatorEntitiesParams{
+			Context:          ctx,
+			Filter:           utils.Ptr(ExportFilter(filter, since, marker)),
+			Sort:             utils.Ptr(markerSort),
+			Limit:            &pageSize,
+			IncludeDeleted:   &includeDeleted,
+			IncludeRelations: utils.Ptr(false),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to query indicators: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+
+		page := res.Payload.Resources
+		if len(page) == 0 {
+			return nil
+		}
+
+		next := utils.Deref(page[len(page)-1].Marker)
+		if next == "" || next == marker {
+			return fmt.Errorf("unable to page through indicators, the last indicator has no new marker")
+		}
+
+		if err := fn(page, next); err != nil {
+			return err
+		}
+		marker = next
+
+		if len(page) < maxIndicatorPage {
+			return nil
+		}
+	}
+}
+
+// ReadMarker returns the marker saved in a file, or an empty marker if the
+// file does not exist yet
+func ReadMarker(path string) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// WriteMarker saves a marker to a file. The file is replaced at once so that
+// an interrupted export never leaves a truncated marker behind.
+func WriteMarker(path, marker string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.WriteString(marker + "\n"); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
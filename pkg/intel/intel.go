@@ -0,0 +1,365 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package intel searches the actors, reports and indicators of Falcon
+// Intelligence.
+package intel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/intel"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Actor is an adversary tracked by Falcon Intelligence
+type Actor = models.DomainActorDocument
+
+// Report is a Falcon Intelligence report
+type Report = models.DomainNewsDocument
+
+// Indicator is an indicator of compromise published by Falcon Intelligence
+type Indicator = models.DomainPublicIndicatorV3
+
+// Page sizes of the intel APIs
+const (
+	maxEntityPage    = 5000
+	maxIndicatorPage = 10000
+	maxIDs           = 100
+)
+
+// fieldsFull selects every field of actors and reports
+const fieldsFull = "__full__"
+
+// Search selects actors, reports or indicators by keyword and FQL filter
+type Search struct {
+	// Query is a keyword matched against every field
+	Query string
+	// Filter is an FQL expression
+	Filter string
+	// Sort is a field and direction such as created_date|desc
+	Sort string
+	// Limit is the maximum number of results, or 0 for every result
+	Limit int
+}
+
+func (s *Search) params() (q, filter, sortBy *string) {
+	if s.Query != "" {
+		q = &s.Query
+	}
+	if s.Filter != "" {
+		filter = &s.Filter
+	}
+	if s.Sort != "" {
+		sortBy = &s.Sort
+	}
+	return q, filter, sortBy
+}
+
+// paginate requests pages of at most maxPage results with increasing
+// offsets until limit results are returned, or every result if limit is 0
+func paginate[T any](limit, maxPage int, page func(offset, size int64) ([]T, int64, error)) ([]T, error) {
+	list := []T{}
+
+	for {
+		size := maxPage
+		if limit > 0 && limit-len(list) < maxPage {
+			size = limit - len(list)
+		}
+
+		results, total, err := page(int64(len(list)), int64(size))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, results...)
+
+		if len(results) == 0 || int64(len(list)) >= total || (limit > 0 && len(list) >= limit) {
+			return list, nil
+		}
+	}
+}
+
+func total(meta *models.MsaMetaInfo) int64 {
+	if meta == nil || meta.Pagination == nil {
+		return 0
+	}
+	return utils.Deref(meta.Pagination.Total)
+}
+
+// splitRefs separates numeric IDs from slugs
+func splitRefs(refs []string) (ids, slugs []string) {
+	for _, r := range refs {
+		if _, err := strconv.ParseInt(r, 10, 64); err == nil {
+			ids = append(ids, r)
+		} else {
+			slugs = append(slugs, strings.ToLower(r))
+		}
+	}
+	return ids, slugs
+}
+
+// checkRefs returns an error naming the first reference that was not found
+func checkRefs(kind string, refs []string, found func(ref string) bool) error {
+	for _, r := range refs {
+		if !found(r) {
+			return fmt.Errorf("%s %q not found", kind, r)
+		}
+	}
+	return nil
+}
+
+// QueryActors returns the actors matching a search
+func QueryActors(ctx context.Context, c *client.CrowdStrikeAPISpecification, s *Search) ([]*Actor, error) {
+	q, filter, sortBy := s.params()
+
+	return paginate(s.Limit, maxEntityPage, func(offset, size int64) ([]*Actor, int64, error) {
+		res, err := c.Intel.QueryIntelActorEntities(&intel.QueryIntelActorEntitiesParams{
+			Context: ctx,
+			Fields:  []string{fieldsFull},
+			Q:       q,
+			Filter:  filter,
+			Sort:    sortBy,
+			Limit:   &size,
+			Offset:  &offset,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to query actors: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, 0, err
+		}
+
+		return res.Payload.Resources, total(res.Payload.Meta), nil
+	})
+}
+
+// GetActors returns actors given by ID or slug
+func GetActors(ctx context.Context, c *client.CrowdStrikeAPISpecification, refs []string) ([]*Actor, error) {
+	ids, slugs := splitRefs(refs)
+	list := []*Actor{}
+
+	for _, chunk := range utils.Chunk(ids, maxIDs) {
+		res, err := c.Intel.GetIntelActorEntities(&intel.GetIntelActorEntitiesParams{
+			Context: ctx,
+			Fields:  []string{fieldsFull},
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get actors: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		list = append(list, res.Payload.Resources...)
+	}
+
+	for _, chunk := range utils.Chunk(slugs, maxIDs) {
+		found, err := QueryActors(ctx, c, &Search{Filter: fql.New().In("slug", chunk...).String()})
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, found...)
+	}
+
+	err := checkRefs("actor", refs, func(ref string) bool {
+		for _, a := range list {
+			if strconv.FormatInt(utils.Deref(a.ID), 10) == ref || strings.EqualFold(utils.Deref(a.Slug), ref) {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// QueryReports returns the reports matching a search
+func QueryReports(ctx context.Context, c *client.CrowdStrikeAPISpecification, s *Search) ([]*Report, error) {
+	q, filter, sortBy := s.params()
+
+	return paginate(s.Limit, maxEntityPage, func(offset, size int64) ([]*Report, int64, error) {
+		res, err := c.Intel.QueryIntelReportEntities(&intel.QueryIntelReportEntitiesParams{
+			Context: ctx,
+			Fields:  []string{fieldsFull},
+			Q:       q,
+			Filter:  filter,
+			Sort:    sortBy,
+			Limit:   &size,
+			Offset:  &offset,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to query reports: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, 0, err
+		}
+
+		return res.Payload.Resources, total(res.Payload.Meta), nil
+	})
+}
+
+// GetReports returns reports given by ID or slug
+func GetReports(ctx context.Context, c *client.CrowdStrikeAPISpecification, refs []string) ([]*Report, error) {
+	ids, slugs := splitRefs(refs)
+	list := []*Report{}
+
+	for _, chunk := range utils.Chunk(ids, maxIDs) {
+		res, err := c.Intel.GetIntelReportEntities(&intel.GetIntelReportEntitiesParams{
+			Context: ctx,
+			Fields:  []string{fieldsFull},
+			Ids:     chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reports: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		list = append(list, res.Payload.Resources...)
+	}
+
+	for _, chunk := range utils.Chunk(slugs, maxIDs) {
+		found, err := QueryReports(ctx, c, &Search{Filter: fql.New().In("slug", chunk...).String()})
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, found...)
+	}
+
+	err := checkRefs("report", refs, func(ref string) bool {
+		for _, r := range list {
+			if strconv.FormatInt(utils.Deref(r.ID), 10) == ref || strings.EqualFold(utils.Deref(r.Slug), ref) {
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// DownloadReport writes the PDF of a report to w
+func DownloadReport(ctx context.Context, c *client.CrowdStrikeAPISpecification, r *Report, w io.Writer) error {
+	_, err := c.Intel.GetIntelReportPDF(&intel.GetIntelReportPDFParams{
+		Context: ctx,
+		ID:      strconv.FormatInt(utils.Deref(r.ID), 10),
+	}, w)
+	if err != nil {
+		return fmt.Errorf("failed to download report %s: %s", utils.Deref(r.Slug), falcon.ErrorExplain(err))
+	}
+
+	return nil
+}
+
+// QueryIndicators returns the indicators matching a search
+func QueryIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification, s *Search, includeDeleted bool) ([]*Indicator, error) {
+	q, filter, sortBy := s.params()
+
+	return paginate(s.Limit, maxIndicatorPage, func(offset, size int64) ([]*Indicator, int64, error) {
+		res, err := c.Intel.QueryIntelIndicatorEntities(&intel.QueryIntelIndicatorEntitiesParams{
+			Context:          ctx,
+			Q:                q,
+			Filter:           filter,
+			Sort:             sortBy,
+			Limit:            &size,
+			Offset:           &offset,
+			IncludeDeleted:   &includeDeleted,
+			IncludeRelations: utils.Ptr(false),
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to query indicators: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, 0, err
+		}
+
+		return res.Payload.Resources, total(res.Payload.Meta), nil
+	})
+}
+
+// GetIndicators returns indicators given by ID
+func GetIndicators(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) ([]*Indicator, error) {
+	list := []*Indicator{}
+
+	for _, chunk := range utils.Chunk(ids, maxIDs) {
+		res, err := c.Intel.GetIntelIndicatorEntities(&intel.GetIntelIndicatorEntitiesParams{
+			Context: ctx,
+			Body:    &models.MsaIdsRequest{Ids: chunk},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get indicators: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		list = append(list, res.Payload.Resources...)
+	}
+
+	return list, nil
+}
+
+// Date formats the Unix timestamps of intel entities for table output
+func Date(ts *int64) string {
+	if ts == nil || *ts == 0 {
+		return ""
+	}
+	return time.Unix(*ts, 0).UTC().Format(time.RFC3339)
+}
+
+// Names joins the names of entities such as motivations or target
+// countries
+func Names(entities []*models.DomainEntity) string {
+	names := []string{}
+	for _, e := range entities {
+		if e == nil {
+			continue
+		}
+		if e.Value != "" {
+			names = append(names, e.Value)
+		} else {
+			names = append(names, e.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
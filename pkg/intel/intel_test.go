@@ -0,0 +1,147 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package intel
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{}
+	for i := 0; i < 12; i++ {
+		items = append(items, i)
+	}
+
+	page := func(offset, size int64) ([]int, int64, error) {
+		end := offset + size
+		if end > int64(len(items)) {
+			end = int64(len(items))
+		}
+		return items[offset:end], int64(len(items)), nil
+	}
+
+	tests := []struct {
+		limit, maxPage, want int
+	}{
+		{limit: 0, maxPage: 5, want: 12},
+		{limit: 7, maxPage: 5, want: 7},
+		{limit: 20, maxPage: 5, want: 12},
+		{limit: 0, maxPage: 12, want: 12},
+	}
+	for _, tt := range tests {
+		got, err := paginate(tt.limit, tt.maxPage, page)
+		if err != nil {
+			t.Fatalf("paginate(%d, %d) failed: %v", tt.limit, tt.maxPage, err)
+		}
+		if diff := cmp.Diff(items[:tt.want], got); diff != "" {
+			t.Errorf("paginate(%d, %d) mismatch (-want +got):\n%s", tt.limit, tt.maxPage, diff)
+		}
+	}
+
+	failing := func(offset, size int64) ([]int, int64, error) {
+		return nil, 0, fmt.Errorf("failed")
+	}
+	if _, err := paginate(0, 5, failing); err == nil {
+		t.Errorf("paginate() did not return the error of a page")
+	}
+}
+
+func TestSplitRefs(t *testing.T) {
+	ids, slugs := splitRefs([]string{"1234", "Fancy-Bear", "csit-22001"})
+	if diff := cmp.Diff([]string{"1234"}, ids); diff != "" {
+		t.Errorf("splitRefs() IDs mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"fancy-bear", "csit-22001"}, slugs); diff != "" {
+		t.Errorf("splitRefs() slugs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestExportFilter(t *testing.T) {
+	since := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		filter string
+		since  time.Time
+		marker string
+		want   string
+	}{
+		{want: ""},
+		{filter: "type:'domain'", want: "type:'domain'"},
+		{since: since, want: "last_updated:>=1677628800"},
+		{filter: "type:'domain'", since: since, marker: "1677700000abc", want: "(type:'domain')+(last_updated:>=1677628800)+_marker:>'1677700000abc'"},
+	}
+	for _, tt := range tests {
+		if got := ExportFilter(tt.filter, tt.since, tt.marker); got != tt.want {
+			t.Errorf("ExportFilter(%q, %s, %q) = %q, want %q", tt.filter, tt.since, tt.marker, got, tt.want)
+		}
+	}
+}
+
+func TestMarker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "indicators.marker")
+
+	marker, err := ReadMarker(path)
+	if err != nil {
+		t.Fatalf("ReadMarker() of a missing file failed: %v", err)
+	}
+	if marker != "" {
+		t.Errorf("ReadMarker() of a missing file = %q, want an empty marker", marker)
+	}
+
+	for _, want := range []string{"1677700000abc", "1677800000def"} {
+		if err := WriteMarker(path, want); err != nil {
+			t.Fatalf("WriteMarker() failed: %v", err)
+		}
+		got, err := ReadMarker(path)
+		if err != nil {
+			t.Fatalf("ReadMarker() failed: %v", err)
+		}
+		if got != want {
+			t.Errorf("ReadMarker() = %q, want %q", got, want)
+		}
+	}
+
+	files, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("WriteMarker() left %d files behind, want 1", len(files))
+	}
+}
+
+func TestNames(t *testing.T) {
+	entities := []*models.DomainEntity{
+		{ID: utils.Ptr(int64(1)), Value: "Criminal"},
+		nil,
+		{ID: utils.Ptr(int64(2)), Name: "Espionage"},
+	}
+	if got, want := Names(entities), "Criminal, Espionage"; got != want {
+		t.Errorf("Names() = %q, want %q", got, want)
+	}
+}
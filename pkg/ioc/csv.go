@@ -0,0 +1,197 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSVColumns are the columns of indicator CSV files. Only type and value
+// are required when reading.
+var CSVColumns = []string{"type", "value", "action", "severity", "platforms", "expiration", "host_groups", "applied_globally", "description", "tags", "source"}
+
+// Row is an indicator read from a file along with its position, so that
+// errors can be reported against the input
+type Row struct {
+	// Line is the line of the row in a CSV file or the position of the
+	// object in a STIX bundle, starting at 1
+	Line int
+	// Indicator is empty when the row could not be parsed
+	Indicator *Indicator
+	Err       error
+}
+
+// invalidRow returns a row that could not be parsed
+func invalidRow(line int, err error) *Row {
+	return &Row{Line: line, Indicator: &Indicator{}, Err: err}
+}
+
+// ReadCSV reads indicators from a CSV file with a header row. Multiple
+// platforms, host groups and tags are separated by commas within a field.
+// Rows that cannot be parsed are returned with an error.
+func ReadCSV(r io.Reader) ([]*Row, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty CSV file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !contains(CSVColumns, name) {
+			return nil, fmt.Errorf("unknown CSV column %q, must be one of: %s", name, strings.Join(CSVColumns, ", "))
+		}
+		columns[name] = i
+	}
+
+	for _, required := range []string{"type", "value"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing CSV column %q", required)
+		}
+	}
+
+	rows := []*Row{}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			// a malformed record is reported but does not stop the import
+			if perr, ok := err.(*csv.ParseError); ok {
+				rows = append(rows, invalidRow(perr.StartLine, perr.Err))
+				continue
+			}
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		row := &Row{Line: line}
+		row.Indicator, row.Err = parseRecord(field)
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseRecord(field func(string) string) (*Indicator, error) {
+	i := &Indicator{
+		Type:        field("type"),
+		Value:       field("value"),
+		Action:      field("action"),
+		Severity:    field("severity"),
+		Platforms:   splitList(field("platforms")),
+		HostGroups:  splitList(field("host_groups")),
+		Description: field("description"),
+		Tags:        splitList(field("tags")),
+		Source:      field("source"),
+	}
+
+	expiration, err := ParseExpiration(field("expiration"))
+	if err != nil {
+		return i, err
+	}
+	i.Expiration = expiration
+
+	if global := field("applied_globally"); global != "" {
+		i.AppliedGlobally, err = strconv.ParseBool(global)
+		if err != nil {
+			return i, fmt.Errorf("invalid applied_globally value %q", global)
+		}
+	}
+
+	i.Normalize()
+	return i, nil
+}
+
+// WriteCSV writes indicators as CSV with a header row
+func WriteCSV(w io.Writer, indicators []*Indicator) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CSVColumns); err != nil {
+		return err
+	}
+
+	for _, i := range indicators {
+		expiration := ""
+		if !i.Expiration.IsZero() {
+			expiration = i.Expiration.UTC().Format(time.RFC3339)
+		}
+
+		record := []string{
+			i.Type,
+			i.Value,
+			i.Action,
+			i.Severity,
+			strings.Join(i.Platforms, ","),
+			expiration,
+			strings.Join(i.HostGroups, ","),
+			strconv.FormatBool(i.AppliedGlobally),
+			i.Description,
+			strings.Join(i.Tags, ","),
+			i.Source,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// splitList splits a comma or semicolon separated field, dropping empty values
+func splitList(s string) []string {
+	values := []string{}
+	for _, v := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
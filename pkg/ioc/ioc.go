@@ -0,0 +1,210 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+// Package ioc converts and validates custom indicators of compromise.
+package ioc
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/strfmt"
+)
+
+// Indicator types
+const (
+	TypeSHA256 = "sha256"
+	TypeMD5    = "md5"
+	TypeDomain = "domain"
+	TypeIPv4   = "ipv4"
+	TypeIPv6   = "ipv6"
+)
+
+// Types lists the supported indicator types
+var Types = []string{TypeSHA256, TypeMD5, TypeDomain, TypeIPv4, TypeIPv6}
+
+// Actions lists the actions taken when an indicator is observed
+var Actions = []string{"no_action", "allow", "prevent_no_ui", "prevent", "detect"}
+
+// Severities lists the severities of detections raised by indicators
+var Severities = []string{"informational", "low", "medium", "high", "critical"}
+
+// Platforms lists the platforms indicators apply to
+var Platforms = []string{"windows", "mac", "linux", "ios", "android"}
+
+var (
+	sha256Regex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+	md5Regex    = regexp.MustCompile(`^[0-9a-fA-F]{32}$`)
+	domainRegex = regexp.MustCompile(`^(?i)([a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9])?\.)+[a-z][a-z0-9-]{0,61}[a-z0-9]$`)
+)
+
+// Indicator is a custom indicator as read from or written to a file
+type Indicator struct {
+	Type            string
+	Value           string
+	Action          string
+	Severity        string
+	Platforms       []string
+	Expiration      time.Time
+	HostGroups      []string
+	AppliedGlobally bool
+	Description     string
+	Tags            []string
+	Source          string
+}
+
+// Validate checks the indicator value against its type and the other fields
+// against the values accepted by the API
+func (i *Indicator) Validate() error {
+	if err := ValidateValue(i.Type, i.Value); err != nil {
+		return err
+	}
+
+	if i.Action == "" {
+		return fmt.Errorf("action is required")
+	}
+	if err := utils.ValidateOneOf("action", Actions, i.Action); err != nil {
+		return err
+	}
+
+	if i.Severity != "" {
+		if err := utils.ValidateOneOf("severity", Severities, i.Severity); err != nil {
+			return err
+		}
+	} else if i.Action == "prevent" || i.Action == "detect" {
+		return fmt.Errorf("severity is required for the %s action", i.Action)
+	}
+
+	if len(i.Platforms) == 0 {
+		return fmt.Errorf("at least one platform is required")
+	}
+	if err := utils.ValidateOneOf("platform", Platforms, i.Platforms...); err != nil {
+		return err
+	}
+
+	if i.AppliedGlobally == (len(i.HostGroups) > 0) {
+		return fmt.Errorf("an indicator must either be applied globally or to host groups")
+	}
+
+	if !i.Expiration.IsZero() && i.Expiration.Before(time.Now()) {
+		return fmt.Errorf("expiration %s is in the past", i.Expiration.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// ValidateValue checks that value is a valid indicator of the given type
+func ValidateValue(iocType, value string) error {
+	if err := utils.ValidateOneOf("type", Types, iocType); err != nil {
+		return err
+	}
+
+	valid := false
+	switch iocType {
+	case TypeSHA256:
+		valid = sha256Regex.MatchString(value)
+	case TypeMD5:
+		valid = md5Regex.MatchString(value)
+	case TypeDomain:
+		valid = len(value) <= 253 && domainRegex.MatchString(value)
+	case TypeIPv4:
+		ip := net.ParseIP(value)
+		valid = ip != nil && ip.To4() != nil && !strings.Contains(value, ":")
+	case TypeIPv6:
+		ip := net.ParseIP(value)
+		valid = ip != nil && strings.Contains(value, ":")
+	}
+
+	if !valid {
+		return fmt.Errorf("invalid %s value %q", iocType, value)
+	}
+	return nil
+}
+
+// Normalize lower cases the values that are case insensitive
+func (i *Indicator) Normalize() {
+	i.Type = strings.ToLower(strings.TrimSpace(i.Type))
+	i.Value = strings.TrimSpace(i.Value)
+	if i.Type != TypeIPv6 {
+		i.Value = strings.ToLower(i.Value)
+	}
+	i.Action = strings.ToLower(strings.TrimSpace(i.Action))
+	i.Severity = strings.ToLower(strings.TrimSpace(i.Severity))
+	for n, p := range i.Platforms {
+		i.Platforms[n] = strings.ToLower(strings.TrimSpace(p))
+	}
+}
+
+// CreateRequest converts the indicator to an API create request
+func (i *Indicator) CreateRequest() *models.APIIndicatorCreateReqV1 {
+	return &models.APIIndicatorCreateReqV1{
+		Type:            i.Type,
+		Value:           i.Value,
+		Action:          i.Action,
+		Severity:        i.Severity,
+		Platforms:       i.Platforms,
+		Expiration:      strfmt.DateTime(i.Expiration),
+		HostGroups:      i.HostGroups,
+		AppliedGlobally: &i.AppliedGlobally,
+		Description:     i.Description,
+		Tags:            i.Tags,
+		Source:          i.Source,
+	}
+}
+
+// FromAPI converts an indicator returned by the API
+func FromAPI(in *models.APIIndicatorV1) *Indicator {
+	return &Indicator{
+		Type:            in.Type,
+		Value:           in.Value,
+		Action:          in.Action,
+		Severity:        in.Severity,
+		Platforms:       in.Platforms,
+		Expiration:      time.Time(in.Expiration),
+		HostGroups:      in.HostGroups,
+		AppliedGlobally: in.AppliedGlobally,
+		Description:     in.Description,
+		Tags:            in.Tags,
+		Source:          in.Source,
+	}
+}
+
+// ParseExpiration parses an expiration given as an RFC 3339 timestamp or a
+// date
+func ParseExpiration(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiration %q, use a date (2006-01-02) or an RFC 3339 timestamp", s)
+	}
+	return t, nil
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateValue(t *testing.T) {
+	tests := []struct {
+		iocType string
+		value   string
+		valid   bool
+	}{
+		{TypeSHA256, strings.Repeat("a", 64), true},
+		{TypeSHA256, strings.Repeat("a", 63), false},
+		{TypeMD5, strings.Repeat("0", 32), true},
+		{TypeMD5, strings.Repeat("g", 32), false},
+		{TypeDomain, "evil.example.com", true},
+		{TypeDomain, "not a domain", false},
+		{TypeDomain, "localhost", false},
+		{TypeIPv4, "192.0.2.1", true},
+		{TypeIPv4, "192.0.2.256", false},
+		{TypeIPv4, "2001:db8::1", false},
+		{TypeIPv6, "2001:db8::1", true},
+		{TypeIPv6, "192.0.2.1", false},
+		{"url", "https://example.com", false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateValue(tt.iocType, tt.value)
+		if valid := err == nil; valid != tt.valid {
+			t.Errorf("ValidateValue(%q, %q) = %v, want valid %v", tt.iocType, tt.value, err, tt.valid)
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	input := `type,value,action,severity,platforms,expiration,applied_globally,tags
+sha256,` + strings.Repeat("A", 64) + `,prevent,High,"windows,mac",2030-01-02,true,"apt,campaign"
+domain,evil.example.com,detect,medium,linux,not-a-date,true,
+ipv4,"192.0.2.1,detect
+`
+
+	rows, err := ReadCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCSV() returned error: %v", err)
+	}
+
+	if len(rows) != 3 {
+		t.Fatalf("ReadCSV() returned %d rows, want 3", len(rows))
+	}
+
+	want := &Indicator{
+		Type:            TypeSHA256,
+		Value:           strings.Repeat("a", 64),
+		Action:          "prevent",
+		Severity:        "high",
+		Platforms:       []string{"windows", "mac"},
+		Expiration:      time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+		HostGroups:      []string{},
+		AppliedGlobally: true,
+		Tags:            []string{"apt", "campaign"},
+	}
+	if diff := cmp.Diff(want, rows[0].Indicator); diff != "" {
+		t.Errorf("first row mismatch (-want +got):\n%s", diff)
+	}
+	if rows[0].Line != 2 || rows[0].Err != nil {
+		t.Errorf("first row: line %d, error %v", rows[0].Line, rows[0].Err)
+	}
+
+	if rows[1].Line != 3 || rows[1].Err == nil {
+		t.Errorf("second row: line %d, error %v, want an expiration error on line 3", rows[1].Line, rows[1].Err)
+	}
+
+	if rows[2].Line != 4 || rows[2].Err == nil {
+		t.Errorf("third row: line %d, error %v, want a parse error on line 4", rows[2].Line, rows[2].Err)
+	}
+	if rows[2].Indicator == nil {
+		t.Error("third row has no indicator")
+	}
+}
+
+func TestReadSTIXInvalid(t *testing.T) {
+	input := `{"type": "bundle", "objects": [
+	{"type": "indicator", "pattern": "[file:name = 'x']", "pattern_type": "stix"},
+	{"type": "indicator", "pattern": "title: rule", "pattern_type": "sigma"},
+	{"type": "indicator", "pattern": "[domain-name:value = 'evil.example.com']", "valid_until": "tomorrow"},
+	{"type": "indicator", "pattern": 42}
+]}`
+
+	rows, err := ReadSTIX(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadSTIX() returned error: %v", err)
+	}
+
+	if len(rows) != 4 {
+		t.Fatalf("ReadSTIX() returned %d rows, want 4", len(rows))
+	}
+
+	for n, row := range rows {
+		if row.Line != n+1 || row.Err == nil {
+			t.Errorf("row %d: line %d, error %v, want an error", n+1, row.Line, row.Err)
+		}
+		if row.Indicator == nil {
+			t.Errorf("row %d has no indicator", n+1)
+		}
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []string
+	}{
+		{"", []string{}},
+		{"windows", []string{"windows"}},
+		{"windows, mac", []string{"windows", "mac"}},
+		{"windows;mac; linux", []string{"windows", "mac", "linux"}},
+		{"a,;b,", []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		if diff := cmp.Diff(tt.want, splitList(tt.in)); diff != "" {
+			t.Errorf("splitList(%q) mismatch (-want +got):\n%s", tt.in, diff)
+		}
+	}
+}
+
+func TestReadCSVUnknownColumn(t *testing.T) {
+	if _, err := ReadCSV(strings.NewReader("type,value,colour\n")); err == nil {
+		t.Error("ReadCSV() did not reject an unknown column")
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    [][2]string
+		wantErr bool
+	}{
+		{
+			pattern: "[file:hashes.'SHA-256' = 'abc']",
+			want:    [][2]string{{TypeSHA256, "abc"}},
+		},
+		{
+			pattern: "[domain-name:value = 'evil.example.com' OR ipv4-addr:value = '192.0.2.1']",
+			want:    [][2]string{{TypeDomain, "evil.example.com"}, {TypeIPv4, "192.0.2.1"}},
+		},
+		{
+			pattern: "[file:hashes.MD5 = 'abc'] OR [ipv6-addr:value = '2001:db8::1']",
+			want:    [][2]string{{TypeMD5, "abc"}, {TypeIPv6, "2001:db8::1"}},
+		},
+		{
+			pattern: "[file:name = 'evil.exe' AND file:hashes.MD5 = 'abc']",
+			wantErr: true,
+		},
+		{
+			pattern: "[url:value = 'https://example.com']",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := parsePattern(tt.pattern)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parsePattern(%q) error = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); !tt.wantErr && diff != "" {
+			t.Errorf("parsePattern(%q) mismatch (-want +got):\n%s", tt.pattern, diff)
+		}
+	}
+}
+
+func TestSTIXRoundTrip(t *testing.T) {
+	indicators := []*Indicator{
+		{
+			Type:        TypeDomain,
+			Value:       "evil.example.com",
+			Action:      "detect",
+			Severity:    "medium",
+			Platforms:   []string{"windows"},
+			Expiration:  time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+			HostGroups:  []string{"group1"},
+			Description: "Phishing domain",
+			Tags:        []string{"phishing"},
+			Source:      "intel",
+		},
+		{
+			Type:            TypeSHA256,
+			Value:           strings.Repeat("b", 64),
+			Action:          "prevent",
+			Severity:        "high",
+			Platforms:       []string{"windows", "mac"},
+			AppliedGlobally: true,
+			Description:     strings.Repeat("b", 64),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteSTIX(&buf, indicators, time.Now()); err != nil {
+		t.Fatalf("WriteSTIX() returned error: %v", err)
+	}
+
+	rows, err := ReadSTIX(&buf)
+	if err != nil {
+		t.Fatalf("ReadSTIX() returned error: %v", err)
+	}
+
+	got := []*Indicator{}
+	for _, row := range rows {
+		if row.Err != nil {
+			t.Fatalf("ReadSTIX() row %d returned error: %v", row.Line, row.Err)
+		}
+		got = append(got, row.Indicator)
+	}
+
+	if diff := cmp.Diff(indicators, got); diff != "" {
+		t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+	}
+}
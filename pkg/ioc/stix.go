@@ -0,0 +1,265 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// stixObjectPaths maps the STIX object paths of indicator patterns to
+// indicator types
+var stixObjectPaths = map[string]string{
+	"file:hashes.'sha-256'": TypeSHA256,
+	"file:hashes.sha-256":   TypeSHA256,
+	"file:hashes.'sha256'":  TypeSHA256,
+	"file:hashes.sha256":    TypeSHA256,
+	"file:hashes.'md5'":     TypeMD5,
+	"file:hashes.md5":       TypeMD5,
+	"domain-name:value":     TypeDomain,
+	"ipv4-addr:value":       TypeIPv4,
+	"ipv6-addr:value":       TypeIPv6,
+}
+
+// stixPatterns are the object paths used when writing each indicator type
+var stixPatterns = map[string]string{
+	TypeSHA256: "file:hashes.'SHA-256'",
+	TypeMD5:    "file:hashes.MD5",
+	TypeDomain: "domain-name:value",
+	TypeIPv4:   "ipv4-addr:value",
+	TypeIPv6:   "ipv6-addr:value",
+}
+
+// stixComparison matches a single equality comparison of a STIX pattern
+var stixComparison = regexp.MustCompile(`([a-z0-9-]+:[A-Za-z0-9_.'-]+)\s*=\s*'((?:[^'\\]|\\.)*)'`)
+
+// stixNamespace seeds the deterministic IDs of exported STIX objects
+const stixNamespace = "falcon-cli/ioc"
+
+type stixBundle struct {
+	Type    string            `json:"type"`
+	ID      string            `json:"id"`
+	Objects []json.RawMessage `json:"objects"`
+}
+
+// stixIndicator is a STIX 2.1 indicator. The x_falcon properties carry the
+// indicator settings that STIX has no equivalent for.
+type stixIndicator struct {
+	Type        string   `json:"type"`
+	SpecVersion string   `json:"spec_version"`
+	ID          string   `json:"id"`
+	Created     string   `json:"created"`
+	Modified    string   `json:"modified"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Pattern     string   `json:"pattern"`
+	PatternType string   `json:"pattern_type"`
+	ValidFrom   string   `json:"valid_from"`
+	ValidUntil  string   `json:"valid_until,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+
+	Action          string   `json:"x_falcon_action,omitempty"`
+	Severity        string   `json:"x_falcon_severity,omitempty"`
+	Platforms       []string `json:"x_falcon_platforms,omitempty"`
+	HostGroups      []string `json:"x_falcon_host_groups,omitempty"`
+	AppliedGlobally bool     `json:"x_falcon_applied_globally,omitempty"`
+	Source          string   `json:"x_falcon_source,omitempty"`
+}
+
+// ReadSTIX reads the indicators of a STIX 2.1 bundle. Only indicator objects
+// with STIX patterns comparing file hashes, domain names or IP addresses are
+// supported, joined with OR. Each comparison becomes an indicator, reported
+// against the position of its object in the bundle, starting at 1. Objects
+// other than indicators are ignored.
+func ReadSTIX(r io.Reader) ([]*Row, error) {
+	var bundle stixBundle
+	if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+		return nil, fmt.Errorf("unable to parse STIX bundle: %v", err)
+	}
+
+	if bundle.Type != "bundle" {
+		return nil, fmt.Errorf("not a STIX bundle, type is %q", bundle.Type)
+	}
+
+	rows := []*Row{}
+	for n, raw := range bundle.Objects {
+		var object stixIndicator
+		if err := json.Unmarshal(raw, &object); err != nil {
+			rows = append(rows, invalidRow(n+1, fmt.Errorf("invalid STIX object: %v", err)))
+			continue
+		}
+
+		if object.Type != "indicator" {
+			continue
+		}
+
+		rows = append(rows, stixRows(n+1, &object)...)
+	}
+
+	return rows, nil
+}
+
+func stixRows(n int, object *stixIndicator) []*Row {
+	if object.PatternType != "" && object.PatternType != "stix" {
+		return []*Row{invalidRow(n, fmt.Errorf("unsupported pattern type %q", object.PatternType))}
+	}
+
+	comparisons, err := parsePattern(object.Pattern)
+	if err != nil {
+		return []*Row{invalidRow(n, err)}
+	}
+
+	description := object.Description
+	if description == "" {
+		description = object.Name
+	}
+
+	var expiration time.Time
+	if object.ValidUntil != "" {
+		if expiration, err = time.Parse(time.RFC3339, object.ValidUntil); err != nil {
+			return []*Row{invalidRow(n, fmt.Errorf("invalid valid_until %q", object.ValidUntil))}
+		}
+	}
+
+	rows := []*Row{}
+	for _, c := range comparisons {
+		i := &Indicator{
+			Type:            c[0],
+			Value:           c[1],
+			Action:          object.Action,
+			Severity:        object.Severity,
+			Platforms:       append([]string{}, object.Platforms...),
+			Expiration:      expiration,
+			HostGroups:      object.HostGroups,
+			AppliedGlobally: object.AppliedGlobally,
+			Description:     description,
+			Tags:            object.Labels,
+			Source:          object.Source,
+		}
+		i.Normalize()
+
+		rows = append(rows, &Row{Line: n, Indicator: i})
+	}
+
+	return rows
+}
+
+// parsePattern returns the type and value of each comparison of a pattern
+func parsePattern(pattern string) ([][2]string, error) {
+	matches := stixComparison.FindAllStringSubmatchIndex(pattern, -1)
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("unsupported pattern %q", pattern)
+	}
+
+	comparisons := [][2]string{}
+	var rest strings.Builder
+	last := 0
+
+	for _, m := range matches {
+		rest.WriteString(pattern[last:m[0]])
+		last = m[1]
+
+		path := strings.ToLower(pattern[m[2]:m[3]])
+		iocType, ok := stixObjectPaths[path]
+		if !ok {
+			return nil, fmt.Errorf("unsupported pattern object %q", pattern[m[2]:m[3]])
+		}
+
+		value := strings.NewReplacer(`\'`, `'`, `\\`, `\`).Replace(pattern[m[4]:m[5]])
+		comparisons = append(comparisons, [2]string{iocType, value})
+	}
+	rest.WriteString(pattern[last:])
+
+	// only brackets and OR may join the comparisons
+	for _, token := range strings.Fields(strings.NewReplacer("[", " ", "]", " ").Replace(rest.String())) {
+		if token != "OR" {
+			return nil, fmt.Errorf("unsupported pattern %q, only comparisons joined with OR are supported", pattern)
+		}
+	}
+
+	return comparisons, nil
+}
+
+// WriteSTIX writes indicators as a STIX 2.1 bundle. Object IDs are derived
+// from the indicator values so that repeated exports produce the same IDs.
+func WriteSTIX(w io.Writer, indicators []*Indicator, now time.Time) error {
+	timestamp := now.UTC().Format(time.RFC3339)
+	bundle := stixBundle{
+		Type:    "bundle",
+		Objects: []json.RawMessage{},
+	}
+
+	ids := []string{}
+	for _, i := range indicators {
+		path, ok := stixPatterns[i.Type]
+		if !ok {
+			return fmt.Errorf("indicator type %q cannot be written as STIX", i.Type)
+		}
+
+		object := stixIndicator{
+			Type:            "indicator",
+			SpecVersion:     "2.1",
+			ID:              "indicator--" + stixID(i.Type+":"+i.Value),
+			Created:         timestamp,
+			Modified:        timestamp,
+			Name:            i.Value,
+			Description:     i.Description,
+			Pattern:         fmt.Sprintf("[%s = '%s']", path, strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(i.Value)),
+			PatternType:     "stix",
+			ValidFrom:       timestamp,
+			Labels:          i.Tags,
+			Action:          i.Action,
+			Severity:        i.Severity,
+			Platforms:       i.Platforms,
+			HostGroups:      i.HostGroups,
+			AppliedGlobally: i.AppliedGlobally,
+			Source:          i.Source,
+		}
+		if !i.Expiration.IsZero() {
+			object.ValidUntil = i.Expiration.UTC().Format(time.RFC3339)
+		}
+
+		raw, err := json.Marshal(object)
+		if err != nil {
+			return err
+		}
+		bundle.Objects = append(bundle.Objects, raw)
+		ids = append(ids, object.ID)
+	}
+
+	bundle.ID = "bundle--" + stixID(strings.Join(ids, ","))
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(bundle)
+}
+
+// stixID returns a name based (version 5 style) UUID for name
+func stixID(name string) string {
+	h := sha1.Sum([]byte(stixNamespace + ":" + name))
+	h[6] = (h[6] & 0x0f) | 0x50
+	h[8] = (h[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", h[0:4], h[4:6], h[6:8], h[8:10], h[10:16])
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package iostreams
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+var spinnerFrames = []string{"|", "/", "-", `\`}
+
+// Spinner animates a label on standard error while a long operation runs
+type Spinner struct {
+	w     io.Writer
+	mu    sync.Mutex
+	label string
+	once  sync.Once
+	stop  chan struct{}
+	done  chan struct{}
+}
+
+// StartSpinner shows label next to a spinner until Stop is called. Nothing
+// is shown when standard error is not a terminal.
+func (s *IOStreams) StartSpinner(label string) *Spinner {
+	sp := &Spinner{
+		w:     s.ErrOut,
+		label: label,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	if !s.IsStderrTTY() {
+		close(sp.done)
+		return sp
+	}
+
+	go sp.run()
+	return sp
+}
+
+func (sp *Spinner) run() {
+	defer close(sp.done)
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for n := 0; ; n++ {
+		sp.mu.Lock()
+		fmt.Fprintf(sp.w, "\r\033[K%s %s", spinnerFrames[n%len(spinnerFrames)], sp.label)
+		sp.mu.Unlock()
+
+		select {
+		case <-sp.stop:
+			fmt.Fprint(sp.w, "\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SetLabel changes the label shown next to the spinner
+func (sp *Spinner) SetLabel(label string) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	sp.label = label
+}
+
+// Stop clears the spinner. It is safe to call Stop more than once.
+func (sp *Spinner) Stop() {
+	sp.once.Do(func() {
+		close(sp.stop)
+	})
+	<-sp.done
+}
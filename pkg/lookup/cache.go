@@ -0,0 +1,164 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package lookup
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Cache keeps lookup results on disk, one file per hash, so that large
+// batches of hashes are not looked up again while their results are fresh
+type Cache struct {
+	// Dir is the directory holding the cached results
+	Dir string
+	// TTL is how long results are used for
+	TTL time.Duration
+
+	now func() time.Time
+}
+
+// entry is a cached result with the time and sources it was looked up
+// with
+type entry struct {
+	Fetched time.Time `json:"fetched"`
+	Sources []string  `json:"sources"`
+	Result  *Result   `json:"result"`
+}
+
+// DefaultCacheDir returns the directory results are cached in by default
+func DefaultCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".falcon", "cache", "lookup"), nil
+}
+
+// NewCache returns a cache of results in dir used for ttl
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, TTL: ttl, now: time.Now}
+}
+
+func (c *Cache) path(sha256 string) string {
+	return filepath.Join(c.Dir, sha256+".json")
+}
+
+// Get returns the cached result of a hash if it is fresh and was looked up
+// with at least the given sources
+func (c *Cache) Get(sha256 string, sources []string) (*Result, bool) {
+	data, err := os.ReadFile(c.path(sha256))
+	if err != nil {
+		return nil, false
+	}
+
+	e := &entry{}
+	if err := json.Unmarshal(data, e); err != nil || e.Result == nil {
+		return nil, false
+	}
+
+	if c.now().Sub(e.Fetched) > c.TTL {
+		return nil, false
+	}
+
+	has := map[string]bool{}
+	for _, s := range e.Sources {
+		has[s] = true
+	}
+	for _, s := range sources {
+		if !has[s] {
+			return nil, false
+		}
+	}
+
+	return e.Result, true
+}
+
+// Put stores the result of a hash looked up with the given sources
+func (c *Cache) Put(r *Result, sources []string) error {
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return err
+	}
+
+	sorted := append([]string{}, sources...)
+	sort.Strings(sorted)
+
+	data, err := json.Marshal(&entry{Fetched: c.now().UTC(), Sources: sorted, Result: r})
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that concurrent lookups never read
+	// a partial result
+	tmp, err := os.CreateTemp(c.Dir, r.SHA256+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(r.SHA256))
+}
+
+// Prune removes the expired results and returns how many were removed
+func (c *Cache) Prune() (int, error) {
+	files, err := os.ReadDir(c.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(c.Dir, f.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return removed, err
+		}
+
+		e := &entry{}
+		if json.Unmarshal(data, e) == nil && c.now().Sub(e.Fetched) <= c.TTL {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
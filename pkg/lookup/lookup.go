@@ -0,0 +1,232 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package lookup looks up the verdicts and metadata of file hashes in the
+// malware intelligence of the Falcon platform.
+package lookup
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/malquery"
+	"github.com/crowdstrike/gofalcon/falcon/client/quick_scan"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Sources of hash intelligence
+const (
+	SourceMalQuery  = "malquery"
+	SourceQuickScan = "quickscan"
+)
+
+// Sources lists the sources of hash intelligence
+var Sources = []string{SourceMalQuery, SourceQuickScan}
+
+// maxHashes is the maximum number of hashes looked up per request
+const maxHashes = 100
+
+// scanDone is the status of a completed quick scan
+const scanDone = "done"
+
+// minPoll and maxPoll bound the delay between quick scan status checks
+const (
+	minPoll = 500 * time.Millisecond
+	maxPoll = 5 * time.Second
+)
+
+var sha256Regex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// Result is what is known about a hash. Verdict comes from quick scan,
+// while the label, family and file details come from MalQuery.
+type Result struct {
+	SHA256    string `json:"sha256"`
+	Verdict   string `json:"verdict,omitempty"`
+	Label     string `json:"label,omitempty"`
+	Family    string `json:"family,omitempty"`
+	FileType  string `json:"file_type,omitempty"`
+	FileSize  int32  `json:"file_size,omitempty"`
+	FirstSeen string `json:"first_seen,omitempty"`
+	MD5       string `json:"md5,omitempty"`
+	SHA1      string `json:"sha1,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NormalizeHashes checks that every hash is a SHA256 hash and returns them
+// in lowercase without duplicates, in their original order
+func NormalizeHashes(hashes []string) ([]string, error) {
+	seen := map[string]bool{}
+	list := []string{}
+	for _, h := range hashes {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if !sha256Regex.MatchString(h) {
+			return nil, fmt.Errorf("invalid hash %q, must be a SHA256 hash", h)
+		}
+		if !seen[h] {
+			seen[h] = true
+			list = append(list, h)
+		}
+	}
+	return list, nil
+}
+
+// Lookup queries the given sources for each hash and returns a result per
+// hash in the order of hashes
+func Lookup(ctx context.Context, c *client.CrowdStrikeAPISpecification, hashes, sources []string) ([]*Result, error) {
+	results := map[string]*Result{}
+	list := []*Result{}
+	for _, h := range hashes {
+		r := &Result{SHA256: h}
+		results[h] = r
+		list = append(list, r)
+	}
+
+	for _, source := range sources {
+		for _, chunk := range utils.Chunk(hashes, maxHashes) {
+			var err error
+			switch source {
+			case SourceMalQuery:
+				err = lookupMalQuery(ctx, c, chunk, results)
+			case SourceQuickScan:
+				err = lookupQuickScan(ctx, c, chunk, results)
+			default:
+				err = fmt.Errorf("unknown source %q", source)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return list, nil
+}
+
+func lookupMalQuery(ctx context.Context, c *client.CrowdStrikeAPISpecification, hashes []string, results map[string]*Result) error {
+	res, err := c.Malquery.GetMalQueryMetadataV1(&malquery.GetMalQueryMetadataV1Params{
+		Context: ctx,
+		Ids:     hashes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get MalQuery metadata: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = malQueryError(res.Payload.Errors); err != nil {
+		return err
+	}
+
+	for _, m := range res.Payload.Resources {
+		r, ok := results[strings.ToLower(m.Sha256)]
+		if !ok {
+			continue
+		}
+		r.Label = m.Label
+		r.Family = m.Family
+		r.FileType = m.Filetype
+		r.FileSize = m.Filesize
+		r.FirstSeen = m.FirstSeen
+		r.MD5 = m.Md5
+		r.SHA1 = m.Sha1
+	}
+
+	return nil
+}
+
+// malQueryError returns the first error of a MalQuery response, which does
+// not use the errors of the other APIs
+func malQueryError(errors []*models.MalqueryQueryError) error {
+	if len(errors) == 0 {
+		return nil
+	}
+	e := errors[0]
+	return fmt.Errorf("MalQuery error %d: %s", utils.Deref(e.Code), utils.Deref(e.Message))
+}
+
+func lookupQuickScan(ctx context.Context, c *client.CrowdStrikeAPISpecification, hashes []string, results map[string]*Result) error {
+	res, err := c.QuickScan.ScanSamples(&quick_scan.ScanSamplesParams{
+		Context: ctx,
+		Body:    &models.MlscannerSamplesScanParameters{Samples: hashes},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start quick scan: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return fmt.Errorf("no quick scan returned")
+	}
+
+	scan, err := waitScan(ctx, c, res.Payload.Resources[0])
+	if err != nil {
+		return err
+	}
+
+	for _, s := range scan.Samples {
+		r, ok := results[strings.ToLower(utils.Deref(s.Sha256))]
+		if !ok {
+			continue
+		}
+		r.Verdict = s.Verdict
+		r.Error = s.Error
+	}
+
+	return nil
+}
+
+// waitScan polls a quick scan until it completes
+func waitScan(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string) (*models.MlscannerSamplesScanResult, error) {
+	delay := minPoll
+
+	for {
+		res, err := c.QuickScan.GetScans(&quick_scan.GetScansParams{
+			Context: ctx,
+			Ids:     []string{id},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quick scan: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		if len(res.Payload.Resources) > 0 && utils.Deref(res.Payload.Resources[0].Status) == scanDone {
+			return res.Payload.Resources[0], nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > maxPoll {
+			delay = maxPoll
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package lookup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	hashA = "275a021bbfb6489e54d471899f7db9d1663fc695ec2fe2a2c4538aabf651fd0f"
+	hashB = "ed076287532e86365e841e92bfc50d8c1cfe8d4ee83b3b5fb2f8b5e9da8b5a3b"
+)
+
+func TestNormalizeHashes(t *testing.T) {
+	got, err := NormalizeHashes([]string{strings.ToUpper(hashA), hashB, " " + hashA + " "})
+	if err != nil {
+		t.Fatalf("NormalizeHashes() failed: %v", err)
+	}
+	if diff := cmp.Diff([]string{hashA, hashB}, got); diff != "" {
+		t.Errorf("NormalizeHashes() mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, h := range []string{"", "d41d8cd98f00b204e9800998ecf8427e", hashA[:63] + "g"} {
+		if _, err := NormalizeHashes([]string{h}); err == nil {
+			t.Errorf("NormalizeHashes(%q) did not fail", h)
+		}
+	}
+}
+
+func TestCache(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := NewCache(t.TempDir(), time.Hour)
+	c.now = func() time.Time { return now }
+
+	if _, ok := c.Get(hashA, Sources); ok {
+		t.Fatalf("Get() of an empty cache returned a result")
+	}
+
+	want := &Result{SHA256: hashA, Verdict: "malware", Family: "emotet", FileSize: 1024}
+	if err := c.Put(want, []string{SourceQuickScan, SourceMalQuery}); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+
+	got, ok := c.Get(hashA, Sources)
+	if !ok {
+		t.Fatalf("Get() did not return the cached result")
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, ok := c.Get(hashB, Sources); ok {
+		t.Errorf("Get() returned a result for another hash")
+	}
+
+	now = now.Add(2 * time.Hour)
+	if _, ok := c.Get(hashA, Sources); ok {
+		t.Errorf("Get() returned an expired result")
+	}
+
+	n, err := c.Prune()
+	if err != nil {
+		t.Fatalf("Prune() failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Prune() removed %d results, want 1", n)
+	}
+}
+
+func TestCacheSources(t *testing.T) {
+	c := NewCache(t.TempDir(), time.Hour)
+
+	if err := c.Put(&Result{SHA256: hashA, Label: "malicious"}, []string{SourceMalQuery}); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+
+	if _, ok := c.Get(hashA, []string{SourceMalQuery}); !ok {
+		t.Errorf("Get() did not return a result looked up with the same sources")
+	}
+	if _, ok := c.Get(hashA, Sources); ok {
+		t.Errorf("Get() returned a result missing a source")
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package manifest reads the YAML manifests describing Falcon resources and
+// plans the changes that bring a tenant in line with them.
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a single YAML document of a manifest file
+type Document struct {
+	// Kind is the kind of resource described, e.g. HostGroup
+	Kind string
+	// Name is the value of the name field, if any
+	Name string
+	// Source locates the document, as the file name followed by the index
+	// of the document in the file when it holds several
+	Source string
+
+	raw []byte
+}
+
+// Decode decodes the document into v, rejecting unknown fields
+func (d *Document) Decode(v interface{}) error {
+	dec := yaml.NewDecoder(bytes.NewReader(d.raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%s: invalid %s: %v", d.Source, d.Kind, err)
+	}
+	return nil
+}
+
+// Errorf returns an error prefixed with the source of the document
+func (d *Document) Errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("%s: %s", d.Source, fmt.Sprintf(format, a...))
+}
+
+// header holds the fields shared by every manifest
+type header struct {
+	Kind string `yaml:"kind"`
+	Name string `yaml:"name"`
+}
+
+// Read splits a multi-document YAML stream into documents. Empty documents
+// are skipped and every other document must have a kind.
+func Read(r io.Reader, source string) ([]*Document, error) {
+	docs := []*Document{}
+	dec := yaml.NewDecoder(r)
+
+	for n := 1; ; n++ {
+		var node yaml.Node
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", source, err)
+		}
+
+		if len(node.Content) == 0 || node.Content[0].Kind == yaml.ScalarNode && node.Content[0].Tag == "!!null" {
+			continue
+		}
+
+		h := header{}
+		if err := node.Decode(&h); err != nil {
+			return nil, fmt.Errorf("%s#%d: a manifest must be a mapping: %v", source, n, err)
+		}
+
+		raw, err := yaml.Marshal(&node)
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, &Document{
+			Kind:   h.Kind,
+			Name:   h.Name,
+			Source: fmt.Sprintf("%s#%d", source, n),
+			raw:    raw,
+		})
+	}
+
+	// a single document is located by its file name alone
+	if len(docs) == 1 {
+		docs[0].Source = source
+	}
+
+	for _, d := range docs {
+		if d.Kind == "" {
+			return nil, fmt.Errorf("%s: kind is required", d.Source)
+		}
+	}
+
+	return docs, nil
+}
+
+// ReadPaths reads the manifests of each path. Directories are walked
+// recursively for .yaml and .yml files, in lexical order, and "-" reads
+// from in.
+func ReadPaths(paths []string, in io.Reader) ([]*Document, error) {
+	docs := []*Document{}
+
+	for _, path := range paths {
+		if path == "-" {
+			read, err := Read(in, "<stdin>")
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, read...)
+			continue
+		}
+
+		files, err := manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			read, err := readFile(file)
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, read...)
+		}
+	}
+
+	return docs, nil
+}
+
+// manifestFiles returns path if it is a file, or the YAML files below it if
+// it is a directory
+func manifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	files := []string{}
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".yaml", ".yml":
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+func readFile(path string) ([]*Document, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(f, path)
+}
+
+// ByKind groups documents by kind, preserving their order, and returns an
+// error for kinds that are not in known
+func ByKind(docs []*Document, known []string) (map[string][]*Document, error) {
+	valid := map[string]bool{}
+	for _, k := range known {
+		valid[k] = true
+	}
+
+	grouped := map[string][]*Document{}
+	for _, d := range docs {
+		if !valid[d.Kind] {
+			return nil, d.Errorf("unknown kind %q, must be one of: %s", d.Kind, strings.Join(known, ", "))
+		}
+		grouped[d.Kind] = append(grouped[d.Kind], d)
+	}
+
+	return grouped, nil
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package manifest
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRead(t *testing.T) {
+	docs, err := Read(strings.NewReader(`
+kind: HostGroup
+name: ring1
+---
+---
+kind: PreventionPolicy
+name: Workstations
+platform: Windows
+`), "all.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, d := range docs {
+		got = append(got, d.Source+" "+d.Kind+"/"+d.Name)
+	}
+	want := []string{"all.yaml#1 HostGroup/ring1", "all.yaml#3 PreventionPolicy/Workstations"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read() mismatch (-want +got):\n%s", diff)
+	}
+
+	single, err := Read(strings.NewReader("kind: HostGroup\nname: ring1\n"), "ring1.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if single[0].Source != "ring1.yaml" {
+		t.Errorf("Read() source = %q, want ring1.yaml", single[0].Source)
+	}
+
+	if _, err := Read(strings.NewReader("name: ring1\n"), "ring1.yaml"); err == nil || !strings.Contains(err.Error(), "kind is required") {
+		t.Errorf("Read() without kind error = %v", err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	docs, err := Read(strings.NewReader("kind: HostGroup\nname: ring1\ngroup_type: static\n"), "ring1.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var strict struct {
+		Kind string `yaml:"kind"`
+		Name string `yaml:"name"`
+	}
+	if err := docs[0].Decode(&strict); err == nil || !strings.Contains(err.Error(), "group_type") {
+		t.Errorf("Decode() with unknown field error = %v", err)
+	}
+}
+
+func TestReadPaths(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"policies/b.yml":  "kind: PreventionPolicy\nname: b\n",
+		"policies/a.yaml": "kind: PreventionPolicy\nname: a\n",
+		"groups.yaml":     "kind: HostGroup\nname: g\n",
+		"README.md":       "not a manifest",
+		".git/config.yml": "kind: Ignored\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	docs, err := ReadPaths([]string{dir, "-"}, strings.NewReader("kind: IOC\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for _, d := range docs {
+		got = append(got, d.Kind+"/"+d.Name)
+	}
+	want := []string{"HostGroup/g", "PreventionPolicy/a", "PreventionPolicy/b", "IOC/"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadPaths() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := ByKind(docs, []string{"HostGroup", "PreventionPolicy"}); err == nil || !strings.Contains(err.Error(), `unknown kind "IOC"`) {
+		t.Errorf("ByKind() error = %v", err)
+	}
+}
+
+func TestPlanOrder(t *testing.T) {
+	plan := Plan{
+		{Kind: "IOC", Name: "a", Action: ActionCreate},
+		{Kind: "HostGroup", Name: "old", Action: ActionDelete},
+		{Kind: "Policy", Name: "p", Action: ActionUpdate},
+		{Kind: "IOC", Name: "b", Action: ActionDelete},
+		{Kind: "HostGroup", Name: "new", Action: ActionCreate},
+		{Kind: "Policy", Name: "q", Action: ActionDelete},
+	}
+	plan.Order([]string{"HostGroup", "Policy", "IOC"})
+
+	got := []string{}
+	for _, s := range plan {
+		got = append(got, s.Action+" "+s.String())
+	}
+	want := []string{
+		"create HostGroup/new",
+		"update Policy/p",
+		"create IOC/a",
+		"delete IOC/b",
+		"delete Policy/q",
+		"delete HostGroup/old",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Order() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPlanPrintAndApply(t *testing.T) {
+	ran := []string{}
+	run := func(s string) func(context.Context) error {
+		return func(context.Context) error {
+			ran = append(ran, s)
+			return nil
+		}
+	}
+
+	plan := Plan{
+		{Kind: "HostGroup", Name: "ring1", Action: ActionCreate, Run: run("ring1")},
+		{Kind: "HostGroup", Name: "ring2", Action: ActionUnchanged, Run: run("ring2")},
+		{Kind: "PreventionPolicy", Name: "Windows/Workstations", Action: ActionUpdate, Run: run("Workstations"),
+			Changes: []diff.Change{{Path: "enabled", Old: false, New: true}}},
+	}
+
+	var buf bytes.Buffer
+	if err := plan.Print(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `+ HostGroup/ring1 (create)
+~ PreventionPolicy/Windows/Workstations (update)
+    ~ enabled: false -> true
+Plan: 1 to create, 1 to update, 0 to delete
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("Print() mismatch (-want +got):\n%s", diff)
+	}
+
+	done := 0
+	if err := plan.Apply(context.Background(), func(*Step) { done++ }); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"ring1", "Workstations"}, ran); diff != "" || done != 2 {
+		t.Errorf("Apply() ran %v, done %d", ran, done)
+	}
+}
+
+func TestFileName(t *testing.T) {
+	tests := map[string]string{
+		"Windows/Ring 1 (pilot)":  "windows-ring-1-pilot.yaml",
+		"sha256:ABCDEF":           "sha256-abcdef.yaml",
+		"domain:evil.example.com": "domain-evil.example.com.yaml",
+		"../..":                   "unnamed.yaml",
+	}
+	for name, want := range tests {
+		if got := FileName(name); got != want {
+			t.Errorf("FileName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestWriteDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "deleted.yaml"), []byte("kind: HostGroup\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("kept"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	type spec struct {
+		Kind     string                 `yaml:"kind"`
+		Name     string                 `yaml:"name"`
+		Settings map[string]interface{} `yaml:"settings,omitempty"`
+	}
+	removed, err := WriteDir(dir, []*Object{
+		{Name: "Ring 1", Spec: &spec{Kind: "HostGroup", Name: "Ring 1", Settings: map[string]interface{}{"b": 2, "a": 1}}},
+		{Name: "ring-1", Spec: &spec{Kind: "HostGroup", Name: "ring-1"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("WriteDir() removed %d files, want 1", removed)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, e := range entries {
+		got = append(got, e.Name())
+	}
+	if diff := cmp.Diff([]string{"notes.txt", "ring-1-2.yaml", "ring-1.yaml"}, got); diff != "" {
+		t.Errorf("WriteDir() files mismatch (-want +got):\n%s", diff)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "ring-1.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "kind: HostGroup\nname: Ring 1\nsettings:\n  a: 1\n  b: 2\n"
+	if diff := cmp.Diff(want, string(b)); diff != "" {
+		t.Errorf("WriteDir() content mismatch (-want +got):\n%s", diff)
+	}
+}
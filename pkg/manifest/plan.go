@@ -0,0 +1,150 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+)
+
+// Actions planned for a resource
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionDelete    = "delete"
+	ActionUnchanged = "unchanged"
+)
+
+// Step is the planned change of a single resource
+type Step struct {
+	Kind    string        `json:"kind"`
+	Name    string        `json:"name"`
+	Action  string        `json:"action"`
+	Changes []diff.Change `json:"changes,omitempty"`
+
+	// Run applies the change
+	Run func(ctx context.Context) error `json:"-"`
+}
+
+// String identifies the resource changed by the step
+func (s *Step) String() string {
+	return s.Kind + "/" + s.Name
+}
+
+// Plan is the ordered list of steps that brings a tenant in line with a set
+// of manifests
+type Plan []*Step
+
+// Order sorts the steps so that resources are created and updated in the
+// order of kinds, which lists dependencies first, and deleted in the reverse
+// order. Steps of the same kind keep their relative order.
+func (p Plan) Order(kinds []string) {
+	rank := map[string]int{}
+	for n, k := range kinds {
+		rank[k] = n
+	}
+
+	key := func(s *Step) int {
+		if s.Action == ActionDelete {
+			return 2*len(kinds) - rank[s.Kind]
+		}
+		return rank[s.Kind]
+	}
+
+	sort.SliceStable(p, func(i, j int) bool {
+		return key(p[i]) < key(p[j])
+	})
+}
+
+// Pending returns the steps that change the tenant
+func (p Plan) Pending() Plan {
+	pending := Plan{}
+	for _, s := range p {
+		if s.Action != ActionUnchanged {
+			pending = append(pending, s)
+		}
+	}
+	return pending
+}
+
+// Summary counts the steps of each action, e.g. "1 to create, 2 to update,
+// 0 to delete"
+func (p Plan) Summary() string {
+	counts := map[string]int{}
+	for _, s := range p {
+		counts[s.Action]++
+	}
+
+	return fmt.Sprintf("%d to create, %d to update, %d to delete",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionDelete])
+}
+
+// Print writes the pending steps of the plan with the changes each makes,
+// followed by the summary
+func (p Plan) Print(w io.Writer) error {
+	for _, s := range p.Pending() {
+		if _, err := fmt.Fprintf(w, "%s %s (%s)\n", symbol(s.Action), s, s.Action); err != nil {
+			return err
+		}
+
+		if len(s.Changes) > 0 {
+			var b strings.Builder
+			if err := diff.Print(&b, s.Changes); err != nil {
+				return err
+			}
+			for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+				if _, err := fmt.Fprintf(w, "    %s\n", line); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "Plan: %s\n", p.Summary())
+	return err
+}
+
+// Apply runs the pending steps in order, calling done after each one. It
+// stops at the first failure.
+func (p Plan) Apply(ctx context.Context, done func(s *Step)) error {
+	for _, s := range p.Pending() {
+		if err := s.Run(ctx); err != nil {
+			return fmt.Errorf("failed to %s %s: %v", s.Action, s, err)
+		}
+		done(s)
+	}
+	return nil
+}
+
+func symbol(action string) string {
+	switch action {
+	case ActionCreate:
+		return "+"
+	case ActionDelete:
+		return "-"
+	}
+	return "~"
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadStream decodes and validates a stream of YAML documents separated by
+// "---", rejecting unknown fields. Errors name the document by title and
+// position, e.g. "invalid rule specification #2".
+func ReadStream[T any](r io.Reader, title string, validate func(*T) error) ([]*T, error) {
+	list := []*T{}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	for n := 1; ; n++ {
+		v := new(T)
+		err := dec.Decode(v)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s #%d: %v", title, n, err)
+		}
+
+		if err := validate(v); err != nil {
+			return nil, fmt.Errorf("invalid %s #%d: %v", title, n, err)
+		}
+		list = append(list, v)
+	}
+
+	return list, nil
+}
+
+// WriteStream writes values as a stream of YAML documents with two space
+// indentation
+func WriteStream[T any](w io.Writer, list []*T) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	for _, v := range list {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return enc.Close()
+}
+
+// ReadFiles reads the streams of several files with read. Pass "-" to read
+// from in. Errors are prefixed with the path of the file.
+func ReadFiles[T any](paths []string, in io.Reader, read func(io.Reader) ([]*T, error)) ([]*T, error) {
+	list := []*T{}
+	for _, path := range paths {
+		var values []*T
+		var err error
+		if path == "-" {
+			values, err = read(in)
+		} else {
+			values, err = readStreamFile(path, read)
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, values...)
+	}
+	return list, nil
+}
+
+func readStreamFile[T any](path string, read func(io.Reader) ([]*T, error)) ([]*T, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values, err := read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return values, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type streamSpec struct {
+	Name  string `yaml:"name"`
+	Value int    `yaml:"value,omitempty"`
+}
+
+func validateStreamSpec(s *streamSpec) error {
+	if s.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	return nil
+}
+
+func readStreamSpecs(r io.Reader) ([]*streamSpec, error) {
+	return ReadStream(r, "test specification", validateStreamSpec)
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	want := []*streamSpec{{Name: "a", Value: 1}, {Name: "b"}}
+
+	var buf bytes.Buffer
+	if err := WriteStream(&buf, want); err != nil {
+		t.Fatalf("WriteStream() returned error: %v", err)
+	}
+	if got := buf.String(); got != "name: a\nvalue: 1\n---\nname: b\n" {
+		t.Errorf("WriteStream() = %q", got)
+	}
+
+	got, err := readStreamSpecs(&buf)
+	if err != nil {
+		t.Fatalf("ReadStream() returned error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReadStream() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadStreamErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"name: a\n---\ncolour: red\n", "invalid test specification #2: "},
+		{"name: a\n---\nvalue: 2\n", "invalid test specification #2: name is required"},
+	}
+
+	for _, tt := range tests {
+		_, err := readStreamSpecs(strings.NewReader(tt.input))
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("ReadStream(%q) error = %v, want %q", tt.input, err, tt.want)
+		}
+	}
+}
+
+func TestReadFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "specs.yaml")
+	if err := os.WriteFile(path, []byte("name: file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadFiles([]string{path, "-"}, strings.NewReader("name: stdin\n"), readStreamSpecs)
+	if err != nil {
+		t.Fatalf("ReadFiles() returned error: %v", err)
+	}
+	if diff := cmp.Diff([]*streamSpec{{Name: "file"}, {Name: "stdin"}}, got); diff != "" {
+		t.Errorf("ReadFiles() mismatch (-want +got):\n%s", diff)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("value: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadFiles([]string{invalid}, nil, readStreamSpecs); err == nil || !strings.HasPrefix(err.Error(), invalid+": ") {
+		t.Errorf("ReadFiles() error = %v, want it prefixed with the path", err)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Object is a resource to write as a manifest
+type Object struct {
+	// Name identifies the resource and gives its file name
+	Name string
+	// Spec is the manifest, encoded as YAML
+	Spec interface{}
+}
+
+var unsafeChars = regexp.MustCompile(`[^a-z0-9._]+`)
+
+// FileName returns a file name for a resource name, made of lower case
+// letters, digits, dots, underscores and dashes
+func FileName(name string) string {
+	s := strings.Trim(unsafeChars.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+	if s == "" {
+		s = "unnamed"
+	}
+	return s + ".yaml"
+}
+
+// Marshal encodes a manifest as YAML with two space indentation. Map keys
+// are sorted, so the output is stable across runs.
+func Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteDir writes each object to its own file in dir, which is created if
+// needed. YAML files of dir that no object was written to are removed, so
+// that the directory reflects deleted resources. It returns the number of
+// files removed.
+func WriteDir(dir string, objects []*Object) (int, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return 0, err
+	}
+
+	sorted := append([]*Object{}, objects...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	written := map[string]bool{}
+	for _, o := range sorted {
+		name := FileName(o.Name)
+		// names differing only by unsafe characters share a file name
+		for n := 2; written[name]; n++ {
+			name = fmt.Sprintf("%s-%d.yaml", strings.TrimSuffix(FileName(o.Name), ".yaml"), n)
+		}
+		written[name] = true
+
+		b, err := Marshal(o.Spec)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode %s: %v", o.Name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), b, 0o600); err != nil {
+			return 0, err
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if e.IsDir() || written[e.Name()] {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".yaml", ".yml":
+			if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+				return removed, err
+			}
+			removed++
+		}
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported output formats
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+
+	// FormatNDJSON writes one JSON document per line and is only offered
+	// by commands that stream their results
+	FormatNDJSON = "ndjson"
+)
+
+// Formats lists the output formats accepted by the --output flag
+var Formats = []string{FormatTable, FormatJSON, FormatYAML}
+
+// AddFormatFlag registers the --output flag on a command
+func AddFormatFlag(cmd *cobra.Command, format *string) {
+	cmd.Flags().StringVarP(format, "output", "o", FormatTable,
+		fmt.Sprintf("Output format. One of: %s", strings.Join(Formats, "|")))
+}
+
+// ValidateFormat checks that the format is one of the supported output formats
+func ValidateFormat(format string) error {
+	for _, f := range Formats {
+		if f == format {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported output format %q, must be one of: %s", format, strings.Join(Formats, "|"))
+}
+
+// Print writes v to w in the requested format. The table function is only
+// called for the table format and is responsible for filling in the rows.
+func Print(w io.Writer, format string, v interface{}, table func(t *Table)) error {
+	switch format {
+	case FormatJSON:
+		return PrintJSON(w, v)
+	case FormatYAML:
+		return PrintYAML(w, v)
+	case FormatTable:
+		t := NewTable()
+		table(t)
+		return t.Render(w)
+	}
+
+	return ValidateFormat(format)
+}
+
+// PrintJSON writes v to w as indented JSON
+func PrintJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// PrintNDJSON writes v to w as a single line of JSON
+func PrintNDJSON(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// PrintYAML writes v to w as YAML. The value is converted through JSON first
+// so that the API model field names are used as keys.
+func PrintYAML(w io.Writer, v interface{}) error {
+	generic, err := ToGeneric(v)
+	if err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(generic); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// ToGeneric converts v into plain maps and slices using its JSON representation
+func ToGeneric(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// Time formats a timestamp for table output, returning an empty string for
+// unset values
+func Time(dt strfmt.DateTime) string {
+	t := time.Time(dt)
+	if t.IsZero() || t.Unix() == 0 {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Table is a simple column aligned table writer
+type Table struct {
+	headers []string
+	rows    [][]string
+}
+
+// NewTable returns a table with the given column headers
+func NewTable(headers ...string) *Table {
+	return &Table{headers: headers}
+}
+
+// SetHeaders sets the column headers of the table
+func (t *Table) SetHeaders(headers ...string) {
+	t.headers = headers
+}
+
+// AddRow appends a row to the table
+func (t *Table) AddRow(fields ...string) {
+	t.rows = append(t.rows, fields)
+}
+
+// Len returns the number of rows in the table
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// Render writes the table to w
+func (t *Table) Render(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	if len(t.headers) > 0 {
+		if _, err := fmt.Fprintln(tw, strings.Join(t.headers, "\t")); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range t.rows {
+		if _, err := fmt.Fprintln(tw, strings.Join(cleanRow(row), "\t")); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+// RenderFixed writes the table to w with fixed column widths rather than
+// aligning the columns on their content, so that tables written one after
+// the other line up. Fields longer than their column are written in full.
+func (t *Table) RenderFixed(w io.Writer, widths ...int) error {
+	lines := [][]string{}
+	if len(t.headers) > 0 {
+		lines = append(lines, t.headers)
+	}
+	for _, row := range t.rows {
+		lines = append(lines, cleanRow(row))
+	}
+
+	for _, fields := range lines {
+		var b strings.Builder
+		for i, field := range fields {
+			if i > 0 {
+				b.WriteString("   ")
+			}
+			b.WriteString(field)
+			if n := utf8.RuneCountInString(field); i < len(fields)-1 && i < len(widths) && n < widths[i] {
+				b.WriteString(strings.Repeat(" ", widths[i]-n))
+			}
+		}
+		if _, err := fmt.Fprintln(w, strings.TrimRight(b.String(), " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cleanRow(row []string) []string {
+	cleaned := make([]string, len(row))
+	for i, field := range row {
+		cleaned[i] = strings.NewReplacer("\t", " ", "\n", " ").Replace(field)
+	}
+	return cleaned
+}
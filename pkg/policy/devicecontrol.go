@@ -0,0 +1,783 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package policy
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/device_control_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/strfmt"
+)
+
+// Settings of device control policies
+const (
+	SettingEnforcementMode     = "enforcement_mode"
+	SettingEndUserNotification = "end_user_notification"
+	SettingClasses             = "classes"
+)
+
+// Enforcement modes, end user notifications, USB classes and actions of
+// device control policies
+var (
+	EnforcementModes     = []string{"MONITOR_ONLY", "MONITOR_ENFORCE", "OFF"}
+	EndUserNotifications = []string{"SILENT", "NOTIFY_USER"}
+	USBClasses           = []string{"ANY", "AUDIO_VIDEO", "IMAGING", "MASS_STORAGE", "MOBILE", "PRINTER", "WIRELESS"}
+	USBActions           = []string{"FULL_ACCESS", "FULL_BLOCK", "READ_ONLY"}
+)
+
+// DeviceControl describes device control policies. Their classes setting
+// maps each USB class to its action and exceptions, and exceptions are
+// matched by vendor ID, product ID and serial number.
+var DeviceControl = &Type{
+	Kind:      "DeviceControlPolicy",
+	Name:      "device-control",
+	Title:     "device control policy",
+	Plural:    "device control policies",
+	Platforms: []string{"Windows", "Mac"},
+	New: func(c *client.CrowdStrikeAPISpecification) Service {
+		return &deviceControlService{c}
+	},
+}
+
+// USBException allows devices of a vendor, optionally narrowed to a product
+// and a serial number, despite the action of their USB class. IDs are
+// hexadecimal.
+type USBException struct {
+	ID           string `json:"id,omitempty"`
+	Class        string `json:"class"`
+	VendorID     string `json:"vendor_id"`
+	ProductID    string `json:"product_id,omitempty"`
+	SerialNumber string `json:"serial_number,omitempty"`
+	Action       string `json:"action,omitempty"`
+	Description  string `json:"description,omitempty"`
+	VendorName   string `json:"vendor_name,omitempty"`
+	ProductName  string `json:"product_name,omitempty"`
+	Expiration   string `json:"expiration_time,omitempty"`
+}
+
+// Key identifies the devices an exception applies to
+func (e *USBException) Key() string {
+	return strings.ToLower(strings.Join([]string{e.Class, e.VendorID, e.ProductID, e.SerialNumber}, "/"))
+}
+
+// String describes the devices an exception applies to, e.g.
+// MASS_STORAGE 0781:5581 serial 4C530001
+func (e *USBException) String() string {
+	s := e.Class + " " + e.VendorID
+	if e.ProductID != "" {
+		s += ":" + e.ProductID
+	}
+	if e.SerialNumber != "" {
+		s += " serial " + e.SerialNumber
+	}
+	return s
+}
+
+// Normalize validates an exception, writes its IDs as four lowercase
+// hexadecimal digits and defaults its action to FULL_ACCESS
+func (e *USBException) Normalize() error {
+	e.Class = strings.ToUpper(strings.TrimSpace(e.Class))
+	if err := utils.ValidateOneOf("class", USBClasses, e.Class); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(e.VendorID) == "" {
+		return fmt.Errorf("vendor_id is required")
+	}
+	var err error
+	if e.VendorID, err = NormalizeUSBID(e.VendorID); err != nil {
+		return fmt.Errorf("invalid vendor_id: %v", err)
+	}
+	if e.ProductID != "" {
+		if e.ProductID, err = NormalizeUSBID(e.ProductID); err != nil {
+			return fmt.Errorf("invalid product_id: %v", err)
+		}
+	}
+
+	e.SerialNumber = strings.TrimSpace(e.SerialNumber)
+	if e.SerialNumber != "" && e.ProductID == "" {
+		return fmt.Errorf("a serial_number requires a product_id")
+	}
+
+	e.Action = strings.ToUpper(strings.TrimSpace(e.Action))
+	if e.Action == "" {
+		e.Action = "FULL_ACCESS"
+	}
+	return utils.ValidateOneOf("action", USBActions, e.Action)
+}
+
+// NormalizeUSBID checks a hexadecimal USB vendor or product ID such as
+// 0x781 and returns it as four lowercase digits, e.g. 0781
+func NormalizeUSBID(id string) (string, error) {
+	s := strings.ToLower(strings.TrimSpace(id))
+	s = strings.TrimPrefix(s, "0x")
+	if s == "" || len(s) > 4 {
+		return "", fmt.Errorf("%q must be 1 to 4 hexadecimal digits", id)
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return "", fmt.Errorf("%q must be 1 to 4 hexadecimal digits", id)
+		}
+	}
+	return strings.Repeat("0", 4-len(s)) + s, nil
+}
+
+// ExceptionCSVColumns are the columns of USB exception CSV files. Only
+// vendor_id is required.
+var ExceptionCSVColumns = []string{"class", "vendor_id", "product_id", "serial_number", "action", "description"}
+
+// ReadExceptionsCSV reads USB exceptions from a CSV file with a header
+// row. Exceptions without a class apply to class. Errors are reported with
+// the line of the row.
+func ReadExceptionsCSV(r io.Reader, class string) ([]*USBException, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty CSV file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if err := utils.ValidateOneOf("CSV column", ExceptionCSVColumns, name); err != nil {
+			return nil, err
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["vendor_id"]; !ok {
+		return nil, fmt.Errorf("missing CSV column \"vendor_id\"")
+	}
+
+	list := []*USBException{}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		e := &USBException{
+			Class:        field("class"),
+			VendorID:     field("vendor_id"),
+			ProductID:    field("product_id"),
+			SerialNumber: field("serial_number"),
+			Action:       field("action"),
+			Description:  field("description"),
+		}
+		if e.Class == "" {
+			e.Class = class
+		}
+		if err := e.Normalize(); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		list = append(list, e)
+	}
+
+	return list, nil
+}
+
+// usbClass is the action of a USB class with its exceptions
+type usbClass struct {
+	ID         string
+	Action     string
+	Exceptions []*USBException
+	// replace is set when the exceptions of the class replace the current
+	// ones rather than being added to them
+	replace bool
+}
+
+// deviceControlSettings are the settings of a device control policy
+type deviceControlSettings struct {
+	EnforcementMode     string
+	EndUserNotification string
+	Classes             []*usbClass
+}
+
+func (s *deviceControlSettings) class(id string) *usbClass {
+	for _, c := range s.Classes {
+		if c.ID == id {
+			return c
+		}
+	}
+	return nil
+}
+
+// exceptions returns the exceptions of every class
+func (s *deviceControlSettings) exceptions() []*USBException {
+	list := []*USBException{}
+	for _, c := range s.Classes {
+		list = append(list, c.Exceptions...)
+	}
+	return list
+}
+
+type deviceControlService struct {
+	c *client.CrowdStrikeAPISpecification
+}
+
+func (s *deviceControlService) List(ctx context.Context, filter string) ([]*Policy, error) {
+	list := []*Policy{}
+	limit := int64(maxPolicies)
+	sortBy := "precedence.asc"
+
+	for {
+		offset := int64(len(list))
+		params := &device_control_policies.QueryCombinedDeviceControlPoliciesParams{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offset,
+			Sort:    &sortBy,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := s.c.DeviceControlPolicies.QueryCombinedDeviceControlPolicies(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query device control policies: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, p := range res.Payload.Resources {
+			list = append(list, deviceControlPolicy(p))
+		}
+
+		if len(res.Payload.Resources) == 0 || !hasMore(res.Payload.Meta, len(list)) {
+			return list, nil
+		}
+	}
+}
+
+func (s *deviceControlService) Create(ctx context.Context, spec *Spec) (*Policy, error) {
+	req := &models.RequestsCreateDeviceControlPolicyV1{
+		Name:         &spec.Name,
+		Description:  spec.Description,
+		PlatformName: &spec.Platform,
+	}
+
+	if len(spec.Settings) > 0 {
+		want, err := parseDeviceControlSettings(spec.Settings)
+		if err != nil {
+			return nil, err
+		}
+		current := &deviceControlSettings{EnforcementMode: "MONITOR_ONLY", EndUserNotification: "SILENT"}
+		req.Settings = mergeDeviceControlSettings(current, want)
+	}
+
+	res, err := s.c.DeviceControlPolicies.CreateDeviceControlPolicies(&device_control_policies.CreateDeviceControlPoliciesParams{
+		Context: ctx,
+		Body: &models.RequestsCreateDeviceControlPoliciesV1{
+			Resources: []*models.RequestsCreateDeviceControlPolicyV1{req},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create device control policy: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstDeviceControlPolicy(res.Payload)
+}
+
+// Update merges the settings of spec into the current settings of the
+// policy, as the API requires the enforcement mode and notification with
+// every change. The exceptions of the classes in spec replace the current
+// ones.
+func (s *deviceControlService) Update(ctx context.Context, id string, spec *Spec) (*Policy, error) {
+	req := &models.RequestsUpdateDeviceControlPolicyV1{
+		ID:          &id,
+		Name:        spec.Name,
+		Description: spec.Description,
+	}
+
+	if len(spec.Settings) > 0 {
+		want, err := parseDeviceControlSettings(spec.Settings)
+		if err != nil {
+			return nil, err
+		}
+		current, err := getDeviceControlSettings(ctx, s.c, id)
+		if err != nil {
+			return nil, err
+		}
+		req.Settings = mergeDeviceControlSettings(current, want)
+	}
+
+	return updateDeviceControlPolicy(ctx, s.c, req)
+}
+
+func (s *deviceControlService) Delete(ctx context.Context, ids []string) error {
+	res, err := s.c.DeviceControlPolicies.DeleteDeviceControlPolicies(&device_control_policies.DeleteDeviceControlPoliciesParams{
+		Context: ctx,
+		Ids:     ids,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete device control policies: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *deviceControlService) Action(ctx context.Context, action string, ids []string, groupID string) error {
+	res, err := s.c.DeviceControlPolicies.PerformDeviceControlPoliciesAction(&device_control_policies.PerformDeviceControlPoliciesActionParams{
+		Context:    ctx,
+		ActionName: action,
+		Body:       actionRequest(ids, groupID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to %s device control policies: %s", action, falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *deviceControlService) SetPrecedence(ctx context.Context, platform string, ids []string) error {
+	res, err := s.c.DeviceControlPolicies.SetDeviceControlPoliciesPrecedence(&device_control_policies.SetDeviceControlPoliciesPrecedenceParams{
+		Context: ctx,
+		Body: &models.RequestsSetPolicyPrecedenceReqV1{
+			Ids:          ids,
+			PlatformName: &platform,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set device control policy precedence: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+// USBExceptions returns the USB exceptions of a device control policy
+func USBExceptions(ctx context.Context, c *client.CrowdStrikeAPISpecification, policyID string) ([]*USBException, error) {
+	settings, err := getDeviceControlSettings(ctx, c, policyID)
+	if err != nil {
+		return nil, err
+	}
+	return settings.exceptions(), nil
+}
+
+// AddUSBExceptions adds normalized exceptions to a device control policy.
+// Exceptions matching the same devices as a current exception update it.
+func AddUSBExceptions(ctx context.Context, c *client.CrowdStrikeAPISpecification, policyID string, list []*USBException) error {
+	current, err := getDeviceControlSettings(ctx, c, policyID)
+	if err != nil {
+		return err
+	}
+
+	want := &deviceControlSettings{}
+	for _, e := range list {
+		class := want.class(e.Class)
+		if class == nil {
+			class = &usbClass{ID: e.Class}
+			want.Classes = append(want.Classes, class)
+		}
+		class.Exceptions = append(class.Exceptions, e)
+	}
+
+	_, err = updateDeviceControlPolicy(ctx, c, &models.RequestsUpdateDeviceControlPolicyV1{
+		ID:       &policyID,
+		Settings: mergeDeviceControlSettings(current, want),
+	})
+	return err
+}
+
+// RemoveUSBExceptions removes exceptions, given by ID, from a device
+// control policy
+func RemoveUSBExceptions(ctx context.Context, c *client.CrowdStrikeAPISpecification, policyID string, ids []string) error {
+	current, err := getDeviceControlSettings(ctx, c, policyID)
+	if err != nil {
+		return err
+	}
+
+	req := mergeDeviceControlSettings(current, &deviceControlSettings{})
+	req.DeleteExceptions = ids
+
+	_, err = updateDeviceControlPolicy(ctx, c, &models.RequestsUpdateDeviceControlPolicyV1{
+		ID:       &policyID,
+		Settings: req,
+	})
+	return err
+}
+
+func getDeviceControlSettings(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string) (*deviceControlSettings, error) {
+	res, err := c.DeviceControlPolicies.GetDeviceControlPolicies(&device_control_policies.GetDeviceControlPoliciesParams{
+		Context: ctx,
+		Ids:     []string{id},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device control policy: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return nil, fmt.Errorf("device control policy %s %w", id, ErrNotFound)
+	}
+	return deviceControlSettingsFromAPI(res.Payload.Resources[0].Settings), nil
+}
+
+func updateDeviceControlPolicy(ctx context.Context, c *client.CrowdStrikeAPISpecification, req *models.RequestsUpdateDeviceControlPolicyV1) (*Policy, error) {
+	res, err := c.DeviceControlPolicies.UpdateDeviceControlPolicies(&device_control_policies.UpdateDeviceControlPoliciesParams{
+		Context: ctx,
+		Body: &models.RequestsUpdateDeviceControlPoliciesV1{
+			Resources: []*models.RequestsUpdateDeviceControlPolicyV1{req},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update device control policy: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstDeviceControlPolicy(res.Payload)
+}
+
+// mergeDeviceControlSettings returns the request bringing the current
+// settings in line with want. Exceptions matching a current exception keep
+// its ID so that they are updated rather than duplicated, and the current
+// exceptions of classes whose exceptions are replaced are deleted.
+func mergeDeviceControlSettings(current, want *deviceControlSettings) *models.RequestsDeviceControlPolicySettingsV1 {
+	req := &models.RequestsDeviceControlPolicySettingsV1{
+		EnforcementMode:     utils.Ptr(current.EnforcementMode),
+		EndUserNotification: utils.Ptr(current.EndUserNotification),
+		Classes:             []*models.RequestsDeviceControlPolicyClassSettingsV1{},
+		DeleteExceptions:    []string{},
+	}
+	if want.EnforcementMode != "" {
+		req.EnforcementMode = utils.Ptr(want.EnforcementMode)
+	}
+	if want.EndUserNotification != "" {
+		req.EndUserNotification = utils.Ptr(want.EndUserNotification)
+	}
+
+	ids := []string{}
+	for _, c := range current.Classes {
+		ids = append(ids, c.ID)
+	}
+	for _, c := range want.Classes {
+		if current.class(c.ID) == nil {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	for _, id := range ids {
+		have := current.class(id)
+		if have == nil {
+			have = &usbClass{ID: id, Action: "FULL_ACCESS"}
+		}
+
+		class := &models.RequestsDeviceControlPolicyClassSettingsV1{
+			ID:         utils.Ptr(id),
+			Action:     utils.Ptr(have.Action),
+			Exceptions: []*models.RequestsDeviceControlPolicyExceptionV1{},
+		}
+
+		w := want.class(id)
+		if w == nil {
+			req.Classes = append(req.Classes, class)
+			continue
+		}
+		if w.Action != "" {
+			class.Action = utils.Ptr(w.Action)
+		}
+
+		existing := map[string]*USBException{}
+		for _, e := range have.Exceptions {
+			existing[e.Key()] = e
+		}
+
+		kept := map[string]bool{}
+		for _, e := range w.Exceptions {
+			ex := &models.RequestsDeviceControlPolicyExceptionV1{
+				VendorID:     e.VendorID,
+				ProductID:    e.ProductID,
+				SerialNumber: e.SerialNumber,
+				Action:       e.Action,
+				Description:  e.Description,
+			}
+			if expiration, err := strfmt.ParseDateTime(e.Expiration); e.Expiration != "" && err == nil {
+				ex.ExpirationTime = expiration
+			}
+			if h, ok := existing[e.Key()]; ok {
+				ex.ID = h.ID
+				kept[h.ID] = true
+			}
+			class.Exceptions = append(class.Exceptions, ex)
+		}
+
+		if w.replace {
+			for _, e := range have.Exceptions {
+				if !kept[e.ID] {
+					req.DeleteExceptions = append(req.DeleteExceptions, e.ID)
+				}
+			}
+		}
+
+		req.Classes = append(req.Classes, class)
+	}
+
+	return req
+}
+
+// parseDeviceControlSettings validates the settings of a specification
+func parseDeviceControlSettings(settings map[string]interface{}) (*deviceControlSettings, error) {
+	s := &deviceControlSettings{}
+
+	for key, value := range settings {
+		switch key {
+		case SettingEnforcementMode, SettingEndUserNotification:
+			v, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("setting %s must be a string", key)
+			}
+			v = strings.ToUpper(v)
+			if key == SettingEnforcementMode {
+				if err := utils.ValidateOneOf(key, EnforcementModes, v); err != nil {
+					return nil, err
+				}
+				s.EnforcementMode = v
+			} else {
+				if err := utils.ValidateOneOf(key, EndUserNotifications, v); err != nil {
+					return nil, err
+				}
+				s.EndUserNotification = v
+			}
+		case SettingClasses:
+			classes, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("setting %s must map USB classes to their action and exceptions", key)
+			}
+
+			ids := []string{}
+			for id := range classes {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+
+			for _, id := range ids {
+				class, err := parseUSBClass(strings.ToUpper(id), classes[id])
+				if err != nil {
+					return nil, fmt.Errorf("class %s: %v", id, err)
+				}
+				s.Classes = append(s.Classes, class)
+			}
+		default:
+			return nil, fmt.Errorf("unknown device control setting %q", key)
+		}
+	}
+
+	return s, nil
+}
+
+func parseUSBClass(id string, value interface{}) (*usbClass, error) {
+	if err := utils.ValidateOneOf("class", USBClasses, id); err != nil {
+		return nil, err
+	}
+
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be a map with an action and exceptions")
+	}
+
+	class := &usbClass{ID: id}
+	for key, v := range m {
+		switch key {
+		case "action":
+			action, _ := v.(string)
+			class.Action = strings.ToUpper(action)
+			if err := utils.ValidateOneOf("action", USBActions, class.Action); err != nil {
+				return nil, err
+			}
+		case "exceptions":
+			list, ok := v.([]interface{})
+			if !ok && v != nil {
+				return nil, fmt.Errorf("exceptions must be a list")
+			}
+			class.replace = true
+			for _, item := range list {
+				e, err := parseUSBException(id, item)
+				if err != nil {
+					return nil, err
+				}
+				class.Exceptions = append(class.Exceptions, e)
+			}
+		default:
+			return nil, fmt.Errorf("unknown field %q, must be action or exceptions", key)
+		}
+	}
+
+	return class, nil
+}
+
+func parseUSBException(class string, value interface{}) (*USBException, error) {
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("every exception must be a map")
+	}
+
+	e := &USBException{Class: class}
+	fields := map[string]*string{
+		"vendor_id":       &e.VendorID,
+		"product_id":      &e.ProductID,
+		"serial_number":   &e.SerialNumber,
+		"action":          &e.Action,
+		"description":     &e.Description,
+		"expiration_time": &e.Expiration,
+	}
+	for key, v := range m {
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown exception field %q", key)
+		}
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("exception field %s must be a string, quote IDs such as \"0781\"", key)
+		}
+		*field = s
+	}
+
+	if err := e.Normalize(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func deviceControlSettingsFromAPI(settings *models.ResponsesDeviceControlSettingsV1) *deviceControlSettings {
+	s := &deviceControlSettings{}
+	if settings == nil {
+		return s
+	}
+
+	s.EnforcementMode = utils.Deref(settings.EnforcementMode)
+	s.EndUserNotification = utils.Deref(settings.EndUserNotification)
+	for _, c := range settings.Classes {
+		class := &usbClass{ID: utils.Deref(c.ID), Action: utils.Deref(c.Action)}
+		for _, e := range c.Exceptions {
+			class.Exceptions = append(class.Exceptions, &USBException{
+				ID:           utils.Deref(e.ID),
+				Class:        class.ID,
+				VendorID:     apiUSBID(e.VendorID),
+				ProductID:    apiUSBID(e.ProductID),
+				SerialNumber: e.SerialNumber,
+				Action:       e.Action,
+				Description:  e.Description,
+				VendorName:   e.VendorName,
+				ProductName:  e.ProductName,
+				Expiration:   e.ExpirationTime,
+			})
+		}
+		s.Classes = append(s.Classes, class)
+	}
+	return s
+}
+
+// apiUSBID normalizes an ID returned by the API, which keeps IDs as they
+// were entered in the console, so that exceptions match those of
+// specifications. IDs that are not valid are returned as is.
+func apiUSBID(id string) string {
+	if normalized, err := NormalizeUSBID(id); err == nil {
+		return normalized
+	}
+	return id
+}
+
+// settings returns the settings as written in specifications
+func (s *deviceControlSettings) settings() map[string]interface{} {
+	classes := map[string]interface{}{}
+	for _, c := range s.Classes {
+		exceptions := []interface{}{}
+		for _, e := range c.Exceptions {
+			m := map[string]interface{}{"vendor_id": e.VendorID}
+			for key, v := range map[string]string{
+				"product_id":      e.ProductID,
+				"serial_number":   e.SerialNumber,
+				"action":          e.Action,
+				"description":     e.Description,
+				"expiration_time": e.Expiration,
+			} {
+				if v != "" {
+					m[key] = v
+				}
+			}
+			exceptions = append(exceptions, m)
+		}
+		classes[c.ID] = map[string]interface{}{"action": c.Action, "exceptions": exceptions}
+	}
+
+	return map[string]interface{}{
+		SettingEnforcementMode:     s.EnforcementMode,
+		SettingEndUserNotification: s.EndUserNotification,
+		SettingClasses:             classes,
+	}
+}
+
+func firstDeviceControlPolicy(payload *models.ResponsesDeviceControlPoliciesV1) (*Policy, error) {
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Resources) == 0 {
+		return nil, fmt.Errorf("no device control policy returned")
+	}
+
+	return deviceControlPolicy(payload.Resources[0]), nil
+}
+
+func deviceControlPolicy(p *models.ResponsesDeviceControlPolicyV1) *Policy {
+	policy := &Policy{
+		ID:          utils.Deref(p.ID),
+		Name:        utils.Deref(p.Name),
+		Description: utils.Deref(p.Description),
+		Platform:    utils.Deref(p.PlatformName),
+		Enabled:     utils.Deref(p.Enabled),
+		ModifiedBy:  utils.Deref(p.ModifiedBy),
+		Groups:      groups(p.Groups),
+		Settings:    deviceControlSettingsFromAPI(p.Settings).settings(),
+	}
+	if p.ModifiedTimestamp != nil {
+		policy.ModifiedTimestamp = *p.ModifiedTimestamp
+	}
+	return policy
+}
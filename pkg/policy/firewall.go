@@ -0,0 +1,404 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/firewall"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/firewall_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Settings of firewall policies
+const (
+	SettingDefaultInbound  = "default_inbound"
+	SettingDefaultOutbound = "default_outbound"
+	SettingEnforce         = "enforce"
+	SettingLocalLogging    = "local_logging"
+	SettingTestMode        = "test_mode"
+	SettingRuleGroups      = "rule_groups"
+)
+
+// Firewall describes firewall policies. Their rule_groups setting lists
+// rule groups by name in precedence order, and default_inbound and
+// default_outbound apply to traffic no rule matches.
+var Firewall = &Type{
+	Kind:      "FirewallPolicy",
+	Name:      "firewall",
+	Title:     "firewall policy",
+	Plural:    "firewall policies",
+	Platforms: Platforms,
+	New: func(c *client.CrowdStrikeAPISpecification) Service {
+		return &firewallService{c: c}
+	},
+}
+
+type firewallService struct {
+	c      *client.CrowdStrikeAPISpecification
+	groups []*firewall.Group
+}
+
+func (s *firewallService) List(ctx context.Context, filter string) ([]*Policy, error) {
+	list := []*Policy{}
+	limit := int64(maxPolicies)
+	sortBy := "precedence.asc"
+
+	for {
+		offset := int64(len(list))
+		params := &firewall_policies.QueryCombinedFirewallPoliciesParams{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offset,
+			Sort:    &sortBy,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := s.c.FirewallPolicies.QueryCombinedFirewallPolicies(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query firewall policies: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, p := range res.Payload.Resources {
+			list = append(list, firewallPolicy(p))
+		}
+
+		if len(res.Payload.Resources) == 0 || !hasMore(res.Payload.Meta, len(list)) {
+			break
+		}
+	}
+
+	if err := s.fill(ctx, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (s *firewallService) Create(ctx context.Context, spec *Spec) (*Policy, error) {
+	res, err := s.c.FirewallPolicies.CreateFirewallPolicies(&firewall_policies.CreateFirewallPoliciesParams{
+		Context: ctx,
+		Body: &models.RequestsCreateFirewallPoliciesV1{
+			Resources: []*models.RequestsCreateFirewallPolicyV1{{
+				Name:         &spec.Name,
+				Description:  spec.Description,
+				PlatformName: &spec.Platform,
+			}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create firewall policy: %s", falcon.ErrorExplain(err))
+	}
+
+	p, err := firstFirewallPolicy(res.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateSettings(ctx, p, spec.Settings)
+}
+
+// Update updates the policy and merges the settings of spec into its
+// current settings, as the API replaces all of them at once
+func (s *firewallService) Update(ctx context.Context, id string, spec *Spec) (*Policy, error) {
+	res, err := s.c.FirewallPolicies.UpdateFirewallPolicies(&firewall_policies.UpdateFirewallPoliciesParams{
+		Context: ctx,
+		Body: &models.RequestsUpdateFirewallPoliciesV1{
+			Resources: []*models.RequestsUpdateFirewallPolicyV1{{
+				ID:          &id,
+				Name:        spec.Name,
+				Description: spec.Description,
+			}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update firewall policy: %s", falcon.ErrorExplain(err))
+	}
+
+	p, err := firstFirewallPolicy(res.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateSettings(ctx, p, spec.Settings)
+}
+
+func (s *firewallService) Delete(ctx context.Context, ids []string) error {
+	res, err := s.c.FirewallPolicies.DeleteFirewallPolicies(&firewall_policies.DeleteFirewallPoliciesParams{
+		Context: ctx,
+		Ids:     ids,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete firewall policies: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *firewallService) Action(ctx context.Context, action string, ids []string, groupID string) error {
+	res, err := s.c.FirewallPolicies.PerformFirewallPoliciesAction(&firewall_policies.PerformFirewallPoliciesActionParams{
+		Context:    ctx,
+		ActionName: action,
+		Body:       actionRequest(ids, groupID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to %s firewall policies: %s", action, falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *firewallService) SetPrecedence(ctx context.Context, platform string, ids []string) error {
+	res, err := s.c.FirewallPolicies.SetFirewallPoliciesPrecedence(&firewall_policies.SetFirewallPoliciesPrecedenceParams{
+		Context: ctx,
+		Body: &models.RequestsSetPolicyPrecedenceReqV1{
+			Ids:          ids,
+			PlatformName: &platform,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set firewall policy precedence: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+// ruleGroups returns the firewall rule groups, fetched once per service
+func (s *firewallService) ruleGroups(ctx context.Context) ([]*firewall.Group, error) {
+	if s.groups == nil {
+		groups, err := firewall.ListGroups(ctx, s.c, "")
+		if err != nil {
+			return nil, err
+		}
+		s.groups = groups
+	}
+	return s.groups, nil
+}
+
+// fill adds the firewall settings of policies, with rule groups written by
+// name
+func (s *firewallService) fill(ctx context.Context, list []*Policy) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	ids := []string{}
+	for _, p := range list {
+		ids = append(ids, p.ID)
+	}
+	settings, err := firewall.GetPolicySettings(ctx, s.c, ids)
+	if err != nil {
+		return err
+	}
+
+	names := map[string]string{}
+	for _, fs := range settings {
+		if len(fs.RuleGroupIDs) == 0 {
+			continue
+		}
+		groups, err := s.ruleGroups(ctx)
+		if err != nil {
+			return err
+		}
+		for _, g := range groups {
+			names[g.ID] = g.Name
+		}
+		break
+	}
+
+	for _, p := range list {
+		fs, ok := settings[p.ID]
+		if !ok {
+			continue
+		}
+
+		groups := []interface{}{}
+		for _, id := range fs.RuleGroupIDs {
+			if name, ok := names[id]; ok {
+				id = name
+			}
+			groups = append(groups, id)
+		}
+
+		p.Settings[SettingDefaultInbound] = fs.DefaultInbound
+		p.Settings[SettingDefaultOutbound] = fs.DefaultOutbound
+		p.Settings[SettingEnforce] = fs.Enforce
+		p.Settings[SettingLocalLogging] = fs.LocalLogging
+		p.Settings[SettingTestMode] = fs.TestMode
+		p.Settings[SettingRuleGroups] = groups
+	}
+	return nil
+}
+
+// updateSettings merges settings into the firewall settings of a policy
+// and returns the policy with its resulting settings
+func (s *firewallService) updateSettings(ctx context.Context, p *Policy, settings map[string]interface{}) (*Policy, error) {
+	current, err := firewall.GetPolicySettings(ctx, s.c, []string{p.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	fs, ok := current[p.ID]
+	if !ok || fs.PlatformID == "" {
+		platforms, err := firewall.Platforms(ctx, s.c)
+		if err != nil {
+			return nil, err
+		}
+		platform, err := firewall.FindPlatform(platforms, p.Platform)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			fs = &firewall.PolicySettings{PolicyID: p.ID, RuleGroupIDs: []string{}}
+		}
+		fs.PlatformID = platform.ID
+	}
+
+	if len(settings) > 0 {
+		if err := s.mergeSettings(ctx, fs, settings); err != nil {
+			return nil, err
+		}
+		if err := firewall.UpdatePolicySettings(ctx, s.c, fs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.fill(ctx, []*Policy{p}); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// mergeSettings validates the settings of a specification and sets them on
+// the firewall settings of a policy
+func (s *firewallService) mergeSettings(ctx context.Context, fs *firewall.PolicySettings, settings map[string]interface{}) error {
+	for key, value := range settings {
+		switch key {
+		case SettingDefaultInbound, SettingDefaultOutbound:
+			v, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("setting %s must be a string", key)
+			}
+			v = strings.ToUpper(v)
+			if err := utils.ValidateOneOf(key, firewall.Actions, v); err != nil {
+				return err
+			}
+			if key == SettingDefaultInbound {
+				fs.DefaultInbound = v
+			} else {
+				fs.DefaultOutbound = v
+			}
+		case SettingEnforce, SettingLocalLogging, SettingTestMode:
+			v, ok := value.(bool)
+			if !ok {
+				return fmt.Errorf("setting %s must be a boolean", key)
+			}
+			switch key {
+			case SettingEnforce:
+				fs.Enforce = v
+			case SettingLocalLogging:
+				fs.LocalLogging = v
+			default:
+				fs.TestMode = v
+			}
+		case SettingRuleGroups:
+			ids, err := s.ruleGroupIDs(ctx, value)
+			if err != nil {
+				return err
+			}
+			fs.RuleGroupIDs = ids
+		default:
+			return fmt.Errorf("unknown firewall setting %q", key)
+		}
+	}
+	return nil
+}
+
+// ruleGroupIDs resolves a list of rule group names or IDs
+func (s *firewallService) ruleGroupIDs(ctx context.Context, value interface{}) ([]string, error) {
+	list, ok := value.([]interface{})
+	if !ok && value != nil {
+		return nil, fmt.Errorf("setting %s must be a list of rule group names", SettingRuleGroups)
+	}
+
+	groups, err := s.ruleGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, v := range list {
+		name, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("setting %s must be a list of rule group names", SettingRuleGroups)
+		}
+
+		var found *firewall.Group
+		for _, g := range groups {
+			if g.ID == name || strings.EqualFold(g.Name, name) {
+				found = g
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("firewall rule group %q %w", name, firewall.ErrNotFound)
+		}
+		ids = append(ids, found.ID)
+	}
+	return ids, nil
+}
+
+func firstFirewallPolicy(payload *models.ResponsesFirewallPoliciesV1) (*Policy, error) {
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Resources) == 0 {
+		return nil, fmt.Errorf("no firewall policy returned")
+	}
+
+	return firewallPolicy(payload.Resources[0]), nil
+}
+
+func firewallPolicy(p *models.ResponsesFirewallPolicyV1) *Policy {
+	policy := &Policy{
+		ID:          utils.Deref(p.ID),
+		Name:        utils.Deref(p.Name),
+		Description: utils.Deref(p.Description),
+		Platform:    utils.Deref(p.PlatformName),
+		Enabled:     utils.Deref(p.Enabled),
+		ModifiedBy:  utils.Deref(p.ModifiedBy),
+		Groups:      groups(p.Groups),
+		Settings:    map[string]interface{}{},
+	}
+	if p.ModifiedTimestamp != nil {
+		policy.ModifiedTimestamp = *p.ModifiedTimestamp
+	}
+	return policy
+}
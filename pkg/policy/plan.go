@@ -0,0 +1,94 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package policy
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/crowdstrike/falcon-cli/pkg/diff"
+)
+
+// Changes returns the differences that applying spec would make to an
+// existing policy. Fields and settings the specification leaves out are
+// not changed by Apply and so are not compared.
+func Changes(t *Type, current *Policy, spec *Spec) ([]diff.Change, error) {
+	have := current.Spec(t)
+	want := *spec
+
+	if want.Enabled == nil {
+		have.Enabled = nil
+	}
+
+	if want.HostGroups == nil {
+		have.HostGroups = nil
+	} else {
+		want.HostGroups = append([]string{}, want.HostGroups...)
+		sort.Strings(want.HostGroups)
+	}
+
+	settings := map[string]interface{}{}
+	for id := range want.Settings {
+		if v, ok := have.Settings[id]; ok {
+			settings[id] = v
+		}
+	}
+	have.Settings = settings
+
+	a, err := generic(have)
+	if err != nil {
+		return nil, err
+	}
+	b, err := generic(&want)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Compare(a, b), nil
+}
+
+// Compare returns the differences between two specifications
+func Compare(a, b *Spec) ([]diff.Change, error) {
+	ga, err := generic(a)
+	if err != nil {
+		return nil, err
+	}
+	gb, err := generic(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.Compare(ga, gb), nil
+}
+
+// generic converts a specification to maps and slices through JSON so that
+// values decoded from YAML and returned by the API compare equal
+func generic(spec *Spec) (interface{}, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
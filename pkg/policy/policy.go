@@ -0,0 +1,347 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+// Package policy manages Falcon policies and converts them to and from YAML
+// specifications that can be kept under version control.
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/go-openapi/strfmt"
+	"gopkg.in/yaml.v3"
+)
+
+// Actions performed on policies
+const (
+	ActionEnable      = "enable"
+	ActionDisable     = "disable"
+	ActionAddGroup    = "add-host-group"
+	ActionRemoveGroup = "remove-host-group"
+)
+
+// DefaultPolicyName is the name of the default policy of each platform, which
+// always has the lowest precedence
+const DefaultPolicyName = "platform_default"
+
+// ErrNotFound is returned when a policy does not exist
+var ErrNotFound = errors.New("not found")
+
+// Platforms lists the platforms of policies as named by the API
+var Platforms = []string{"Windows", "Mac", "Linux"}
+
+// Type describes a kind of policy and how to manage it
+type Type struct {
+	// Kind identifies the policy type in specifications, e.g. PreventionPolicy
+	Kind string
+	// Name is the name of the command managing the type, e.g. prevention
+	Name string
+	// Title is the human readable name, e.g. prevention policy
+	Title string
+	// Plural is the plural of Title, e.g. prevention policies
+	Plural string
+	// Platforms lists the platforms policies can be created for
+	Platforms []string
+	// New returns the service managing policies of this type
+	New func(c *client.CrowdStrikeAPISpecification) Service
+}
+
+// Service manages the policies of one type through the Falcon API
+type Service interface {
+	// List returns the policies matching filter, in precedence order
+	List(ctx context.Context, filter string) ([]*Policy, error)
+	// Create creates a policy from a specification
+	Create(ctx context.Context, spec *Spec) (*Policy, error)
+	// Update updates the name, description and settings of a policy
+	Update(ctx context.Context, id string, spec *Spec) (*Policy, error)
+	// Delete deletes policies, which must be disabled first
+	Delete(ctx context.Context, ids []string) error
+	// Action enables, disables or assigns host groups to policies
+	Action(ctx context.Context, action string, ids []string, groupID string) error
+	// SetPrecedence sets the order of the policies of a platform
+	SetPrecedence(ctx context.Context, platform string, ids []string) error
+}
+
+// Group is a host group a policy is assigned to
+type Group struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Policy is a policy as returned by the API
+type Policy struct {
+	ID                string                 `json:"id"`
+	Name              string                 `json:"name"`
+	Description       string                 `json:"description"`
+	Platform          string                 `json:"platform"`
+	Enabled           bool                   `json:"enabled"`
+	Groups            []Group                `json:"groups"`
+	ModifiedBy        string                 `json:"modified_by"`
+	ModifiedTimestamp strfmt.DateTime        `json:"modified_timestamp"`
+	Settings          map[string]interface{} `json:"settings"`
+}
+
+// Spec is the specification of a policy as written to and read from YAML
+// files. Host groups are referenced by name so that a specification can be
+// applied to several tenants. When host_groups is omitted the assignments of
+// an existing policy are left unchanged.
+type Spec struct {
+	Kind        string                 `json:"kind" yaml:"kind"`
+	Name        string                 `json:"name" yaml:"name"`
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Platform    string                 `json:"platform" yaml:"platform"`
+	Enabled     *bool                  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	HostGroups  []string               `json:"host_groups,omitempty" yaml:"host_groups,omitempty"`
+	Settings    map[string]interface{} `json:"settings,omitempty" yaml:"settings,omitempty"`
+}
+
+// Spec returns the specification of the policy
+func (p *Policy) Spec(t *Type) *Spec {
+	enabled := p.Enabled
+	spec := &Spec{
+		Kind:        t.Kind,
+		Name:        p.Name,
+		Description: p.Description,
+		Platform:    p.Platform,
+		Enabled:     &enabled,
+		Settings:    p.Settings,
+	}
+
+	for _, g := range p.Groups {
+		spec.HostGroups = append(spec.HostGroups, g.Name)
+	}
+	sort.Strings(spec.HostGroups)
+
+	return spec
+}
+
+// Validate checks that the specification is complete and of the given type
+func (s *Spec) Validate(t *Type) error {
+	if s.Kind != t.Kind {
+		return fmt.Errorf("expected kind %s, got %q", t.Kind, s.Kind)
+	}
+
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	platform, err := NormalizePlatform(t, s.Platform)
+	if err != nil {
+		return err
+	}
+	s.Platform = platform
+
+	return nil
+}
+
+// NormalizePlatform returns the API name of a platform, matched case
+// insensitively against the platforms of the policy type
+func NormalizePlatform(t *Type, platform string) (string, error) {
+	for _, p := range t.Platforms {
+		if strings.EqualFold(p, platform) {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("invalid platform %q, must be one of: %s", platform, strings.Join(t.Platforms, ", "))
+}
+
+// ReadSpec decodes and validates a YAML specification
+func ReadSpec(t *Type, r io.Reader) (*Spec, error) {
+	spec := &Spec{}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(spec); err != nil {
+		return nil, fmt.Errorf("invalid %s specification: %v", t.Title, err)
+	}
+
+	if err := spec.Validate(t); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// ReadSpecFile reads a YAML specification from a file, or from in if path
+// is "-"
+func ReadSpecFile(t *Type, path string, in io.Reader) (*Spec, error) {
+	if path == "-" {
+		return ReadSpec(t, in)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	spec, err := ReadSpec(t, f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return spec, nil
+}
+
+// Find returns the policy with the given ID or name. A platform must be
+// given when policies of several platforms share the name.
+func Find(ctx context.Context, svc Service, t *Type, nameOrID, platform string) (*Policy, error) {
+	list, err := svc.List(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []*Policy{}
+	for _, p := range list {
+		if p.ID == nameOrID {
+			return p, nil
+		}
+		if strings.EqualFold(p.Name, nameOrID) && (platform == "" || strings.EqualFold(p.Platform, platform)) {
+			matches = append(matches, p)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%s %q %w", t.Title, nameOrID, ErrNotFound)
+	case 1:
+		return matches[0], nil
+	}
+	return nil, fmt.Errorf("several %s are named %q, specify a platform", t.Plural, nameOrID)
+}
+
+// Apply creates the policy described by spec, or updates the policy of the
+// same name and platform. The enabled state and the host groups are then
+// brought in line with the specification. It reports whether the policy was
+// created.
+func Apply(ctx context.Context, c *client.CrowdStrikeAPISpecification, t *Type, spec *Spec) (*Policy, bool, error) {
+	svc := t.New(c)
+
+	existing, err := Find(ctx, svc, t, spec.Name, spec.Platform)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, false, err
+	}
+
+	var p *Policy
+	created := existing == nil
+	if created {
+		p, err = svc.Create(ctx, spec)
+	} else {
+		p, err = svc.Update(ctx, existing.ID, spec)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if spec.HostGroups != nil {
+		groups, err := hosts.ResolveGroupIDs(ctx, c, spec.HostGroups)
+		if err != nil {
+			return p, created, err
+		}
+
+		ids := []string{}
+		for _, name := range spec.HostGroups {
+			ids = append(ids, groups[name])
+		}
+
+		add, remove := GroupChanges(p.Groups, ids)
+		for _, id := range add {
+			if err := svc.Action(ctx, ActionAddGroup, []string{p.ID}, id); err != nil {
+				return p, created, err
+			}
+		}
+		for _, id := range remove {
+			if err := svc.Action(ctx, ActionRemoveGroup, []string{p.ID}, id); err != nil {
+				return p, created, err
+			}
+		}
+	}
+
+	if spec.Enabled != nil && *spec.Enabled != p.Enabled {
+		action := ActionDisable
+		if *spec.Enabled {
+			action = ActionEnable
+		}
+		if err := svc.Action(ctx, action, []string{p.ID}, ""); err != nil {
+			return p, created, err
+		}
+	}
+
+	return p, created, nil
+}
+
+// GroupChanges returns the host groups to add and remove so that a policy
+// assigned to current is assigned to exactly the groups in want
+func GroupChanges(current []Group, want []string) (add, remove []string) {
+	has := map[string]bool{}
+	for _, g := range current {
+		has[g.ID] = true
+	}
+
+	wanted := map[string]bool{}
+	for _, id := range want {
+		if !wanted[id] && !has[id] {
+			add = append(add, id)
+		}
+		wanted[id] = true
+	}
+
+	for _, g := range current {
+		if !wanted[g.ID] {
+			remove = append(remove, g.ID)
+		}
+	}
+
+	return add, remove
+}
+
+// Precedence returns the IDs of the policies of a platform ordered with the
+// policies in first at the top, followed by the other policies in their
+// current order. The default policy is left out as its precedence is fixed.
+func Precedence(current []*Policy, first []string) ([]string, error) {
+	known := map[string]bool{}
+	for _, p := range current {
+		known[p.ID] = p.Name != DefaultPolicyName
+	}
+
+	order := []string{}
+	placed := map[string]bool{}
+	for _, id := range first {
+		if !known[id] {
+			return nil, fmt.Errorf("policy %s is not a policy of the platform or is the default policy", id)
+		}
+		if !placed[id] {
+			order = append(order, id)
+			placed[id] = true
+		}
+	}
+
+	for _, p := range current {
+		if !placed[p.ID] && p.Name != DefaultPolicyName {
+			order = append(order, p.ID)
+		}
+	}
+
+	return order, nil
+}
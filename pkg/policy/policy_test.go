@@ -0,0 +1,374 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPrecedence(t *testing.T) {
+	current := []*Policy{
+		{ID: "a", Name: "Servers"},
+		{ID: "b", Name: "Workstations"},
+		{ID: "c", Name: "Kiosks"},
+		{ID: "d", Name: DefaultPolicyName},
+	}
+
+	got, err := Precedence(current, []string{"c", "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"c", "a", "b"}, got); diff != "" {
+		t.Errorf("Precedence() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := Precedence(current, []string{"d"}); err == nil {
+		t.Error("Precedence() did not reject the default policy")
+	}
+	if _, err := Precedence(current, []string{"x"}); err == nil {
+		t.Error("Precedence() did not reject an unknown policy")
+	}
+}
+
+func TestGroupChanges(t *testing.T) {
+	add, remove := GroupChanges([]Group{{ID: "1"}, {ID: "2"}}, []string{"2", "3", "3"})
+	if diff := cmp.Diff([]string{"3"}, add); diff != "" {
+		t.Errorf("add mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"1"}, remove); diff != "" {
+		t.Errorf("remove mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadSpec(t *testing.T) {
+	spec, err := ReadSpec(Prevention, strings.NewReader(`
+kind: PreventionPolicy
+name: Workstations
+platform: windows
+enabled: true
+host_groups: [Laptops]
+settings:
+  CloudAntiMalware:
+    detection: MODERATE
+    prevention: CAUTIOUS
+  ScriptBasedExecutionMonitoring: true
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if spec.Platform != "Windows" {
+		t.Errorf("Platform = %q, want Windows", spec.Platform)
+	}
+
+	settings, err := preventionSettings(spec.Settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*models.RequestsPreventionSettingV1{
+		{ID: utils.Ptr("CloudAntiMalware"), Value: map[string]interface{}{"detection": "MODERATE", "prevention": "CAUTIOUS"}},
+		{ID: utils.Ptr("ScriptBasedExecutionMonitoring"), Value: map[string]interface{}{"enabled": true}},
+	}
+	if diff := cmp.Diff(want, settings); diff != "" {
+		t.Errorf("preventionSettings() mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, doc := range []string{
+		"kind: SensorUpdatePolicy\nname: x\nplatform: Windows\n",
+		"kind: PreventionPolicy\nplatform: Windows\n",
+		"kind: PreventionPolicy\nname: x\nplatform: BeOS\n",
+		"kind: PreventionPolicy\nname: x\nplatform: Windows\nunknown: 1\n",
+	} {
+		if _, err := ReadSpec(Prevention, strings.NewReader(doc)); err == nil {
+			t.Errorf("ReadSpec(%q) did not return an error", doc)
+		}
+	}
+}
+
+func TestSettingValue(t *testing.T) {
+	if got := settingValue(map[string]interface{}{"enabled": false}); got != false {
+		t.Errorf("settingValue(toggle) = %v, want false", got)
+	}
+
+	slider := map[string]interface{}{"detection": "MODERATE", "prevention": "DISABLED"}
+	if diff := cmp.Diff(slider, settingValue(slider)); diff != "" {
+		t.Errorf("settingValue(slider) mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestChanges(t *testing.T) {
+	current := &Policy{
+		Name:     "Workstations",
+		Platform: "Windows",
+		Enabled:  true,
+		Groups:   []Group{{ID: "1", Name: "Laptops"}},
+		Settings: map[string]interface{}{
+			"CloudAntiMalware":               map[string]interface{}{"detection": "MODERATE", "prevention": "CAUTIOUS"},
+			"ScriptBasedExecutionMonitoring": true,
+		},
+	}
+
+	spec := &Spec{
+		Kind:     Prevention.Kind,
+		Name:     "Workstations",
+		Platform: "Windows",
+		Settings: map[string]interface{}{
+			"CloudAntiMalware": map[string]interface{}{"detection": "AGGRESSIVE", "prevention": "CAUTIOUS"},
+		},
+	}
+
+	changes, err := Changes(Prevention, current, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 1 || changes[0].Path != "settings.CloudAntiMalware.detection" {
+		t.Errorf("Changes() = %v, want a single change of settings.CloudAntiMalware.detection", changes)
+	}
+}
+
+func TestResolveBuild(t *testing.T) {
+	builds := []*Build{
+		{Platform: utils.Ptr("Windows"), Build: utils.Ptr("18110|n|tagged|17"), SensorVersion: utils.Ptr("7.11.18110")},
+		{Platform: utils.Ptr("Windows"), Build: utils.Ptr("18007|n-1|tagged|16"), SensorVersion: utils.Ptr("7.10.18007")},
+		{Platform: utils.Ptr("Windows"), Build: utils.Ptr("18007"), SensorVersion: utils.Ptr("7.10.18007")},
+		{Platform: utils.Ptr("Windows"), Build: utils.Ptr("17905|n-2|tagged|15"), SensorVersion: utils.Ptr("7.09.17905")},
+		{Platform: utils.Ptr("Linux"), Build: utils.Ptr("18005|n-1|tagged|16"), SensorVersion: utils.Ptr("7.10.18005")},
+	}
+
+	tests := []struct {
+		platform, want, build string
+	}{
+		{"Windows", "n-1", "18007|n-1|tagged|16"},
+		{"windows", "N-2", "17905|n-2|tagged|15"},
+		{"Linux", "n-1", "18005|n-1|tagged|16"},
+		{"Windows", "18007", "18007"},
+		{"Windows", "7.10", "18007"},
+		{"Windows", "7.11.18110", "18110|n|tagged|17"},
+		{"Windows", "off", ""},
+		{"Windows", "18110|n|tagged|17", "18110|n|tagged|17"},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveBuild(builds, tt.platform, tt.want)
+		if err != nil {
+			t.Errorf("ResolveBuild(%s, %q) returned error: %v", tt.platform, tt.want, err)
+			continue
+		}
+		if got != tt.build {
+			t.Errorf("ResolveBuild(%s, %q) = %q, want %q", tt.platform, tt.want, got, tt.build)
+		}
+	}
+
+	if _, err := ResolveBuild(builds, "Mac", "n-1"); err == nil {
+		t.Error("ResolveBuild() did not reject a build of another platform")
+	}
+}
+
+func TestBuildName(t *testing.T) {
+	for build, want := range map[string]string{
+		"":                    BuildOff,
+		"18007|n-1|tagged|16": "n-1",
+		"18007":               "18007",
+	} {
+		if got := BuildName(build); got != want {
+			t.Errorf("BuildName(%q) = %q, want %q", build, got, want)
+		}
+	}
+}
+
+func TestNormalizeUSBID(t *testing.T) {
+	for id, want := range map[string]string{
+		"0781":   "0781",
+		"0x781":  "0781",
+		"ABCD":   "abcd",
+		" 1 ":    "0001",
+		"12345":  "",
+		"0xzz":   "",
+		"":       "",
+		"0x":     "",
+		"05ac ":  "05ac",
+		"0X05AC": "05ac",
+	} {
+		got, err := NormalizeUSBID(id)
+		if want == "" {
+			if err == nil {
+				t.Errorf("NormalizeUSBID(%q) = %q, want an error", id, got)
+			}
+			continue
+		}
+		if err != nil || got != want {
+			t.Errorf("NormalizeUSBID(%q) = %q, %v, want %q", id, got, err, want)
+		}
+	}
+}
+
+func TestReadExceptionsCSV(t *testing.T) {
+	list, err := ReadExceptionsCSV(strings.NewReader(`vendor_id,product_id,serial_number,description,class
+0x781,5581,4C530001,SanDisk of J. Doe,
+05AC,,,Apple devices,mobile
+`), "MASS_STORAGE")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*USBException{
+		{Class: "MASS_STORAGE", VendorID: "0781", ProductID: "5581", SerialNumber: "4C530001", Action: "FULL_ACCESS", Description: "SanDisk of J. Doe"},
+		{Class: "MOBILE", VendorID: "05ac", Action: "FULL_ACCESS", Description: "Apple devices"},
+	}
+	if diff := cmp.Diff(want, list); diff != "" {
+		t.Errorf("ReadExceptionsCSV() mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, doc := range []string{
+		"product_id\n5581\n",
+		"vendor_id,color\n0781,red\n",
+		"vendor_id,serial_number\n0781,123\n",
+		"vendor_id,class\n0781,floppy\n",
+	} {
+		if _, err := ReadExceptionsCSV(strings.NewReader(doc), "MASS_STORAGE"); err == nil {
+			t.Errorf("ReadExceptionsCSV(%q) did not fail", doc)
+		}
+	}
+}
+
+func TestMergeDeviceControlSettings(t *testing.T) {
+	current := &deviceControlSettings{
+		EnforcementMode:     "MONITOR_ENFORCE",
+		EndUserNotification: "NOTIFY_USER",
+		Classes: []*usbClass{
+			{ID: "MASS_STORAGE", Action: "READ_ONLY", Exceptions: []*USBException{
+				{ID: "e1", Class: "MASS_STORAGE", VendorID: "0781", ProductID: "5581", Action: "FULL_ACCESS"},
+				{ID: "e2", Class: "MASS_STORAGE", VendorID: "05ac", Action: "FULL_ACCESS"},
+			}},
+			{ID: "IMAGING", Action: "FULL_ACCESS"},
+		},
+	}
+
+	want, err := parseDeviceControlSettings(map[string]interface{}{
+		SettingEnforcementMode: "monitor_only",
+		SettingClasses: map[string]interface{}{
+			"mass_storage": map[string]interface{}{
+				"action": "FULL_BLOCK",
+				"exceptions": []interface{}{
+					map[string]interface{}{"vendor_id": "0x0781", "product_id": "5581", "description": "kept"},
+					map[string]interface{}{"vendor_id": "1234"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := mergeDeviceControlSettings(current, want)
+	if got := utils.Deref(req.EnforcementMode); got != "MONITOR_ONLY" {
+		t.Errorf("enforcement mode = %s, want MONITOR_ONLY", got)
+	}
+	if got := utils.Deref(req.EndUserNotification); got != "NOTIFY_USER" {
+		t.Errorf("end user notification = %s, want the current NOTIFY_USER", got)
+	}
+	if diff := cmp.Diff([]string{"e2"}, req.DeleteExceptions); diff != "" {
+		t.Errorf("deleted exceptions mismatch (-want +got):\n%s", diff)
+	}
+
+	if len(req.Classes) != 2 {
+		t.Fatalf("got %d classes, want 2", len(req.Classes))
+	}
+	storage := req.Classes[0]
+	if utils.Deref(storage.Action) != "FULL_BLOCK" || len(storage.Exceptions) != 2 {
+		t.Fatalf("mass storage = %s with %d exceptions, want FULL_BLOCK with 2", utils.Deref(storage.Action), len(storage.Exceptions))
+	}
+	if storage.Exceptions[0].ID != "e1" || storage.Exceptions[1].ID != "" {
+		t.Errorf("exception IDs = %q, %q, want e1 kept and a new exception", storage.Exceptions[0].ID, storage.Exceptions[1].ID)
+	}
+	if imaging := req.Classes[1]; utils.Deref(imaging.Action) != "FULL_ACCESS" || len(imaging.Exceptions) != 0 {
+		t.Errorf("imaging class was changed")
+	}
+
+	if _, err := parseDeviceControlSettings(map[string]interface{}{
+		SettingClasses: map[string]interface{}{"MASS_STORAGE": map[string]interface{}{"exceptions": []interface{}{map[string]interface{}{"vendor_id": 781}}}},
+	}); err == nil || !strings.Contains(err.Error(), "quote IDs") {
+		t.Errorf("unquoted vendor ID error = %v", err)
+	}
+}
+
+func TestDeviceControlSettingsFromAPI(t *testing.T) {
+	settings := deviceControlSettingsFromAPI(&models.ResponsesDeviceControlSettingsV1{
+		Classes: []*models.ResponsesDeviceControlPolicyClassSettingsV1{
+			{ID: utils.Ptr("MASS_STORAGE"), Action: utils.Ptr("READ_ONLY"), Exceptions: []*models.ResponsesDeviceControlPolicyExceptionV1{
+				{ID: utils.Ptr("e1"), VendorID: "781", ProductID: "0x5581"},
+				{ID: utils.Ptr("e2"), VendorID: "not-hex"},
+			}},
+		},
+	})
+
+	got := []string{}
+	for _, e := range settings.Classes[0].Exceptions {
+		got = append(got, e.Key())
+	}
+	if diff := cmp.Diff([]string{"mass_storage/0781/5581/", "mass_storage/not-hex//"}, got); diff != "" {
+		t.Errorf("exception keys mismatch (-want +got):\n%s", diff)
+	}
+
+	want, err := parseDeviceControlSettings(map[string]interface{}{
+		SettingClasses: map[string]interface{}{
+			"MASS_STORAGE": map[string]interface{}{
+				"exceptions": []interface{}{map[string]interface{}{"vendor_id": "0781", "product_id": "5581"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := mergeDeviceControlSettings(settings, want)
+	if exceptions := req.Classes[0].Exceptions; len(exceptions) != 1 || exceptions[0].ID != "e1" {
+		t.Errorf("the exception entered as 781 was not updated: %+v", exceptions)
+	}
+}
+
+func TestValidateResponseSettings(t *testing.T) {
+	for _, settings := range []map[string]interface{}{
+		{},
+		{SettingRealTimeFunctionality: true, SettingExecCommand: true},
+		{SettingCustomScripts: true},
+		{SettingRealTimeFunctionality: false, SettingGetCommand: false},
+	} {
+		if err := validateResponseSettings(settings); err != nil {
+			t.Errorf("validateResponseSettings(%v) = %v", settings, err)
+		}
+	}
+
+	for _, settings := range []map[string]interface{}{
+		{SettingRealTimeFunctionality: false, SettingPutCommand: true},
+		{SettingRealTimeFunctionality: "yes"},
+	} {
+		if err := validateResponseSettings(settings); err == nil {
+			t.Errorf("validateResponseSettings(%v) did not fail", settings)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/prevention_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// maxPolicies is the maximum number of policies returned per request
+const maxPolicies = 5000
+
+// Prevention describes prevention policies. Their settings are keyed by
+// setting ID. Toggles are written as booleans and machine learning sliders
+// as a map of their detection and prevention levels.
+var Prevention = &Type{
+	Kind:      "PreventionPolicy",
+	Name:      "prevention",
+	Title:     "prevention policy",
+	Plural:    "prevention policies",
+	Platforms: Platforms,
+	New: func(c *client.CrowdStrikeAPISpecification) Service {
+		return &preventionService{c}
+	},
+}
+
+type preventionService struct {
+	c *client.CrowdStrikeAPISpecification
+}
+
+func (s *preventionService) List(ctx context.Context, filter string) ([]*Policy, error) {
+	list := []*Policy{}
+	limit := int64(maxPolicies)
+	sortBy := "precedence.asc"
+
+	for {
+		offset := int64(len(list))
+		params := &prevention_policies.QueryCombinedPreventionPoliciesParams{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offset,
+			Sort:    &sortBy,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := s.c.PreventionPolicies.QueryCombinedPreventionPolicies(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query prevention policies: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, p := range res.Payload.Resources {
+			list = append(list, preventionPolicy(p))
+		}
+
+		if len(res.Payload.Resources) == 0 || !hasMore(res.Payload.Meta, len(list)) {
+			return list, nil
+		}
+	}
+}
+
+func (s *preventionService) Create(ctx context.Context, spec *Spec) (*Policy, error) {
+	settings, err := preventionSettings(spec.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.c.PreventionPolicies.CreatePreventionPolicies(&prevention_policies.CreatePreventionPoliciesParams{
+		Context: ctx,
+		Body: &models.RequestsCreatePreventionPoliciesV1{
+			Resources: []*models.RequestsCreatePreventionPolicyV1{{
+				Name:         &spec.Name,
+				Description:  spec.Description,
+				PlatformName: &spec.Platform,
+				Settings:     settings,
+			}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prevention policy: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstPreventionPolicy(res.Payload)
+}
+
+func (s *preventionService) Update(ctx context.Context, id string, spec *Spec) (*Policy, error) {
+	settings, err := preventionSettings(spec.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.c.PreventionPolicies.UpdatePreventionPolicies(&prevention_policies.UpdatePreventionPoliciesParams{
+		Context: ctx,
+		Body: &models.RequestsUpdatePreventionPoliciesV1{
+			Resources: []*models.RequestsUpdatePreventionPolicyV1{{
+				ID:          &id,
+				Name:        spec.Name,
+				Description: spec.Description,
+				Settings:    settings,
+			}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update prevention policy: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstPreventionPolicy(res.Payload)
+}
+
+func (s *preventionService) Delete(ctx context.Context, ids []string) error {
+	res, err := s.c.PreventionPolicies.DeletePreventionPolicies(&prevention_policies.DeletePreventionPoliciesParams{
+		Context: ctx,
+		Ids:     ids,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete prevention policies: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *preventionService) Action(ctx context.Context, action string, ids []string, groupID string) error {
+	res, err := s.c.PreventionPolicies.PerformPreventionPoliciesAction(&prevention_policies.PerformPreventionPoliciesActionParams{
+		Context:    ctx,
+		ActionName: action,
+		Body:       actionRequest(ids, groupID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to %s prevention policies: %s", action, falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *preventionService) SetPrecedence(ctx context.Context, platform string, ids []string) error {
+	res, err := s.c.PreventionPolicies.SetPreventionPoliciesPrecedence(&prevention_policies.SetPreventionPoliciesPrecedenceParams{
+		Context: ctx,
+		Body: &models.RequestsSetPolicyPrecedenceReqV1{
+			Ids:          ids,
+			PlatformName: &platform,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set prevention policy precedence: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func firstPreventionPolicy(payload *models.ResponsesPreventionPoliciesV1) (*Policy, error) {
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Resources) == 0 {
+		return nil, fmt.Errorf("no prevention policy returned")
+	}
+
+	return preventionPolicy(payload.Resources[0]), nil
+}
+
+func preventionPolicy(p *models.ResponsesPreventionPolicyV1) *Policy {
+	policy := &Policy{
+		ID:          utils.Deref(p.ID),
+		Name:        utils.Deref(p.Name),
+		Description: utils.Deref(p.Description),
+		Platform:    utils.Deref(p.PlatformName),
+		Enabled:     utils.Deref(p.Enabled),
+		ModifiedBy:  utils.Deref(p.ModifiedBy),
+		Groups:      groups(p.Groups),
+		Settings:    map[string]interface{}{},
+	}
+	if p.ModifiedTimestamp != nil {
+		policy.ModifiedTimestamp = *p.ModifiedTimestamp
+	}
+
+	for _, category := range p.PreventionSettings {
+		for _, setting := range category.Settings {
+			policy.Settings[utils.Deref(setting.ID)] = settingValue(setting.Value)
+		}
+	}
+
+	return policy
+}
+
+// settingValue simplifies toggles, which the API represents as
+// {"enabled": true}, to a boolean
+func settingValue(v interface{}) interface{} {
+	if m, ok := v.(map[string]interface{}); ok && len(m) == 1 {
+		if enabled, ok := m["enabled"].(bool); ok {
+			return enabled
+		}
+	}
+	return v
+}
+
+// preventionSettings converts the settings of a specification to the API
+// representation, sorted by ID
+func preventionSettings(settings map[string]interface{}) ([]*models.RequestsPreventionSettingV1, error) {
+	ids := []string{}
+	for id := range settings {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	list := []*models.RequestsPreventionSettingV1{}
+	for _, id := range ids {
+		id := id
+		var value interface{}
+		switch v := settings[id].(type) {
+		case bool:
+			value = map[string]interface{}{"enabled": v}
+		case map[string]interface{}:
+			value = v
+		default:
+			return nil, fmt.Errorf("setting %s must be a boolean or a map of levels", id)
+		}
+		list = append(list, &models.RequestsPreventionSettingV1{ID: &id, Value: value})
+	}
+
+	return list, nil
+}
+
+func groups(list []*models.ResponsesHostGroupV1) []Group {
+	out := []Group{}
+	for _, g := range list {
+		out = append(out, Group{ID: utils.Deref(g.ID), Name: utils.Deref(g.Name)})
+	}
+	return out
+}
+
+func actionRequest(ids []string, groupID string) *models.MsaEntityActionRequestV2 {
+	req := &models.MsaEntityActionRequestV2{Ids: ids}
+	if groupID != "" {
+		name := "group_id"
+		req.ActionParameters = []*models.MsaActionParameter{{Name: &name, Value: &groupID}}
+	}
+	return req
+}
+
+// hasMore reports whether more results are available after count results
+func hasMore(meta *models.MsaMetaInfo, count int) bool {
+	if meta == nil || meta.Pagination == nil {
+		return false
+	}
+	return int64(count) < utils.Deref(meta.Pagination.Total)
+}
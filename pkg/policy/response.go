@@ -0,0 +1,252 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/response_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Settings of response policies. The commands are only available to
+// responders when real time functionality is enabled.
+const (
+	SettingRealTimeFunctionality = "RealTimeFunctionality"
+	SettingCustomScripts         = "CustomScripts"
+	SettingGetCommand            = "GetCommand"
+	SettingPutCommand            = "PutCommand"
+	SettingExecCommand           = "ExecCommand"
+)
+
+// ResponseCommands lists the settings that depend on real time
+// functionality
+var ResponseCommands = []string{SettingCustomScripts, SettingGetCommand, SettingPutCommand, SettingExecCommand}
+
+// Response describes real time response policies, which control what
+// responders can do on the hosts of a policy. Their settings are keyed by
+// setting ID like those of prevention policies.
+var Response = &Type{
+	Kind:      "ResponsePolicy",
+	Name:      "response",
+	Title:     "response policy",
+	Plural:    "response policies",
+	Platforms: Platforms,
+	New: func(c *client.CrowdStrikeAPISpecification) Service {
+		return &responseService{c}
+	},
+}
+
+type responseService struct {
+	c *client.CrowdStrikeAPISpecification
+}
+
+func (s *responseService) List(ctx context.Context, filter string) ([]*Policy, error) {
+	list := []*Policy{}
+	limit := int64(maxPolicies)
+	sortBy := "precedence.asc"
+
+	for {
+		offset := int64(len(list))
+		params := &response_policies.QueryCombinedRTResponsePoliciesParams{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offset,
+			Sort:    &sortBy,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := s.c.ResponsePolicies.QueryCombinedRTResponsePolicies(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query response policies: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, p := range res.Payload.Resources {
+			list = append(list, responsePolicy(p))
+		}
+
+		if len(res.Payload.Resources) == 0 || !hasMore(res.Payload.Meta, len(list)) {
+			return list, nil
+		}
+	}
+}
+
+func (s *responseService) Create(ctx context.Context, spec *Spec) (*Policy, error) {
+	settings, err := responseSettings(spec.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.c.ResponsePolicies.CreateRTResponsePolicies(&response_policies.CreateRTResponsePoliciesParams{
+		Context: ctx,
+		Body: &models.RequestsCreateRTResponsePoliciesV1{
+			Resources: []*models.RequestsCreateRTResponsePolicyV1{{
+				Name:         &spec.Name,
+				Description:  spec.Description,
+				PlatformName: &spec.Platform,
+				Settings:     settings,
+			}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create response policy: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstResponsePolicy(res.Payload)
+}
+
+func (s *responseService) Update(ctx context.Context, id string, spec *Spec) (*Policy, error) {
+	settings, err := responseSettings(spec.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.c.ResponsePolicies.UpdateRTResponsePolicies(&response_policies.UpdateRTResponsePoliciesParams{
+		Context: ctx,
+		Body: &models.RequestsUpdateRTResponsePoliciesV1{
+			Resources: []*models.RequestsUpdateRTResponsePolicyV1{{
+				ID:          &id,
+				Name:        spec.Name,
+				Description: spec.Description,
+				Settings:    settings,
+			}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update response policy: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstResponsePolicy(res.Payload)
+}
+
+func (s *responseService) Delete(ctx context.Context, ids []string) error {
+	res, err := s.c.ResponsePolicies.DeleteRTResponsePolicies(&response_policies.DeleteRTResponsePoliciesParams{
+		Context: ctx,
+		Ids:     ids,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete response policies: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *responseService) Action(ctx context.Context, action string, ids []string, groupID string) error {
+	res, err := s.c.ResponsePolicies.PerformRTResponsePoliciesAction(&response_policies.PerformRTResponsePoliciesActionParams{
+		Context:    ctx,
+		ActionName: action,
+		Body:       actionRequest(ids, groupID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to %s response policies: %s", action, falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *responseService) SetPrecedence(ctx context.Context, platform string, ids []string) error {
+	res, err := s.c.ResponsePolicies.SetRTResponsePoliciesPrecedence(&response_policies.SetRTResponsePoliciesPrecedenceParams{
+		Context: ctx,
+		Body: &models.RequestsSetPolicyPrecedenceReqV1{
+			Ids:          ids,
+			PlatformName: &platform,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set response policy precedence: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func firstResponsePolicy(payload *models.ResponsesRTResponsePoliciesV1) (*Policy, error) {
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Resources) == 0 {
+		return nil, fmt.Errorf("no response policy returned")
+	}
+
+	return responsePolicy(payload.Resources[0]), nil
+}
+
+func responsePolicy(p *models.ResponsesRTResponsePolicyV1) *Policy {
+	policy := &Policy{
+		ID:          utils.Deref(p.ID),
+		Name:        utils.Deref(p.Name),
+		Description: utils.Deref(p.Description),
+		Platform:    utils.Deref(p.PlatformName),
+		Enabled:     utils.Deref(p.Enabled),
+		ModifiedBy:  utils.Deref(p.ModifiedBy),
+		Groups:      groups(p.Groups),
+		Settings:    map[string]interface{}{},
+	}
+	if p.ModifiedTimestamp != nil {
+		policy.ModifiedTimestamp = *p.ModifiedTimestamp
+	}
+
+	for _, category := range p.Settings {
+		for _, setting := range category.Settings {
+			policy.Settings[utils.Deref(setting.ID)] = settingValue(setting.Value)
+		}
+	}
+
+	return policy
+}
+
+// responseSettings checks the settings of a specification and converts
+// them to the API representation. Commands cannot be enabled when real
+// time functionality is turned off.
+func responseSettings(settings map[string]interface{}) ([]*models.RequestsPreventionSettingV1, error) {
+	if err := validateResponseSettings(settings); err != nil {
+		return nil, err
+	}
+	return preventionSettings(settings)
+}
+
+func validateResponseSettings(settings map[string]interface{}) error {
+	for id, v := range settings {
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("setting %s must be a boolean", id)
+		}
+	}
+
+	if rtr, ok := settings[SettingRealTimeFunctionality].(bool); !ok || rtr {
+		return nil
+	}
+	for _, id := range ResponseCommands {
+		if enabled, _ := settings[id].(bool); enabled {
+			return fmt.Errorf("setting %s requires %s", id, SettingRealTimeFunctionality)
+		}
+	}
+	return nil
+}
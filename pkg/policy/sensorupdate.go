@@ -0,0 +1,438 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/sensor_update_policies"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Settings of sensor update policies
+const (
+	SettingBuild               = "build"
+	SettingUninstallProtection = "uninstall_protection"
+	SettingVariants            = "variants"
+)
+
+// BuildOff is the build of policies that do not update sensors
+const BuildOff = "off"
+
+// UninstallProtections lists the uninstall protection modes of sensor
+// update policies
+var UninstallProtections = []string{"ENABLED", "DISABLED", "MAINTENANCE_MODE"}
+
+// BulkMaintenanceDeviceID is passed instead of a device ID to reveal the bulk
+// maintenance token, which is valid for every host of the tenant
+const BulkMaintenanceDeviceID = "MAINTENANCE"
+
+// SensorUpdate describes sensor update policies. The build setting is a
+// release tag such as n-1, a build number or "off", and variants lists the
+// builds of platform variants such as LinuxArm64.
+var SensorUpdate = &Type{
+	Kind:      "SensorUpdatePolicy",
+	Name:      "sensor-update",
+	Title:     "sensor update policy",
+	Plural:    "sensor update policies",
+	Platforms: Platforms,
+	New: func(c *client.CrowdStrikeAPISpecification) Service {
+		return &sensorUpdateService{c: c}
+	},
+}
+
+// Build is a sensor build that policies can deploy
+type Build = models.ResponsesSensorUpdateBuildV1
+
+type sensorUpdateService struct {
+	c      *client.CrowdStrikeAPISpecification
+	builds []*Build
+}
+
+func (s *sensorUpdateService) List(ctx context.Context, filter string) ([]*Policy, error) {
+	list := []*Policy{}
+	limit := int64(maxPolicies)
+	sortBy := "precedence.asc"
+
+	for {
+		offset := int64(len(list))
+		params := &sensor_update_policies.QueryCombinedSensorUpdatePoliciesV2Params{
+			Context: ctx,
+			Limit:   &limit,
+			Offset:  &offset,
+			Sort:    &sortBy,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+
+		res, err := s.c.SensorUpdatePolicies.QueryCombinedSensorUpdatePoliciesV2(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query sensor update policies: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, p := range res.Payload.Resources {
+			list = append(list, sensorUpdatePolicy(p))
+		}
+
+		if len(res.Payload.Resources) == 0 || !hasMore(res.Payload.Meta, len(list)) {
+			return list, nil
+		}
+	}
+}
+
+func (s *sensorUpdateService) Create(ctx context.Context, spec *Spec) (*Policy, error) {
+	settings, err := s.settings(ctx, spec.Platform, spec.Settings)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.c.SensorUpdatePolicies.CreateSensorUpdatePoliciesV2(&sensor_update_policies.CreateSensorUpdatePoliciesV2Params{
+		Context: ctx,
+		Body: &models.RequestsCreateSensorUpdatePoliciesV2{
+			Resources: []*models.RequestsCreateSensorUpdatePolicyV2{{
+				Name:         &spec.Name,
+				Description:  spec.Description,
+				PlatformName: &spec.Platform,
+				Settings:     settings,
+			}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sensor update policy: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstSensorUpdatePolicy(res.Payload)
+}
+
+// Update merges the settings of spec into the current settings of the
+// policy, as the API replaces the build variants when they are not sent
+func (s *sensorUpdateService) Update(ctx context.Context, id string, spec *Spec) (*Policy, error) {
+	current, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]interface{}{}
+	for k, v := range current.Settings {
+		merged[k] = v
+	}
+	for k, v := range spec.Settings {
+		merged[k] = v
+	}
+
+	settings, err := s.settings(ctx, current.Platform, merged)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.c.SensorUpdatePolicies.UpdateSensorUpdatePoliciesV2(&sensor_update_policies.UpdateSensorUpdatePoliciesV2Params{
+		Context: ctx,
+		Body: &models.RequestsUpdateSensorUpdatePoliciesV2{
+			Resources: []*models.RequestsUpdateSensorUpdatePolicyV2{{
+				ID:          &id,
+				Name:        spec.Name,
+				Description: spec.Description,
+				Settings:    settings,
+			}},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update sensor update policy: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstSensorUpdatePolicy(res.Payload)
+}
+
+func (s *sensorUpdateService) Delete(ctx context.Context, ids []string) error {
+	res, err := s.c.SensorUpdatePolicies.DeleteSensorUpdatePolicies(&sensor_update_policies.DeleteSensorUpdatePoliciesParams{
+		Context: ctx,
+		Ids:     ids,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete sensor update policies: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *sensorUpdateService) Action(ctx context.Context, action string, ids []string, groupID string) error {
+	res, err := s.c.SensorUpdatePolicies.PerformSensorUpdatePoliciesAction(&sensor_update_policies.PerformSensorUpdatePoliciesActionParams{
+		Context:    ctx,
+		ActionName: action,
+		Body:       actionRequest(ids, groupID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to %s sensor update policies: %s", action, falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *sensorUpdateService) SetPrecedence(ctx context.Context, platform string, ids []string) error {
+	res, err := s.c.SensorUpdatePolicies.SetSensorUpdatePoliciesPrecedence(&sensor_update_policies.SetSensorUpdatePoliciesPrecedenceParams{
+		Context: ctx,
+		Body: &models.RequestsSetPolicyPrecedenceReqV1{
+			Ids:          ids,
+			PlatformName: &platform,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set sensor update policy precedence: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+func (s *sensorUpdateService) get(ctx context.Context, id string) (*Policy, error) {
+	res, err := s.c.SensorUpdatePolicies.GetSensorUpdatePoliciesV2(&sensor_update_policies.GetSensorUpdatePoliciesV2Params{
+		Context: ctx,
+		Ids:     []string{id},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sensor update policy: %s", falcon.ErrorExplain(err))
+	}
+
+	return firstSensorUpdatePolicy(res.Payload)
+}
+
+// settings converts the settings of a specification to the API
+// representation, resolving build tags and versions to builds
+func (s *sensorUpdateService) settings(ctx context.Context, platform string, settings map[string]interface{}) (*models.RequestsSensorUpdateSettingsV2, error) {
+	req := &models.RequestsSensorUpdateSettingsV2{Variants: []*models.RequestsSensorUpdateBuildV1{}}
+
+	for key, value := range settings {
+		switch key {
+		case SettingBuild, SettingUninstallProtection:
+			if _, ok := value.(string); !ok {
+				return nil, fmt.Errorf("setting %s must be a string", key)
+			}
+		case SettingVariants:
+		default:
+			return nil, fmt.Errorf("unknown sensor update setting %q", key)
+		}
+	}
+
+	if s.builds == nil {
+		builds, err := ListBuilds(ctx, s.c, "")
+		if err != nil {
+			return nil, err
+		}
+		s.builds = builds
+	}
+
+	if want, ok := settings[SettingBuild].(string); ok {
+		build, err := ResolveBuild(s.builds, platform, want)
+		if err != nil {
+			return nil, err
+		}
+		req.Build = build
+	}
+
+	if mode, ok := settings[SettingUninstallProtection].(string); ok {
+		mode = strings.ToUpper(mode)
+		if err := utils.ValidateOneOf(SettingUninstallProtection, UninstallProtections, mode); err != nil {
+			return nil, err
+		}
+		req.UninstallProtection = mode
+	}
+
+	if variants, ok := settings[SettingVariants]; ok {
+		list, ok := variants.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("setting %s must be a list of platforms and builds", SettingVariants)
+		}
+
+		for _, v := range list {
+			m, ok := v.(map[string]interface{})
+			variant, _ := m["platform"].(string)
+			want, _ := m["build"].(string)
+			if !ok || variant == "" {
+				return nil, fmt.Errorf("every variant needs a platform and a build")
+			}
+
+			build, err := ResolveBuild(s.builds, variant, want)
+			if err != nil {
+				return nil, err
+			}
+			req.Variants = append(req.Variants, &models.RequestsSensorUpdateBuildV1{
+				Platform: utils.Ptr(variant),
+				Build:    &build,
+			})
+		}
+	}
+
+	return req, nil
+}
+
+// ListBuilds returns the sensor builds available for a platform, or for
+// every platform if platform is empty
+func ListBuilds(ctx context.Context, c *client.CrowdStrikeAPISpecification, platform string) ([]*Build, error) {
+	params := &sensor_update_policies.QueryCombinedSensorUpdateBuildsParams{Context: ctx}
+	if platform != "" {
+		platform = strings.ToLower(platform)
+		params.Platform = &platform
+	}
+
+	res, err := c.SensorUpdatePolicies.QueryCombinedSensorUpdateBuilds(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sensor builds: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	return res.Payload.Resources, nil
+}
+
+// ResolveBuild returns the build of a platform matching want, which is a
+// release tag (n, n-1, n-2), a build number, a sensor version or version
+// prefix such as 7.10, or "off" to stop updating sensors. Tags resolve to
+// the tagged build so that the policy keeps following the tag.
+func ResolveBuild(builds []*Build, platform, want string) (string, error) {
+	want = strings.TrimSpace(want)
+	if want == "" || strings.EqualFold(want, BuildOff) {
+		return "", nil
+	}
+
+	var found *Build
+	for _, b := range builds {
+		if !strings.EqualFold(utils.Deref(b.Platform), platform) {
+			continue
+		}
+
+		build := utils.Deref(b.Build)
+		parts := strings.Split(build, "|")
+		version := utils.Deref(b.SensorVersion)
+
+		switch {
+		case build == want:
+			return build, nil
+		case len(parts) > 1 && strings.EqualFold(parts[1], want) && strings.Contains(build, "|tagged|"):
+			return build, nil
+		case parts[0] == want || version == want || strings.HasPrefix(version, want+"."):
+			// untagged builds are preferred for numbers and versions, which
+			// pin the policy to a build
+			if found == nil || !strings.Contains(build, "|") {
+				found = b
+			}
+		}
+	}
+
+	if found == nil {
+		return "", fmt.Errorf("no %s build matches %q", platform, want)
+	}
+	return utils.Deref(found.Build), nil
+}
+
+// BuildName returns how a build is written in specifications: its release
+// tag for tagged builds, its build number otherwise, or "off"
+func BuildName(build string) string {
+	if build == "" {
+		return BuildOff
+	}
+
+	parts := strings.Split(build, "|")
+	if len(parts) > 2 && parts[2] == "tagged" {
+		return parts[1]
+	}
+	return parts[0]
+}
+
+// RevealUninstallToken returns the maintenance token of a device, or the
+// bulk maintenance token for BulkMaintenanceDeviceID. The audit message is
+// recorded in the audit log.
+func RevealUninstallToken(ctx context.Context, c *client.CrowdStrikeAPISpecification, deviceID, auditMessage string) (string, error) {
+	res, err := c.SensorUpdatePolicies.RevealUninstallToken(&sensor_update_policies.RevealUninstallTokenParams{
+		Context: ctx,
+		Body: &models.RequestsRevealUninstallTokenV1{
+			DeviceID:     &deviceID,
+			AuditMessage: auditMessage,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to reveal maintenance token: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return "", err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return "", fmt.Errorf("no maintenance token returned for %s", deviceID)
+	}
+	return utils.Deref(res.Payload.Resources[0].UninstallToken), nil
+}
+
+func firstSensorUpdatePolicy(payload *models.ResponsesSensorUpdatePoliciesV2) (*Policy, error) {
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Resources) == 0 {
+		return nil, fmt.Errorf("no sensor update policy returned")
+	}
+
+	return sensorUpdatePolicy(payload.Resources[0]), nil
+}
+
+func sensorUpdatePolicy(p *models.ResponsesSensorUpdatePolicyV2) *Policy {
+	policy := &Policy{
+		ID:          utils.Deref(p.ID),
+		Name:        utils.Deref(p.Name),
+		Description: utils.Deref(p.Description),
+		Platform:    utils.Deref(p.PlatformName),
+		Enabled:     utils.Deref(p.Enabled),
+		ModifiedBy:  utils.Deref(p.ModifiedBy),
+		Groups:      groups(p.Groups),
+		Settings:    map[string]interface{}{},
+	}
+	if p.ModifiedTimestamp != nil {
+		policy.ModifiedTimestamp = *p.ModifiedTimestamp
+	}
+
+	if p.Settings != nil {
+		policy.Settings[SettingBuild] = BuildName(utils.Deref(p.Settings.Build))
+		policy.Settings[SettingUninstallProtection] = utils.Deref(p.Settings.UninstallProtection)
+
+		if len(p.Settings.Variants) > 0 {
+			variants := []interface{}{}
+			for _, v := range p.Settings.Variants {
+				variants = append(variants, map[string]interface{}{
+					"platform": utils.Deref(v.Platform),
+					"build":    BuildName(utils.Deref(v.Build)),
+				})
+			}
+			policy.Settings[SettingVariants] = variants
+		}
+	}
+
+	return policy
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package quarantine manages the files quarantined by the sensor on hosts.
+package quarantine
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/aggregates"
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/quarantine"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// File is a file quarantined on a host
+type File = models.QuarantineQuarantinedFile
+
+// Actions performed on quarantined files
+const (
+	ActionRelease   = "release"
+	ActionUnrelease = "unrelease"
+	ActionDelete    = "delete"
+)
+
+// maxQueryLimit is the maximum number of file IDs returned per query
+const maxQueryLimit = 5000
+
+// maxEntities is the maximum number of files fetched or updated per request
+const maxEntities = 1000
+
+var sha256Regex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// Query holds the criteria used to select quarantined files
+type Query struct {
+	// Hosts are matched by device ID or hostname
+	Hosts  []string
+	Hashes []string
+	States []string
+	Since  time.Time
+	Until  time.Time
+	Filter string
+}
+
+// Validate checks that the hashes are SHA256 hashes
+func (q *Query) Validate() error {
+	for _, h := range q.Hashes {
+		if !sha256Regex.MatchString(strings.TrimSpace(h)) {
+			return fmt.Errorf("invalid hash %q, must be a SHA256 hash", h)
+		}
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && !q.Until.After(q.Since) {
+		return fmt.Errorf("the end of the time range must be after its start")
+	}
+	return nil
+}
+
+// FQL returns the filter expression selecting the files, matching the date
+// they were quarantined against the time range
+func (q *Query) FQL() string {
+	aids, hostnames := []string{}, []string{}
+	for _, h := range q.Hosts {
+		if hosts.IsDeviceID(h) {
+			aids = append(aids, strings.ToLower(h))
+		} else {
+			hostnames = append(hostnames, h)
+		}
+	}
+
+	hashes := []string{}
+	for _, h := range q.Hashes {
+		hashes = append(hashes, strings.ToLower(strings.TrimSpace(h)))
+	}
+
+	states := []string{}
+	for _, s := range q.States {
+		states = append(states, strings.ToLower(strings.TrimSpace(s)))
+	}
+
+	filter := fql.New().
+		Raw(q.Filter).
+		Or(fql.New().In("aid", aids...), fql.New().In("hostname", hostnames...)).
+		In("sha256", hashes...).
+		In("state", states...)
+	if !q.Since.IsZero() {
+		filter.Compare("date_created", ">=", q.Since.UTC().Format(time.RFC3339))
+	}
+	if !q.Until.IsZero() {
+		filter.Compare("date_created", "<", q.Until.UTC().Format(time.RFC3339))
+	}
+	return filter.String()
+}
+
+// QueryIDs returns the IDs of the files matching filter, sorted by sortBy.
+// At most limit IDs are returned, or all of them if limit is 0.
+func QueryIDs(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter, sortBy string, limit int) ([]string, error) {
+	ids := []string{}
+
+	for {
+		pageSize := int64(maxQueryLimit)
+		if limit > 0 && limit-len(ids) < maxQueryLimit {
+			pageSize = int64(limit - len(ids))
+		}
+		offset := strconv.Itoa(len(ids))
+
+		params := &quarantine.QueryQuarantineFilesParams{
+			Context: ctx,
+			Limit:   &pageSize,
+			Offset:  &offset,
+		}
+		if filter != "" {
+			params.Filter = &filter
+		}
+		if sortBy != "" {
+			params.Sort = &sortBy
+		}
+
+		res, err := c.Quarantine.QueryQuarantineFiles(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query quarantined files: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, res.Payload.Resources...)
+
+		if len(res.Payload.Resources) == 0 || (limit > 0 && len(ids) >= limit) {
+			return ids, nil
+		}
+		if p := res.Payload.Meta.Pagination; p == nil || int64(len(ids)) >= utils.Deref(p.Total) {
+			return ids, nil
+		}
+	}
+}
+
+// Get fetches the files with the given IDs
+func Get(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) ([]*File, error) {
+	list := []*File{}
+
+	for _, chunk := range utils.Chunk(ids, maxEntities) {
+		res, err := c.Quarantine.GetQuarantineFiles(&quarantine.GetQuarantineFilesParams{
+			Context: ctx,
+			Body:    &models.MsaIdsRequest{Ids: chunk},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quarantined files: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return nil, err
+		}
+
+		list = append(list, res.Payload.Resources...)
+	}
+
+	return list, nil
+}
+
+// Update performs an action on the files with the given IDs
+func Update(ctx context.Context, c *client.CrowdStrikeAPISpecification, action, comment string, ids []string) error {
+	for _, chunk := range utils.Chunk(ids, maxEntities) {
+		res, err := c.Quarantine.UpdateQuarantinedDetectsByIds(&quarantine.UpdateQuarantinedDetectsByIdsParams{
+			Context: ctx,
+			Body: &models.DomainEntitiesPatchRequest{
+				Action:  action,
+				Comment: comment,
+				Ids:     chunk,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to %s quarantined files: %s", action, falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Summary counts the files matching filter by the distinct values of field
+func Summary(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter, field string, size int32) ([]*models.MsaAggregationResult, error) {
+	res, err := c.Quarantine.GetAggregateFiles(&quarantine.GetAggregateFilesParams{
+		Context: ctx,
+		Body:    aggregates.Terms(field, filter, size),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate quarantined files: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	return res.Payload.Resources, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package quarantine
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryFQL(t *testing.T) {
+	since := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		query Query
+		want  string
+	}{
+		{Query{}, ""},
+		{Query{States: []string{"Quarantined"}}, "state:'quarantined'"},
+		{
+			Query{Hosts: []string{"WIN-DC01", "8E7656B27D8C49A34A1AF416424D6231"}, Filter: "username:'jdoe'"},
+			"(username:'jdoe')+(aid:'8e7656b27d8c49a34a1af416424d6231',hostname:'WIN-DC01')",
+		},
+		{
+			Query{Hashes: []string{strings.Repeat("AB", 32)}, Since: since, Until: since.AddDate(0, 0, 7)},
+			"sha256:'" + strings.Repeat("ab", 32) + "'+date_created:>='2023-03-01T00:00:00Z'+date_created:<'2023-03-08T00:00:00Z'",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.query.FQL(); got != tt.want {
+			t.Errorf("FQL() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestQueryValidate(t *testing.T) {
+	now := time.Now()
+	for _, q := range []Query{
+		{Hashes: []string{"d41d8cd98f00b204e9800998ecf8427e"}},
+		{Since: now, Until: now.Add(-time.Hour)},
+	} {
+		if err := q.Validate(); err == nil {
+			t.Errorf("Validate(%+v) did not fail", q)
+		}
+	}
+
+	q := Query{Hashes: []string{strings.Repeat("0", 64)}, Since: now.Add(-time.Hour), Until: now}
+	if err := q.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package rtr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/real_time_response"
+	"github.com/crowdstrike/gofalcon/falcon/client/real_time_response_admin"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// MaxBatchHosts is the maximum number of hosts in a single batch session
+const MaxBatchHosts = 10000
+
+// MaxBatchTimeout is the longest a batch request may wait for hosts to
+// respond. The API allows ten minutes, but the API client gives up on
+// requests after five.
+const MaxBatchTimeout = 4 * time.Minute
+
+// Status of a command run on a host in a batch session
+const (
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+	StatusError   = "error"
+	StatusTimeout = "timeout"
+	StatusQueued  = "queued"
+)
+
+// Batch is a Real Time Response batch session with many hosts
+type Batch struct {
+	client *client.CrowdStrikeAPISpecification
+
+	// ID is the batch ID
+	ID string
+	// DeviceIDs are the hosts that joined the batch session
+	DeviceIDs []string
+	// Failed holds the hosts for which a session could not be initialized
+	Failed []*HostResult
+}
+
+// HostResult is the outcome of a batch request on a single host
+type HostResult struct {
+	DeviceID  string `json:"device_id"`
+	SessionID string `json:"session_id,omitempty"`
+	TaskID    string `json:"task_id,omitempty"`
+	Status    string `json:"status"`
+	Stdout    string `json:"stdout,omitempty"`
+	Stderr    string `json:"stderr,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// NewBatch initializes a batch session with up to MaxBatchHosts hosts,
+// waiting at most timeout for the hosts to respond
+func NewBatch(ctx context.Context, c *client.CrowdStrikeAPISpecification, deviceIDs []string, queueOffline bool, timeout time.Duration) (*Batch, error) {
+	if len(deviceIDs) > MaxBatchHosts {
+		return nil, fmt.Errorf("a batch session is limited to %d hosts, got %d", MaxBatchHosts, len(deviceIDs))
+	}
+
+	res, err := c.RealTimeResponse.BatchInitSessions(&real_time_response.BatchInitSessionsParams{
+		Context:             ctx,
+		TimeoutDuration:     durationParam(timeout),
+		HostTimeoutDuration: durationParam(timeout),
+		Body: &models.DomainBatchInitSessionRequest{
+			HostIds:      deviceIDs,
+			QueueOffline: &queueOffline,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize RTR batch session: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	b := &Batch{
+		client: c,
+		ID:     utils.Deref(res.Payload.BatchID),
+	}
+
+	for _, id := range deviceIDs {
+		r, ok := res.Payload.Resources[id]
+		if !ok {
+			b.Failed = append(b.Failed, &HostResult{DeviceID: id, Status: StatusError, Error: "no response from host"})
+			continue
+		}
+
+		if err := falcon.AssertNoError(r.Errors); err != nil {
+			b.Failed = append(b.Failed, &HostResult{DeviceID: id, Status: StatusError, Error: err.Error()})
+			continue
+		}
+
+		b.DeviceIDs = append(b.DeviceIDs, id)
+	}
+
+	log.Debugf("Initialized RTR batch session %s with %d of %d hosts", b.ID, len(b.DeviceIDs), len(deviceIDs))
+	return b, nil
+}
+
+// Refresh keeps the sessions of the batch from expiring
+func (b *Batch) Refresh(ctx context.Context) error {
+	res, err := b.client.RealTimeResponse.BatchRefreshSessions(&real_time_response.BatchRefreshSessionsParams{
+		Context: ctx,
+		Body: &models.DomainBatchRefreshSessionRequest{
+			BatchID: &b.ID,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to refresh RTR batch session: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+// KeepAlive refreshes the batch every interval until ctx is cancelled
+func (b *Batch) KeepAlive(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := b.Refresh(ctx); err != nil && ctx.Err() == nil {
+				log.Warnf("Unable to keep RTR batch session alive: %v", err)
+			}
+		}
+	}
+}
+
+// Run executes a command line on every host of the batch, waiting at most
+// timeout for the hosts to respond. The endpoint is chosen from the
+// permission level the command requires. Results are returned in the order
+// of the batch's device IDs.
+func (b *Batch) Run(ctx context.Context, commandLine string, timeout time.Duration) ([]*HostResult, error) {
+	commandLine = strings.TrimSpace(commandLine)
+	fields := strings.Fields(commandLine)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no command given")
+	}
+	baseCommand := fields[0]
+
+	if len(b.DeviceIDs) == 0 {
+		return []*HostResult{}, nil
+	}
+
+	body := &models.DomainBatchExecuteCommandRequest{
+		BaseCommand:   &baseCommand,
+		BatchID:       &b.ID,
+		CommandString: &commandLine,
+	}
+
+	var payload *models.DomainMultiCommandExecuteResponseWrapper
+	switch RequiredLevel(commandLine) {
+	case ReadOnly:
+		res, err := b.client.RealTimeResponse.BatchCmd(&real_time_response.BatchCmdParams{
+			Context:             ctx,
+			TimeoutDuration:     durationParam(timeout),
+			HostTimeoutDuration: durationParam(timeout),
+			Body:                body,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to run %s: %s", baseCommand, falcon.ErrorExplain(err))
+		}
+		payload = res.Payload
+	case ActiveResponder:
+		res, err := b.client.RealTimeResponse.BatchActiveResponderCmd(&real_time_response.BatchActiveResponderCmdParams{
+			Context:             ctx,
+			TimeoutDuration:     durationParam(timeout),
+			HostTimeoutDuration: durationParam(timeout),
+			Body:                body,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to run %s: %s", baseCommand, falcon.ErrorExplain(err))
+		}
+		payload = res.Payload
+	default:
+		res, err := b.client.RealTimeResponseAdmin.BatchAdminCmd(&real_time_response_admin.BatchAdminCmdParams{
+			Context:             ctx,
+			TimeoutDuration:     durationParam(timeout),
+			HostTimeoutDuration: durationParam(timeout),
+			Body:                body,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to run %s: %s", baseCommand, falcon.ErrorExplain(err))
+		}
+		payload = res.Payload
+	}
+
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	resources := map[string]models.DomainMultiStatusSensorResponse{}
+	if payload.Combined != nil {
+		resources = payload.Combined.Resources
+	}
+
+	return b.results(resources), nil
+}
+
+// results converts the per host responses of a batch request
+func (b *Batch) results(resources map[string]models.DomainMultiStatusSensorResponse) []*HostResult {
+	results := make([]*HostResult, 0, len(b.DeviceIDs))
+
+	for _, id := range b.DeviceIDs {
+		r, ok := resources[id]
+		if !ok {
+			results = append(results, &HostResult{DeviceID: id, Status: StatusError, Error: "no response from host"})
+			continue
+		}
+
+		result := &HostResult{
+			DeviceID:  id,
+			SessionID: utils.Deref(r.SessionID),
+			TaskID:    r.TaskID,
+			Stdout:    utils.Deref(r.Stdout),
+			Stderr:    utils.Deref(r.Stderr),
+		}
+
+		switch err := falcon.AssertNoError(r.Errors); {
+		case err != nil:
+			result.Status = StatusError
+			result.Error = err.Error()
+		case utils.Deref(r.OfflineQueued):
+			result.Status = StatusQueued
+		case !utils.Deref(r.Complete):
+			result.Status = StatusTimeout
+		case result.Stderr != "":
+			result.Status = StatusFailed
+		default:
+			result.Status = StatusSuccess
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// durationParam formats a timeout in the duration syntax of the batch endpoints
+func durationParam(d time.Duration) *string {
+	return utils.Ptr(fmt.Sprintf("%ds", int64(d/time.Second)))
+}
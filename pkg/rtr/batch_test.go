@@ -0,0 +1,127 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package rtr
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBatchResults(t *testing.T) {
+	b := &Batch{DeviceIDs: []string{"ok", "stderr", "error", "slow", "offline", "missing"}}
+
+	response := func(complete, queued bool, stdout, stderr string, errs ...*models.MsaAPIError) models.DomainMultiStatusSensorResponse {
+		return models.DomainMultiStatusSensorResponse{
+			Complete:      &complete,
+			OfflineQueued: &queued,
+			SessionID:     utils.Ptr("session"),
+			Stdout:        &stdout,
+			Stderr:        &stderr,
+			Errors:        errs,
+		}
+	}
+
+	got := b.results(map[string]models.DomainMultiStatusSensorResponse{
+		"ok":      response(true, false, "output", ""),
+		"stderr":  response(true, false, "", "access denied"),
+		"error":   response(false, false, "", "", &models.MsaAPIError{Code: utils.Ptr(int32(500)), Message: utils.Ptr("failure")}),
+		"slow":    response(false, false, "", ""),
+		"offline": response(false, true, "", ""),
+	})
+
+	want := []*HostResult{
+		{DeviceID: "ok", SessionID: "session", Status: StatusSuccess, Stdout: "output"},
+		{DeviceID: "stderr", SessionID: "session", Status: StatusFailed, Stderr: "access denied"},
+		{DeviceID: "error", SessionID: "session", Status: StatusError, Error: "API Error : failure"},
+		{DeviceID: "slow", SessionID: "session", Status: StatusTimeout},
+		{DeviceID: "offline", SessionID: "session", Status: StatusQueued},
+		{DeviceID: "missing", Status: StatusError, Error: "no response from host"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("results() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// TestBatchRunEndpoint checks that commands are sent to the batch endpoint
+// of the permission level they require
+func TestBatchRunEndpoint(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"combined": map[string]interface{}{
+				"resources": map[string]interface{}{
+					"aid": map[string]interface{}{"complete": true, "stdout": "ok"},
+				},
+			},
+		})
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	b := &Batch{
+		client:    client.New(httptransport.New(u.Host, "/", []string{"http"}), strfmt.Default),
+		ID:        "batch",
+		DeviceIDs: []string{"aid"},
+	}
+
+	tests := []struct {
+		commandLine string
+		want        string
+	}{
+		{"ls C:\\Windows", "/real-time-response/combined/batch-command/v1"},
+		{"reg query HKLM\\Software", "/real-time-response/combined/batch-command/v1"},
+		{"reg set HKLM\\Software\\Test -Value x", "/real-time-response/combined/batch-active-responder-command/v1"},
+		{"kill 1234", "/real-time-response/combined/batch-active-responder-command/v1"},
+		{"runscript -CloudFile=collect", "/real-time-response/combined/batch-admin-command/v1"},
+		{"put tool.exe", "/real-time-response/combined/batch-admin-command/v1"},
+		{"unknowncommand", "/real-time-response/combined/batch-admin-command/v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.commandLine, func(t *testing.T) {
+			results, err := b.Run(context.Background(), tt.commandLine, time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if path != tt.want {
+				t.Errorf("Run(%q) used %s, want %s", tt.commandLine, path, tt.want)
+			}
+			if len(results) != 1 || results[0].Status != StatusSuccess {
+				t.Errorf("unexpected results %+v", results)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package rtr
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Level is the Real Time Response permission level required to run a command
+type Level int
+
+const (
+	// ReadOnly commands only inspect the host
+	ReadOnly Level = iota
+	// ActiveResponder commands can change the host and retrieve files
+	ActiveResponder
+	// Admin commands can upload and execute arbitrary files and scripts
+	Admin
+)
+
+// LevelNames are the names accepted by ParseLevel, from least to most privileged
+var LevelNames = []string{"read-only", "active", "admin"}
+
+// ParseLevel converts a level name into a Level
+func ParseLevel(name string) (Level, error) {
+	for i, n := range LevelNames {
+		if strings.EqualFold(n, name) {
+			return Level(i), nil
+		}
+	}
+	return ReadOnly, fmt.Errorf("invalid permission level %q, must be one of: %s", name, strings.Join(LevelNames, ", "))
+}
+
+func (l Level) String() string {
+	if l < ReadOnly || l > Admin {
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+	return LevelNames[l]
+}
+
+// Command describes a Real Time Response command
+type Command struct {
+	Name        string
+	Level       Level
+	Description string
+	SubCommands []string
+}
+
+// Commands is the catalog of Real Time Response commands
+var Commands = []Command{
+	{Name: "cat", Level: ReadOnly, Description: "Read a file from disk and display as ASCII or hex"},
+	{Name: "cd", Level: ReadOnly, Description: "Change the current working directory"},
+	{Name: "clear", Level: ReadOnly, Description: "Clear the screen"},
+	{Name: "csrutil", Level: ReadOnly, Description: "Get system integrity protection (SIP) status (macOS)"},
+	{Name: "env", Level: ReadOnly, Description: "Print out the environment variables"},
+	{Name: "eventlog", Level: ReadOnly, Description: "Inspect event logs", SubCommands: []string{"backup", "export", "list", "view"}},
+	{Name: "filehash", Level: ReadOnly, Description: "Generate the MD5 and SHA256 hashes of a file"},
+	{Name: "getsid", Level: ReadOnly, Description: "Enumerate local users and security identifiers (SID)"},
+	{Name: "help", Level: ReadOnly, Description: "Show help for a command"},
+	{Name: "history", Level: ReadOnly, Description: "Show the commands run in this session"},
+	{Name: "ifconfig", Level: ReadOnly, Description: "Show network configuration (Linux, macOS)"},
+	{Name: "ipconfig", Level: ReadOnly, Description: "Show network configuration (Windows)"},
+	{Name: "ls", Level: ReadOnly, Description: "Display the contents of a directory"},
+	{Name: "mount", Level: ReadOnly, Description: "List or mount filesystem volumes"},
+	{Name: "netstat", Level: ReadOnly, Description: "Display network statistics and active connections"},
+	{Name: "ps", Level: ReadOnly, Description: "Display process information"},
+	{Name: "pwd", Level: ReadOnly, Description: "Print the current working directory"},
+	{Name: "reg", Level: ReadOnly, Description: "Query or modify the Windows registry", SubCommands: []string{"query", "set", "delete", "load", "unload"}},
+	{Name: "users", Level: ReadOnly, Description: "Get details about local users (macOS)"},
+	{Name: "cp", Level: ActiveResponder, Description: "Copy a file or directory"},
+	{Name: "encrypt", Level: ActiveResponder, Description: "Encrypt a file with an AES-256 key"},
+	{Name: "get", Level: ActiveResponder, Description: "Retrieve a file from the host"},
+	{Name: "kill", Level: ActiveResponder, Description: "Kill a process"},
+	{Name: "map", Level: ActiveResponder, Description: "Map an SMB share drive (Windows)"},
+	{Name: "memdump", Level: ActiveResponder, Description: "Dump the memory of a process (Windows)"},
+	{Name: "mkdir", Level: ActiveResponder, Description: "Create a new directory"},
+	{Name: "mv", Level: ActiveResponder, Description: "Move a file or directory"},
+	{Name: "restart", Level: ActiveResponder, Description: "Restart the host"},
+	{Name: "rm", Level: ActiveResponder, Description: "Delete a file or directory"},
+	{Name: "shutdown", Level: ActiveResponder, Description: "Shut down the host"},
+	{Name: "umount", Level: ActiveResponder, Description: "Unmount a filesystem volume (Linux, macOS)"},
+	{Name: "unmap", Level: ActiveResponder, Description: "Unmap an SMB share drive (Windows)"},
+	{Name: "update", Level: ActiveResponder, Description: "Manage Windows updates", SubCommands: []string{"history", "install", "list", "query"}},
+	{Name: "xmemdump", Level: ActiveResponder, Description: "Dump the complete memory of the host (Windows)"},
+	{Name: "zip", Level: ActiveResponder, Description: "Compress a file or directory into a zip file"},
+	{Name: "falconscript", Level: Admin, Description: "Run a script from the Falcon script library"},
+	{Name: "put", Level: Admin, Description: "Upload a put file to the host"},
+	{Name: "put-and-run", Level: Admin, Description: "Upload a put file to the host and run it"},
+	{Name: "run", Level: Admin, Description: "Run an executable on the host"},
+	{Name: "runscript", Level: Admin, Description: "Run a script or cloud script"},
+}
+
+// registryReadOnly are the reg subcommands available at the read-only level
+var registryReadOnly = map[string]bool{"query": true}
+
+// LookupCommand returns the catalog entry for a base command
+func LookupCommand(name string) (Command, bool) {
+	for _, c := range Commands {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Command{}, false
+}
+
+// RequiredLevel returns the permission level needed to run a command line.
+// Unknown commands require the admin level so that they are only sent to
+// the most privileged endpoint.
+func RequiredLevel(commandLine string) Level {
+	fields := strings.Fields(commandLine)
+	if len(fields) == 0 {
+		return ReadOnly
+	}
+
+	c, ok := LookupCommand(fields[0])
+	if !ok {
+		return Admin
+	}
+
+	// reg query is read-only but modifying the registry needs active responder
+	if c.Name == "reg" && len(fields) > 1 && !registryReadOnly[strings.ToLower(fields[1])] {
+		return ActiveResponder
+	}
+
+	return c.Level
+}
+
+// JoinArgs rebuilds a command line from arguments split by the shell,
+// quoting the arguments that contain whitespace so that the host receives
+// them as single arguments
+func JoinArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\n") {
+			a = `"` + a + `"`
+		}
+		quoted[i] = a
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Available returns the commands that can be run at the given level, sorted by name
+func Available(level Level) []Command {
+	available := []Command{}
+	for _, c := range Commands {
+		if c.Level <= level {
+			available = append(available, c)
+		}
+	}
+
+	sort.Slice(available, func(i, j int) bool {
+		return available[i].Name < available[j].Name
+	})
+
+	return available
+}
+
+// Complete returns the completions of the partially typed command line for
+// commands available at the given level. Base commands are completed for the
+// first word and subcommands for the second.
+func Complete(line string, level Level) []string {
+	fields := strings.Fields(line)
+	trailingSpace := strings.HasSuffix(line, " ")
+
+	switch {
+	case len(fields) == 0 || (len(fields) == 1 && !trailingSpace):
+		prefix := ""
+		if len(fields) == 1 {
+			prefix = fields[0]
+		}
+
+		var matches []string
+		for _, c := range Available(level) {
+			if strings.HasPrefix(c.Name, prefix) {
+				matches = append(matches, c.Name)
+			}
+		}
+		return matches
+
+	case len(fields) == 1 || (len(fields) == 2 && !trailingSpace):
+		c, ok := LookupCommand(fields[0])
+		if !ok || c.Level > level {
+			return nil
+		}
+
+		prefix := ""
+		if len(fields) == 2 {
+			prefix = fields[1]
+		}
+
+		var matches []string
+		for _, sub := range c.SubCommands {
+			if strings.HasPrefix(sub, prefix) {
+				matches = append(matches, sub)
+			}
+		}
+		return matches
+	}
+
+	return nil
+}
+
+// CommonPrefix returns the longest prefix shared by all values
+func CommonPrefix(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package rtr
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRequiredLevel(t *testing.T) {
+	tests := []struct {
+		commandLine string
+		want        Level
+	}{
+		{"ls C:\\Windows", ReadOnly},
+		{"reg query HKLM\\Software", ReadOnly},
+		{"reg set HKLM\\Software\\Test -Value x", ActiveResponder},
+		{"get C:\\Windows\\Temp\\evil.exe", ActiveResponder},
+		{"put tool.exe", Admin},
+		{"runscript -CloudFile=collect -CommandLine=\"-Full\"", Admin},
+		{"runscript -Raw=```Get-Process```", Admin},
+		{"unknowncommand", Admin},
+		{"", ReadOnly},
+	}
+
+	for _, tt := range tests {
+		if got := RequiredLevel(tt.commandLine); got != tt.want {
+			t.Errorf("RequiredLevel(%q) = %s, want %s", tt.commandLine, got, tt.want)
+		}
+	}
+}
+
+func TestJoinArgs(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"ls", `C:\Temp`}, `ls C:\Temp`},
+		{[]string{"ls", `C:\Program Files`}, `ls "C:\Program Files"`},
+		{[]string{"runscript", "-Raw=```Get-Process```"}, "runscript -Raw=```Get-Process```"},
+		{[]string{"reg", "query", "HKLM\\Software\\My App", ""}, `reg query "HKLM\Software\My App" ""`},
+	}
+
+	for _, tt := range tests {
+		if got := JoinArgs(tt.args); got != tt.want {
+			t.Errorf("JoinArgs(%q) = %s, want %s", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		line  string
+		level Level
+		want  []string
+	}{
+		{"p", ReadOnly, []string{"ps", "pwd"}},
+		{"p", Admin, []string{"ps", "put", "put-and-run", "pwd"}},
+		{"ge", ReadOnly, []string{"getsid"}},
+		{"ge", ActiveResponder, []string{"get", "getsid"}},
+		{"reg ", ReadOnly, []string{"query", "set", "delete", "load", "unload"}},
+		{"reg q", ReadOnly, []string{"query"}},
+		{"ls C:\\", ReadOnly, nil},
+	}
+
+	for _, tt := range tests {
+		got := Complete(tt.line, tt.level)
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("Complete(%q, %s) mismatch (-want +got):\n%s", tt.line, tt.level, diff)
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, name := range LevelNames {
+		level, err := ParseLevel(name)
+		if err != nil {
+			t.Fatalf("ParseLevel(%q) returned error: %v", name, err)
+		}
+		if level.String() != name {
+			t.Errorf("ParseLevel(%q).String() = %q", name, level.String())
+		}
+	}
+
+	if _, err := ParseLevel("root"); err == nil {
+		t.Error("ParseLevel(\"root\") did not return an error")
+	}
+}
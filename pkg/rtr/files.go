@@ -0,0 +1,169 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package rtr
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bodgit/sevenzip"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/real_time_response"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// ArchivePassword is the password of the 7z archives files retrieved with get
+// are wrapped in
+const ArchivePassword = "infected"
+
+// Get issues the get command for path on every host of the batch. It returns
+// the request ID to check the upload status with and the result of the
+// command on each host.
+func (b *Batch) Get(ctx context.Context, path string, timeout time.Duration) (string, []*HostResult, error) {
+	res, err := b.client.RealTimeResponse.BatchGetCmd(&real_time_response.BatchGetCmdParams{
+		Context:             ctx,
+		TimeoutDuration:     durationParam(timeout),
+		HostTimeoutDuration: durationParam(timeout),
+		Body: &models.DomainBatchGetCommandRequest{
+			BatchID:  &b.ID,
+			FilePath: &path,
+		},
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get %s: %s", path, falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return "", nil, err
+	}
+
+	resources := map[string]models.DomainMultiStatusSensorResponse{}
+	if res.Payload.Combined != nil {
+		resources = res.Payload.Combined.Resources
+	}
+
+	return utils.Deref(res.Payload.BatchGetCmdReqID), b.results(resources), nil
+}
+
+// GetStatus returns the files uploaded so far for a get request, by device ID
+func (b *Batch) GetStatus(ctx context.Context, requestID string) (map[string]models.ModelFile, error) {
+	res, err := b.client.RealTimeResponse.BatchGetCmdStatus(&real_time_response.BatchGetCmdStatusParams{
+		Context:          ctx,
+		BatchGetCmdReqID: requestID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check get status: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	return res.Payload.Resources, nil
+}
+
+// DownloadFile writes the 7z archive of a file uploaded in a session to w
+func DownloadFile(ctx context.Context, c *client.CrowdStrikeAPISpecification, sessionID, sha256, name string, w io.Writer) error {
+	_, err := c.RealTimeResponse.RTRGetExtractedFileContents(&real_time_response.RTRGetExtractedFileContentsParams{
+		Context:   ctx,
+		SessionID: sessionID,
+		Sha256:    sha256,
+		Filename:  &name,
+	}, w)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %s", name, falcon.ErrorExplain(err))
+	}
+
+	return nil
+}
+
+// ExtractedFile is a file extracted from a retrieved archive
+type ExtractedFile struct {
+	Path   string
+	Size   int64
+	SHA256 string
+}
+
+// ExtractArchive extracts the files of a retrieved 7z archive into dir and
+// returns them along with their SHA256 hash
+func ExtractArchive(archive, dir string) ([]ExtractedFile, error) {
+	r, err := sevenzip.OpenReaderWithPassword(archive, ArchivePassword)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open archive %s: %v", archive, err)
+	}
+	defer r.Close()
+
+	extracted := []ExtractedFile{}
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		// archive entries use Windows paths, only the file name is kept
+		name := filepath.Base(strings.ReplaceAll(f.Name, `\`, "/"))
+		if name == "." || name == "/" || name == ".." {
+			return nil, fmt.Errorf("invalid file name %q in archive %s", f.Name, archive)
+		}
+
+		file, err := extractFile(f, filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		extracted = append(extracted, file)
+	}
+
+	return extracted, nil
+}
+
+func extractFile(f *sevenzip.File, path string) (ExtractedFile, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return ExtractedFile{}, fmt.Errorf("unable to extract %s: %v", f.Name, err)
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return ExtractedFile{}, err
+	}
+	defer out.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(io.MultiWriter(out, h), rc)
+	if err != nil {
+		return ExtractedFile{}, fmt.Errorf("unable to extract %s: %v", f.Name, err)
+	}
+
+	return ExtractedFile{
+		Path:   path,
+		Size:   size,
+		SHA256: hex.EncodeToString(h.Sum(nil)),
+	}, out.Close()
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package rtr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// testArchive is a 7z archive as retrieved with get, encrypted with
+// ArchivePassword and holding C:\Windows\Temp\evil.exe
+const (
+	testArchive       = "testdata/evil.exe.7z"
+	testArchiveSHA256 = "a9a706b34719a7cf0f5e5341521df2247c61c68df30b8cbc6c017f3002a0aaa4"
+	testArchiveData   = "MZ this is not really malware\n"
+)
+
+func TestExtractArchive(t *testing.T) {
+	dir := t.TempDir()
+
+	got, err := ExtractArchive(testArchive, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ExtractedFile{{
+		Path:   filepath.Join(dir, "evil.exe"),
+		Size:   int64(len(testArchiveData)),
+		SHA256: testArchiveSHA256,
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ExtractArchive() mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "evil.exe"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != testArchiveData {
+		t.Errorf("extracted %q, want %q", data, testArchiveData)
+	}
+}
+
+func TestExtractArchiveInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.7z")
+	if err := os.WriteFile(path, []byte("not an archive"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ExtractArchive(path, t.TempDir()); err == nil {
+		t.Error("expected an error")
+	}
+}
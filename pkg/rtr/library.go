@@ -0,0 +1,313 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package rtr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/real_time_response_admin"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// CloudFile is a custom script or put-file stored in the Falcon cloud
+type CloudFile = models.DomainRemoteCommandPutFileV2
+
+// Permissions lists the permission types of custom scripts
+var Permissions = []string{"private", "group", "public"}
+
+// Platforms lists the platforms custom scripts can target
+var Platforms = []string{"windows", "mac", "linux"}
+
+// maxCloudFilesPerQuery is the page size used to list scripts and put-files
+const maxCloudFilesPerQuery = 100
+
+// ScriptSpec describes a custom script to create or update. Empty fields are
+// left unchanged on update.
+type ScriptSpec struct {
+	Name        string
+	Description string
+	Content     string
+	Platforms   []string
+	Permission  string
+	Comment     string
+}
+
+// cloudFileKind holds the endpoints of scripts or put-files
+type cloudFileKind struct {
+	name   string
+	query  func(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter *string, offset string) (*models.BinservclientMsaPutFileResponse, error)
+	get    func(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) (*models.DomainMsaPFResponseV2, error)
+	delete func(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string) (*models.MsaReplyMetaOnly, error)
+}
+
+var scripts = cloudFileKind{
+	name: "script",
+	query: func(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter *string, offset string) (*models.BinservclientMsaPutFileResponse, error) {
+		res, err := c.RealTimeResponseAdmin.RTRListScripts(&real_time_response_admin.RTRListScriptsParams{
+			Context: ctx,
+			Filter:  filter,
+			Limit:   utils.Ptr(int64(maxCloudFilesPerQuery)),
+			Offset:  &offset,
+			Sort:    utils.Ptr("name|asc"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Payload, nil
+	},
+	get: func(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) (*models.DomainMsaPFResponseV2, error) {
+		res, err := c.RealTimeResponseAdmin.RTRGetScriptsV2(&real_time_response_admin.RTRGetScriptsV2Params{
+			Context: ctx,
+			Ids:     ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Payload, nil
+	},
+	delete: func(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string) (*models.MsaReplyMetaOnly, error) {
+		res, err := c.RealTimeResponseAdmin.RTRDeleteScripts(&real_time_response_admin.RTRDeleteScriptsParams{
+			Context: ctx,
+			Ids:     id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Payload, nil
+	},
+}
+
+var putFiles = cloudFileKind{
+	name: "put-file",
+	query: func(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter *string, offset string) (*models.BinservclientMsaPutFileResponse, error) {
+		res, err := c.RealTimeResponseAdmin.RTRListPutFiles(&real_time_response_admin.RTRListPutFilesParams{
+			Context: ctx,
+			Filter:  filter,
+			Limit:   utils.Ptr(int64(maxCloudFilesPerQuery)),
+			Offset:  &offset,
+			Sort:    utils.Ptr("name|asc"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Payload, nil
+	},
+	get: func(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) (*models.DomainMsaPFResponseV2, error) {
+		res, err := c.RealTimeResponseAdmin.RTRGetPutFilesV2(&real_time_response_admin.RTRGetPutFilesV2Params{
+			Context: ctx,
+			Ids:     ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Payload, nil
+	},
+	delete: func(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string) (*models.MsaReplyMetaOnly, error) {
+		res, err := c.RealTimeResponseAdmin.RTRDeletePutFiles(&real_time_response_admin.RTRDeletePutFilesParams{
+			Context: ctx,
+			Ids:     id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return res.Payload, nil
+	},
+}
+
+// ListScripts returns the custom scripts matching filter, sorted by name
+func ListScripts(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter string) ([]*CloudFile, error) {
+	return scripts.list(ctx, c, filter)
+}
+
+// ListPutFiles returns the put-files matching filter, sorted by name
+func ListPutFiles(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter string) ([]*CloudFile, error) {
+	return putFiles.list(ctx, c, filter)
+}
+
+// FindScript returns the custom script with the given name or ID
+func FindScript(ctx context.Context, c *client.CrowdStrikeAPISpecification, nameOrID string) (*CloudFile, error) {
+	return scripts.find(ctx, c, nameOrID)
+}
+
+// FindPutFile returns the put-file with the given name or ID
+func FindPutFile(ctx context.Context, c *client.CrowdStrikeAPISpecification, nameOrID string) (*CloudFile, error) {
+	return putFiles.find(ctx, c, nameOrID)
+}
+
+// DeleteScript deletes the custom script with the given ID
+func DeleteScript(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string) error {
+	return scripts.remove(ctx, c, id)
+}
+
+// DeletePutFile deletes the put-file with the given ID
+func DeletePutFile(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string) error {
+	return putFiles.remove(ctx, c, id)
+}
+
+func (k cloudFileKind) list(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter string) ([]*CloudFile, error) {
+	ids := []string{}
+
+	for {
+		payload, err := k.query(ctx, c, fql.New().Raw(filter).Ptr(), strconv.Itoa(len(ids)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %ss: %s", k.name, falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(payload.Errors); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, payload.Resources...)
+
+		if len(payload.Resources) < maxCloudFilesPerQuery {
+			break
+		}
+		if p := payload.Meta.Pagination; p != nil && p.Total != nil && int64(len(ids)) >= *p.Total {
+			break
+		}
+	}
+
+	return k.details(ctx, c, ids)
+}
+
+func (k cloudFileKind) details(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) ([]*CloudFile, error) {
+	byID := map[string]*CloudFile{}
+
+	for _, chunk := range utils.Chunk(ids, maxCloudFilesPerQuery) {
+		payload, err := k.get(ctx, c, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %ss: %s", k.name, falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(payload.Errors); err != nil {
+			return nil, err
+		}
+
+		for _, f := range payload.Resources {
+			byID[f.ID] = f
+		}
+	}
+
+	files := []*CloudFile{}
+	for _, id := range ids {
+		if f, ok := byID[id]; ok {
+			files = append(files, f)
+		}
+	}
+
+	return files, nil
+}
+
+func (k cloudFileKind) find(ctx context.Context, c *client.CrowdStrikeAPISpecification, nameOrID string) (*CloudFile, error) {
+	files, err := k.list(ctx, c, fql.New().Equal("name", nameOrID).String())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range files {
+		if f.Name == nameOrID {
+			return f, nil
+		}
+	}
+
+	// not a name, look it up as an ID
+	files, err = k.details(ctx, c, []string{nameOrID})
+	if err != nil || len(files) == 0 {
+		return nil, fmt.Errorf("%s %q not found", k.name, nameOrID)
+	}
+
+	return files[0], nil
+}
+
+func (k cloudFileKind) remove(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string) error {
+	payload, err := k.delete(ctx, c, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s %s: %s", k.name, id, falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(payload.Errors)
+}
+
+// CreateScript uploads a new custom script
+func CreateScript(ctx context.Context, c *client.CrowdStrikeAPISpecification, spec *ScriptSpec) error {
+	res, err := c.RealTimeResponseAdmin.RTRCreateScripts(&real_time_response_admin.RTRCreateScriptsParams{
+		Context:             ctx,
+		Name:                &spec.Name,
+		Content:             &spec.Content,
+		Description:         spec.Description,
+		Platform:            spec.Platforms,
+		PermissionType:      spec.Permission,
+		CommentsForAuditLog: utils.OptionalPtr(spec.Comment),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create script %s: %s", spec.Name, falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+// UpdateScript replaces the fields of a custom script that are set in spec
+func UpdateScript(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string, spec *ScriptSpec) error {
+	res, err := c.RealTimeResponseAdmin.RTRUpdateScripts(&real_time_response_admin.RTRUpdateScriptsParams{
+		Context:             ctx,
+		ID:                  id,
+		Name:                utils.OptionalPtr(spec.Name),
+		Content:             utils.OptionalPtr(spec.Content),
+		Description:         utils.OptionalPtr(spec.Description),
+		Platform:            spec.Platforms,
+		PermissionType:      utils.OptionalPtr(spec.Permission),
+		CommentsForAuditLog: utils.OptionalPtr(spec.Comment),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update script %s: %s", id, falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+// CreatePutFile uploads the file at path as a put-file. The name defaults to
+// the base name of the file.
+func CreatePutFile(ctx context.Context, c *client.CrowdStrikeAPISpecification, path, name, description, comment string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	res, err := c.RealTimeResponseAdmin.RTRCreatePutFiles(&real_time_response_admin.RTRCreatePutFilesParams{
+		Context:             ctx,
+		File:                file,
+		Name:                utils.OptionalPtr(name),
+		Description:         description,
+		CommentsForAuditLog: utils.OptionalPtr(comment),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload put-file %s: %s", path, falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
@@ -0,0 +1,322 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package rtr implements Real Time Response sessions against Falcon hosts.
+package rtr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/real_time_response"
+	"github.com/crowdstrike/gofalcon/falcon/client/real_time_response_admin"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// PulseInterval is how often a session is refreshed to keep it alive. RTR
+// sessions expire after ten minutes without activity.
+const PulseInterval = 5 * time.Minute
+
+// minPoll and maxPoll bound the delay between command status checks
+const (
+	minPoll = 250 * time.Millisecond
+	maxPoll = 2 * time.Second
+)
+
+// Session is a Real Time Response session with a single host
+type Session struct {
+	client *client.CrowdStrikeAPISpecification
+
+	// ID is the RTR session ID
+	ID string
+	// DeviceID is the agent ID of the host
+	DeviceID string
+	// Level is the highest permission level commands may use in this session
+	Level Level
+	// Pwd is the current working directory on the host
+	Pwd string
+	// Offline reports whether the session was queued for an offline host
+	Offline bool
+}
+
+// Result is the outcome of a command run in a session
+type Result struct {
+	BaseCommand    string `json:"base_command"`
+	CommandString  string `json:"command_string"`
+	CloudRequestID string `json:"cloud_request_id"`
+	Stdout         string `json:"stdout"`
+	Stderr         string `json:"stderr"`
+	Queued         bool   `json:"queued,omitempty"`
+}
+
+// NewSession initializes a session with a host. Commands run in the session
+// are limited to the given permission level.
+func NewSession(ctx context.Context, c *client.CrowdStrikeAPISpecification, deviceID string, level Level, queueOffline bool) (*Session, error) {
+	res, err := c.RealTimeResponse.RTRInitSession(&real_time_response.RTRInitSessionParams{
+		Context: ctx,
+		Body: &models.DomainInitRequest{
+			DeviceID:     &deviceID,
+			Origin:       utils.Ptr("falcon-cli"),
+			QueueOffline: &queueOffline,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize RTR session with %s: %s", deviceID, falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(res.Payload.Resources) != 1 {
+		return nil, fmt.Errorf("unexpected response initializing RTR session with %s", deviceID)
+	}
+	init := res.Payload.Resources[0]
+
+	return &Session{
+		client:   c,
+		ID:       utils.Deref(init.SessionID),
+		DeviceID: deviceID,
+		Level:    level,
+		Pwd:      init.Pwd,
+		Offline:  utils.Deref(init.OfflineQueued),
+	}, nil
+}
+
+// Pulse refreshes the session so that it does not expire
+func (s *Session) Pulse(ctx context.Context) error {
+	res, err := s.client.RealTimeResponse.RTRPulseSession(&real_time_response.RTRPulseSessionParams{
+		Context: ctx,
+		Body: &models.DomainInitRequest{
+			DeviceID: &s.DeviceID,
+			Origin:   utils.Ptr("falcon-cli"),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to refresh RTR session: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+// KeepAlive pulses the session every interval until ctx is cancelled
+func (s *Session) KeepAlive(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Pulse(ctx); err != nil && ctx.Err() == nil {
+				log.Warnf("Unable to keep RTR session alive: %v", err)
+			}
+		}
+	}
+}
+
+// Close deletes the session
+func (s *Session) Close(ctx context.Context) error {
+	res, err := s.client.RealTimeResponse.RTRDeleteSession(&real_time_response.RTRDeleteSessionParams{
+		Context:   ctx,
+		SessionID: s.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to close RTR session: %s", falcon.ErrorExplain(err))
+	}
+
+	return falcon.AssertNoError(res.Payload.Errors)
+}
+
+// Run executes a command line in the session and waits for it to complete.
+// Commands are sent to the endpoint of the session's permission level, and
+// commands that need a higher level are rejected without being sent.
+func (s *Session) Run(ctx context.Context, commandLine string) (*Result, error) {
+	commandLine = strings.TrimSpace(commandLine)
+	fields := strings.Fields(commandLine)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no command given")
+	}
+
+	required := RequiredLevel(commandLine)
+	if required > s.Level {
+		return nil, fmt.Errorf("%s requires the %s permission level, this session is limited to %s", fields[0], required, s.Level)
+	}
+
+	result := &Result{
+		BaseCommand:   fields[0],
+		CommandString: commandLine,
+	}
+
+	cloudRequestID, queued, err := s.execute(ctx, result.BaseCommand, commandLine)
+	if err != nil {
+		return nil, err
+	}
+	result.CloudRequestID = cloudRequestID
+	result.Queued = queued
+
+	// commands on offline hosts run once the host connects again
+	if queued {
+		return result, nil
+	}
+
+	status, err := s.Wait(ctx, cloudRequestID)
+	if err != nil {
+		return nil, err
+	}
+	result.Stdout = utils.Deref(status.Stdout)
+	result.Stderr = utils.Deref(status.Stderr)
+
+	if result.BaseCommand == "cd" && result.Stderr == "" {
+		if pwd := strings.TrimSpace(result.Stdout); pwd != "" {
+			s.Pwd = pwd
+		}
+	}
+
+	return result, nil
+}
+
+// execute sends a command to the endpoint matching the session level
+func (s *Session) execute(ctx context.Context, baseCommand, commandLine string) (string, bool, error) {
+	body := &models.DomainCommandExecuteRequest{
+		BaseCommand:   &baseCommand,
+		CommandString: &commandLine,
+		SessionID:     &s.ID,
+	}
+
+	var payload *models.DomainCommandExecuteResponseWrapper
+	switch s.Level {
+	case ReadOnly:
+		res, err := s.client.RealTimeResponse.RTRExecuteCommand(&real_time_response.RTRExecuteCommandParams{
+			Context: ctx,
+			Body:    body,
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("failed to run %s: %s", baseCommand, falcon.ErrorExplain(err))
+		}
+		payload = res.Payload
+	case ActiveResponder:
+		res, err := s.client.RealTimeResponse.RTRExecuteActiveResponderCommand(&real_time_response.RTRExecuteActiveResponderCommandParams{
+			Context: ctx,
+			Body:    body,
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("failed to run %s: %s", baseCommand, falcon.ErrorExplain(err))
+		}
+		payload = res.Payload
+	default:
+		res, err := s.client.RealTimeResponseAdmin.RTRExecuteAdminCommand(&real_time_response_admin.RTRExecuteAdminCommandParams{
+			Context: ctx,
+			Body:    body,
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("failed to run %s: %s", baseCommand, falcon.ErrorExplain(err))
+		}
+		payload = res.Payload
+	}
+
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return "", false, err
+	}
+
+	if len(payload.Resources) != 1 {
+		return "", false, fmt.Errorf("unexpected response running %s", baseCommand)
+	}
+	execution := payload.Resources[0]
+
+	return utils.Deref(execution.CloudRequestID), utils.Deref(execution.QueuedCommandOffline), nil
+}
+
+// Wait polls the status of a command until it completes
+func (s *Session) Wait(ctx context.Context, cloudRequestID string) (*models.DomainStatusResponse, error) {
+	delay := minPoll
+
+	for {
+		status, err := s.status(ctx, cloudRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		if utils.Deref(status.Complete) {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > maxPoll {
+			delay = maxPoll
+		}
+	}
+}
+
+func (s *Session) status(ctx context.Context, cloudRequestID string) (*models.DomainStatusResponse, error) {
+	var payload *models.DomainStatusResponseWrapper
+	switch s.Level {
+	case ReadOnly:
+		res, err := s.client.RealTimeResponse.RTRCheckCommandStatus(&real_time_response.RTRCheckCommandStatusParams{
+			Context:        ctx,
+			CloudRequestID: cloudRequestID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check command status: %s", falcon.ErrorExplain(err))
+		}
+		payload = res.Payload
+	case ActiveResponder:
+		res, err := s.client.RealTimeResponse.RTRCheckActiveResponderCommandStatus(&real_time_response.RTRCheckActiveResponderCommandStatusParams{
+			Context:        ctx,
+			CloudRequestID: cloudRequestID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check command status: %s", falcon.ErrorExplain(err))
+		}
+		payload = res.Payload
+	default:
+		res, err := s.client.RealTimeResponseAdmin.RTRCheckAdminCommandStatus(&real_time_response_admin.RTRCheckAdminCommandStatusParams{
+			Context:        ctx,
+			CloudRequestID: cloudRequestID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check command status: %s", falcon.ErrorExplain(err))
+		}
+		payload = res.Payload
+	}
+
+	if err := falcon.AssertNoError(payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Resources) != 1 {
+		return nil, fmt.Errorf("unexpected response checking command status")
+	}
+
+	return payload.Resources[0], nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package rtr
+
+import (
+	"sort"
+	"strings"
+)
+
+// Sync actions
+const (
+	SyncCreate    = "create"
+	SyncUpdate    = "update"
+	SyncDelete    = "delete"
+	SyncUnchanged = "unchanged"
+)
+
+// SyncChange is a change needed to bring a remote script in line with its
+// local copy
+type SyncChange struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	// ID is the ID of the remote script, empty for scripts to create
+	ID string `json:"id,omitempty"`
+	// Fields lists the fields that differ for scripts to update
+	Fields []string `json:"fields,omitempty"`
+	// Spec is the local script for scripts to create or update
+	Spec *ScriptSpec `json:"-"`
+}
+
+// PlanScriptSync compares local scripts with the scripts in the cloud and
+// returns the change needed for each script, sorted by name. Optional fields
+// of the local scripts that are empty are not compared. Remote scripts
+// without a local copy are only deleted when prune is set.
+func PlanScriptSync(local []*ScriptSpec, remote []*CloudFile, prune bool) []*SyncChange {
+	remoteByName := map[string]*CloudFile{}
+	for _, r := range remote {
+		remoteByName[r.Name] = r
+	}
+
+	changes := []*SyncChange{}
+	localNames := map[string]bool{}
+
+	for _, l := range local {
+		localNames[l.Name] = true
+
+		r, ok := remoteByName[l.Name]
+		if !ok {
+			changes = append(changes, &SyncChange{Action: SyncCreate, Name: l.Name, Spec: l})
+			continue
+		}
+
+		change := &SyncChange{Action: SyncUnchanged, Name: l.Name, ID: r.ID, Spec: l}
+		if l.Content != r.Content {
+			change.Fields = append(change.Fields, "content")
+		}
+		if l.Description != "" && l.Description != r.Description {
+			change.Fields = append(change.Fields, "description")
+		}
+		if len(l.Platforms) > 0 && !sameSet(l.Platforms, r.Platform) {
+			change.Fields = append(change.Fields, "platform")
+		}
+		if l.Permission != "" && l.Permission != r.PermissionType {
+			change.Fields = append(change.Fields, "permission")
+		}
+
+		if len(change.Fields) > 0 {
+			change.Action = SyncUpdate
+		}
+		changes = append(changes, change)
+	}
+
+	if prune {
+		for _, r := range remote {
+			if !localNames[r.Name] {
+				changes = append(changes, &SyncChange{Action: SyncDelete, Name: r.Name, ID: r.ID})
+			}
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+// sameSet reports whether a and b hold the same values, ignoring order and case
+func sameSet(a, b []string) bool {
+	normalize := func(s []string) string {
+		values := make([]string, len(s))
+		for i, v := range s {
+			values[i] = strings.ToLower(v)
+		}
+		sort.Strings(values)
+		return strings.Join(values, ",")
+	}
+
+	return normalize(a) == normalize(b)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package rtr
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestPlanScriptSync(t *testing.T) {
+	local := []*ScriptSpec{
+		{Name: "new.ps1", Content: "Write-Output new"},
+		{Name: "same.ps1", Content: "Get-Process", Platforms: []string{"windows"}},
+		{Name: "changed.sh", Content: "ps aux", Platforms: []string{"mac", "linux"}, Permission: "group"},
+		{Name: "described.ps1", Content: "dir", Description: "List files"},
+	}
+	remote := []*CloudFile{
+		{ID: "1", Name: "same.ps1", Content: "Get-Process", Platform: []string{"windows"}, PermissionType: "private"},
+		{ID: "2", Name: "changed.sh", Content: "ps -ef", Platform: []string{"linux", "mac"}, PermissionType: "private"},
+		{ID: "3", Name: "described.ps1", Content: "dir", Description: "Old", Platform: []string{"windows"}},
+		{ID: "4", Name: "old.ps1", Content: "Remove-Item"},
+	}
+
+	tests := []struct {
+		name  string
+		prune bool
+		want  []*SyncChange
+	}{
+		{
+			name: "without prune",
+			want: []*SyncChange{
+				{Action: SyncUpdate, Name: "changed.sh", ID: "2", Fields: []string{"content", "permission"}},
+				{Action: SyncUpdate, Name: "described.ps1", ID: "3", Fields: []string{"description"}},
+				{Action: SyncCreate, Name: "new.ps1"},
+				{Action: SyncUnchanged, Name: "same.ps1", ID: "1"},
+			},
+		},
+		{
+			name:  "with prune",
+			prune: true,
+			want: []*SyncChange{
+				{Action: SyncUpdate, Name: "changed.sh", ID: "2", Fields: []string{"content", "permission"}},
+				{Action: SyncUpdate, Name: "described.ps1", ID: "3", Fields: []string{"description"}},
+				{Action: SyncCreate, Name: "new.ps1"},
+				{Action: SyncDelete, Name: "old.ps1", ID: "4"},
+				{Action: SyncUnchanged, Name: "same.ps1", ID: "1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PlanScriptSync(local, remote, tt.prune)
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(SyncChange{}, "Spec")); diff != "" {
+				t.Errorf("PlanScriptSync() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
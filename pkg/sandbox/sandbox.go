@@ -0,0 +1,322 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package sandbox submits files and URLs to the Falcon sandbox for
+// detonation and retrieves their reports and artifacts.
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/falconx_sandbox"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/go-openapi/runtime"
+)
+
+// Submission is a file or URL submitted for detonation
+type Submission = models.FalconxSubmissionV1
+
+// Report is the report of a submission
+type Report = models.FalconxReportV1
+
+// States of submissions
+const (
+	StateCreated = "created"
+	StateRunning = "running"
+	StateSuccess = "success"
+	StateError   = "error"
+)
+
+// minPoll and maxPoll bound the delay between submission state checks
+const (
+	minPoll = 5 * time.Second
+	maxPoll = 30 * time.Second
+)
+
+// Environment is a sandbox environment samples are detonated in
+type Environment struct {
+	ID          int32
+	Name        string
+	Description string
+}
+
+// Environments lists the sandbox environments
+var Environments = []Environment{
+	{ID: 160, Name: "win10", Description: "Windows 10, 64-bit"},
+	{ID: 110, Name: "win7-64", Description: "Windows 7, 64-bit"},
+	{ID: 100, Name: "win7", Description: "Windows 7, 32-bit"},
+	{ID: 300, Name: "linux", Description: "Linux Ubuntu 16.04, 64-bit"},
+	{ID: 200, Name: "android", Description: "Android (static analysis)"},
+}
+
+// NetworkSettings lists the network settings of detonations
+var NetworkSettings = []string{"default", "tor", "simulated", "offline"}
+
+// ActionScripts lists the runtime scripts run during detonations
+var ActionScripts = []string{"default", "default_maxantievasion", "default_randomfiles", "default_randomtheme", "default_openie"}
+
+// Artifacts downloaded from reports
+const (
+	ArtifactReport = "report"
+	ArtifactIOCs   = "iocs"
+	ArtifactPCAP   = "pcap"
+)
+
+// ArtifactKinds lists the kinds of artifacts that can be downloaded
+var ArtifactKinds = []string{ArtifactReport, ArtifactIOCs, ArtifactPCAP}
+
+// ParseEnvironment returns the ID of an environment given by name or ID
+func ParseEnvironment(s string) (int32, error) {
+	s = strings.TrimSpace(s)
+	names := []string{}
+	for _, e := range Environments {
+		if strings.EqualFold(e.Name, s) || strconv.Itoa(int(e.ID)) == s {
+			return e.ID, nil
+		}
+		names = append(names, e.Name)
+	}
+	return 0, fmt.Errorf("invalid environment %q, must be one of: %s", s, strings.Join(names, ", "))
+}
+
+// EnvironmentName returns the name of an environment ID
+func EnvironmentName(id int32) string {
+	for _, e := range Environments {
+		if e.ID == id {
+			return e.Name
+		}
+	}
+	return strconv.Itoa(int(id))
+}
+
+// IsURL reports whether a submission target is a URL rather than a file
+func IsURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "ftp":
+		return true
+	}
+	return false
+}
+
+// Done reports whether a submission has completed, successfully or not
+func Done(s *Submission) bool {
+	return s.State != StateCreated && s.State != StateRunning
+}
+
+// Upload uploads a sample file and returns its SHA256 hash, which
+// identifies it in submissions
+func Upload(ctx context.Context, c *client.CrowdStrikeAPISpecification, path, comment string, confidential bool) (string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	params := &falconx_sandbox.UploadSampleV2Params{
+		Context:        ctx,
+		FileName:       filepath.Base(path),
+		IsConfidential: &confidential,
+		Sample:         f,
+	}
+	if comment != "" {
+		params.Comment = &comment
+	}
+
+	res, err := c.FalconxSandbox.UploadSampleV2(params)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload %s: %s", path, falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return "", err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return "", fmt.Errorf("no sample returned for %s", path)
+	}
+	return utils.Deref(res.Payload.Resources[0].Sha256), nil
+}
+
+// Submit submits a sample, given by SHA256 hash, or a URL for detonation
+func Submit(ctx context.Context, c *client.CrowdStrikeAPISpecification, params *models.FalconxSandboxParametersV1, tags []string) (*Submission, error) {
+	res, err := c.FalconxSandbox.Submit(&falconx_sandbox.SubmitParams{
+		Context: ctx,
+		Body: &models.FalconxSubmissionParametersV1{
+			Sandbox:  []*models.FalconxSandboxParametersV1{params},
+			UserTags: tags,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit for detonation: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return nil, fmt.Errorf("no submission returned")
+	}
+	return res.Payload.Resources[0], nil
+}
+
+// GetSubmission returns a submission
+func GetSubmission(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string) (*Submission, error) {
+	res, err := c.FalconxSandbox.GetSubmissions(&falconx_sandbox.GetSubmissionsParams{
+		Context: ctx,
+		Ids:     []string{id},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return nil, fmt.Errorf("submission %s not found", id)
+	}
+	return res.Payload.Resources[0], nil
+}
+
+// Wait polls a submission until it completes. progress, if not nil, is
+// called with the state after each check.
+func Wait(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string, progress func(state string)) (*Submission, error) {
+	delay := minPoll
+
+	for {
+		s, err := GetSubmission(ctx, c, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if progress != nil {
+			progress(s.State)
+		}
+		if Done(s) {
+			return s, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > maxPoll {
+			delay = maxPoll
+		}
+	}
+}
+
+// GetReport returns the report of a submission. Reports share the ID of
+// their submission.
+func GetReport(ctx context.Context, c *client.CrowdStrikeAPISpecification, id string) (*Report, error) {
+	res, err := c.FalconxSandbox.GetReports(&falconx_sandbox.GetReportsParams{
+		Context: ctx,
+		Ids:     []string{id},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return nil, fmt.Errorf("report %s not found, the submission may still be running", id)
+	}
+	return res.Payload.Resources[0], nil
+}
+
+// Artifact is a file of a report that can be downloaded
+type Artifact struct {
+	ID   string
+	Kind string
+	// Name is the file name the artifact is saved as
+	Name string
+}
+
+// Artifacts returns the artifacts of a report of the given kinds. IOCs are
+// the strict IOC report in JSON and the PCAP comes from each environment
+// the sample was detonated in.
+func Artifacts(r *Report, kinds []string) []Artifact {
+	want := map[string]bool{}
+	for _, k := range kinds {
+		want[strings.ToLower(k)] = true
+	}
+
+	list := []Artifact{}
+	if want[ArtifactIOCs] && r.IocReportStrictJSONArtifactID != "" {
+		list = append(list, Artifact{ID: r.IocReportStrictJSONArtifactID, Kind: ArtifactIOCs, Name: r.ID + "-iocs.json"})
+	}
+	if want[ArtifactPCAP] {
+		for _, s := range r.Sandbox {
+			if s.PcapReportArtifactID != "" {
+				name := fmt.Sprintf("%s-%s.pcap", r.ID, EnvironmentName(s.EnvironmentID))
+				list = append(list, Artifact{ID: s.PcapReportArtifactID, Kind: ArtifactPCAP, Name: name})
+			}
+		}
+	}
+	return list
+}
+
+// DownloadArtifact writes the content of an artifact to w
+func DownloadArtifact(ctx context.Context, c *client.CrowdStrikeAPISpecification, a Artifact, w io.Writer) error {
+	// the generated client discards the content, which is read here instead.
+	// The transport asks for and decompresses gzip content by itself.
+	reader := runtime.ClientResponseReaderFunc(func(res runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+		if res.Code() != http.StatusOK {
+			return nil, runtime.NewAPIError("GetArtifacts", res.Message(), res.Code())
+		}
+		_, err := io.Copy(w, res.Body())
+		return nil, err
+	})
+
+	err := c.FalconxSandbox.GetArtifacts(&falconx_sandbox.GetArtifactsParams{
+		Context: ctx,
+		ID:      a.ID,
+		Name:    &a.Name,
+	}, func(op *runtime.ClientOperation) {
+		op.Reader = reader
+	})
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %s", a.Name, falcon.ErrorExplain(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package sandbox
+
+import (
+	"testing"
+
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseEnvironment(t *testing.T) {
+	tests := map[string]int32{
+		"win10":   160,
+		"Win7-64": 110,
+		"300":     300,
+	}
+	for s, want := range tests {
+		got, err := ParseEnvironment(s)
+		if err != nil {
+			t.Errorf("ParseEnvironment(%q) failed: %v", s, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseEnvironment(%q) = %d, want %d", s, got, want)
+		}
+	}
+
+	for _, s := range []string{"", "win11", "150"} {
+		if _, err := ParseEnvironment(s); err == nil {
+			t.Errorf("ParseEnvironment(%q) did not fail", s)
+		}
+	}
+}
+
+func TestIsURL(t *testing.T) {
+	tests := map[string]bool{
+		"https://example.com/invoice.doc": true,
+		"FTP://files.example.com/a.exe":   true,
+		"invoice.doc":                     false,
+		"./samples/a.exe":                 false,
+		`C:\samples\a.exe`:                false,
+		"file:///tmp/a.exe":               false,
+	}
+	for s, want := range tests {
+		if got := IsURL(s); got != want {
+			t.Errorf("IsURL(%q) = %t, want %t", s, got, want)
+		}
+	}
+}
+
+func TestArtifacts(t *testing.T) {
+	r := &Report{
+		ID:                            "abc_123",
+		IocReportStrictJSONArtifactID: "iocs",
+		Sandbox: []*models.FalconxSandboxReportV1{
+			{EnvironmentID: 160, PcapReportArtifactID: "pcap160"},
+			{EnvironmentID: 100},
+			{EnvironmentID: 999, PcapReportArtifactID: "pcap999"},
+		},
+	}
+
+	want := []Artifact{
+		{ID: "iocs", Kind: ArtifactIOCs, Name: "abc_123-iocs.json"},
+		{ID: "pcap160", Kind: ArtifactPCAP, Name: "abc_123-win10.pcap"},
+		{ID: "pcap999", Kind: ArtifactPCAP, Name: "abc_123-999.pcap"},
+	}
+	if diff := cmp.Diff(want, Artifacts(r, []string{"IOCs", "pcap"})); diff != "" {
+		t.Errorf("Artifacts() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := Artifacts(r, []string{ArtifactReport}); len(got) != 0 {
+		t.Errorf("Artifacts(report) = %v, want none", got)
+	}
+}
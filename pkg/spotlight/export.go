@@ -0,0 +1,156 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package spotlight
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+)
+
+// CSVColumns are the columns written by CSVWriter
+var CSVColumns = []string{
+	"id",
+	"aid",
+	"hostname",
+	"platform",
+	"os_version",
+	"local_ip",
+	"host_groups",
+	"cve",
+	"severity",
+	"base_score",
+	"exprt_rating",
+	"exploit_status",
+	"product",
+	"status",
+	"created",
+	"updated",
+	"closed",
+	"remediations",
+}
+
+// Record flattens a vulnerability into the values of CSVColumns
+func Record(v *Vulnerability) []string {
+	var aid, hostname, platform, osVersion, localIP, groups string
+	if v.HostInfo != nil {
+		hostname = utils.Deref(v.HostInfo.Hostname)
+		platform = v.HostInfo.Platform
+		osVersion = utils.Deref(v.HostInfo.OsVersion)
+		localIP = utils.Deref(v.HostInfo.LocalIP)
+
+		names := []string{}
+		for _, g := range v.HostInfo.Groups {
+			names = append(names, utils.Deref(g.Name))
+		}
+		groups = strings.Join(names, ";")
+	}
+	aid = utils.Deref(v.Aid)
+
+	var cve, severity, score, exprt, exploit string
+	if v.Cve != nil {
+		cve = utils.Deref(v.Cve.ID)
+		severity = v.Cve.Severity
+		score = strconv.FormatFloat(v.Cve.BaseScore, 'f', -1, 64)
+		exprt = v.Cve.ExprtRating
+		exploit = strconv.FormatInt(v.Cve.ExploitStatus, 10)
+	}
+
+	remediations := []string{}
+	if v.Remediation != nil {
+		for _, r := range v.Remediation.Entities {
+			remediations = append(remediations, utils.Deref(r.Action))
+		}
+	}
+
+	return []string{
+		utils.Deref(v.ID),
+		aid,
+		hostname,
+		platform,
+		osVersion,
+		localIP,
+		groups,
+		cve,
+		severity,
+		score,
+		exprt,
+		exploit,
+		Product(v),
+		utils.Deref(v.Status),
+		utils.Deref(v.CreatedTimestamp),
+		utils.Deref(v.UpdatedTimestamp),
+		v.ClosedTimestamp,
+		strings.Join(remediations, ";"),
+	}
+}
+
+// Product returns the vulnerable applications of a vulnerability
+func Product(v *Vulnerability) string {
+	products := []string{}
+	for _, app := range v.Apps {
+		if name := utils.Deref(app.ProductNameVersion); name != "" {
+			products = append(products, name)
+		}
+	}
+	if len(products) == 0 && v.App != nil {
+		return utils.Deref(v.App.ProductNameVersion)
+	}
+	return strings.Join(products, ";")
+}
+
+// CSVWriter writes vulnerabilities as CSV rows, starting with a header row
+type CSVWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+// NewCSVWriter returns a writer of vulnerabilities to w
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// Write writes a page of vulnerabilities and flushes them to the underlying
+// writer
+func (cw *CSVWriter) Write(list []*Vulnerability) error {
+	if !cw.header {
+		if err := cw.w.Write(CSVColumns); err != nil {
+			return err
+		}
+		cw.header = true
+	}
+
+	for _, v := range list {
+		if err := cw.w.Write(Record(v)); err != nil {
+			return err
+		}
+	}
+
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// Close writes the header row if no vulnerabilities were written
+func (cw *CSVWriter) Close() error {
+	return cw.Write(nil)
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+// Package spotlight queries the vulnerabilities found by Falcon Spotlight.
+package spotlight
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdstrike/falcon-cli/pkg/fql"
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/spotlight_vulnerabilities"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// MaxPageSize is the maximum number of vulnerabilities returned per request
+const MaxPageSize = 5000
+
+// Vulnerability is a vulnerability of an application on a host
+type Vulnerability = models.DomainBaseAPIVulnerabilityV2
+
+// Facets lists the detail blocks requested for each vulnerability
+var Facets = []string{"cve", "host_info", "remediation"}
+
+// Statuses lists the statuses of vulnerabilities
+var Statuses = []string{"open", "closed", "reopen", "expired"}
+
+// Severities lists the CVSS severities of vulnerabilities
+var Severities = []string{"critical", "high", "medium", "low", "unknown", "none"}
+
+// ExPRTRatings lists the CrowdStrike Exploit Prediction Ratings
+var ExPRTRatings = []string{"critical", "high", "medium", "low", "unknown"}
+
+// Query holds the criteria used to select vulnerabilities
+type Query struct {
+	CVEs         []string
+	Hosts        []string
+	Products     []string
+	Severities   []string
+	ExPRTRatings []string
+	Statuses     []string
+	Filter       string
+}
+
+// Validate checks the values of the enumerated criteria
+func (q *Query) Validate() error {
+	if err := utils.ValidateOneOf("status", Statuses, lower(q.Statuses)...); err != nil {
+		return err
+	}
+	if err := utils.ValidateOneOf("severity", Severities, lower(q.Severities)...); err != nil {
+		return err
+	}
+	return utils.ValidateOneOf("ExPRT rating", ExPRTRatings, lower(q.ExPRTRatings)...)
+}
+
+// FQL returns the filter expression selecting the vulnerabilities. Hosts are
+// matched by device ID or hostname, matching any of the hosts.
+func (q *Query) FQL() string {
+	aids, hostnames := []string{}, []string{}
+	for _, h := range q.Hosts {
+		if hosts.IsDeviceID(h) {
+			aids = append(aids, strings.ToLower(h))
+		} else {
+			hostnames = append(hostnames, h)
+		}
+	}
+
+	return fql.New().
+		Raw(q.Filter).
+		In("cve.id", upper(q.CVEs)...).
+		Or(fql.New().In("aid", aids...), fql.New().In("host_info.hostname", hostnames...)).
+		In("apps.product_name_version", q.Products...).
+		In("cve.severity", upper(q.Severities)...).
+		In("cve.exprt_rating", upper(q.ExPRTRatings)...).
+		In("status", lower(q.Statuses)...).
+		String()
+}
+
+// Iterate pages through the vulnerabilities matching filter using the after
+// token and calls fn with each page, so that large result sets are never held
+// in memory. At most limit vulnerabilities are returned, or all of them if
+// limit is 0.
+func Iterate(ctx context.Context, c *client.CrowdStrikeAPISpecification, filter, sortBy string, limit int, fn func([]*Vulnerability) error) error {
+	if filter == "" {
+		return fmt.Errorf("a filter is required to query vulnerabilities")
+	}
+
+	var after *string
+	count := 0
+
+	for {
+		pageSize := int64(MaxPageSize)
+		if limit > 0 && limit-count < MaxPageSize {
+			pageSize = int64(limit - count)
+		}
+
+		params := &spotlight_vulnerabilities.CombinedQueryVulnerabilitiesParams{
+			Context: ctx,
+			Filter:  filter,
+			Facet:   Facets,
+			Limit:   &pageSize,
+			After:   after,
+		}
+		if sortBy != "" {
+			params.Sort = &sortBy
+		}
+
+		res, err := c.SpotlightVulnerabilities.CombinedQueryVulnerabilities(params)
+		if err != nil {
+			return fmt.Errorf("failed to query vulnerabilities: %s", falcon.ErrorExplain(err))
+		}
+
+		if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+			return err
+		}
+
+		page := res.Payload.Resources
+		if len(page) > 0 {
+			if err := fn(page); err != nil {
+				return err
+			}
+		}
+		count += len(page)
+
+		if len(page) == 0 || (limit > 0 && count >= limit) {
+			return nil
+		}
+
+		after = nil
+		if res.Payload.Meta != nil && res.Payload.Meta.Pagination != nil {
+			after = res.Payload.Meta.Pagination.After
+		}
+		if after == nil || *after == "" {
+			return nil
+		}
+	}
+}
+
+func lower(values []string) []string {
+	out := make([]string, len(values))
+	for n, v := range values {
+		out[n] = strings.ToLower(strings.TrimSpace(v))
+	}
+	return out
+}
+
+func upper(values []string) []string {
+	out := make([]string, len(values))
+	for n, v := range values {
+		out[n] = strings.ToUpper(strings.TrimSpace(v))
+	}
+	return out
+}
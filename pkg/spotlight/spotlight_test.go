@@ -0,0 +1,107 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package spotlight
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestQueryFQL(t *testing.T) {
+	tests := []struct {
+		query Query
+		want  string
+	}{
+		{Query{}, ""},
+		{Query{Statuses: []string{"Open", "reopen"}}, "status:['open','reopen']"},
+		{
+			Query{CVEs: []string{"cve-2021-44228"}, ExPRTRatings: []string{"high"}},
+			"cve.id:'CVE-2021-44228'+cve.exprt_rating:'HIGH'",
+		},
+		{
+			Query{Hosts: []string{"WIN-DC01", "8E7656B27D8C49A34A1AF416424D6231"}, Filter: "cve.base_score:>7"},
+			"(cve.base_score:>7)+(aid:'8e7656b27d8c49a34a1af416424d6231',host_info.hostname:'WIN-DC01')",
+		},
+		{
+			Query{Products: []string{"Google Chrome 108.0.5359.125"}, Severities: []string{"critical"}},
+			"apps.product_name_version:'Google Chrome 108.0.5359.125'+cve.severity:'CRITICAL'",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.query.FQL(); got != tt.want {
+			t.Errorf("FQL() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestQueryValidate(t *testing.T) {
+	if err := (&Query{ExPRTRatings: []string{"HIGH"}, Statuses: []string{"open"}}).Validate(); err != nil {
+		t.Errorf("Validate() returned error: %v", err)
+	}
+	if err := (&Query{ExPRTRatings: []string{"severe"}}).Validate(); err == nil {
+		t.Error("Validate() did not reject an unknown ExPRT rating")
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	v := &Vulnerability{
+		ID:     utils.Ptr("v1"),
+		Aid:    utils.Ptr("aid1"),
+		Status: utils.Ptr("open"),
+		Apps: []*models.DomainAPIVulnerabilityExtendedAppV2{
+			{ProductNameVersion: utils.Ptr("OpenSSL 3.0.1")},
+		},
+		Cve: &models.DomainAPIVulnerabilityCVEDetailsFacetV2{
+			ID:          utils.Ptr("CVE-2022-3602"),
+			Severity:    "HIGH",
+			BaseScore:   7.5,
+			ExprtRating: "MEDIUM",
+		},
+		HostInfo: &models.DomainAPIVulnerabilityHostFacetV2{
+			Hostname: utils.Ptr("web01"),
+			Platform: "Linux",
+			Groups:   []*models.DomainAPIHostGroup{{Name: utils.Ptr("web")}, {Name: utils.Ptr("prod")}},
+		},
+		Remediation: &models.DomainAPIVulnerabilityRemediationFacetV2{
+			Entities: []*models.DomainAPIRemediationV2{{Action: utils.Ptr("Update OpenSSL")}},
+		},
+	}
+
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf)
+	if err := w.Write([]*Vulnerability{v}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join(CSVColumns, ",") + "\n" +
+		"v1,aid1,web01,Linux,,,web;prod,CVE-2022-3602,HIGH,7.5,MEDIUM,0,OpenSSL 3.0.1,open,,,,Update OpenSSL\n"
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Errorf("CSV mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+)
+
+// minRetry and maxRetry bound the delay between reconnections
+const (
+	minRetry = time.Second
+	maxRetry = time.Minute
+)
+
+// defaultSaveInterval is how often offsets are saved while events are
+// handled
+const defaultSaveInterval = 5 * time.Second
+
+// Consumer reads the events of every partition of the event stream of an
+// app, refreshing the sessions before they expire and reconnecting from the
+// last handled offset when a connection drops
+type Consumer struct {
+	// AppID names the consumer and must be unique to each running consumer
+	AppID string
+	// Client is used to discover the streams and refresh their sessions
+	Client *client.CrowdStrikeAPISpecification
+	// HTTPClient is used to read the streams
+	HTTPClient *http.Client
+	// Offsets records the handled events. Events resume after the saved
+	// offsets.
+	Offsets *Offsets
+	// SaveInterval is how often offsets are saved, 5 seconds by default
+	SaveInterval time.Duration
+	// Handle is called with each event, one event at a time. Any error
+	// stops the consumer.
+	Handle func(e *Event) error
+	// Flush is called before offsets are saved, so that events buffered by
+	// the handler are delivered first
+	Flush func() error
+	// Logf reports dropped connections and refresh failures
+	Logf func(format string, args ...interface{})
+
+	mu       sync.Mutex
+	lastSave time.Time
+}
+
+// errHandle wraps the errors that stop the consumer
+type errHandle struct {
+	err error
+}
+
+func (e *errHandle) Error() string {
+	return e.err.Error()
+}
+
+// Run consumes the streams until ctx is done or an event cannot be handled.
+// Offsets are saved before returning.
+func (c *Consumer) Run(ctx context.Context) error {
+	if c.Offsets == nil {
+		c.Offsets, _ = LoadOffsets("")
+	}
+	if c.HTTPClient == nil {
+		c.HTTPClient = &http.Client{}
+	}
+	if c.SaveInterval == 0 {
+		c.SaveInterval = defaultSaveInterval
+	}
+	if c.Logf == nil {
+		c.Logf = func(string, ...interface{}) {}
+	}
+
+	streams, err := Discover(ctx, c.Client, c.AppID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(streams))
+	for _, s := range streams {
+		go func(s *Stream) {
+			errs <- c.consume(ctx, s)
+		}(s)
+	}
+
+	for range streams {
+		if e := <-errs; e != nil && err == nil {
+			err = e
+			cancel()
+		}
+	}
+
+	if saveErr := c.save(); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	return err
+}
+
+// save flushes the handler and saves the offsets of the events it handled
+func (c *Consumer) save() error {
+	if c.Flush != nil {
+		if err := c.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Offsets.Save(); err != nil {
+		return fmt.Errorf("failed to save offsets: %v", err)
+	}
+	return nil
+}
+
+// consume reads a partition, reconnecting with a new session whenever the
+// connection drops, until ctx is done or an event cannot be handled
+func (c *Consumer) consume(ctx context.Context, s *Stream) error {
+	delay := minRetry
+
+	for {
+		handled, err := c.read(ctx, s)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		var stop *errHandle
+		if errors.As(err, &stop) {
+			return stop.err
+		}
+
+		if handled > 0 {
+			delay = minRetry
+		}
+		if err == nil {
+			err = fmt.Errorf("the stream was closed")
+		}
+		c.Logf("Partition %d: %v, reconnecting in %s", s.Partition, err, delay)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > maxRetry {
+			delay = maxRetry
+		}
+
+		// a new session is needed to reconnect
+		s, err = c.rediscover(ctx, s.Partition)
+		if err != nil && ctx.Err() == nil {
+			c.Logf("Partition %d: %v", s.Partition, err)
+		}
+	}
+}
+
+// rediscover returns a new session of a partition, or the previous one if
+// the partition could not be discovered
+func (c *Consumer) rediscover(ctx context.Context, partition int64) (*Stream, error) {
+	previous := &Stream{Partition: partition}
+
+	streams, err := Discover(ctx, c.Client, c.AppID)
+	if err != nil {
+		return previous, err
+	}
+	for _, s := range streams {
+		if s.Partition == partition {
+			return s, nil
+		}
+	}
+	return previous, fmt.Errorf("partition %d is no longer available", partition)
+}
+
+// read handles the events of a partition while its session is refreshed,
+// and returns the number of events handled once the connection drops
+func (c *Consumer) read(ctx context.Context, s *Stream) (int, error) {
+	if s.URL == "" {
+		return 0, fmt.Errorf("no session")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	offset := uint64(0)
+	if last, ok := c.Offsets.Get(s.Partition); ok {
+		offset = last + 1
+	}
+
+	body, err := Open(ctx, c.HTTPClient, s, offset)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	go c.refresh(ctx, cancel, s)
+
+	handled := 0
+	dec := json.NewDecoder(body)
+	for {
+		raw := json.RawMessage{}
+		if err := dec.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return handled, nil
+			}
+			return handled, err
+		}
+
+		e, err := ParseEvent(raw)
+		if err != nil {
+			c.Logf("Partition %d: %v", s.Partition, err)
+			continue
+		}
+
+		if err := c.handle(s.Partition, e); err != nil {
+			return handled, &errHandle{err: err}
+		}
+		handled++
+	}
+}
+
+// refresh refreshes the session of a partition before it expires, and
+// closes the connection if it cannot be refreshed
+func (c *Consumer) refresh(ctx context.Context, cancel context.CancelFunc, s *Stream) {
+	interval := s.RefreshInterval * 9 / 10
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := Refresh(ctx, c.Client, c.AppID, s.Partition); err != nil {
+				if ctx.Err() == nil {
+					c.Logf("Partition %d: %v", s.Partition, err)
+				}
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// handle passes an event to the handler, one event at a time across
+// partitions, and records its offset once handled
+func (c *Consumer) handle(partition int64, e *Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.Handle(e); err != nil {
+		return err
+	}
+	c.Offsets.Set(partition, e.Metadata.Offset)
+
+	if time.Since(c.lastSave) < c.SaveInterval {
+		return nil
+	}
+	if err := c.save(); err != nil {
+		return err
+	}
+	c.lastSave = time.Now()
+	return nil
+}
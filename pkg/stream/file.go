@@ -0,0 +1,184 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// rotatedSuffix is the time format appended to the names of rotated files,
+// which sorts them by age
+const rotatedSuffix = "20060102T150405.000"
+
+// FileSink appends events to a file as NDJSON, rotating the file by size
+// and age
+type FileSink struct {
+	path       string
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+
+	f       *os.File
+	size    int64
+	created time.Time
+	now     func() time.Time
+}
+
+// NewFileSink returns a sink appending events to path. The file is rotated
+// once it reaches maxSize bytes or once it is older than maxAge, keeping at
+// most maxBackups rotated files. Zero values disable each limit.
+func NewFileSink(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*FileSink, error) {
+	s := &FileSink{
+		path:       filepath.Clean(path),
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+		now:        time.Now,
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) open() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	s.f = f
+	s.size = info.Size()
+	s.created = s.now()
+	return nil
+}
+
+func (s *FileSink) Write(e *Event) error {
+	line := append(json.RawMessage{}, e.Raw...)
+	line = append(line, '\n')
+
+	if s.due(int64(len(line))) {
+		if err := s.rotate(); err != nil {
+			return fmt.Errorf("failed to rotate %s: %v", s.path, err)
+		}
+	}
+
+	n, err := s.f.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+// due reports whether the file must be rotated before writing n bytes. A
+// file is never rotated while empty.
+func (s *FileSink) due(n int64) bool {
+	if s.size == 0 {
+		return false
+	}
+	if s.maxSize > 0 && s.size+n > s.maxSize {
+		return true
+	}
+	return s.maxAge > 0 && s.now().Sub(s.created) >= s.maxAge
+}
+
+// rotate renames the file with the current time and opens a new one,
+// removing the oldest rotated files beyond maxBackups
+func (s *FileSink) rotate() error {
+	if err := s.f.Close(); err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(s.path)
+	rotated := strings.TrimSuffix(s.path, ext) + "-" + s.now().UTC().Format(rotatedSuffix) + ext
+	if err := os.Rename(s.path, rotated); err != nil {
+		return err
+	}
+
+	if err := s.open(); err != nil {
+		return err
+	}
+
+	return s.prune()
+}
+
+// Backups returns the rotated files, oldest first
+func (s *FileSink) Backups() ([]string, error) {
+	ext := filepath.Ext(s.path)
+	pattern := strings.TrimSuffix(s.path, ext) + "-*" + ext
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []string{}
+	prefix := strings.TrimSuffix(s.path, ext) + "-"
+	for _, m := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(m, prefix), ext)
+		if _, err := time.Parse(rotatedSuffix, stamp); err == nil {
+			backups = append(backups, m)
+		}
+	}
+	sort.Strings(backups)
+	return backups, nil
+}
+
+func (s *FileSink) prune() error {
+	if s.maxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := s.Backups()
+	if err != nil {
+		return err
+	}
+
+	for len(backups) > s.maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// Flush commits the file to disk
+func (s *FileSink) Flush() error {
+	return s.f.Sync()
+}
+
+func (s *FileSink) Close() error {
+	return s.f.Close()
+}
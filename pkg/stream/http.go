@@ -0,0 +1,222 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of HTTP sinks
+const (
+	defaultBatchSize = 100
+	defaultBatchWait = 5 * time.Second
+	defaultRetries   = 5
+	httpTimeout      = 30 * time.Second
+	minHTTPRetry     = time.Second
+	maxHTTPRetry     = 30 * time.Second
+)
+
+// HTTPSink posts batches of events to a URL as NDJSON. A batch is posted
+// once it holds BatchSize events or once it is BatchWait old, and failed
+// posts are retried with a growing delay.
+type HTTPSink struct {
+	url       string
+	headers   http.Header
+	batchSize int
+	retries   int
+	client    *http.Client
+	retry     time.Duration
+
+	mu     sync.Mutex
+	batch  *bytes.Buffer
+	count  int
+	err    error
+	done   chan struct{}
+	closed sync.Once
+}
+
+// NewHTTPSink returns a sink posting events to rawURL, configured by the
+// HTTP options of opts
+func NewHTTPSink(rawURL string, opts *SinkOptions) (*HTTPSink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid URL %q", rawURL)
+	}
+
+	headers := http.Header{}
+	for _, h := range opts.Headers {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid header %q, must be name: value", h)
+		}
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	if headers.Get("Content-Type") == "" {
+		headers.Set("Content-Type", "application/x-ndjson")
+	}
+
+	s := &HTTPSink{
+		url:       u.String(),
+		headers:   headers,
+		batchSize: opts.BatchSize,
+		retries:   opts.Retries,
+		client:    &http.Client{Timeout: httpTimeout},
+		retry:     minHTTPRetry,
+		batch:     &bytes.Buffer{},
+		done:      make(chan struct{}),
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = defaultBatchSize
+	}
+	if s.retries < 0 {
+		s.retries = defaultRetries
+	}
+
+	wait := opts.BatchWait
+	if wait <= 0 {
+		wait = defaultBatchWait
+	}
+	go s.flushEvery(wait)
+
+	return s, nil
+}
+
+// flushEvery posts the pending events regularly so that a quiet stream
+// does not hold events back
+func (s *HTTPSink) flushEvery(wait time.Duration) {
+	ticker := time.NewTicker(wait)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			if s.err == nil {
+				s.err = s.post()
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *HTTPSink) Write(e *Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+
+	s.batch.Write(e.Raw)
+	s.batch.WriteByte('\n')
+	s.count++
+
+	if s.count >= s.batchSize {
+		s.err = s.post()
+	}
+	return s.err
+}
+
+// Flush posts the pending events
+func (s *HTTPSink) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.err = s.post()
+	return s.err
+}
+
+// Close stops posting events in the background. Pending events are
+// dropped unless flushed first.
+func (s *HTTPSink) Close() error {
+	s.closed.Do(func() {
+		close(s.done)
+	})
+	return nil
+}
+
+// post sends the pending events, retrying failed requests. It is called
+// with the lock held.
+func (s *HTTPSink) post() error {
+	if s.count == 0 {
+		return nil
+	}
+
+	delay := s.retry
+	var err error
+	for attempt := 0; attempt <= s.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			if delay *= 2; delay > maxHTTPRetry {
+				delay = maxHTTPRetry
+			}
+		}
+
+		var retry bool
+		if retry, err = s.send(s.batch.Bytes()); err == nil {
+			s.batch.Reset()
+			s.count = 0
+			return nil
+		}
+		if !retry {
+			break
+		}
+	}
+
+	return fmt.Errorf("failed to post %d events to %s: %v", s.count, s.url, err)
+}
+
+// send posts a batch once and reports whether a failure may be retried
+func (s *HTTPSink) send(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for name, values := range s.headers {
+		req.Header[name] = values
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<20))
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500
+	return retry, fmt.Errorf("%s", res.Status)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// Offsets keeps the offset of the last event handled on each partition,
+// saved to a file so that a consumer resumes where it stopped
+type Offsets struct {
+	path    string
+	mu      sync.Mutex
+	offsets map[int64]uint64
+	dirty   bool
+}
+
+// LoadOffsets returns the offsets saved in a file, or no offsets if the file
+// does not exist yet. The offsets are only kept in memory if path is empty.
+func LoadOffsets(path string) (*Offsets, error) {
+	o := &Offsets{path: path, offsets: map[int64]uint64{}}
+	if path == "" {
+		return o, nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return o, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	saved := map[string]uint64{}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	for k, v := range saved {
+		partition, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		o.offsets[partition] = v
+	}
+
+	return o, nil
+}
+
+// Get returns the offset of the last event handled on a partition
+func (o *Offsets) Get(partition int64) (uint64, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	offset, ok := o.offsets[partition]
+	return offset, ok
+}
+
+// Set records the offset of the last event handled on a partition
+func (o *Offsets) Set(partition int64, offset uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.offsets[partition] = offset
+	o.dirty = true
+}
+
+// Save writes the offsets to their file if they changed. The file is
+// replaced at once so that a consumer stopped while saving never leaves a
+// truncated file behind.
+func (o *Offsets) Save() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.path == "" || !o.dirty {
+		return nil
+	}
+
+	saved := map[string]uint64{}
+	for k, v := range o.offsets {
+		saved[strconv.FormatInt(k, 10)] = v
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(o.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(o.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), o.path); err != nil {
+		return err
+	}
+
+	o.dirty = false
+	return nil
+}
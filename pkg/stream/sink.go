@@ -0,0 +1,231 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/output"
+)
+
+// Sink receives the events of a stream. Sinks may buffer events, which are
+// only delivered once Flush returns.
+type Sink interface {
+	Write(e *Event) error
+	Flush() error
+	Close() error
+}
+
+// Schemes of sink specifications
+const (
+	SchemeStdout    = "stdout"
+	SchemeFile      = "file"
+	SchemeSyslogUDP = "syslog+udp"
+	SchemeSyslogTCP = "syslog+tcp"
+	SchemeSyslogTLS = "syslog+tls"
+	SchemeHTTP      = "http"
+	SchemeHTTPS     = "https"
+)
+
+// SinkOptions configures the sinks of each kind
+type SinkOptions struct {
+	// File sinks rotate files once they reach MaxSize bytes or once they
+	// are older than MaxAge, keeping at most MaxBackups rotated files.
+	// Zero values disable each limit.
+	MaxSize    int64
+	MaxAge     time.Duration
+	MaxBackups int
+
+	// Syslog sinks name the sender AppName. TLS connections trust the
+	// certificates of CAFile in addition to the system ones.
+	AppName            string
+	CAFile             string
+	InsecureSkipVerify bool
+
+	// HTTP sinks post batches of at most BatchSize events, or the events
+	// received over BatchWait, retrying failed requests Retries times
+	Headers   []string
+	BatchSize int
+	BatchWait time.Duration
+	Retries   int
+}
+
+// NewSink returns the sink of a specification:
+//
+//	stdout or -                      standard output
+//	file:<path>                      a file, rotated according to opts
+//	syslog+udp://<host>:<port>       RFC 5424 syslog over UDP
+//	syslog+tcp://<host>:<port>       RFC 5424 syslog over TCP
+//	syslog+tls://<host>:<port>       RFC 5424 syslog over TLS
+//	http(s)://<url>                  batches of NDJSON events posted to url
+func NewSink(spec string, opts *SinkOptions, stdout io.Writer) (Sink, error) {
+	if spec == "-" || spec == SchemeStdout {
+		return NewWriterSink(stdout), nil
+	}
+
+	scheme, rest, ok := strings.Cut(spec, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid sink %q, must start with one of: %s", spec, strings.Join(sinkSchemes, ", "))
+	}
+
+	switch strings.ToLower(scheme) {
+	case SchemeFile:
+		path := strings.TrimPrefix(rest, "//")
+		if path == "" {
+			return nil, fmt.Errorf("invalid sink %q, no file path", spec)
+		}
+		return NewFileSink(path, opts.MaxSize, opts.MaxAge, opts.MaxBackups)
+	case SchemeSyslogUDP, SchemeSyslogTCP, SchemeSyslogTLS:
+		address := strings.TrimPrefix(rest, "//")
+		if address == "" {
+			return nil, fmt.Errorf("invalid sink %q, no address", spec)
+		}
+		return NewSyslogSink(strings.TrimPrefix(strings.ToLower(scheme), "syslog+"), address, opts)
+	case SchemeHTTP, SchemeHTTPS:
+		return NewHTTPSink(spec, opts)
+	}
+
+	return nil, fmt.Errorf("invalid sink %q, must start with one of: %s", spec, strings.Join(sinkSchemes, ", "))
+}
+
+var sinkSchemes = []string{SchemeStdout, SchemeFile, SchemeSyslogUDP, SchemeSyslogTCP, SchemeSyslogTLS, SchemeHTTP, SchemeHTTPS}
+
+// writerSink writes events to a writer as NDJSON
+type writerSink struct {
+	w io.Writer
+}
+
+// NewWriterSink returns a sink writing events to w as NDJSON
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+func (s *writerSink) Write(e *Event) error {
+	return output.PrintNDJSON(s.w, e)
+}
+
+func (s *writerSink) Flush() error {
+	return nil
+}
+
+func (s *writerSink) Close() error {
+	return nil
+}
+
+// MultiSink writes events to every one of its sinks
+type MultiSink []Sink
+
+func (m MultiSink) Write(e *Event) error {
+	for _, s := range m {
+		if err := s.Write(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m MultiSink) Flush() error {
+	for _, s := range m {
+		if err := s.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close flushes and closes every sink, returning the first error
+func (m MultiSink) Close() error {
+	var first error
+	for _, s := range m {
+		if err := s.Flush(); err != nil && first == nil {
+			first = err
+		}
+		if err := s.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// FilterSink only passes the events of the given types to its sink
+type FilterSink struct {
+	Sink
+	types map[string]bool
+}
+
+// NewFilterSink returns a sink passing the events whose type is one of
+// types, compared without case, to s. Every event is passed if types is
+// empty.
+func NewFilterSink(s Sink, types []string) Sink {
+	if len(types) == 0 {
+		return s
+	}
+
+	f := &FilterSink{Sink: s, types: map[string]bool{}}
+	for _, t := range types {
+		f.types[strings.ToLower(strings.TrimSpace(t))] = true
+	}
+	return f
+}
+
+func (f *FilterSink) Write(e *Event) error {
+	if !f.types[strings.ToLower(e.Metadata.EventType)] {
+		return nil
+	}
+	return f.Sink.Write(e)
+}
+
+// ParseSize parses a size in bytes such as 1048576, 512KB, 100MB or 1GB
+func ParseSize(s string) (int64, error) {
+	size := strings.ToUpper(strings.TrimSpace(s))
+	if size == "" || size == "0" {
+		return 0, nil
+	}
+
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"B", 1},
+	}
+
+	unit := int64(1)
+	for _, u := range units {
+		if strings.HasSuffix(size, u.suffix) {
+			size = strings.TrimSpace(strings.TrimSuffix(size, u.suffix))
+			unit = u.size
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(size, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q, must be a number of bytes such as 512KB, 100MB or 1GB", s)
+	}
+	return n * unit, nil
+}
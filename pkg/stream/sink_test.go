@@ -0,0 +1,353 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func newEvent(t *testing.T, offset uint64, eventType string) *Event {
+	t.Helper()
+	e, err := ParseEvent([]byte(fmt.Sprintf(`{"metadata": {"offset": %d, "eventType": %q, "eventCreationTime": 1677628800123}, "event": {}}`, offset, eventType)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"":      0,
+		"0":     0,
+		"4096":  4096,
+		"512KB": 512 << 10,
+		"100mb": 100 << 20,
+		"1 GB":  1 << 30,
+		"2048B": 2048,
+	}
+	for s, want := range tests {
+		got, err := ParseSize(s)
+		if err != nil {
+			t.Errorf("ParseSize(%q) failed: %v", s, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseSize(%q) = %d, want %d", s, got, want)
+		}
+	}
+
+	for _, s := range []string{"MB", "-1MB", "1TB", "ten"} {
+		if _, err := ParseSize(s); err == nil {
+			t.Errorf("ParseSize(%q) did not fail", s)
+		}
+	}
+}
+
+func TestNewSinkErrors(t *testing.T) {
+	for _, spec := range []string{"events.ndjson", "file:", "syslog+udp://", "syslog+tcp://collector", "kafka://broker:9092", "https://"} {
+		if _, err := NewSink(spec, &SinkOptions{}, io.Discard); err == nil {
+			t.Errorf("NewSink(%q) did not fail", spec)
+		}
+	}
+}
+
+// recordingSink records the offsets of the events written to it
+type recordingSink struct {
+	offsets []uint64
+}
+
+func (r *recordingSink) Write(e *Event) error {
+	r.offsets = append(r.offsets, e.Metadata.Offset)
+	return nil
+}
+
+func (r *recordingSink) Flush() error { return nil }
+func (r *recordingSink) Close() error { return nil }
+
+func TestFilterSink(t *testing.T) {
+	r := &recordingSink{}
+	s := NewFilterSink(r, []string{"detectionsummaryevent", " AuthActivityAuditEvent"})
+
+	for n, eventType := range []string{"DetectionSummaryEvent", "UserActivityAuditEvent", "AuthActivityAuditEvent"} {
+		if err := s.Write(newEvent(t, uint64(n), eventType)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if diff := cmp.Diff([]uint64{0, 2}, r.offsets); diff != "" {
+		t.Errorf("FilterSink passed events mismatch (-want +got):\n%s", diff)
+	}
+
+	if NewFilterSink(r, nil) != Sink(r) {
+		t.Errorf("NewFilterSink() without types did not return the sink")
+	}
+}
+
+func TestFormatSyslog(t *testing.T) {
+	e := newEvent(t, 7, "DetectionSummaryEvent")
+	got := string(FormatSyslog(e, "edge 01", "falcon"))
+	want := `<134>1 2023-03-01T00:00:00.123Z edge01 falcon - DetectionSummaryEvent - ` + string(e.Raw)
+	if got != want {
+		t.Errorf("FormatSyslog() = %s, want %s", got, want)
+	}
+
+	e.Metadata.EventCreationTime = 0
+	e.Metadata.EventType = ""
+	if got := string(FormatSyslog(e, "", "falcon")); !strings.HasPrefix(got, "<134>1 - - falcon - - - {") {
+		t.Errorf("FormatSyslog() without time, hostname and type = %s", got)
+	}
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	received := make(chan string, 2)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		for {
+			// messages are framed by octet counting
+			length, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(length))
+			msg := make([]byte, n)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return
+			}
+			received <- string(msg)
+		}
+	}()
+
+	s, err := NewSink("syslog+tcp://"+l.Addr().String(), &SinkOptions{AppName: "edge"}, io.Discard)
+	if err != nil {
+		t.Fatalf("NewSink() failed: %v", err)
+	}
+	defer s.Close()
+
+	for n := uint64(1); n <= 2; n++ {
+		if err := s.Write(newEvent(t, n, "DetectionSummaryEvent")); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+	}
+
+	for n := 1; n <= 2; n++ {
+		select {
+		case msg := <-received:
+			if !strings.Contains(msg, " edge - DetectionSummaryEvent - ") || !strings.Contains(msg, fmt.Sprintf(`"offset":%d`, n)) {
+				t.Errorf("message %d = %s", n, msg)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("message %d was not received", n)
+		}
+	}
+}
+
+func TestSyslogSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	s, err := NewSink("syslog+udp://"+conn.LocalAddr().String(), &SinkOptions{}, io.Discard)
+	if err != nil {
+		t.Fatalf("NewSink() failed: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.Write(newEvent(t, 1, "DetectionSummaryEvent")); err != nil {
+		t.Fatalf("Write() failed: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("no datagram received: %v", err)
+	}
+	if msg := string(buf[:n]); !strings.HasPrefix(msg, "<134>1 ") {
+		t.Errorf("datagram = %s, want an unframed RFC 5424 message", msg)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	var mu sync.Mutex
+	batches := []string{}
+	failures := 1
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		batches = append(batches, string(body))
+	}))
+	defer srv.Close()
+
+	s, err := NewHTTPSink(srv.URL+"/events", &SinkOptions{
+		Headers:   []string{"Authorization: Bearer secret"},
+		BatchSize: 2,
+		BatchWait: time.Hour,
+		Retries:   2,
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPSink() failed: %v", err)
+	}
+	s.retry = time.Millisecond
+	defer s.Close()
+
+	events := []*Event{}
+	for n := uint64(1); n <= 3; n++ {
+		e := newEvent(t, n, "DetectionSummaryEvent")
+		events = append(events, e)
+		if err := s.Write(e); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatalf("Flush() failed: %v", err)
+	}
+
+	// the first batch is retried once the server recovers, the last event
+	// is only posted on flush
+	want := []string{
+		string(events[0].Raw) + "\n" + string(events[1].Raw) + "\n",
+		string(events[2].Raw) + "\n",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff(want, batches); diff != "" {
+		t.Errorf("posted batches mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHTTPSinkError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s, err := NewHTTPSink(srv.URL, &SinkOptions{BatchSize: 1, Retries: 3})
+	if err != nil {
+		t.Fatalf("NewHTTPSink() failed: %v", err)
+	}
+	defer s.Close()
+
+	if err := s.Write(newEvent(t, 1, "DetectionSummaryEvent")); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("Write() = %v, want the error of the rejected batch", err)
+	}
+	if err := s.Flush(); err == nil {
+		t.Errorf("Flush() after a rejected batch did not fail")
+	}
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.ndjson")
+	now := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	e := newEvent(t, 1, "DetectionSummaryEvent")
+	line := int64(len(e.Raw) + 1)
+
+	s, err := NewFileSink(path, 2*line, time.Hour, 2)
+	if err != nil {
+		t.Fatalf("NewFileSink() failed: %v", err)
+	}
+	s.now = func() time.Time { return now }
+	s.created = now
+	defer s.Close()
+
+	write := func() {
+		t.Helper()
+		if err := s.Write(e); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+		now = now.Add(time.Second)
+	}
+
+	// two events fill the file, the third rotates it by size
+	write()
+	write()
+	write()
+
+	// the file is rotated by age
+	now = now.Add(time.Hour)
+	write()
+
+	// a third rotation removes the oldest backup
+	write()
+	write()
+
+	backups, err := s.Backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join(dir, "events-20230301T010003.000.ndjson"),
+		filepath.Join(dir, "events-20230301T010005.000.ndjson"),
+	}
+	if diff := cmp.Diff(want, backups); diff != "" {
+		t.Errorf("Backups() mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := bytes.Count(data, []byte("\n")); got != 1 {
+		t.Errorf("current file holds %d events, want 1", got)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package stream consumes the event streams of the Falcon platform.
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/event_streams"
+)
+
+// refreshAction is the action refreshing a stream session
+const refreshAction = "refresh_active_stream_session"
+
+// Stream is a partition of the event stream of an app, as discovered
+type Stream struct {
+	Partition int64
+	URL       string
+	Token     string
+	// RefreshInterval is how often the session must be refreshed to stay
+	// open
+	RefreshInterval time.Duration
+}
+
+// Metadata is the metadata of an event
+type Metadata struct {
+	CustomerID        string `json:"customerIDString"`
+	Offset            uint64 `json:"offset"`
+	EventType         string `json:"eventType"`
+	EventCreationTime int64  `json:"eventCreationTime"`
+	Version           string `json:"version"`
+}
+
+// Event is an event of a stream. The event is kept as received so that no
+// field is lost, only its metadata is decoded.
+type Event struct {
+	Metadata Metadata
+	Raw      json.RawMessage
+}
+
+// MarshalJSON returns the event as received
+func (e *Event) MarshalJSON() ([]byte, error) {
+	return e.Raw, nil
+}
+
+// ParseEvent decodes the metadata of an event
+func ParseEvent(data []byte) (*Event, error) {
+	v := struct {
+		Metadata *Metadata `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("invalid event: %v", err)
+	}
+	if v.Metadata == nil {
+		return nil, fmt.Errorf("invalid event: no metadata")
+	}
+
+	raw := &bytes.Buffer{}
+	if err := json.Compact(raw, data); err != nil {
+		return nil, fmt.Errorf("invalid event: %v", err)
+	}
+
+	return &Event{Metadata: *v.Metadata, Raw: raw.Bytes()}, nil
+}
+
+// Discover returns the partitions of the event stream of an app. The app ID
+// names the consumer and must be unique to each running consumer.
+func Discover(ctx context.Context, c *client.CrowdStrikeAPISpecification, appID string) ([]*Stream, error) {
+	res, err := c.EventStreams.ListAvailableStreamsOAuth2(&event_streams.ListAvailableStreamsOAuth2Params{
+		Context: ctx,
+		AppID:   appID,
+		Format:  utils.Ptr("json"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover event streams: %s", falcon.ErrorExplain(err))
+	}
+
+	if err = falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	streams := []*Stream{}
+	for _, r := range res.Payload.Resources {
+		if r.DataFeedURL == nil || r.SessionToken == nil {
+			continue
+		}
+		partition, err := partitionOf(*r.DataFeedURL)
+		if err != nil {
+			return nil, err
+		}
+		streams = append(streams, &Stream{
+			Partition:       partition,
+			URL:             *r.DataFeedURL,
+			Token:           utils.Deref(r.SessionToken.Token),
+			RefreshInterval: time.Duration(utils.Deref(r.RefreshActiveSessionInterval)) * time.Second,
+		})
+	}
+
+	if len(streams) == 0 {
+		return nil, fmt.Errorf("no event streams available for app ID %q", appID)
+	}
+
+	return streams, nil
+}
+
+// partitionOf returns the partition of a data feed URL, which is the last
+// element of its path
+func partitionOf(feedURL string) (int64, error) {
+	u, err := url.Parse(feedURL)
+	if err != nil {
+		return 0, fmt.Errorf("invalid data feed URL %q: %v", feedURL, err)
+	}
+
+	partition, err := strconv.ParseInt(path.Base(u.Path), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid data feed URL %q, no partition", feedURL)
+	}
+	return partition, nil
+}
+
+// Refresh keeps the session of a partition open
+func Refresh(ctx context.Context, c *client.CrowdStrikeAPISpecification, appID string, partition int64) error {
+	_, err := c.EventStreams.RefreshActiveStreamSession(&event_streams.RefreshActiveStreamSessionParams{
+		Context:    ctx,
+		ActionName: refreshAction,
+		AppID:      appID,
+		Partition:  partition,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to refresh the session of partition %d: %s", partition, falcon.ErrorExplain(err))
+	}
+
+	return nil
+}
+
+// Open connects to a partition and returns its events as a stream of JSON
+// documents. Events start at offset, or where the API starts by default if
+// offset is 0.
+func Open(ctx context.Context, httpClient *http.Client, s *Stream, offset uint64) (io.ReadCloser, error) {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data feed URL %q: %v", s.URL, err)
+	}
+	if offset > 0 {
+		q := u.Query()
+		q.Set("offset", strconv.FormatUint(offset, 10))
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Token "+s.Token)
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to partition %d: %v", s.Partition, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		res.Body.Close()
+		return nil, fmt.Errorf("failed to connect to partition %d: %s %s", s.Partition, res.Status, strings.TrimSpace(string(body)))
+	}
+
+	return res.Body, nil
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testAppID = "falconcli"
+	testToken = "session-token"
+)
+
+// testServer emulates the discovery, refresh and data feed endpoints of
+// the event streams with a single partition holding events at offsets 10
+// to 12. Once they are sent, the feed waits for the session to be refreshed
+// and sends a last event at offset 13.
+type testServer struct {
+	*httptest.Server
+
+	refreshInterval int
+	refreshed       chan struct{}
+
+	mu      sync.Mutex
+	offsets []string
+}
+
+func newTestServer(t *testing.T, refreshInterval int) *testServer {
+	s := &testServer{refreshInterval: refreshInterval, refreshed: make(chan struct{}, 10)}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) client() *client.CrowdStrikeAPISpecification {
+	u, _ := url.Parse(s.URL)
+	return client.New(httptransport.New(u.Host, "/", []string{"http"}), strfmt.Default)
+}
+
+func (s *testServer) serve(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.URL.Path == "/sensors/entities/datafeed/v2":
+		if r.URL.Query().Get("appId") != testAppID {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"errors": [{"code": 400, "message": "invalid appId"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"resources": [{
+			"dataFeedURL": "%s/sensors/entities/datafeed/v2/0?appId=%s",
+			"refreshActiveSessionInterval": %d,
+			"refreshActiveSessionURL": "%s/sensors/entities/datafeed-actions/v1/0",
+			"sessionToken": {"token": "%s", "expiration": "2030-01-01T00:00:00Z"}
+		}]}`, s.URL, testAppID, s.refreshInterval, s.URL, testToken)
+
+	case r.URL.Path == "/sensors/entities/datafeed-actions/v1/0":
+		if r.Method != http.MethodPost || r.URL.Query().Get("action_name") != refreshAction {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.refreshed <- struct{}{}
+		fmt.Fprint(w, `{"errors": []}`)
+
+	case r.URL.Path == "/sensors/entities/datafeed/v2/0":
+		if r.Header.Get("Authorization") != "Token "+testToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		s.feed(w, r)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *testServer) feed(w http.ResponseWriter, r *http.Request) {
+	offset := r.URL.Query().Get("offset")
+	s.mu.Lock()
+	s.offsets = append(s.offsets, offset)
+	s.mu.Unlock()
+
+	start := uint64(0)
+	fmt.Sscan(offset, &start)
+
+	for n := uint64(10); n <= 12; n++ {
+		if n >= start {
+			fmt.Fprintf(w, "%s\r\n", testEvent(n))
+		}
+	}
+	w.(http.Flusher).Flush()
+
+	select {
+	case <-s.refreshed:
+		fmt.Fprintf(w, "%s\r\n", testEvent(13))
+		w.(http.Flusher).Flush()
+	case <-r.Context().Done():
+		return
+	}
+	<-r.Context().Done()
+}
+
+func testEvent(offset uint64) string {
+	return fmt.Sprintf(`{"metadata": {"customerIDString": "cid", "offset": %d, "eventType": "DetectionSummaryEvent", "eventCreationTime": 1677628800000, "version": "1.0"}, "event": {"DetectId": "ldt:%d"}}`, offset, offset)
+}
+
+func (s *testServer) requestedOffsets() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.offsets...)
+}
+
+func TestConsumer(t *testing.T) {
+	srv := newTestServer(t, 1)
+	path := filepath.Join(t.TempDir(), "offsets.json")
+
+	offsets, err := LoadOffsets(path)
+	if err != nil {
+		t.Fatalf("LoadOffsets() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	got := []uint64{}
+	c := &Consumer{
+		AppID:   testAppID,
+		Client:  srv.client(),
+		Offsets: offsets,
+		Handle: func(e *Event) error {
+			got = append(got, e.Metadata.Offset)
+			if len(got) == 4 {
+				cancel()
+			}
+			return nil
+		},
+		Logf: t.Logf,
+	}
+
+	if err := c.Run(ctx); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	// the last event is only sent once the session is refreshed
+	if diff := cmp.Diff([]uint64{10, 11, 12, 13}, got); diff != "" {
+		t.Errorf("Run() events mismatch (-want +got):\n%s", diff)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("offsets were not saved: %v", err)
+	}
+	if got, want := string(data), `{"0":13}`; got != want {
+		t.Errorf("saved offsets = %s, want %s", got, want)
+	}
+}
+
+func TestConsumerResume(t *testing.T) {
+	srv := newTestServer(t, 0)
+	path := filepath.Join(t.TempDir(), "offsets.json")
+	if err := os.WriteFile(path, []byte(`{"0":11}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	offsets, err := LoadOffsets(path)
+	if err != nil {
+		t.Fatalf("LoadOffsets() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	got := []uint64{}
+	c := &Consumer{
+		AppID:   testAppID,
+		Client:  srv.client(),
+		Offsets: offsets,
+		Handle: func(e *Event) error {
+			got = append(got, e.Metadata.Offset)
+			cancel()
+			return nil
+		},
+	}
+
+	if err := c.Run(ctx); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{"12"}, srv.requestedOffsets()); diff != "" {
+		t.Errorf("requested offsets mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]uint64{12}, got); diff != "" {
+		t.Errorf("Run() events mismatch (-want +got):\n%s", diff)
+	}
+	if offset, _ := offsets.Get(0); offset != 12 {
+		t.Errorf("offset of partition 0 = %d, want 12", offset)
+	}
+}
+
+func TestConsumerHandleError(t *testing.T) {
+	srv := newTestServer(t, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	failed := errors.New("disk full")
+	c := &Consumer{
+		AppID:  testAppID,
+		Client: srv.client(),
+		Handle: func(e *Event) error {
+			return failed
+		},
+	}
+
+	if err := c.Run(ctx); !errors.Is(err, failed) {
+		t.Errorf("Run() = %v, want %v", err, failed)
+	}
+	if offset, ok := c.Offsets.Get(0); ok {
+		t.Errorf("offset of partition 0 = %d, want none as no event was handled", offset)
+	}
+}
+
+func TestDiscoverError(t *testing.T) {
+	srv := newTestServer(t, 0)
+
+	_, err := Discover(context.Background(), srv.client(), "other")
+	if err == nil {
+		t.Fatalf("Discover() of an unknown app ID did not fail")
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	e, err := ParseEvent([]byte("{\n  \"metadata\": {\"offset\": 42, \"eventType\": \"AuthActivityAuditEvent\"},\n  \"event\": {\"UserId\": \"jdoe\"}\n}"))
+	if err != nil {
+		t.Fatalf("ParseEvent() failed: %v", err)
+	}
+	if e.Metadata.Offset != 42 || e.Metadata.EventType != "AuthActivityAuditEvent" {
+		t.Errorf("ParseEvent() metadata = %+v", e.Metadata)
+	}
+
+	data, err := e.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() failed: %v", err)
+	}
+	if want := `{"metadata":{"offset":42,"eventType":"AuthActivityAuditEvent"},"event":{"UserId":"jdoe"}}`; string(data) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", data, want)
+	}
+
+	for _, s := range []string{`{"event": {}}`, `[1, 2]`, `{`} {
+		if _, err := ParseEvent([]byte(s)); err == nil {
+			t.Errorf("ParseEvent(%q) did not fail", s)
+		}
+	}
+}
+
+func TestPartitionOf(t *testing.T) {
+	got, err := partitionOf("https://firehose.crowdstrike.com/sensors/entities/datafeed/v2/3?appId=falconcli")
+	if err != nil {
+		t.Fatalf("partitionOf() failed: %v", err)
+	}
+	if got != 3 {
+		t.Errorf("partitionOf() = %d, want 3", got)
+	}
+
+	if _, err := partitionOf("https://firehose.crowdstrike.com/sensors/entities/datafeed/v2"); err == nil || !strings.Contains(err.Error(), "no partition") {
+		t.Errorf("partitionOf() of a URL without partition = %v, want an error", err)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package stream
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Network transports of syslog sinks
+const (
+	syslogUDP = "udp"
+	syslogTCP = "tcp"
+	syslogTLS = "tls"
+)
+
+// syslogPriority is the priority of syslog messages, the local0 facility
+// with the informational severity
+const syslogPriority = 16*8 + 6
+
+// syslogDialTimeout bounds the time taken to connect to a syslog server
+const syslogDialTimeout = 30 * time.Second
+
+// SyslogSink sends events as RFC 5424 syslog messages. Messages are
+// framed by octet counting over TCP and TLS as per RFC 6587 and RFC 5425,
+// and sent one per datagram over UDP.
+type SyslogSink struct {
+	network  string
+	address  string
+	appName  string
+	hostname string
+	tls      *tls.Config
+
+	conn net.Conn
+}
+
+// NewSyslogSink returns a sink sending events to a syslog server over the
+// udp, tcp or tls network
+func NewSyslogSink(network, address string, opts *SinkOptions) (*SyslogSink, error) {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return nil, fmt.Errorf("invalid syslog address %q, must be host:port", address)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+
+	s := &SyslogSink{
+		network:  network,
+		address:  address,
+		appName:  opts.AppName,
+		hostname: hostname,
+	}
+	if s.appName == "" {
+		s.appName = "falcon"
+	}
+
+	switch network {
+	case syslogUDP, syslogTCP:
+	case syslogTLS:
+		if s.tls, err = tlsConfig(address, opts.CAFile, opts.InsecureSkipVerify); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid syslog network %q, must be udp, tcp or tls", network)
+	}
+
+	if err := s.dial(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func tlsConfig(address, caFile string, insecure bool) (*tls.Config, error) {
+	host, _, _ := net.SplitHostPort(address)
+	config := &tls.Config{
+		ServerName:         host,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecure,
+	}
+
+	if caFile == "" {
+		return config, nil
+	}
+
+	pem, err := os.ReadFile(filepath.Clean(caFile))
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificate found in %s", caFile)
+	}
+	config.RootCAs = pool
+	return config, nil
+}
+
+func (s *SyslogSink) dial() error {
+	var conn net.Conn
+	var err error
+
+	dialer := &net.Dialer{Timeout: syslogDialTimeout}
+	if s.network == syslogTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.address, s.tls)
+	} else {
+		conn, err = dialer.Dial(s.network, s.address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to syslog server %s: %v", s.address, err)
+	}
+
+	s.conn = conn
+	return nil
+}
+
+func (s *SyslogSink) Write(e *Event) error {
+	msg := FormatSyslog(e, s.hostname, s.appName)
+	if s.network != syslogUDP {
+		msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+
+	if s.conn != nil {
+		if _, err := s.conn.Write(msg); err == nil {
+			return nil
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	// the server may have closed the connection, reconnect once
+	if err := s.dial(); err != nil {
+		return err
+	}
+	if _, err := s.conn.Write(msg); err != nil {
+		return fmt.Errorf("failed to send to syslog server %s: %v", s.address, err)
+	}
+	return nil
+}
+
+// Flush does nothing as messages are sent as they are written
+func (s *SyslogSink) Flush() error {
+	return nil
+}
+
+func (s *SyslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.Close()
+}
+
+// FormatSyslog returns an event as an RFC 5424 message, timestamped with
+// the creation time of the event, identified by its type and holding the
+// event as JSON
+func FormatSyslog(e *Event, hostname, appName string) []byte {
+	timestamp := "-"
+	if e.Metadata.EventCreationTime > 0 {
+		timestamp = time.UnixMilli(e.Metadata.EventCreationTime).UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	}
+
+	msg := fmt.Sprintf("<%d>1 %s %s %s - %s - %s",
+		syslogPriority,
+		timestamp,
+		syslogField(hostname, 255),
+		syslogField(appName, 48),
+		syslogField(e.Metadata.EventType, 32),
+		e.Raw,
+	)
+	return []byte(msg)
+}
+
+// syslogField restricts a header field to printable ASCII characters
+// without spaces and to the given length, or to the nil value "-"
+func syslogField(s string, size int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, s)
+	if len(s) > size {
+		s = s[:size]
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadIDs(t *testing.T) {
+	tests := []struct {
+		args    []string
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{args: []string{"a,b", " c "}, want: []string{"a", "b", "c"}},
+		{args: []string{"-"}, in: "a\n# comment\n\nb\n", want: []string{"a", "b"}},
+		{args: []string{"-"}, in: "", wantErr: true},
+		{args: []string{"-"}, in: "# nothing\n", wantErr: true},
+		{args: []string{" , "}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ReadIDs(tt.args, strings.NewReader(tt.in))
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ReadIDs(%q) with input %q returned no error", tt.args, tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ReadIDs(%q) returned error: %v", tt.args, err)
+			continue
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("ReadIDs(%q) mismatch (-want +got):\n%s", tt.args, diff)
+		}
+	}
+}
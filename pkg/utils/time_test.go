@@ -0,0 +1,54 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2023, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Time{
+		"":                     {},
+		"36h":                  now.Add(-36 * time.Hour),
+		"7d":                   time.Date(2023, 3, 3, 12, 0, 0, 0, time.UTC),
+		"2023-03-01":           time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+		"2023-03-01T08:30:00Z": time.Date(2023, 3, 1, 8, 30, 0, 0, time.UTC),
+	}
+
+	for s, want := range tests {
+		got, err := ParseTime(s, now)
+		if err != nil {
+			t.Errorf("ParseTime(%q) failed: %v", s, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseTime(%q) = %s, want %s", s, got, want)
+		}
+	}
+
+	for _, s := range []string{"yesterday", "-3d", "03/01/2023"} {
+		if _, err := ParseTime(s, now); err == nil {
+			t.Errorf("ParseTime(%q) did not fail", s)
+		}
+	}
+}
@@ -21,9 +21,11 @@
 package utils
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
@@ -37,6 +39,24 @@ func ValidateRegExp(regex string, value string) bool {
 	return true
 }
 
+// ValidateOneOf checks that every value is one of the valid options
+func ValidateOneOf(name string, valid []string, values ...string) error {
+	for _, v := range values {
+		found := false
+		for _, option := range valid {
+			if v == option {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("invalid %s %q, must be one of: %s", name, v, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
 func ConfigExists(path string) {
 	path = filepath.Clean(path)
 	if _, err := os.Stat(path); os.IsNotExist(err) {
@@ -0,0 +1,256 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package zta reads the Zero Trust Assessment scores of hosts.
+package zta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/crowdstrike/falcon-cli/pkg/hosts"
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/client/zero_trust_assessment"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+)
+
+// Assessment is the assessment of a host with its scores and signals
+type Assessment = models.DomainSignalProperties
+
+// Signal is a check contributing to the sensor or OS score of a host
+type Signal = models.DomainSignalProperty
+
+// Compliance is the average assessment of the hosts of a CID by platform
+type Compliance = models.CommonCIDComplianceResult
+
+// Scores of an assessment. The overall score is computed from the sensor
+// configuration and operating system scores.
+const (
+	ScoreOverall      = "overall"
+	ScoreSensorConfig = "sensor_config"
+	ScoreOS           = "os"
+)
+
+// ScoreNames lists the scores of an assessment
+var ScoreNames = []string{ScoreOverall, ScoreSensorConfig, ScoreOS}
+
+// maxIDs is the most hosts assessed per request
+const maxIDs = 100
+
+// HostAssessment is the assessment of a host with its hostname
+type HostAssessment struct {
+	Hostname string `json:"hostname"`
+	*Assessment
+}
+
+// GetAssessments returns the assessments of the hosts with the given
+// device IDs. Hosts that have not been assessed, such as hosts with sensors
+// too old to report signals, are left out.
+func GetAssessments(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) ([]*Assessment, error) {
+	list := []*Assessment{}
+
+	for _, chunk := range utils.Chunk(ids, maxIDs) {
+		payload, err := getAssessments(ctx, c, chunk)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, payload.Resources...)
+	}
+	return list, nil
+}
+
+func getAssessments(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) (*models.DomainAssessmentsResponse, error) {
+	res, err := c.ZeroTrustAssessment.GetAssessmentV1(&zero_trust_assessment.GetAssessmentV1Params{
+		Context: ctx,
+		Ids:     ids,
+	})
+
+	// hosts without assessment are reported as not found next to the
+	// assessments of the others
+	var notFound *zero_trust_assessment.GetAssessmentV1NotFound
+	if errors.As(err, &notFound) && notFound.Payload != nil {
+		return notFound.Payload, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessments: %s", falcon.ErrorExplain(err))
+	}
+
+	if err := falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+	return res.Payload, nil
+}
+
+// Assess returns the assessments of the hosts with the given device IDs,
+// with their hostnames
+func Assess(ctx context.Context, c *client.CrowdStrikeAPISpecification, ids []string) ([]*HostAssessment, error) {
+	assessments, err := GetAssessments(ctx, c, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	assessed := []string{}
+	for _, a := range assessments {
+		assessed = append(assessed, utils.Deref(a.Aid))
+	}
+
+	devices, err := hosts.GetDevices(ctx, c, assessed)
+	if err != nil {
+		return nil, err
+	}
+
+	hostnames := map[string]string{}
+	for _, d := range devices {
+		hostnames[utils.Deref(d.DeviceID)] = d.Hostname
+	}
+
+	list := []*HostAssessment{}
+	for _, a := range assessments {
+		list = append(list, &HostAssessment{Hostname: hostnames[utils.Deref(a.Aid)], Assessment: a})
+	}
+	return list, nil
+}
+
+// GetCompliance returns the average scores of the hosts of the CID
+func GetCompliance(ctx context.Context, c *client.CrowdStrikeAPISpecification) (*Compliance, error) {
+	res, err := c.ZeroTrustAssessment.GetComplianceV1(&zero_trust_assessment.GetComplianceV1Params{
+		Context: ctx,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compliance: %s", falcon.ErrorExplain(err))
+	}
+
+	if err := falcon.AssertNoError(res.Payload.Errors); err != nil {
+		return nil, err
+	}
+
+	if len(res.Payload.Resources) == 0 {
+		return nil, fmt.Errorf("no compliance data, no host has been assessed yet")
+	}
+	return res.Payload.Resources[0], nil
+}
+
+// Score returns a score of an assessment and whether it is set
+func Score(a *Assessment, name string) (int32, bool) {
+	if a == nil || a.Assessment == nil {
+		return 0, false
+	}
+
+	var score *int32
+	switch name {
+	case ScoreOverall:
+		score = a.Assessment.Overall
+	case ScoreSensorConfig:
+		score = a.Assessment.SensorConfig
+	case ScoreOS:
+		score = a.Assessment.Os
+	}
+	if score == nil {
+		return 0, false
+	}
+	return *score, true
+}
+
+// SortByScore sorts assessments by a score, lowest first. Ties are broken
+// by hostname and assessments without the score come last.
+func SortByScore(list []*HostAssessment, name string) {
+	sort.SliceStable(list, func(i, j int) bool {
+		a, aok := Score(list[i].Assessment, name)
+		b, bok := Score(list[j].Assessment, name)
+		if aok != bok {
+			return aok
+		}
+		if a != b {
+			return a < b
+		}
+		return list[i].Hostname < list[j].Hostname
+	})
+}
+
+// Bucket counts the hosts scoring between Min and Max, both included
+type Bucket struct {
+	Min     int32   `json:"min"`
+	Max     int32   `json:"max"`
+	Hosts   int     `json:"hosts"`
+	Percent float64 `json:"percent"`
+}
+
+// Distribution counts assessments by ranges of a score of the given width,
+// from 0 to 100. The last range includes 100. Assessments without the score
+// are left out.
+func Distribution(list []*Assessment, name string, width int32) []*Bucket {
+	if width < 1 {
+		width = 1
+	}
+
+	buckets := []*Bucket{}
+	for min := int32(0); min < 100; min += width {
+		max := min + width - 1
+		if max >= 99 {
+			max = 100
+		}
+		buckets = append(buckets, &Bucket{Min: min, Max: max})
+	}
+
+	total := 0
+	for _, a := range list {
+		score, ok := Score(a, name)
+		if !ok {
+			continue
+		}
+
+		i := int(score / width)
+		if i >= len(buckets) {
+			i = len(buckets) - 1
+		}
+		if i < 0 {
+			i = 0
+		}
+		buckets[i].Hosts++
+		total++
+	}
+
+	for _, b := range buckets {
+		if total > 0 {
+			b.Percent = float64(b.Hosts) * 100 / float64(total)
+		}
+	}
+	return buckets
+}
+
+// Average returns the average of a score over assessments and the number
+// of assessments with the score
+func Average(list []*Assessment, name string) (float64, int) {
+	sum, n := 0.0, 0
+	for _, a := range list {
+		if score, ok := Score(a, name); ok {
+			sum += float64(score)
+			n++
+		}
+	}
+	if n == 0 {
+		return 0, 0
+	}
+	return sum / float64(n), n
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2022 CrowdStrike, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package zta
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/crowdstrike/falcon-cli/pkg/utils"
+	"github.com/crowdstrike/gofalcon/falcon/client"
+	"github.com/crowdstrike/gofalcon/falcon/models"
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+)
+
+func assessment(aid string, overall, sensor, os int32) *Assessment {
+	return &Assessment{
+		Aid: utils.Ptr(aid),
+		Assessment: &models.DomainAssessment{
+			Overall:      utils.Ptr(overall),
+			SensorConfig: utils.Ptr(sensor),
+			Os:           utils.Ptr(os),
+		},
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	list := []*Assessment{
+		assessment("a", 100, 100, 100),
+		assessment("b", 95, 90, 100),
+		assessment("c", 42, 30, 60),
+		assessment("d", 0, 0, 0),
+		{Aid: utils.Ptr("e")},
+	}
+
+	got := Distribution(list, ScoreOverall, 25)
+	want := []*Bucket{
+		{Min: 0, Max: 24, Hosts: 1, Percent: 25},
+		{Min: 25, Max: 49, Hosts: 1, Percent: 25},
+		{Min: 50, Max: 74},
+		{Min: 75, Max: 100, Hosts: 2, Percent: 50},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Distribution() mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := Distribution(list, ScoreOS, 10); len(got) != 10 || got[9].Min != 90 || got[9].Max != 100 || got[9].Hosts != 2 || got[6].Hosts != 1 {
+		t.Errorf("unexpected distribution of OS scores %+v", got)
+	}
+
+	if avg, n := Average(list, ScoreSensorConfig); n != 4 || avg != 55 {
+		t.Errorf("Average() = %v, %d, want 55, 4", avg, n)
+	}
+}
+
+func TestSortByScore(t *testing.T) {
+	list := []*HostAssessment{
+		{Hostname: "web-02", Assessment: assessment("a", 80, 90, 70)},
+		{Hostname: "none", Assessment: &Assessment{}},
+		{Hostname: "db-01", Assessment: assessment("b", 40, 20, 60)},
+		{Hostname: "web-01", Assessment: assessment("c", 80, 60, 100)},
+	}
+
+	tests := []struct {
+		score string
+		want  []string
+	}{
+		{score: ScoreOverall, want: []string{"db-01", "web-01", "web-02", "none"}},
+		{score: ScoreOS, want: []string{"db-01", "web-02", "web-01", "none"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.score, func(t *testing.T) {
+			SortByScore(list, tt.score)
+			got := []string{}
+			for _, a := range list {
+				got = append(got, a.Hostname)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SortByScore() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestGetAssessmentsNotFound checks that hosts without assessment do not
+// fail the request for the others
+func TestGetAssessmentsNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		resources := []*Assessment{}
+		errs := []map[string]interface{}{}
+		for _, id := range r.URL.Query()["ids"] {
+			if id == "missing" {
+				errs = append(errs, map[string]interface{}{"code": 404, "message": "No assessment found for aid " + id})
+				continue
+			}
+			resources = append(resources, assessment(id, 50, 50, 50))
+		}
+
+		if len(errs) > 0 {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"errors": errs, "resources": resources})
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	c := client.New(httptransport.New(u.Host, "/", []string{"http"}), strfmt.Default)
+
+	got, err := GetAssessments(context.Background(), c, []string{"a", "missing", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || utils.Deref(got[0].Aid) != "a" || utils.Deref(got[1].Aid) != "b" {
+		t.Errorf("unexpected assessments %+v", got)
+	}
+}